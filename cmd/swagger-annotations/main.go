@@ -0,0 +1,41 @@
+// Command swagger-annotations generates APIDefinition registration code from swag-style comment
+// annotations on the handler functions of a package. Run it through go:generate:
+//
+//	//go:generate go run github.com/smartcat999/go-swagger/cmd/swagger-annotations -out swagger_gen.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/smartcat999/go-swagger/pkg/annotations"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the annotated package")
+	out := flag.String("out", "swagger_annotations_gen.go", "generated file, relative to -dir")
+	fn := flag.String("func", "", "name of a generated func(*api.Registry) to use instead of registering from init")
+	flag.Parse()
+
+	if err := run(*dir, *out, *fn); err != nil {
+		fmt.Fprintln(os.Stderr, "swagger-annotations:", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir, out, fn string) error {
+	pkg, err := annotations.ParseDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(pkg.Routes) == 0 {
+		return fmt.Errorf("no @Router annotations found in %s", dir)
+	}
+	src, err := annotations.Generate(pkg, annotations.Options{Func: fn})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, out), src, 0o644)
+}
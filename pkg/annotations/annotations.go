@@ -0,0 +1,375 @@
+// Package annotations reads swag-style comment annotations (@Summary, @Param, @Success, @Router, ...)
+// on handler functions and generates the equivalent APIDefinition registration code, so services
+// documented for swaggo/swag can move to runtime-validated definitions without rewriting their comments
+//
+// Only handler functions carrying a @Router annotation are picked up. Annotations without an
+// APIDefinition counterpart (@Accept, @Produce, ...) are ignored
+package annotations
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Package holds the annotated handlers of a Go package
+type Package struct {
+	Name    string            // Package name the code is generated for
+	Imports map[string]string // Import paths of the packages referenced by models, keyed by package name
+	Routes  []Route           // Annotated handlers in source order
+}
+
+// Route is a handler function documented with annotations
+type Route struct {
+	Handler     string     // Handler function name
+	HTTP        bool       // Whether the handler is a net/http handler rather than a framework-specific one
+	Method      string     // HTTP method from @Router
+	Path        string     // Route path from @Router
+	OperationID string     // @ID
+	Summary     string     // @Summary
+	Description string     // @Description lines joined by newlines
+	Tags        []string   // @Tags
+	Params      []Param    // @Param annotations other than the body
+	Body        string     // Go type of the body parameter
+	Responses   []Response // @Success and @Failure annotations
+	Security    []Security // @Security annotations, each an alternative requirement
+	Deprecated  bool       // @Deprecated
+}
+
+// Param is a non-body @Param annotation
+type Param struct {
+	Name        string
+	In          string // path, query, header or cookie
+	Type        string // Annotated type (string, integer, []string, ...)
+	Required    bool
+	Description string
+	Attributes  map[string]string // Trailing attributes such as enums(a,b), minimum(1) or default(10)
+}
+
+// Response is a @Success or @Failure annotation
+type Response struct {
+	Status      int
+	Model       string // Go type of the response body, empty when there is none
+	Description string
+}
+
+// Security is a @Security annotation
+type Security struct {
+	Scheme string
+	Scopes []string
+}
+
+var (
+	paramPattern     = regexp.MustCompile(`^(\S+)\s+(\S+)\s+(\S+)\s+(\S+)\s*(.*)$`)
+	quotedPattern    = regexp.MustCompile(`^"([^"]*)"`)
+	attributePattern = regexp.MustCompile(`(\w+)\(([^)]*)\)`)
+	routerPattern    = regexp.MustCompile(`^(\S+)\s+\[(\w+)\]$`)
+	securityPattern  = regexp.MustCompile(`^(\w+)(?:\[([^\]]*)\])?$`)
+)
+
+// ParseDir parses the non-test Go files of a directory
+func ParseDir(dir string) (*Package, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(names))
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return ParseFiles(fset, files...)
+}
+
+// ParseFiles collects the annotated handlers of files belonging to one package
+func ParseFiles(fset *token.FileSet, files ...*ast.File) (*Package, error) {
+	pkg := &Package{Imports: make(map[string]string)}
+	for _, file := range files {
+		if pkg.Name == "" {
+			pkg.Name = file.Name.Name
+		} else if file.Name.Name != pkg.Name {
+			return nil, fmt.Errorf("%s: package %s, expected %s", fset.Position(file.Package), file.Name.Name, pkg.Name)
+		}
+		imports := fileImports(file)
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Doc == nil {
+				continue
+			}
+			route, ok, err := parseRoute(fn)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", fset.Position(fn.Pos()), fn.Name.Name, err)
+			}
+			if !ok {
+				continue
+			}
+			if err := pkg.resolve(&route, imports); err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", fset.Position(fn.Pos()), fn.Name.Name, err)
+			}
+			pkg.Routes = append(pkg.Routes, route)
+		}
+	}
+	return pkg, nil
+}
+
+// fileImports maps the package names visible in a file to their import paths
+func fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = importPath
+	}
+	return imports
+}
+
+// parseRoute reads the annotations of a function, reporting false when it has no @Router
+func parseRoute(fn *ast.FuncDecl) (Route, bool, error) {
+	route := Route{Handler: fn.Name.Name, HTTP: isHTTPHandler(fn.Type)}
+	found := false
+	for _, comment := range fn.Doc.List {
+		line := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+		keyword, value := line, ""
+		if i := strings.IndexAny(line, " \t"); i >= 0 {
+			keyword, value = line[:i], strings.TrimSpace(line[i+1:])
+		}
+		var err error
+		switch strings.ToLower(keyword) {
+		case "@router":
+			m := routerPattern.FindStringSubmatch(value)
+			if m == nil {
+				return route, false, fmt.Errorf("invalid @Router %q, expected \"/path [method]\"", value)
+			}
+			route.Path, route.Method = m[1], strings.ToUpper(m[2])
+			found = true
+		case "@id":
+			route.OperationID = value
+		case "@summary":
+			route.Summary = value
+		case "@description":
+			if route.Description != "" {
+				route.Description += "\n"
+			}
+			route.Description += value
+		case "@tags":
+			for _, tag := range strings.Split(value, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					route.Tags = append(route.Tags, tag)
+				}
+			}
+		case "@param":
+			err = route.addParam(value)
+		case "@success", "@failure":
+			err = route.addResponse(value)
+		case "@security":
+			m := securityPattern.FindStringSubmatch(value)
+			if m == nil {
+				return route, false, fmt.Errorf("invalid @Security %q", value)
+			}
+			security := Security{Scheme: m[1], Scopes: []string{}}
+			for _, scope := range strings.Split(m[2], ",") {
+				if scope = strings.TrimSpace(scope); scope != "" {
+					security.Scopes = append(security.Scopes, scope)
+				}
+			}
+			route.Security = append(route.Security, security)
+		case "@deprecated":
+			route.Deprecated = true
+		}
+		if err != nil {
+			return route, false, err
+		}
+	}
+	if found && fn.Recv != nil {
+		return route, false, fmt.Errorf("annotated methods are not supported, wrap the handler in a function")
+	}
+	return route, found, nil
+}
+
+// addParam parses "name in type required "description" attributes..."
+func (r *Route) addParam(value string) error {
+	fields := paramPattern.FindStringSubmatch(value)
+	if fields == nil {
+		return fmt.Errorf("invalid @Param %q, expected \"name in type required description\"", value)
+	}
+	fields = fields[1:]
+	required, err := strconv.ParseBool(fields[3])
+	if err != nil {
+		return fmt.Errorf("invalid @Param %q: required must be true or false", value)
+	}
+	description, rest := quoted(fields[4])
+
+	switch in := fields[1]; in {
+	case "body":
+		r.Body = fields[2]
+	case "path", "query", "header", "cookie":
+		param := Param{
+			Name:        fields[0],
+			In:          in,
+			Type:        fields[2],
+			Required:    required || in == "path",
+			Description: description,
+			Attributes:  make(map[string]string),
+		}
+		for _, m := range attributePattern.FindAllStringSubmatch(rest, -1) {
+			param.Attributes[strings.ToLower(m[1])] = m[2]
+		}
+		r.Params = append(r.Params, param)
+	default:
+		return fmt.Errorf("unsupported @Param location %q", in)
+	}
+	return nil
+}
+
+// addResponse parses "status {kind} type "description"", where the kind, type and description are optional
+func (r *Route) addResponse(value string) error {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return fmt.Errorf("invalid response annotation %q", value)
+	}
+	status, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return fmt.Errorf("unsupported response status %q", fields[0])
+	}
+	resp := Response{Status: status}
+	rest := strings.TrimSpace(value[len(fields[0]):])
+	if strings.HasPrefix(rest, "{") {
+		end := strings.Index(rest, "}")
+		if end < 0 {
+			return fmt.Errorf("invalid response annotation %q", value)
+		}
+		kind := rest[1:end]
+		rest = strings.TrimSpace(rest[end+1:])
+		typ := strings.Fields(rest)
+		if len(typ) == 0 {
+			return fmt.Errorf("invalid response annotation %q: missing type", value)
+		}
+		rest = strings.TrimSpace(rest[len(typ[0]):])
+		switch kind {
+		case "array":
+			resp.Model = "[]" + typ[0]
+		case "object", "string", "integer", "number", "boolean":
+			resp.Model = typ[0]
+		default:
+			return fmt.Errorf("unsupported response kind {%s}", kind)
+		}
+	}
+	resp.Description, _ = quoted(rest)
+	r.Responses = append(r.Responses, resp)
+	return nil
+}
+
+// quoted splits a leading quoted string off s
+func quoted(s string) (string, string) {
+	m := quotedPattern.FindStringSubmatch(s)
+	if m == nil {
+		return "", s
+	}
+	return m[1], strings.TrimSpace(s[len(m[0]):])
+}
+
+// isHTTPHandler reports whether a function has the func(http.ResponseWriter, *http.Request) shape
+func isHTTPHandler(fn *ast.FuncType) bool {
+	params := fn.Params.List
+	if len(params) != 2 && !(len(params) == 1 && len(params[0].Names) == 2) {
+		return false
+	}
+	types := make([]ast.Expr, 0, 2)
+	for _, p := range params {
+		n := len(p.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			types = append(types, p.Type)
+		}
+	}
+	if len(types) != 2 {
+		return false
+	}
+	writer, ok := types[0].(*ast.SelectorExpr)
+	if !ok || writer.Sel.Name != "ResponseWriter" {
+		return false
+	}
+	request, ok := types[1].(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := request.X.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Request"
+}
+
+// resolve checks the model types of a route and records the imports they need
+// Types qualified with the package's own name, as swag allows, are unqualified
+func (p *Package) resolve(route *Route, imports map[string]string) error {
+	models := []*string{&route.Body}
+	for i := range route.Responses {
+		models = append(models, &route.Responses[i].Model)
+	}
+	for _, model := range models {
+		if *model == "" {
+			continue
+		}
+		if strings.ContainsAny(*model, "{}=") {
+			return fmt.Errorf("composed type %s is not supported", *model)
+		}
+		expr, err := parser.ParseExpr(*model)
+		if err != nil {
+			return fmt.Errorf("invalid type %s: %w", *model, err)
+		}
+		var resolveErr error
+		ast.Inspect(expr, func(n ast.Node) bool {
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			pkgIdent, ok := sel.X.(*ast.Ident)
+			if !ok || pkgIdent.Name == p.Name {
+				return false
+			}
+			importPath, ok := imports[pkgIdent.Name]
+			if !ok {
+				resolveErr = fmt.Errorf("type %s refers to package %s, which is not imported", *model, pkgIdent.Name)
+				return false
+			}
+			if existing, ok := p.Imports[pkgIdent.Name]; ok && existing != importPath {
+				resolveErr = fmt.Errorf("package name %s refers to both %s and %s", pkgIdent.Name, existing, importPath)
+				return false
+			}
+			p.Imports[pkgIdent.Name] = importPath
+			return false
+		})
+		if resolveErr != nil {
+			return resolveErr
+		}
+		*model = regexp.MustCompile(`\b`+regexp.QuoteMeta(p.Name)+`\.`).ReplaceAllString(*model, "")
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package annotations
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const handlersSource = `package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"example.com/app/model"
+)
+
+// GetUser returns a user
+// @Summary Get user
+// @Description Returns a single user
+// @Description by its ID
+// @Tags users, admin
+// @ID getUser
+// @Param id path int true "User ID" minimum(1)
+// @Param fields query []string false "Fields to return"
+// @Success 200 {object} model.User
+// @Failure 404 {object} handlers.ErrorResponse "User not found"
+// @Security OAuth2[read, admin]
+// @Router /users/{id} [get]
+func GetUser(c *gin.Context) {}
+
+// @Summary Create user
+// @Accept json
+// @Param body body model.CreateUser true "User to create"
+// @Success 201 {object} model.User "Created"
+// @Success 200 {array} model.User
+// @Deprecated
+// @Router /users [post]
+func CreateUser(w http.ResponseWriter, r *http.Request) {}
+
+// helper has no @Router and is not a handler
+// @Summary Ignored
+func helper() {}
+
+type ErrorResponse struct{}
+`
+
+// parseSource parses Go source for the tests
+func parseSource(t *testing.T, sources ...string) (*Package, error) {
+	t.Helper()
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(sources))
+	for i, src := range sources {
+		file, err := parser.ParseFile(fset, "handlers"+string(rune('a'+i))+".go", src, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("Failed to parse source: %v", err)
+		}
+		files = append(files, file)
+	}
+	return ParseFiles(fset, files...)
+}
+
+// TestParseFiles tests that annotated handlers are read into routes
+func TestParseFiles(t *testing.T) {
+	pkg, err := parseSource(t, handlersSource)
+	if err != nil {
+		t.Fatalf("ParseFiles failed: %v", err)
+	}
+	if pkg.Name != "handlers" {
+		t.Errorf("Expected package handlers, got %q", pkg.Name)
+	}
+	if !reflect.DeepEqual(pkg.Imports, map[string]string{"model": "example.com/app/model"}) {
+		t.Errorf("Expected only the model package import, got %v", pkg.Imports)
+	}
+	if len(pkg.Routes) != 2 {
+		t.Fatalf("Expected 2 routes, got %d", len(pkg.Routes))
+	}
+
+	get := pkg.Routes[0]
+	want := Route{
+		Handler:     "GetUser",
+		Method:      "GET",
+		Path:        "/users/{id}",
+		OperationID: "getUser",
+		Summary:     "Get user",
+		Description: "Returns a single user\nby its ID",
+		Tags:        []string{"users", "admin"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "User ID", Attributes: map[string]string{"minimum": "1"}},
+			{Name: "fields", In: "query", Type: "[]string", Description: "Fields to return", Attributes: map[string]string{}},
+		},
+		Responses: []Response{
+			{Status: 200, Model: "model.User"},
+			{Status: 404, Model: "ErrorResponse", Description: "User not found"},
+		},
+		Security: []Security{{Scheme: "OAuth2", Scopes: []string{"read", "admin"}}},
+	}
+	if !reflect.DeepEqual(get, want) {
+		t.Errorf("Expected GetUser route %+v, got %+v", want, get)
+	}
+
+	create := pkg.Routes[1]
+	if !create.HTTP || create.Method != "POST" || create.Body != "model.CreateUser" || !create.Deprecated {
+		t.Errorf("Expected a deprecated net/http POST with a model.CreateUser body, got %+v", create)
+	}
+	if len(create.Responses) != 2 || create.Responses[1].Model != "[]model.User" {
+		t.Errorf("Expected a []model.User response, got %+v", create.Responses)
+	}
+}
+
+// TestParseFilesErrors tests that malformed annotations are reported
+func TestParseFilesErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		wantErr    string
+	}{
+		{"invalid router", "// @Router /users", "invalid @Router"},
+		{"short param", "// @Param id path\n// @Router /users [get]", "invalid @Param"},
+		{"required flag", "// @Param id path int yes \"ID\"\n// @Router /users [get]", "required must be true or false"},
+		{"form data", "// @Param file formData file true \"File\"\n// @Router /users [post]", "unsupported @Param location"},
+		{"unknown kind", "// @Success 200 {file} string\n// @Router /users [get]", "unsupported response kind"},
+		{"composed type", "// @Success 200 {object} Envelope{data=User}\n// @Router /users [get]", "composed type"},
+		{"missing import", "// @Success 200 {object} dto.User\n// @Router /users [get]", "not imported"},
+		{"method", "// @Router /users [get]\nfunc (h *Handler) List() {}\n// unused", "annotated methods"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package handlers\n\n" + tt.annotation + "\n"
+			if !strings.Contains(tt.annotation, "func ") {
+				src += "func Handler() {}\n"
+			}
+			_, err := parseSource(t, src)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestParseFilesPackageMismatch tests that files of different packages are rejected
+func TestParseFilesPackageMismatch(t *testing.T) {
+	_, err := parseSource(t, "package a\n", "package b\n")
+	if err == nil || !strings.Contains(err.Error(), "expected a") {
+		t.Errorf("Expected a package mismatch, got %v", err)
+	}
+}
+
+// TestParseDir tests that test files are skipped when parsing a directory
+func TestParseDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"handlers.go":      handlersSource,
+		"handlers_test.go": "package handlers_test\n\n// @Router /ignored [get]\nfunc Ignored() {}\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	pkg, err := ParseDir(dir)
+	if err != nil {
+		t.Fatalf("ParseDir failed: %v", err)
+	}
+	if len(pkg.Routes) != 2 {
+		t.Errorf("Expected 2 routes, got %d", len(pkg.Routes))
+	}
+
+	if _, err := ParseDir(t.TempDir()); err == nil {
+		t.Error("Expected parsing an empty directory to fail")
+	}
+}
@@ -0,0 +1,305 @@
+package annotations
+
+import (
+	"fmt"
+	"go/format"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Options configure the generated registration code
+type Options struct {
+	// Func names a generated function adding the definitions to a registry passed by the caller,
+	// when empty they are added to api.DefaultRegistry from an init function
+	Func string
+}
+
+const apiImport = "github.com/smartcat999/go-swagger/pkg/api"
+
+// Generate emits a gofmt-ed Go file registering an APIDefinition for every annotated handler
+func Generate(pkg *Package, opts Options) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("// Code generated by swagger-annotations. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg.Name)
+
+	names := make([]string, 0, len(pkg.Imports))
+	for name := range pkg.Imports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	b.WriteString("import (\n")
+	fmt.Fprintf(&b, "\t%q\n", apiImport)
+	for _, name := range names {
+		fmt.Fprintf(&b, "\t%s %q\n", name, pkg.Imports[name])
+	}
+	b.WriteString(")\n\n")
+
+	if opts.Func != "" {
+		fmt.Fprintf(&b, "// %s adds the annotated handlers of this package to registry\n", opts.Func)
+		fmt.Fprintf(&b, "func %s(registry *api.Registry) {\n\tregistry.Add(\n", opts.Func)
+	} else {
+		b.WriteString("func init() {\n\tapi.Add(\n")
+	}
+	for _, route := range pkg.Routes {
+		if err := writeDefinition(&b, route); err != nil {
+			return nil, fmt.Errorf("%s: %w", route.Handler, err)
+		}
+	}
+	b.WriteString("\t)\n}\n")
+
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return src, nil
+}
+
+// writeDefinition emits the APIDefinition literal of a route
+func writeDefinition(b *strings.Builder, route Route) error {
+	b.WriteString("&api.APIDefinition{\n")
+	fmt.Fprintf(b, "Method: %q,\nPath: %q,\n", route.Method, route.Path)
+	if route.OperationID != "" {
+		fmt.Fprintf(b, "OperationID: %q,\n", route.OperationID)
+	}
+	if route.Summary != "" {
+		fmt.Fprintf(b, "Summary: %q,\n", route.Summary)
+	}
+	if route.Description != "" {
+		fmt.Fprintf(b, "Description: %q,\n", route.Description)
+	}
+	if len(route.Tags) > 0 {
+		fmt.Fprintf(b, "Tags: %s,\n", stringSlice(route.Tags))
+	}
+	if route.Body != "" {
+		fmt.Fprintf(b, "Request: %s,\n", zeroValue(route.Body))
+	}
+
+	// A 200 model is the definition's response, the remaining statuses are additional responses
+	var responses []Response
+	for _, resp := range route.Responses {
+		if resp.Status == 200 && resp.Model != "" {
+			fmt.Fprintf(b, "Response: %s,\n", zeroValue(resp.Model))
+			if resp.Description == "" {
+				continue
+			}
+		}
+		responses = append(responses, resp)
+	}
+	if len(responses) > 0 {
+		b.WriteString("Responses: map[int]api.StatusResponse{\n")
+		for _, resp := range responses {
+			description := resp.Description
+			if description == "" {
+				description = defaultDescription(resp.Status)
+			}
+			fmt.Fprintf(b, "%d: {Description: %q", resp.Status, description)
+			if resp.Model != "" {
+				fmt.Fprintf(b, ", Model: %s", zeroValue(resp.Model))
+			}
+			b.WriteString("},\n")
+		}
+		b.WriteString("},\n")
+	}
+
+	if len(route.Params) > 0 {
+		b.WriteString("Params: []api.Parameter{\n")
+		for _, param := range route.Params {
+			if err := writeParam(b, param); err != nil {
+				return err
+			}
+		}
+		b.WriteString("},\n")
+	}
+	if len(route.Security) > 0 {
+		b.WriteString("Security: []map[string][]string{\n")
+		for _, security := range route.Security {
+			fmt.Fprintf(b, "{%q: %s},\n", security.Scheme, stringSlice(security.Scopes))
+		}
+		b.WriteString("},\n")
+	}
+	if route.Deprecated {
+		b.WriteString("Deprecated: true,\n")
+	}
+	if route.HTTP {
+		fmt.Fprintf(b, "Handler: %s,\n", route.Handler)
+	} else {
+		fmt.Fprintf(b, "NativeHandler: %s,\n", route.Handler)
+	}
+	b.WriteString("},\n")
+	return nil
+}
+
+// writeParam emits the Parameter literal of a @Param, turning its type and attributes into
+// a schema and the validation rules the router enforces
+func writeParam(b *strings.Builder, param Param) error {
+	schema, err := paramSchema(param.Type)
+	if err != nil {
+		return fmt.Errorf("parameter %s: %w", param.Name, err)
+	}
+	itemType := schemaType(param.Type)
+	array := strings.HasPrefix(param.Type, "[]")
+
+	var rules []string
+	switch {
+	case array:
+		// Rules check whole values, so array parameters carry none
+	case itemType == "integer":
+		rules = append(rules, fmt.Sprintf("api.Pattern(%q, %q)", "^-?[0-9]+$", param.Name+" must be an integer"))
+	case itemType == "number":
+		rules = append(rules, fmt.Sprintf("api.Pattern(%q, %q)", `^-?[0-9]+(\.[0-9]+)?$`, param.Name+" must be a number"))
+	case itemType == "boolean":
+		rules = append(rules, fmt.Sprintf("api.Enum(%q, \"true\", \"false\")", param.Name+" must be true or false"))
+	}
+
+	keys := make([]string, 0, len(param.Attributes))
+	for key := range param.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := strings.TrimSpace(param.Attributes[key])
+		if array && key != "format" {
+			return fmt.Errorf("parameter %s: %s() is not supported on arrays", param.Name, key)
+		}
+		switch key {
+		case "enums":
+			values := make([]string, 0)
+			for _, v := range strings.Split(value, ",") {
+				lit, err := literal(itemType, strings.TrimSpace(v))
+				if err != nil {
+					return fmt.Errorf("parameter %s: %w", param.Name, err)
+				}
+				values = append(values, lit)
+			}
+			rules = append(rules, fmt.Sprintf("api.Enum(%q, %s)", param.Name+" must be one of "+strings.Join(strings.Split(value, ","), ", "), strings.Join(values, ", ")))
+		case "minimum", "maximum":
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return fmt.Errorf("parameter %s: invalid %s(%s)", param.Name, key, value)
+			}
+			constructor, message := "Minimum", " must be at least "
+			if key == "maximum" {
+				constructor, message = "Maximum", " must be at most "
+			}
+			rules = append(rules, fmt.Sprintf("api.%s(%s, %q)", constructor, value, param.Name+message+value))
+		case "minlength", "maxlength":
+			if _, err := strconv.Atoi(value); err != nil {
+				return fmt.Errorf("parameter %s: invalid %s(%s)", param.Name, key, value)
+			}
+			constructor, message := "MinLength", " must have at least "
+			if key == "maxlength" {
+				constructor, message = "MaxLength", " must have at most "
+			}
+			rules = append(rules, fmt.Sprintf("api.%s(%s, %q)", constructor, value, param.Name+message+value+" characters"))
+		case "default", "example":
+			lit, err := literal(itemType, value)
+			if err != nil {
+				return fmt.Errorf("parameter %s: %w", param.Name, err)
+			}
+			schema = append(schema, fmt.Sprintf("%q: %s", key, lit))
+		case "format":
+			schema = append(schema, fmt.Sprintf("%q: %q", key, value))
+		}
+	}
+
+	fmt.Fprintf(b, "{Name: %q, In: %q", param.Name, param.In)
+	if param.Description != "" {
+		fmt.Fprintf(b, ", Description: %q", param.Description)
+	}
+	if param.Required {
+		b.WriteString(", Required: true")
+	}
+	fmt.Fprintf(b, ", Schema: map[string]interface{}{%s}", strings.Join(schema, ", "))
+	if len(rules) > 0 {
+		fmt.Fprintf(b, ", Validations: []api.ValidationRule{%s}", strings.Join(rules, ", "))
+	}
+	b.WriteString("},\n")
+	return nil
+}
+
+// schemaType maps an annotated parameter type, or the item type of an array, to its schema type
+func schemaType(typ string) string {
+	switch strings.TrimPrefix(typ, "[]") {
+	case "string":
+		return "string"
+	case "int", "integer", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return "integer"
+	case "number", "float32", "float64":
+		return "number"
+	case "bool", "boolean":
+		return "boolean"
+	}
+	return ""
+}
+
+// paramSchema returns the schema entries of an annotated parameter type
+func paramSchema(typ string) ([]string, error) {
+	itemType := schemaType(typ)
+	if itemType == "" || strings.HasPrefix(strings.TrimPrefix(typ, "[]"), "[]") {
+		return nil, fmt.Errorf("unsupported type %s", typ)
+	}
+	if strings.HasPrefix(typ, "[]") {
+		return []string{`"type": "array"`, fmt.Sprintf(`"items": map[string]interface{}{"type": %q}`, itemType)}, nil
+	}
+	return []string{fmt.Sprintf(`"type": %q`, itemType)}, nil
+}
+
+// literal renders an attribute value as a Go literal of the schema type
+func literal(typ, value string) (string, error) {
+	switch typ {
+	case "integer":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return "", fmt.Errorf("invalid integer %q", value)
+		}
+		return value, nil
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", fmt.Errorf("invalid number %q", value)
+		}
+		return value, nil
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return "", fmt.Errorf("invalid boolean %q", value)
+		}
+		return value, nil
+	}
+	return strconv.Quote(value), nil
+}
+
+// zeroValue renders the zero value expression of a model type
+func zeroValue(typ string) string {
+	switch typ {
+	case "string":
+		return `""`
+	case "integer", "int":
+		return "0"
+	case "number", "float64":
+		return "0.0"
+	case "boolean", "bool":
+		return "false"
+	case "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32":
+		return typ + "(0)"
+	}
+	if strings.HasPrefix(typ, "*") {
+		return "&" + typ[1:] + "{}"
+	}
+	return typ + "{}"
+}
+
+// stringSlice renders a []string literal
+func stringSlice(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
+
+// defaultDescription returns the reason phrase used when an annotation has no description
+func defaultDescription(status int) string {
+	if text := http.StatusText(status); text != "" {
+		return text
+	}
+	return "Status " + strconv.Itoa(status)
+}
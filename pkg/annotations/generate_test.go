@@ -0,0 +1,134 @@
+package annotations
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+// TestGenerate tests the registration code emitted for annotated handlers
+func TestGenerate(t *testing.T) {
+	pkg, err := parseSource(t, handlersSource)
+	if err != nil {
+		t.Fatalf("ParseFiles failed: %v", err)
+	}
+	src, err := Generate(pkg, Options{})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "gen.go", src, 0); err != nil {
+		t.Fatalf("Expected the generated code to parse, got %v\n%s", err, src)
+	}
+
+	// Compare with whitespace collapsed, gofmt aligns the fields of each literal
+	code := strings.Join(strings.Fields(string(src)), " ")
+	for _, want := range []string{
+		"// Code generated by swagger-annotations. DO NOT EDIT.",
+		"package handlers",
+		`model "example.com/app/model"`,
+		"func init() { api.Add(",
+		`OperationID: "getUser"`,
+		`Description: "Returns a single user\nby its ID"`,
+		`Tags: []string{"users", "admin"}`,
+		"Response: model.User{}",
+		`404: {Description: "User not found", Model: ErrorResponse{}}`,
+		`{Name: "id", In: "path", Description: "User ID", Required: true, Schema: map[string]interface{}{"type": "integer"}, Validations: []api.ValidationRule{api.Pattern("^-?[0-9]+$", "id must be an integer"), api.Minimum(1, "id must be at least 1")}}`,
+		`{Name: "fields", In: "query", Description: "Fields to return", Schema: map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}}`,
+		`{"OAuth2": []string{"read", "admin"}}`,
+		"NativeHandler: GetUser",
+		"Request: model.CreateUser{}",
+		`201: {Description: "Created", Model: model.User{}}`,
+		"Response: []model.User{}",
+		"Deprecated: true",
+		"Handler: CreateUser",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("Expected the generated code to contain %q\n%s", want, src)
+		}
+	}
+}
+
+// TestGenerateFunc tests that a named registration function replaces init
+func TestGenerateFunc(t *testing.T) {
+	pkg, err := parseSource(t, handlersSource)
+	if err != nil {
+		t.Fatalf("ParseFiles failed: %v", err)
+	}
+	src, err := Generate(pkg, Options{Func: "RegisterHandlers"})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	code := string(src)
+	if !strings.Contains(code, "func RegisterHandlers(registry *api.Registry) {\n\tregistry.Add(") {
+		t.Errorf("Expected the generated code to define RegisterHandlers\n%s", code)
+	}
+	if strings.Contains(code, "func init()") {
+		t.Error("Expected the generated code not to register from init")
+	}
+}
+
+// TestGenerateParams tests the schemas and rules generated from parameter types and attributes
+func TestGenerateParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   Param
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "string enums",
+			param: Param{Name: "sort", In: "query", Type: "string", Attributes: map[string]string{"enums": "asc,desc", "default": "asc"}},
+			want:  `{Name: "sort", In: "query", Schema: map[string]interface{}{"type": "string", "default": "asc"}, Validations: []api.ValidationRule{api.Enum("sort must be one of asc, desc", "asc", "desc")}}`,
+		},
+		{
+			name:  "integer bounds",
+			param: Param{Name: "limit", In: "query", Type: "integer", Attributes: map[string]string{"maximum": "100", "default": "20"}},
+			want:  `Schema: map[string]interface{}{"type": "integer", "default": 20}, Validations: []api.ValidationRule{api.Pattern("^-?[0-9]+$", "limit must be an integer"), api.Maximum(100, "limit must be at most 100")}`,
+		},
+		{
+			name:  "string lengths and format",
+			param: Param{Name: "X-Email", In: "header", Type: "string", Required: true, Attributes: map[string]string{"format": "email", "maxlength": "64"}},
+			want:  `Required: true, Schema: map[string]interface{}{"type": "string", "format": "email"}, Validations: []api.ValidationRule{api.MaxLength(64, "X-Email must have at most 64 characters")}`,
+		},
+		{
+			name:  "boolean",
+			param: Param{Name: "active", In: "query", Type: "bool"},
+			want:  `Validations: []api.ValidationRule{api.Enum("active must be true or false", "true", "false")}`,
+		},
+		{
+			name:    "unsupported type",
+			param:   Param{Name: "filter", In: "query", Type: "model.Filter"},
+			wantErr: "unsupported type",
+		},
+		{
+			name:    "invalid integer enum",
+			param:   Param{Name: "level", In: "query", Type: "int", Attributes: map[string]string{"enums": "1,high"}},
+			wantErr: `invalid integer "high"`,
+		},
+		{
+			name:    "array constraint",
+			param:   Param{Name: "ids", In: "query", Type: "[]int", Attributes: map[string]string{"minimum": "1"}},
+			wantErr: "not supported on arrays",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			err := writeParam(&b, tt.param)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("writeParam failed: %v", err)
+			}
+			if !strings.Contains(b.String(), tt.want) {
+				t.Errorf("Expected the parameter to contain %s, got %s", tt.want, b.String())
+			}
+		})
+	}
+}
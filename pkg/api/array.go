@@ -0,0 +1,50 @@
+package api
+
+import (
+	"fmt"
+)
+
+// Chain call: declare a parameter as an array of strings with item count and uniqueness constraints
+// A zero minItems or maxItems leaves that bound out; rules of the parameter apply to each item
+func (api *APIDefinition) WithArrayConstraints(name string, minItems, maxItems int, unique bool) *APIDefinition {
+	for i := range api.Params {
+		if api.Params[i].Name != name {
+			continue
+		}
+		schema := api.Params[i].Schema
+		if schema == nil || schema["type"] != "array" {
+			schema = map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}
+			api.Params[i].Schema = schema
+		}
+		if minItems > 0 {
+			schema["minItems"] = minItems
+		}
+		if maxItems > 0 {
+			schema["maxItems"] = maxItems
+		}
+		if unique {
+			schema["uniqueItems"] = true
+		}
+	}
+	return api
+}
+
+// validateItems checks the minItems, maxItems and uniqueItems keywords of an array parameter's schema
+func (p *Parameter) validateItems(items []string) error {
+	if minItems, ok := toNumber(p.Schema["minItems"]); ok && float64(len(items)) < minItems {
+		return fmt.Errorf("parameter %s must have at least %v items", p.Name, minItems)
+	}
+	if maxItems, ok := toNumber(p.Schema["maxItems"]); ok && float64(len(items)) > maxItems {
+		return fmt.Errorf("parameter %s must have at most %v items", p.Name, maxItems)
+	}
+	if p.Schema["uniqueItems"] == true {
+		seen := make(map[string]bool, len(items))
+		for _, item := range items {
+			if seen[item] {
+				return fmt.Errorf("parameter %s has duplicate item %q", p.Name, item)
+			}
+			seen[item] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package api
+
+import "net/http"
+
+// The status polling endpoint documented by WithAsyncResponse and AsyncStatusDefinition
+const (
+	AsyncStatusOperationID = "getOperationStatus"
+	AsyncStatusPath        = "/operations/{id}"
+)
+
+// States of a long-running operation reported by OperationStatus
+const (
+	OperationPending   = "pending"
+	OperationRunning   = "running"
+	OperationSucceeded = "succeeded"
+	OperationFailed    = "failed"
+)
+
+// OperationStatus is a ready-made status model for long-running operations
+type OperationStatus struct {
+	ID     string      `json:"id" doc:"Identifier of the operation"`
+	Status string      `json:"status" binding:"oneof=pending running succeeded failed" doc:"State of the operation"`
+	Result interface{} `json:"result,omitempty" doc:"Result of the operation once it succeeded"`
+	Error  string      `json:"error,omitempty" doc:"Reason the operation failed"`
+}
+
+// Chain call: document the operation as long-running, answering 202 Accepted with the status model
+// and a Location header pointing at the status endpoint (see AsyncStatusDefinition)
+// The 202 response links to that endpoint, passing the id property of the status model
+func (api *APIDefinition) WithAsyncResponse(statusModel interface{}) *APIDefinition {
+	api.WithStatusResponse(http.StatusAccepted, "Accepted - The operation continues in the background; poll the Location URL for its status", statusModel)
+	accepted := api.Responses[http.StatusAccepted]
+	accepted.Headers = map[string]Header{
+		"Location": {Description: "URL of the operation status", Schema: map[string]interface{}{"type": "string"}},
+	}
+	accepted.Links = map[string]Link{
+		"status": {
+			OperationID: AsyncStatusOperationID,
+			Parameters:  map[string]interface{}{"id": "$response.body#/id"},
+			Description: "Poll the status of the operation",
+		},
+	}
+	api.Responses[http.StatusAccepted] = accepted
+	return api
+}
+
+// AsyncStatusDefinition returns the GET /operations/{id} definition polled by clients of WithAsyncResponse operations
+// Set its handler and register it once alongside them
+func AsyncStatusDefinition(statusModel interface{}) *APIDefinition {
+	return NewAPIDefinition(http.MethodGet, AsyncStatusPath, "Get operation status").
+		WithOperationID(AsyncStatusOperationID).
+		WithDescription("Returns the status of a long-running operation, with its result once it completes").
+		WithPathParam("id", "Operation ID", true).
+		WithResponse(statusModel).
+		WithStatusResponse(http.StatusNotFound, "Not Found - The operation does not exist", nil)
+}
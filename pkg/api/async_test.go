@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+// TestWithAsyncResponse tests documenting the 202 response of a long-running operation and its status endpoint
+func TestWithAsyncResponse(t *testing.T) {
+	def := NewAPIDefinition("POST", "/reports", "Generate report").WithAsyncResponse(OperationStatus{})
+
+	accepted, ok := def.Responses[http.StatusAccepted]
+	if !ok {
+		t.Fatal("Expected a 202 response")
+	}
+	if _, ok := accepted.Headers["Location"]; !ok {
+		t.Error("Expected a Location header")
+	}
+	link := accepted.Links["status"]
+	if link.OperationID != AsyncStatusOperationID || link.Parameters["id"] != "$response.body#/id" {
+		t.Errorf("Unexpected status link %+v", link)
+	}
+
+	status := AsyncStatusDefinition(OperationStatus{})
+	if status.Method != "GET" || status.Path != AsyncStatusPath || status.OperationID != AsyncStatusOperationID {
+		t.Errorf("Unexpected status definition %s %s %s", status.Method, status.Path, status.OperationID)
+	}
+	if len(status.Params) != 1 || status.Params[0].Name != "id" || !status.Params[0].Required {
+		t.Errorf("Expected a required id path parameter, got %+v", status.Params)
+	}
+
+	schema, err := SchemaFromStruct(OperationStatus{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	state := schema["properties"].(map[string]interface{})["status"].(map[string]interface{})
+	if enum, _ := state["enum"].([]interface{}); len(enum) != 4 {
+		t.Errorf("Expected the operation states as an enum, got %v", state)
+	}
+}
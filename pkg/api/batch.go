@@ -0,0 +1,89 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// BatchPolicy marks a request body as an array of the request model
+type BatchPolicy struct {
+	MaxItems int // Maximum number of items per request (0 for no limit)
+}
+
+// BatchItemResult is the outcome of one item of a batch request
+type BatchItemResult struct {
+	Index  int         `json:"index" doc:"Position of the item in the request"`
+	Status int         `json:"status" doc:"HTTP status code of the item"`
+	Data   interface{} `json:"data,omitempty" doc:"Result of the item when it succeeded"`
+	Error  string      `json:"error,omitempty" doc:"Reason the item failed"`
+}
+
+// BatchResponse is the body of a 207 Multi-Status response, holding one result per item in request order
+type BatchResponse struct {
+	Results []BatchItemResult `json:"results"`
+}
+
+// Chain call: accept an array of model as the request body, with at most maxItems items (0 for no limit)
+// The 207 Multi-Status response is documented as a BatchResponse whose data is the model
+func (api *APIDefinition) WithBatchRequest(model interface{}, maxItems int) *APIDefinition {
+	api.Request = model
+	api.Batch = &BatchPolicy{MaxItems: maxItems}
+	return api.WithStatusResponse(http.StatusMultiStatus, "Multi-Status - One result per item, in request order", batchResultsOf(model))
+}
+
+// Schema returns the array schema of a batch of items
+func (p *BatchPolicy) Schema(items map[string]interface{}) map[string]interface{} {
+	schema := map[string]interface{}{
+		"type":     "array",
+		"items":    items,
+		"minItems": 1,
+	}
+	if p.MaxItems > 0 {
+		schema["maxItems"] = p.MaxItems
+	}
+	return schema
+}
+
+// Check validates the number of items of a batch
+func (p *BatchPolicy) Check(count int) error {
+	if count == 0 {
+		return fmt.Errorf("batch must contain at least one item")
+	}
+	if p.MaxItems > 0 && count > p.MaxItems {
+		return fmt.Errorf("batch exceeds %d items", p.MaxItems)
+	}
+	return nil
+}
+
+// RequestSchema returns the schema of a definition's request body: the request model, or an array of it for batches
+// Presence-only required_if conditions between body properties are documented in x-dependentRequired
+func RequestSchema(def *APIDefinition) (map[string]interface{}, error) {
+	schema, err := SafeSchemaFromStruct(def.Request)
+	if err != nil || schema == nil {
+		return schema, err
+	}
+	documentDependentRequired(def, schema)
+	if def.Batch == nil {
+		return schema, nil
+	}
+	return def.Batch.Schema(schema), nil
+}
+
+// batchResultsOf returns an empty BatchResponse-shaped value whose item data is typed as the model
+func batchResultsOf(model interface{}) interface{} {
+	t := reflect.TypeOf(model)
+	if t == nil {
+		return BatchResponse{}
+	}
+	result := reflect.StructOf([]reflect.StructField{
+		{Name: "Index", Type: reflect.TypeOf(0), Tag: `json:"index" doc:"Position of the item in the request"`},
+		{Name: "Status", Type: reflect.TypeOf(0), Tag: `json:"status" doc:"HTTP status code of the item"`},
+		{Name: "Data", Type: t, Tag: `json:"data,omitempty" doc:"Result of the item when it succeeded"`},
+		{Name: "Error", Type: reflect.TypeOf(""), Tag: `json:"error,omitempty" doc:"Reason the item failed"`},
+	})
+	response := reflect.StructOf([]reflect.StructField{
+		{Name: "Results", Type: reflect.SliceOf(result), Tag: `json:"results"`},
+	})
+	return reflect.New(response).Elem().Interface()
+}
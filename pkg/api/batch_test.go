@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+type batchItem struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// TestWithBatchRequest tests documenting an array request body and its multi-status response
+func TestWithBatchRequest(t *testing.T) {
+	def := NewAPIDefinition("POST", "/users/batch", "Create users").WithBatchRequest(batchItem{}, 50)
+
+	schema, err := RequestSchema(def)
+	if err != nil {
+		t.Fatalf("RequestSchema failed: %v", err)
+	}
+	items, _ := schema["items"].(map[string]interface{})
+	if schema["type"] != "array" || schema["maxItems"] != 50 || schema["minItems"] != 1 || items["type"] != "object" {
+		t.Errorf("Unexpected batch schema %v", schema)
+	}
+
+	response, ok := def.Responses[http.StatusMultiStatus]
+	if !ok {
+		t.Fatal("Expected a 207 response")
+	}
+	results, err := SchemaFromStruct(response.Model)
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	result := results["properties"].(map[string]interface{})["results"].(map[string]interface{})["items"].(map[string]interface{})
+	data := result["properties"].(map[string]interface{})["data"].(map[string]interface{})
+	if _, ok := data["properties"].(map[string]interface{})["name"]; !ok {
+		t.Errorf("Expected item results typed as the model, got %v", result)
+	}
+}
+
+// TestBatchPolicyCheck tests batch size checks
+func TestBatchPolicyCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  BatchPolicy
+		count   int
+		wantErr bool
+	}{
+		{name: "within limit", policy: BatchPolicy{MaxItems: 2}, count: 2},
+		{name: "unlimited", policy: BatchPolicy{}, count: 1000},
+		{name: "empty", policy: BatchPolicy{MaxItems: 2}, count: 0, wantErr: true},
+		{name: "too many", policy: BatchPolicy{MaxItems: 2}, count: 3, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Check(tt.count); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// BreakingChange is a change to an operation of a published document that breaks its existing clients
+type BreakingChange struct {
+	Operation string `json:"operation"` // "METHOD /path"
+	Message   string `json:"message"`
+}
+
+func (c BreakingChange) String() string {
+	return c.Operation + ": " + c.Message
+}
+
+// BreakingChanges reports the operations of oldDoc that newDoc removes or narrows: new required parameters,
+// parameter and request body schemas that reject previously valid input, and response schemas that omit
+// previously returned data; additions are never breaking
+func BreakingChanges(oldDoc, newDoc []byte) ([]BreakingChange, error) {
+	oldOps, err := decodeOperations(oldDoc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read old document: %w", err)
+	}
+	newOps, err := decodeOperations(newDoc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read new document: %w", err)
+	}
+
+	var changes []BreakingChange
+	for _, key := range sortedKeys(oldOps) {
+		report := func(format string, args ...interface{}) {
+			changes = append(changes, BreakingChange{Operation: key, Message: fmt.Sprintf(format, args...)})
+		}
+		oldOp := oldOps[key]
+		newOp, ok := newOps[key]
+		if !ok {
+			report("operation was removed")
+			continue
+		}
+
+		// New requests must accept everything old clients send
+		oldParams, newParams := indexParameters(oldOp), indexParameters(newOp)
+		for _, name := range sortedKeys(newParams) {
+			param := newParams[name]
+			oldParam, existed := oldParams[name]
+			required, _ := param["required"].(bool)
+			wasRequired, _ := oldParam["required"].(bool)
+			if required && !wasRequired {
+				report("parameter %s is now required", name)
+			}
+			if existed {
+				for _, issue := range backwardIssues(schemaOf(oldParam), schemaOf(param)) {
+					report("parameter %s: %s", name, issue)
+				}
+			}
+		}
+		oldBody, newBody := contentSchemas(oldOp["requestBody"]), contentSchemas(newOp["requestBody"])
+		for _, mediaType := range sortedKeys(oldBody) {
+			schema, ok := newBody[mediaType]
+			if !ok {
+				report("%s request bodies are no longer accepted", mediaType)
+				continue
+			}
+			for _, issue := range backwardIssues(oldBody[mediaType], schema) {
+				report("request body: %s", issue)
+			}
+		}
+
+		// Responses must keep what old clients read
+		oldResponses, _ := oldOp["responses"].(map[string]interface{})
+		newResponses, _ := newOp["responses"].(map[string]interface{})
+		for _, status := range sortedKeys(oldResponses) {
+			newResponse, ok := newResponses[status]
+			if !ok {
+				continue
+			}
+			oldContent, newContent := contentSchemas(oldResponses[status]), contentSchemas(newResponse)
+			for _, mediaType := range sortedKeys(oldContent) {
+				schema, ok := newContent[mediaType]
+				if !ok {
+					report("%s response no longer returns %s", status, mediaType)
+					continue
+				}
+				issues, _ := CheckCompatibility(oldContent[mediaType], schema, CompatForward)
+				for _, issue := range issues {
+					report("%s response: %s", status, issueText(issue))
+				}
+			}
+		}
+	}
+	return changes, nil
+}
+
+// decodeOperations maps "METHOD /path" to each decoded operation of a serialized document, with the local
+// $refs of its parameters, request bodies, responses and schemas resolved against the document's components
+func decodeOperations(doc []byte) (map[string]map[string]interface{}, error) {
+	ops := make(map[string]map[string]interface{})
+	if len(doc) == 0 {
+		return ops, nil
+	}
+
+	var root map[string]interface{}
+	if err := json.Unmarshal(doc, &root); err != nil {
+		return nil, err
+	}
+	paths, _ := root["paths"].(map[string]interface{})
+	for path, value := range paths {
+		item, _ := value.(map[string]interface{})
+		for method, op := range item {
+			if !IsSupportedMethod(method) {
+				continue
+			}
+			if resolved, ok := inlineRefs(root, op, nil).(map[string]interface{}); ok {
+				ops[strings.ToUpper(method)+" "+path] = resolved
+			}
+		}
+	}
+	return ops, nil
+}
+
+// inlineRefs returns a copy of a decoded value with its local $refs replaced by their targets in root
+// References that can't be resolved, or that would recurse into themselves, are kept as is
+func inlineRefs(root map[string]interface{}, value interface{}, resolving []string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok && strings.HasPrefix(ref, "#") && !containsString(resolving, ref) {
+			if target, err := resolvePointer(root, strings.TrimPrefix(ref, "#")); err == nil {
+				return inlineRefs(root, target, append(resolving, ref))
+			}
+		}
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = inlineRefs(root, item, resolving)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = inlineRefs(root, item, resolving)
+		}
+		return out
+	}
+	return value
+}
+
+// indexParameters maps "in name" to each decoded parameter of an operation
+func indexParameters(op map[string]interface{}) map[string]map[string]interface{} {
+	params := make(map[string]map[string]interface{})
+	list, _ := op["parameters"].([]interface{})
+	for _, item := range list {
+		if param, ok := item.(map[string]interface{}); ok {
+			params[fmt.Sprintf("%v %v", param["in"], param["name"])] = param
+		}
+	}
+	return params
+}
+
+// schemaOf returns the schema of a decoded parameter, nil when it has none
+func schemaOf(param map[string]interface{}) map[string]interface{} {
+	schema, _ := param["schema"].(map[string]interface{})
+	return schema
+}
+
+// contentSchemas maps the media types of a decoded request body or response to their schemas
+func contentSchemas(value interface{}) map[string]map[string]interface{} {
+	schemas := make(map[string]map[string]interface{})
+	object, _ := value.(map[string]interface{})
+	content, _ := object["content"].(map[string]interface{})
+	for mediaType, item := range content {
+		media, _ := item.(map[string]interface{})
+		schema, _ := media["schema"].(map[string]interface{})
+		schemas[mediaType] = schema
+	}
+	return schemas
+}
+
+// backwardIssues describes why input valid against oldSchema may be rejected by newSchema
+func backwardIssues(oldSchema, newSchema map[string]interface{}) []string {
+	issues, _ := CheckCompatibility(oldSchema, newSchema, CompatBackward)
+	texts := make([]string, len(issues))
+	for i, issue := range issues {
+		texts[i] = issueText(issue)
+	}
+	return texts
+}
+
+// issueText describes a compatibility issue without its direction, which the caller implies
+func issueText(issue CompatibilityIssue) string {
+	if issue.Path == "" {
+		return issue.Message
+	}
+	return issue.Path + ": " + issue.Message
+}
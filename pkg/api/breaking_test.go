@@ -0,0 +1,120 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestBreakingChanges tests detecting removed and narrowed operations
+func TestBreakingChanges(t *testing.T) {
+	base := `{"paths":{"/users":{"get":{
+		"parameters":[{"name":"q","in":"query","required":false,"schema":{"type":"string"}}],
+		"responses":{"200":{"description":"OK","content":{"application/json":{"schema":{"type":"object","required":["id"],"properties":{"id":{"type":"integer"}}}}}}}},
+		"post":{"requestBody":{"content":{"application/json":{"schema":{"type":"object","properties":{"name":{"type":"string"}}}}}},"responses":{}}}}}`
+
+	tests := []struct {
+		name   string
+		newDoc string
+		want   []string
+	}{
+		{"unchanged", base, nil},
+		{
+			"additions",
+			`{"paths":{"/users":{"get":{
+				"parameters":[{"name":"q","in":"query","required":false,"schema":{"type":"string"}},{"name":"page","in":"query","required":false}],
+				"responses":{"200":{"description":"OK","content":{"application/json":{"schema":{"type":"object","required":["id"],"properties":{"id":{"type":"integer"},"name":{"type":"string"}}}}}}}},
+				"post":{"requestBody":{"content":{"application/json":{"schema":{"type":"object","properties":{"name":{"type":"string"}}}}}},"responses":{}}},
+				"/orders":{"get":{"responses":{}}}}}`,
+			nil,
+		},
+		{
+			"removed and narrowed",
+			`{"paths":{"/users":{"get":{
+				"parameters":[{"name":"q","in":"query","required":true,"schema":{"type":"string","maxLength":10}}],
+				"responses":{"200":{"description":"OK","content":{"application/json":{"schema":{"type":"object","properties":{"name":{"type":"string"}}}}}}}}}}}`,
+			[]string{
+				"GET /users: parameter query q is now required",
+				"GET /users: parameter query q: maxLength tightened to 10",
+				"GET /users: 200 response: id: required field is missing from written data",
+				"POST /users: operation was removed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := BreakingChanges([]byte(base), []byte(tt.newDoc))
+			if err != nil {
+				t.Fatalf("BreakingChanges() error = %v", err)
+			}
+			var got []string
+			for _, change := range changes {
+				got = append(got, change.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BreakingChanges() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := BreakingChanges([]byte(base), []byte("not json")); err == nil {
+		t.Error("Expected error for invalid document")
+	}
+}
+
+// TestBreakingChangesRefs tests resolving $refs against each document's components before comparing
+func TestBreakingChangesRefs(t *testing.T) {
+	base := `{"paths":{"/users":{"post":{
+		"parameters":[{"$ref":"#/components/parameters/Tenant"},{"$ref":"#/components/parameters/Trace"}],
+		"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/User"}}}},"responses":{}}}},
+		"components":{
+			"parameters":{
+				"Tenant":{"name":"tenant","in":"header","required":false,"schema":{"type":"string"}},
+				"Trace":{"name":"trace","in":"header","required":false,"schema":{"type":"string"}}},
+			"schemas":{"User":{"type":"object","properties":{"name":{"type":"string"},"manager":{"$ref":"#/components/schemas/User"}}}}}}`
+
+	tests := []struct {
+		name   string
+		newDoc string
+		want   []string
+	}{
+		{name: "unchanged", newDoc: base},
+		{
+			name: "inlined",
+			newDoc: `{"paths":{"/users":{"post":{
+				"parameters":[{"name":"tenant","in":"header","required":false,"schema":{"type":"string"}},{"name":"trace","in":"header","required":false,"schema":{"type":"string"}}],
+				"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/User"}}}},"responses":{}}}},
+				"components":{"schemas":{"User":{"type":"object","properties":{"name":{"type":"string"},"manager":{"$ref":"#/components/schemas/User"}}}}}}`,
+		},
+		{
+			name: "narrowed components",
+			newDoc: `{"paths":{"/users":{"post":{
+				"parameters":[{"$ref":"#/components/parameters/Tenant"},{"$ref":"#/components/parameters/Trace"}],
+				"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/User"}}}},"responses":{}}}},
+				"components":{
+					"parameters":{
+						"Tenant":{"name":"tenant","in":"header","required":true,"schema":{"type":"string"}},
+						"Trace":{"name":"trace","in":"header","required":false,"schema":{"type":"string"}}},
+					"schemas":{"User":{"type":"object","properties":{"name":{"type":"string","maxLength":20},"manager":{"$ref":"#/components/schemas/User"}}}}}}`,
+			want: []string{
+				"POST /users: parameter header tenant is now required",
+				"POST /users: request body: name: maxLength tightened to 20",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := BreakingChanges([]byte(base), []byte(tt.newDoc))
+			if err != nil {
+				t.Fatalf("BreakingChanges() error = %v", err)
+			}
+			var got []string
+			for _, change := range changes {
+				got = append(got, change.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BreakingChanges() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
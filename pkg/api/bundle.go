@@ -0,0 +1,300 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// splitComponents lists the component kinds Split writes to separate files
+var splitComponents = []string{"schemas", "parameters", "requestBodies", "responses", "headers", "examples"}
+
+// Bundle inlines the external references of a document so it fits in one file
+// Relative references are resolved against the working directory; use a RefResolver for another base
+func Bundle(doc *OpenAPIDoc) error {
+	return NewRefResolver(RefBundle, "").Resolve(doc)
+}
+
+// Split writes a document as a multi-file layout under dir: openapi.json referencing one file per path
+// under paths/ and one file per component under components/<kind>/, linked by relative references
+// LoadSplit reads the layout back into a single document
+func Split(doc *OpenAPIDoc, dir string) error {
+	var root map[string]interface{}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal document: %w", err)
+	}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	files := make(map[string]interface{})
+	if paths, ok := root["paths"].(map[string]interface{}); ok {
+		used := make(map[string]bool)
+		for _, path := range sortedKeys(paths) {
+			name := pathFileName(path)
+			unique := name
+			for n := 2; used[unique]; n++ {
+				unique = fmt.Sprintf("%s_%d", name, n)
+			}
+			used[unique] = true
+			file := "paths/" + unique + ".json"
+			files[file] = splitRefs(paths[path], "paths")
+			paths[path] = map[string]interface{}{"$ref": file}
+		}
+	}
+	if components, ok := root["components"].(map[string]interface{}); ok {
+		for _, kind := range splitComponents {
+			entries, ok := components[kind].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for name, value := range entries {
+				file := "components/" + kind + "/" + name + ".json"
+				files[file] = splitRefs(value, "components/"+kind)
+				entries[name] = map[string]interface{}{"$ref": file}
+			}
+		}
+	}
+	files["openapi.json"] = root
+
+	for file, value := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", file, err)
+		}
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", file, err)
+		}
+		if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// pathFileNameChars matches characters replaced in the file names of split paths
+var pathFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// pathFileName names the file of a split path, e.g. /users/{id} -> users_id
+func pathFileName(path string) string {
+	name := strings.Trim(pathFileNameChars.ReplaceAllString(path, "_"), "_")
+	if name == "" {
+		return "root"
+	}
+	return name
+}
+
+// splitRefs rewrites the component references of a value moved to a file in dir into relative file references
+func splitRefs(value interface{}, dir string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if ref, ok := item.(string); ok && key == "$ref" && strings.HasPrefix(ref, "#/components/") {
+				target := strings.TrimPrefix(ref, "#/") + ".json"
+				if rel, err := filepath.Rel(filepath.FromSlash(dir), filepath.FromSlash(target)); err == nil {
+					out[key] = filepath.ToSlash(rel)
+					continue
+				}
+			}
+			out[key] = splitRefs(item, dir)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = splitRefs(item, dir)
+		}
+		return out
+	}
+	return value
+}
+
+// LoadSplit reads a multi-file document, such as one written by Split, into a single document
+// Files registered as components become local references again; other external references are inlined
+func LoadSplit(path string) (*OpenAPIDoc, error) {
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	b := &bundler{
+		resolver:   NewRefResolver(RefBundle, filepath.Dir(root)),
+		root:       root,
+		components: make(map[string]string),
+	}
+	document, err := b.resolver.load(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	top, ok := document.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is not an OpenAPI document", path)
+	}
+
+	// Components kept in their own files are referenced locally in the bundle
+	components, _ := top["components"].(map[string]interface{})
+	for kind, value := range components {
+		entries, _ := value.(map[string]interface{})
+		for name, entry := range entries {
+			if ref, ok := externalRef(entry); ok {
+				location, pointer, _ := strings.Cut(ref, "#")
+				if location != "" && pointer == "" {
+					b.components[b.resolver.locate(location, root)] = "#/components/" + kind + "/" + name
+				}
+			}
+		}
+	}
+
+	bundled := make(map[string]interface{}, len(top))
+	for key, value := range top {
+		if key == "components" {
+			continue
+		}
+		if bundled[key], err = b.bundle(value, root, nil); err != nil {
+			return nil, err
+		}
+	}
+	if components != nil {
+		out := make(map[string]interface{}, len(components))
+		for kind, value := range components {
+			entries, ok := value.(map[string]interface{})
+			if !ok {
+				out[kind] = value
+				continue
+			}
+			resolved := make(map[string]interface{}, len(entries))
+			for name, entry := range entries {
+				// The entry itself is inlined rather than turned into a reference to itself
+				if ref, ok := externalRef(entry); ok {
+					entry, err = b.inline(ref, root, nil)
+				} else {
+					entry, err = b.bundle(entry, root, nil)
+				}
+				if err != nil {
+					return nil, err
+				}
+				resolved[name] = entry
+			}
+			out[kind] = resolved
+		}
+		bundled["components"] = out
+	}
+
+	data, err := json.Marshal(bundled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bundled document: %w", err)
+	}
+	var doc OpenAPIDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode bundled document: %w", err)
+	}
+	return &doc, nil
+}
+
+// bundler merges the files of a multi-file document
+type bundler struct {
+	resolver   *RefResolver
+	root       string            // Location of the root document
+	components map[string]string // Local references of the files registered as components
+}
+
+// bundle returns a copy of a value from the document at base with its external references resolved
+func (b *bundler) bundle(value interface{}, base string, stack []string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			return b.reference(ref, base, stack)
+		}
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			resolved, err := b.bundle(item, base, stack)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = resolved
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			resolved, err := b.bundle(item, base, stack)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = resolved
+		}
+		return out, nil
+	}
+	return value, nil
+}
+
+// reference keeps references into the root document and registered components, inlining the others
+func (b *bundler) reference(ref, base string, stack []string) (interface{}, error) {
+	location, pointer, _ := strings.Cut(ref, "#")
+	if location == "" {
+		location = base
+	} else {
+		location = b.resolver.locate(location, base)
+	}
+	if local, ok := b.components[location]; ok && pointer == "" {
+		return map[string]interface{}{"$ref": local}, nil
+	}
+	if location == b.root {
+		return map[string]interface{}{"$ref": "#" + pointer}, nil
+	}
+	return b.inline(ref, base, stack)
+}
+
+// inline replaces a reference by the value it designates
+func (b *bundler) inline(ref, base string, stack []string) (interface{}, error) {
+	location, pointer, _ := strings.Cut(ref, "#")
+	if location == "" {
+		location = base
+	} else {
+		location = b.resolver.locate(location, base)
+	}
+	target := location + "#" + pointer
+	for _, seen := range stack {
+		if seen == target {
+			return nil, fmt.Errorf("circular reference: %s", target)
+		}
+	}
+	document, err := b.resolver.load(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", ref, err)
+	}
+	value, err := resolvePointer(document, pointer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	return b.bundle(value, location, append(stack, target))
+}
+
+// externalRef returns the reference of a {"$ref": ...} value pointing outside its document
+func externalRef(value interface{}) (string, bool) {
+	entry, ok := value.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	ref, ok := entry["$ref"].(string)
+	if !ok || strings.HasPrefix(ref, "#") {
+		return "", false
+	}
+	return ref, true
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// splitDoc creates a document with shared and recursive components
+func splitDoc() *OpenAPIDoc {
+	return &OpenAPIDoc{
+		OpenAPI: "3.0.0",
+		Info:    OpenAPIInfo{Title: "Test API", Version: "1.0.0"},
+		Paths: map[string]PathItem{
+			"/customers/{id}": {Get: &Operation{
+				Summary:    "Get customer",
+				Parameters: []Parameter{{Name: "page", In: "query", Ref: "#/components/parameters/Page"}},
+				Responses: map[string]Response{"200": {Description: "Success", Content: map[string]Content{
+					"application/json": {Schema: map[string]interface{}{"$ref": "#/components/schemas/Customer"}},
+				}}},
+				Extensions: map[string]interface{}{"x-owner": "accounts"},
+			}},
+			"/": {Get: &Operation{Summary: "Index", Responses: map[string]Response{"200": {Description: "Success"}}}},
+		},
+		Components: &Components{
+			Schemas: map[string]interface{}{
+				"Customer": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"address":  map[string]interface{}{"$ref": "#/components/schemas/Address"},
+						"referrer": map[string]interface{}{"$ref": "#/components/schemas/Customer"},
+					},
+				},
+				"Address": map[string]interface{}{"type": "object"},
+			},
+			Parameters: map[string]Parameter{
+				"Page": {Name: "page", In: "query", Schema: map[string]interface{}{"type": "integer"}},
+			},
+			SecuritySchemes: map[string]SecurityScheme{"bearer": {Type: "http", Scheme: "bearer"}},
+		},
+	}
+}
+
+// TestSplit tests writing a document as a multi-file layout and reading it back
+func TestSplit(t *testing.T) {
+	dir := t.TempDir()
+	doc := splitDoc()
+	if err := Split(doc, dir); err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+
+	for _, file := range []string{
+		"openapi.json",
+		"paths/customers_id.json",
+		"paths/root.json",
+		"components/schemas/Customer.json",
+		"components/schemas/Address.json",
+		"components/parameters/Page.json",
+	} {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			t.Errorf("Expected %s to be written: %v", file, err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "paths/customers_id.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var item map[string]interface{}
+	if err := json.Unmarshal(data, &item); err != nil {
+		t.Fatal(err)
+	}
+	content := item["get"].(map[string]interface{})["responses"].(map[string]interface{})["200"].(map[string]interface{})["content"]
+	schema := content.(map[string]interface{})["application/json"].(map[string]interface{})["schema"]
+	if want := map[string]interface{}{"$ref": "../components/schemas/Customer.json"}; !reflect.DeepEqual(schema, want) {
+		t.Errorf("Expected a relative reference to the component file, got %v", schema)
+	}
+
+	loaded, err := LoadSplit(filepath.Join(dir, "openapi.json"))
+	if err != nil {
+		t.Fatalf("LoadSplit failed: %v", err)
+	}
+	want, _ := json.Marshal(splitDoc())
+	got, _ := json.Marshal(loaded)
+	if string(got) != string(want) {
+		t.Errorf("Expected the split document to load back unchanged\nwant: %s\ngot:  %s", want, got)
+	}
+}
+
+// TestLoadSplitErrors tests reading broken multi-file layouts
+func TestLoadSplitErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{name: "missing root", files: map[string]string{}},
+		{name: "missing path file", files: map[string]string{
+			"openapi.json": `{"openapi": "3.0.0", "paths": {"/users": {"$ref": "paths/users.json"}}}`,
+		}},
+		{name: "circular inline", files: map[string]string{
+			"openapi.json": `{"openapi": "3.0.0", "paths": {"/users": {"$ref": "paths/users.json"}}}`,
+			"paths/users.json": `{"get": {"responses": {"200": {"description": "ok", "content": {"application/json": ` +
+				`{"schema": {"$ref": "../node.json"}}}}}}}`,
+			"node.json": `{"type": "object", "properties": {"next": {"$ref": "node.json"}}}`,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := LoadSplit(filepath.Join(dir, "openapi.json")); err == nil {
+				t.Error("Expected LoadSplit to fail")
+			}
+		})
+	}
+}
+
+// TestBundle tests inlining external references into a document
+func TestBundle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "common.json")
+	if err := os.WriteFile(path, []byte(`{"Address": {"type": "object"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	doc := refDoc(ExternalRef(path + "#/Address"))
+	if err := Bundle(doc); err != nil {
+		t.Fatalf("Bundle failed: %v", err)
+	}
+	if got := responseSchema(doc); !reflect.DeepEqual(got, map[string]interface{}{"type": "object"}) {
+		t.Errorf("Expected the reference to be inlined, got %v", got)
+	}
+}
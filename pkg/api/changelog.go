@@ -0,0 +1,76 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChangelogEntry records a change to an API in a given release
+type ChangelogEntry struct {
+	Version string `json:"version"`
+	Note    string `json:"note"`
+}
+
+// Chain call: add a changelog entry, emitted as x-changelog and in the generated changelog
+func (api *APIDefinition) WithChangelog(version, note string) *APIDefinition {
+	api.Changelog = append(api.Changelog, ChangelogEntry{Version: version, Note: note})
+	return api
+}
+
+// GenerateChangelog renders a markdown changelog from the entries of the given definitions,
+// newest version first
+func GenerateChangelog(title string, defs []APIDefinition) string {
+	byVersion := make(map[string][]string)
+	for _, def := range defs {
+		for _, entry := range def.Changelog {
+			line := fmt.Sprintf("- `%s %s`: %s", strings.ToUpper(def.Method), def.Path, entry.Note)
+			byVersion[entry.Version] = append(byVersion[entry.Version], line)
+		}
+	}
+
+	versions := make([]string, 0, len(byVersion))
+	for version := range byVersion {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) > 0
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s Changelog\n", title)
+	for _, version := range versions {
+		lines := byVersion[version]
+		sort.Strings(lines)
+		fmt.Fprintf(&b, "\n## %s\n\n%s\n", version, strings.Join(lines, "\n"))
+	}
+	return b.String()
+}
+
+// compareVersions compares dotted version strings numerically segment by segment (a leading "v" is ignored)
+func compareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		av, bv := "0", "0"
+		if i < len(as) {
+			av = as[i]
+		}
+		if i < len(bs) {
+			bv = bs[i]
+		}
+		an, aErr := strconv.Atoi(av)
+		bn, bErr := strconv.Atoi(bv)
+		switch {
+		case aErr == nil && bErr == nil && an != bn:
+			if an < bn {
+				return -1
+			}
+			return 1
+		case (aErr != nil || bErr != nil) && av != bv:
+			return strings.Compare(av, bv)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGenerateChangelog tests markdown changelog rendering
+func TestGenerateChangelog(t *testing.T) {
+	defs := []APIDefinition{
+		*NewAPIDefinition("GET", "/users", "List users").
+			WithChangelog("1.2.0", "Added pagination").
+			WithChangelog("1.10.0", "Added filter parameter"),
+		*NewAPIDefinition("post", "/users", "Create user").
+			WithChangelog("1.2.0", "Initial release"),
+	}
+
+	got := GenerateChangelog("Users API", defs)
+	want := "# Users API Changelog\n" +
+		"\n## 1.10.0\n\n- `GET /users`: Added filter parameter\n" +
+		"\n## 1.2.0\n\n- `GET /users`: Added pagination\n- `POST /users`: Initial release\n"
+	if got != want {
+		t.Errorf("Unexpected changelog:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestCompareVersions tests numeric version ordering
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.0", "1.10.0", -1},
+		{"v2.0", "1.9.9", 1},
+		{"1.0", "1.0.0", 0},
+		{"1.0.0-beta", "1.0.0-alpha", 1},
+	}
+	for _, tt := range tests {
+		got := compareVersions(tt.a, tt.b)
+		if (got < 0) != (tt.want < 0) || (got > 0) != (tt.want > 0) {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+	if !strings.HasPrefix(GenerateChangelog("Empty", nil), "# Empty Changelog") {
+		t.Error("Expected title for empty changelog")
+	}
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"mime"
+	"sync"
+)
+
+// Media types of the codecs in pkg/codec
+const (
+	MediaTypeMsgPack  = "application/msgpack"
+	MediaTypeProtobuf = "application/x-protobuf"
+	MediaTypeCBOR     = "application/cbor"
+)
+
+// Codec decodes request bodies of a media type other than JSON into the request model
+type Codec interface {
+	ContentType() string
+	Decode(data []byte, v interface{}) error
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = make(map[string]Codec)
+)
+
+// RegisterCodec makes adapters decode request bodies of the codec's media type, for definitions accepting it
+// with WithRequestTypes
+func RegisterCodec(codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[codec.ContentType()] = codec
+}
+
+// CodecFor returns the codec registered for the media type of a Content-Type header value
+func CodecFor(contentType string) (Codec, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	codec, ok := codecs[mediaType]
+	return codec, ok
+}
+
+// Chain call: accept request bodies in media types besides JSON, decoded by the codecs registered with
+// RegisterCodec and documented with the request model's schema
+func (api *APIDefinition) WithRequestTypes(mediaTypes ...string) *APIDefinition {
+	api.RequestTypes = append(api.RequestTypes, mediaTypes...)
+	return api
+}
+
+// RequestCodec returns the codec decoding a request body with the given Content-Type, when the definition
+// accepts its media type; JSON bodies have none
+func (api *APIDefinition) RequestCodec(contentType string) (Codec, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !containsString(api.RequestTypes, mediaType) {
+		return nil, false
+	}
+	return CodecFor(mediaType)
+}
@@ -0,0 +1,229 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// CompatibilityMode selects which readers and writers of a model schema must keep working together,
+// following the Avro schema evolution rules
+type CompatibilityMode string
+
+const (
+	// CompatBackward requires readers using the new schema to read data written with the old one
+	CompatBackward CompatibilityMode = "BACKWARD"
+	// CompatForward requires readers using the old schema to read data written with the new one
+	CompatForward CompatibilityMode = "FORWARD"
+	// CompatFull requires both backward and forward compatibility
+	CompatFull CompatibilityMode = "FULL"
+)
+
+// CompatibilityIssue is a schema change breaking a compatibility direction
+type CompatibilityIssue struct {
+	Mode    CompatibilityMode // Direction broken: CompatBackward or CompatForward
+	Path    string            // Property path, e.g. address.street or items[] ("" for the root)
+	Message string
+}
+
+func (i CompatibilityIssue) String() string {
+	path := i.Path
+	if path == "" {
+		path = "(root)"
+	}
+	return fmt.Sprintf("%s %s: %s", i.Mode, path, i.Message)
+}
+
+// CheckCompatibility reports the changes from oldSchema to newSchema that break the compatibility mode,
+// e.g. to verify a model persisted or published as events can still be read after a struct change
+func CheckCompatibility(oldSchema, newSchema map[string]interface{}, mode CompatibilityMode) ([]CompatibilityIssue, error) {
+	var issues []CompatibilityIssue
+	switch mode {
+	case CompatBackward:
+		issues = checkReadable(newSchema, oldSchema, "", CompatBackward, issues)
+	case CompatForward:
+		issues = checkReadable(oldSchema, newSchema, "", CompatForward, issues)
+	case CompatFull:
+		issues = checkReadable(newSchema, oldSchema, "", CompatBackward, issues)
+		issues = checkReadable(oldSchema, newSchema, "", CompatForward, issues)
+	default:
+		return nil, fmt.Errorf("unknown compatibility mode: %s", mode)
+	}
+	return issues, nil
+}
+
+// schemaBounds lists the constraints a reader may not tighten, with whether they are upper bounds
+var schemaBounds = []struct {
+	keyword string
+	upper   bool
+}{
+	{"maximum", true}, {"maxLength", true}, {"maxItems", true}, {"maxProperties", true},
+	{"minimum", false}, {"minLength", false}, {"minItems", false}, {"minProperties", false},
+}
+
+// checkReadable appends the reasons data valid against writer may be rejected by reader
+func checkReadable(reader, writer map[string]interface{}, path string, mode CompatibilityMode, issues []CompatibilityIssue) []CompatibilityIssue {
+	report := func(format string, args ...interface{}) {
+		issues = append(issues, CompatibilityIssue{Mode: mode, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+	if reader == nil || writer == nil {
+		return issues
+	}
+
+	// References and composed schemas are compared as a whole, through their JSON encoding so
+	// generated and decoded schemas match
+	for _, keyword := range []string{"$ref", "allOf", "oneOf", "anyOf"} {
+		if !jsonEqual(reader[keyword], writer[keyword]) {
+			report("%s changed", keyword)
+			return issues
+		}
+	}
+
+	readerType, _ := reader["type"].(string)
+	writerType, _ := writer["type"].(string)
+	if readerType != "" && readerType != writerType && !(readerType == "number" && writerType == "integer") {
+		if writerType == "" {
+			writerType = "any"
+		}
+		report("type %s can't be read as %s", writerType, readerType)
+		return issues
+	}
+
+	if nullable(writer) && !nullable(reader) {
+		report("null values are no longer accepted")
+	}
+	if readerFormat, ok := reader["format"].(string); ok && readerType == writerType && readerFormat != writer["format"] {
+		report("format changed to %s", readerFormat)
+	}
+
+	// Every written enum value must stay readable
+	if readerEnum, ok := reader["enum"].([]interface{}); ok {
+		writerEnum, ok := writer["enum"].([]interface{})
+		if !ok {
+			report("values are restricted to an enum")
+		}
+		for _, value := range writerEnum {
+			if !containsValue(readerEnum, value) {
+				report("enum value %v can't be read", value)
+			}
+		}
+	}
+
+	for _, bound := range schemaBounds {
+		readerBound, ok := toNumber(reader[bound.keyword])
+		if !ok {
+			continue
+		}
+		writerBound, ok := toNumber(writer[bound.keyword])
+		if !ok || (bound.upper && writerBound > readerBound) || (!bound.upper && writerBound < readerBound) {
+			report("%s tightened to %v", bound.keyword, readerBound)
+		}
+	}
+
+	if readerItems, ok := reader["items"].(map[string]interface{}); ok {
+		writerItems, _ := writer["items"].(map[string]interface{})
+		issues = checkReadable(readerItems, writerItems, path+"[]", mode, issues)
+	}
+
+	readerProps, _ := reader["properties"].(map[string]interface{})
+	writerProps, _ := writer["properties"].(map[string]interface{})
+	readerRequired := requiredSet(reader)
+	writerRequired := requiredSet(writer)
+	for _, name := range sortedKeys(readerProps) {
+		propPath := name
+		if path != "" {
+			propPath = path + "." + name
+		}
+		writerProp, written := writerProps[name]
+		readerSchema, _ := readerProps[name].(map[string]interface{})
+		_, defaulted := readerSchema["default"]
+		switch {
+		case defaulted:
+			// The reader fills in missing fields with their default
+		case readerRequired[name] && !written:
+			issues = append(issues, CompatibilityIssue{Mode: mode, Path: propPath, Message: "required field is missing from written data"})
+			continue
+		case readerRequired[name] && !writerRequired[name]:
+			issues = append(issues, CompatibilityIssue{Mode: mode, Path: propPath, Message: "required field may be missing from written data"})
+		}
+		if written {
+			writerSchema, _ := writerProp.(map[string]interface{})
+			issues = checkReadable(readerSchema, writerSchema, propPath, mode, issues)
+		}
+	}
+	if additional, ok := reader["additionalProperties"].(bool); ok && !additional {
+		for _, name := range sortedKeys(writerProps) {
+			if _, ok := readerProps[name]; !ok {
+				report("field %s is written but not allowed", name)
+			}
+		}
+	}
+	return issues
+}
+
+// nullable reports whether a schema accepts null
+func nullable(schema map[string]interface{}) bool {
+	value, _ := schema["nullable"].(bool)
+	return value
+}
+
+// requiredSet returns the required properties of a schema, from generated ([]string) or decoded ([]interface{}) schemas
+func requiredSet(schema map[string]interface{}) map[string]bool {
+	set := make(map[string]bool)
+	switch required := schema["required"].(type) {
+	case []string:
+		for _, name := range required {
+			set[name] = true
+		}
+	case []interface{}:
+		for _, name := range required {
+			if s, ok := name.(string); ok {
+				set[s] = true
+			}
+		}
+	}
+	return set
+}
+
+// containsValue reports whether values holds value, comparing numbers by value
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+		a, aok := toNumber(v)
+		b, bok := toNumber(value)
+		if aok && bok && a == b {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonEqual reports whether two schema values have the same JSON encoding
+func jsonEqual(a, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+// toNumber converts the numeric values found in generated and decoded schemas to float64
+func toNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return 0, false
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// Versions of an event model
+type orderEventV1 struct {
+	ID     string  `json:"id"`
+	Amount float64 `json:"amount"`
+	Status string  `json:"status" binding:"oneof=open closed"`
+	Note   string  `json:"note,omitempty"`
+}
+
+type orderEventAddedOptional struct {
+	ID       string  `json:"id"`
+	Amount   float64 `json:"amount"`
+	Status   string  `json:"status" binding:"oneof=open closed"`
+	Note     string  `json:"note,omitempty"`
+	Currency string  `json:"currency,omitempty"`
+}
+
+type orderEventAddedRequired struct {
+	ID       string  `json:"id"`
+	Amount   float64 `json:"amount"`
+	Status   string  `json:"status" binding:"oneof=open closed"`
+	Note     string  `json:"note,omitempty"`
+	Currency string  `json:"currency"`
+}
+
+type orderEventRemovedRequired struct {
+	Amount float64 `json:"amount"`
+	Status string  `json:"status" binding:"oneof=open closed"`
+	Note   string  `json:"note,omitempty"`
+}
+
+type orderEventNarrowed struct {
+	ID     string `json:"id"`
+	Amount int    `json:"amount"`
+	Status string `json:"status" binding:"oneof=open"`
+	Note   string `json:"note,omitempty" binding:"max=100"`
+}
+
+// mustSchema generates the schema of a model
+func mustSchema(t *testing.T, v interface{}) map[string]interface{} {
+	t.Helper()
+	schema, err := SchemaFromStruct(v)
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	return schema
+}
+
+// TestCheckCompatibility tests the Avro-style evolution rules for each mode
+func TestCheckCompatibility(t *testing.T) {
+	issueKeys := func(issues []CompatibilityIssue) []string {
+		var keys []string
+		for _, issue := range issues {
+			keys = append(keys, string(issue.Mode)+" "+issue.Path)
+		}
+		return keys
+	}
+
+	tests := []struct {
+		name string
+		next interface{}
+		mode CompatibilityMode
+		want []string
+	}{
+		{name: "optional field added is fully compatible", next: orderEventAddedOptional{}, mode: CompatFull},
+		{name: "required field added breaks backward", next: orderEventAddedRequired{}, mode: CompatBackward, want: []string{"BACKWARD currency"}},
+		{name: "required field added keeps forward", next: orderEventAddedRequired{}, mode: CompatForward},
+		{name: "required field removed breaks forward", next: orderEventRemovedRequired{}, mode: CompatForward, want: []string{"FORWARD id"}},
+		{name: "required field removed keeps backward", next: orderEventRemovedRequired{}, mode: CompatBackward},
+		{name: "narrowed fields break backward", next: orderEventNarrowed{}, mode: CompatBackward,
+			want: []string{"BACKWARD amount", "BACKWARD note", "BACKWARD status"}},
+		{name: "narrowed fields keep forward", next: orderEventNarrowed{}, mode: CompatForward},
+		{name: "full reports both directions", next: orderEventRemovedRequired{}, mode: CompatFull, want: []string{"FORWARD id"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := CheckCompatibility(mustSchema(t, orderEventV1{}), mustSchema(t, tt.next), tt.mode)
+			if err != nil {
+				t.Fatalf("CheckCompatibility failed: %v", err)
+			}
+			if got := issueKeys(issues); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected issues %v, got %v", tt.want, issues)
+			}
+		})
+	}
+
+	if _, err := CheckCompatibility(nil, nil, "SIDEWAYS"); err == nil {
+		t.Error("Expected an unknown mode to be rejected")
+	}
+}
+
+// TestCheckCompatibilityDecoded tests schemas decoded from stored JSON
+func TestCheckCompatibilityDecoded(t *testing.T) {
+	stored := map[string]interface{}{
+		"type":                 "object",
+		"required":             []interface{}{"id"},
+		"additionalProperties": false,
+		"properties": map[string]interface{}{
+			"id":   map[string]interface{}{"type": "integer"},
+			"tags": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+		},
+	}
+	current := map[string]interface{}{
+		"type":     "object",
+		"required": []string{"id"},
+		"properties": map[string]interface{}{
+			"id":    map[string]interface{}{"type": "number"},
+			"tags":  map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "integer"}},
+			"extra": map[string]interface{}{"type": "string"},
+		},
+	}
+	issues, err := CheckCompatibility(stored, current, CompatFull)
+	if err != nil {
+		t.Fatalf("CheckCompatibility failed: %v", err)
+	}
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.String())
+	}
+	want := []string{
+		"BACKWARD tags[]: type string can't be read as integer",
+		"FORWARD id: type number can't be read as integer",
+		"FORWARD tags[]: type integer can't be read as string",
+		"FORWARD (root): field extra is written but not allowed",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+// TestCheckCompatibilityRoundTrip tests composed schemas compared against their JSON round trip
+func TestCheckCompatibilityRoundTrip(t *testing.T) {
+	generated := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"payment": map[string]interface{}{
+				"oneOf": []map[string]interface{}{
+					{"$ref": "#/components/schemas/Card"},
+					{"type": "object", "required": []string{"iban"}, "properties": map[string]interface{}{
+						"iban": map[string]interface{}{"type": "string", "maxLength": 34},
+					}},
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(generated)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var stored map[string]interface{}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	issues, err := CheckCompatibility(stored, generated, CompatFull)
+	if err != nil {
+		t.Fatalf("CheckCompatibility failed: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues, got %v", issues)
+	}
+}
+
+// TestCheckCompatibilityDefaults tests fields added with a default stay readable from old data
+func TestCheckCompatibilityDefaults(t *testing.T) {
+	stored := map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"id"},
+		"properties": map[string]interface{}{
+			"id": map[string]interface{}{"type": "string"},
+		},
+	}
+	tests := []struct {
+		name     string
+		currency map[string]interface{}
+		want     int
+	}{
+		{name: "required without default", currency: map[string]interface{}{"type": "string"}, want: 1},
+		{name: "required with default", currency: map[string]interface{}{"type": "string", "default": "EUR"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := map[string]interface{}{
+				"type":     "object",
+				"required": []string{"id", "currency"},
+				"properties": map[string]interface{}{
+					"id":       map[string]interface{}{"type": "string"},
+					"currency": tt.currency,
+				},
+			}
+			issues, err := CheckCompatibility(stored, current, CompatBackward)
+			if err != nil {
+				t.Fatalf("CheckCompatibility failed: %v", err)
+			}
+			if len(issues) != tt.want {
+				t.Errorf("Expected %d issues, got %v", tt.want, issues)
+			}
+		})
+	}
+}
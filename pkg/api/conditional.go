@@ -0,0 +1,26 @@
+package api
+
+import "net/http"
+
+// Chain call: document conditional GET semantics (ETag, If-None-Match, 304)
+// Adapters compute ETags for successful responses and answer matching requests with 304 Not Modified
+func (api *APIDefinition) WithConditionalGet() *APIDefinition {
+	api.Conditional = true
+	api.WithStandardParam(IfNoneMatchParam, false)
+	api.WithResponseHeader("ETag", Header{
+		Description: "Entity tag of the returned representation",
+		Schema:      map[string]interface{}{"type": "string"},
+	})
+	return api.WithStatusResponse(http.StatusNotModified, "Not Modified - The representation matches If-None-Match", nil)
+}
+
+// Chain call: document optimistic concurrency for updates (If-Match, 412)
+// When required is true the If-Match header is mandatory and 428 is documented as well
+func (api *APIDefinition) WithConditionalUpdate(required bool) *APIDefinition {
+	api.WithIfMatch(required)
+	api.WithStatusResponse(http.StatusPreconditionFailed, "Precondition Failed - The resource ETag does not match If-Match", nil)
+	if required {
+		api.WithStatusResponse(http.StatusPreconditionRequired, "Precondition Required - The If-Match header is missing", nil)
+	}
+	return api
+}
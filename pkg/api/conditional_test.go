@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+// TestWithConditionalGet tests documentation of conditional GET semantics
+func TestWithConditionalGet(t *testing.T) {
+	api := NewAPIDefinition("GET", "/users/{id}", "Get user").WithConditionalGet()
+
+	if !api.Conditional {
+		t.Error("Expected Conditional to be enabled")
+	}
+	if len(api.Params) != 1 || api.Params[0].Name != "If-None-Match" {
+		t.Errorf("Expected If-None-Match parameter, got %+v", api.Params)
+	}
+	if _, ok := api.Headers["ETag"]; !ok {
+		t.Error("Expected ETag response header")
+	}
+	if _, ok := api.Responses[http.StatusNotModified]; !ok {
+		t.Error("Expected 304 response")
+	}
+}
+
+// TestWithConditionalUpdate tests documentation of If-Match preconditions
+func TestWithConditionalUpdate(t *testing.T) {
+	api := NewAPIDefinition("PUT", "/users/{id}", "Update user").WithConditionalUpdate(true)
+
+	if len(api.Params) != 1 || api.Params[0].Name != "If-Match" || !api.Params[0].Required {
+		t.Errorf("Expected required If-Match parameter, got %+v", api.Params)
+	}
+	for _, status := range []int{http.StatusPreconditionFailed, http.StatusPreconditionRequired} {
+		if _, ok := api.Responses[status]; !ok {
+			t.Errorf("Expected %d response", status)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ConditionKind identifies a cross-field validation rule
+type ConditionKind string
+
+const (
+	// ConditionRequiredIf requires Field when Other is present (and equals Value, if set)
+	ConditionRequiredIf ConditionKind = "required_if"
+	// ConditionRequiredWithout requires Field when any of Others is absent
+	ConditionRequiredWithout ConditionKind = "required_without"
+	// ConditionMutuallyExclusive allows at most one of Others
+	ConditionMutuallyExclusive ConditionKind = "mutually_exclusive"
+)
+
+// Condition is a cross-field rule over parameters and top-level request body properties, referenced by name
+type Condition struct {
+	Kind   ConditionKind `json:"kind"`
+	Field  string        `json:"field,omitempty"`
+	Others []string      `json:"others"`
+	Value  string        `json:"value,omitempty"`
+}
+
+// Chain call: require field when other is present, or when other equals value if value is not empty
+func (api *APIDefinition) WithRequiredIf(field, other, value string) *APIDefinition {
+	api.Conditions = append(api.Conditions, Condition{Kind: ConditionRequiredIf, Field: field, Others: []string{other}, Value: value})
+	return api
+}
+
+// Chain call: require field when any of the other fields is absent
+func (api *APIDefinition) WithRequiredWithout(field string, others ...string) *APIDefinition {
+	api.Conditions = append(api.Conditions, Condition{Kind: ConditionRequiredWithout, Field: field, Others: others})
+	return api
+}
+
+// Chain call: allow at most one of the fields; pair with WithRequiredWithout to require exactly one
+func (api *APIDefinition) WithMutuallyExclusive(fields ...string) *APIDefinition {
+	api.Conditions = append(api.Conditions, Condition{Kind: ConditionMutuallyExclusive, Others: fields})
+	return api
+}
+
+// Check evaluates the condition against the values present in a request, keyed by name
+func (c Condition) Check(present map[string]string) error {
+	switch c.Kind {
+	case ConditionRequiredIf:
+		if _, ok := present[c.Field]; ok {
+			return nil
+		}
+		other, ok := present[c.Others[0]]
+		if ok && (c.Value == "" || other == c.Value) {
+			if c.Value == "" {
+				return fmt.Errorf("%s is required when %s is present", c.Field, c.Others[0])
+			}
+			return fmt.Errorf("%s is required when %s is %s", c.Field, c.Others[0], c.Value)
+		}
+	case ConditionRequiredWithout:
+		if _, ok := present[c.Field]; ok {
+			return nil
+		}
+		for _, other := range c.Others {
+			if _, ok := present[other]; !ok {
+				return fmt.Errorf("%s is required without %s", c.Field, strings.Join(c.Others, ", "))
+			}
+		}
+	case ConditionMutuallyExclusive:
+		var found []string
+		for _, field := range c.Others {
+			if _, ok := present[field]; ok {
+				found = append(found, field)
+			}
+		}
+		if len(found) > 1 {
+			return fmt.Errorf("%s are mutually exclusive", strings.Join(found, ", "))
+		}
+	}
+	return nil
+}
+
+// DependentRequired returns the JSON Schema 2019-09 dependentRequired keyword (OpenAPI 3.1) for the
+// presence-only required_if conditions of a definition, keyed by the field whose presence requires the others
+func DependentRequired(def *APIDefinition) map[string][]string {
+	var dependent map[string][]string
+	for _, c := range def.Conditions {
+		if c.Kind != ConditionRequiredIf || c.Value != "" {
+			continue
+		}
+		if dependent == nil {
+			dependent = make(map[string][]string)
+		}
+		if !containsString(dependent[c.Others[0]], c.Field) {
+			dependent[c.Others[0]] = append(dependent[c.Others[0]], c.Field)
+			sort.Strings(dependent[c.Others[0]])
+		}
+	}
+	return dependent
+}
+
+// documentDependentRequired adds the dependentRequired rules between properties of a request model schema as
+// the x-dependentRequired extension, which ToJSONSchema exports as dependentRequired
+func documentDependentRequired(def *APIDefinition, schema map[string]interface{}) {
+	props, _ := schema["properties"].(map[string]interface{})
+	var documented map[string]interface{}
+	for field, required := range DependentRequired(def) {
+		if _, ok := props[field]; !ok {
+			continue
+		}
+		var names []interface{}
+		for _, name := range required {
+			if _, ok := props[name]; ok {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			continue
+		}
+		if documented == nil {
+			documented = make(map[string]interface{})
+		}
+		documented[field] = names
+	}
+	if documented != nil {
+		schema["x-dependentRequired"] = documented
+	}
+}
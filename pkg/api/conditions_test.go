@@ -0,0 +1,92 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestConditionCheck tests required_if, required_without and mutually exclusive rules
+func TestConditionCheck(t *testing.T) {
+	def := NewAPIDefinition("POST", "/contacts", "Create contact").
+		WithRequiredIf("state", "country", "US").
+		WithRequiredIf("zip", "street", "").
+		WithRequiredWithout("email", "phone").
+		WithMutuallyExclusive("email", "phone")
+
+	tests := []struct {
+		name    string
+		present map[string]string
+		wantErr string
+	}{
+		{name: "email only", present: map[string]string{"email": "a@b.c"}},
+		{name: "phone only", present: map[string]string{"phone": "123"}},
+		{name: "neither", present: map[string]string{}, wantErr: "email is required without phone"},
+		{name: "both", present: map[string]string{"email": "a@b.c", "phone": "123"}, wantErr: "email, phone are mutually exclusive"},
+		{name: "US without state", present: map[string]string{"email": "a@b.c", "country": "US"}, wantErr: "state is required when country is US"},
+		{name: "FR without state", present: map[string]string{"email": "a@b.c", "country": "FR"}},
+		{name: "street without zip", present: map[string]string{"email": "a@b.c", "street": "Main"}, wantErr: "zip is required when street is present"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			for _, condition := range def.Conditions {
+				if err = condition.Check(tt.present); err != nil {
+					break
+				}
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	want := map[string][]string{"street": {"zip"}}
+	if got := DependentRequired(def); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected dependentRequired %v, got %v", want, got)
+	}
+}
+
+// contactRequest is a request model with fields linked by conditions
+type contactRequest struct {
+	Country string `json:"country,omitempty"`
+	State   string `json:"state,omitempty"`
+	Street  string `json:"street,omitempty"`
+	Zip     string `json:"zip,omitempty"`
+}
+
+// TestDependentRequiredSchema tests documenting presence-only conditions in the request schema and its export
+func TestDependentRequiredSchema(t *testing.T) {
+	def := NewAPIDefinition("POST", "/contacts", "Create contact").
+		WithRequest(contactRequest{}).
+		WithRequiredIf("state", "country", "US").
+		WithRequiredIf("zip", "street", "").
+		WithRequiredIf("zip", "token", "")
+
+	schema, err := RequestSchema(def)
+	if err != nil {
+		t.Fatalf("RequestSchema failed: %v", err)
+	}
+	want := map[string]interface{}{"street": []interface{}{"zip"}}
+	if got := schema["x-dependentRequired"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected x-dependentRequired %v, got %v", want, got)
+	}
+
+	exported := ToJSONSchema(schema)
+	if got := exported["dependentRequired"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected dependentRequired %v, got %v", want, got)
+	}
+	if _, ok := exported["x-dependentRequired"]; ok {
+		t.Error("Expected the extension to be replaced in the export")
+	}
+
+	plain, err := RequestSchema(NewAPIDefinition("POST", "/contacts", "Create contact").WithRequest(contactRequest{}))
+	if err != nil {
+		t.Fatalf("RequestSchema failed: %v", err)
+	}
+	if _, ok := plain["x-dependentRequired"]; ok {
+		t.Error("Expected no x-dependentRequired without conditions")
+	}
+}
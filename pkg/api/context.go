@@ -0,0 +1,48 @@
+package api
+
+import "context"
+
+// operationKey keys the matched API definition in request contexts
+type operationKey struct{}
+
+// ContextWithOperation returns a copy of ctx carrying the API definition matched for a request
+// Adapters call it before running middlewares and handlers
+func ContextWithOperation(ctx context.Context, def *APIDefinition) context.Context {
+	return context.WithValue(ctx, operationKey{}, def)
+}
+
+// OperationFromContext returns the API definition matched for a request (operationId, tags, deprecation, metadata)
+// The definition is shared between requests and must not be modified
+func OperationFromContext(ctx context.Context) (*APIDefinition, bool) {
+	def, ok := ctx.Value(operationKey{}).(*APIDefinition)
+	return def, ok
+}
+
+// requestIDKey keys the request's correlation ID in request contexts
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the correlation ID of a request
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the correlation ID of a request, so loggers and exporters can attach it
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// principalKey keys the authenticated principal of a request in request contexts
+type principalKey struct{}
+
+// ContextWithPrincipal returns a copy of ctx carrying the principal authenticated for a request
+// Authentication middlewares call it so request rules can check who sent the request
+func ContextWithPrincipal(ctx context.Context, principal interface{}) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal authenticated for a request
+func PrincipalFromContext(ctx context.Context) (interface{}, bool) {
+	principal := ctx.Value(principalKey{})
+	return principal, principal != nil
+}
@@ -0,0 +1,19 @@
+package api
+
+import (
+	"context"
+	"testing"
+)
+
+// TestOperationContext tests storing and reading the matched definition
+func TestOperationContext(t *testing.T) {
+	if _, ok := OperationFromContext(context.Background()); ok {
+		t.Error("Expected no operation in an empty context")
+	}
+
+	def := NewAPIDefinition("GET", "/users", "List users").WithOperationID("listUsers")
+	got, ok := OperationFromContext(ContextWithOperation(context.Background(), def))
+	if !ok || got != def {
+		t.Errorf("Expected the stored definition, got %v", got)
+	}
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// CORSPolicy declares which cross-origin requests an operation accepts
+type CORSPolicy struct {
+	AllowOrigins     []string      // Allowed origins; "*" allows any origin
+	AllowMethods     []string      // Allowed methods; defaults to the methods registered on the path
+	AllowHeaders     []string      // Request headers allowed in preflight requests
+	ExposeHeaders    []string      // Response headers exposed to the browser
+	AllowCredentials bool          // Whether cookies and authorization headers may be sent
+	MaxAge           time.Duration // How long preflight results may be cached
+}
+
+// AllowsOrigin reports whether the policy accepts requests from the given origin
+func (p *CORSPolicy) AllowsOrigin(origin string) bool {
+	for _, allowed := range p.AllowOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate rejects policies allowing credentials from any origin, which browsers refuse and which would
+// otherwise expose authenticated responses to every site
+func (p *CORSPolicy) Validate() error {
+	if !p.AllowCredentials {
+		return nil
+	}
+	for _, allowed := range p.AllowOrigins {
+		if allowed == "*" {
+			return errors.New("CORS policies allowing credentials must list explicit origins instead of \"*\"")
+		}
+	}
+	return nil
+}
+
+// Extension returns the x-cors representation of the policy
+func (p *CORSPolicy) Extension() map[string]interface{} {
+	ext := map[string]interface{}{
+		"allowOrigins": p.AllowOrigins,
+	}
+	if len(p.AllowMethods) > 0 {
+		ext["allowMethods"] = p.AllowMethods
+	}
+	if len(p.AllowHeaders) > 0 {
+		ext["allowHeaders"] = p.AllowHeaders
+	}
+	if len(p.ExposeHeaders) > 0 {
+		ext["exposeHeaders"] = p.ExposeHeaders
+	}
+	if p.AllowCredentials {
+		ext["allowCredentials"] = true
+	}
+	if p.MaxAge > 0 {
+		ext["maxAge"] = int(p.MaxAge / time.Second)
+	}
+	return ext
+}
+
+// Chain call: set the cross-origin policy for this API
+func (api *APIDefinition) WithCORS(policy CORSPolicy) *APIDefinition {
+	api.CORS = &policy
+	return api
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// CRUDHandlers holds the handlers of a resource's standard operations
+// Each is an http.HandlerFunc or a framework handler (e.g., gin.HandlerFunc); operations without one are left out
+type CRUDHandlers struct {
+	List   interface{} // GET /{resource}
+	Get    interface{} // GET /{resource}/{id}
+	Create interface{} // POST /{resource}
+	Update interface{} // PUT /{resource}/{id}
+	Delete interface{} // DELETE /{resource}/{id}
+}
+
+// CRUDFor returns the list, get, create, update and delete definitions of a resource, e.g. CRUDFor("users", User{}, handlers)
+// Lists are paginated and return a PagedResponse-shaped page of the model; single-item operations take an {id} path
+// parameter and document 404, creation answers 201 with a Location header and deletion 204
+// The definitions are tagged with the resource name and can be passed to RegisterGroup or adjusted first
+func CRUDFor(resource string, model interface{}, handlers CRUDHandlers) []APIDefinition {
+	collection := "/" + strings.Trim(resource, "/")
+	item := collection + "/{id}"
+	name := resourceLabel(resource, model)
+
+	var defs []APIDefinition
+	add := func(def *APIDefinition, handler interface{}) {
+		if handler == nil {
+			return
+		}
+		switch h := handler.(type) {
+		case http.HandlerFunc:
+			def.WithHandler(h)
+		case func(http.ResponseWriter, *http.Request):
+			def.WithHandler(h)
+		default:
+			def.WithNativeHandler(h)
+		}
+		defs = append(defs, *def.WithTags(resource))
+	}
+
+	add(NewAPIDefinition(http.MethodGet, collection, "List "+strings.Trim(resource, "/")).
+		WithPagination(PaginationPage).
+		WithResponse(pageOf(model)), handlers.List)
+	add(NewAPIDefinition(http.MethodGet, item, "Get "+name).
+		WithPathParam("id", name+" ID", true).
+		WithResponse(model).
+		WithStatusResponse(http.StatusNotFound, "Not Found - The "+name+" does not exist", nil), handlers.Get)
+
+	create := NewAPIDefinition(http.MethodPost, collection, "Create "+name).
+		WithRequest(model).
+		WithStatusResponse(http.StatusCreated, "Created", model)
+	created := create.Responses[http.StatusCreated]
+	created.Headers = map[string]Header{
+		"Location": {Description: "URL of the created " + name, Schema: map[string]interface{}{"type": "string"}},
+	}
+	create.Responses[http.StatusCreated] = created
+	add(create, handlers.Create)
+
+	add(NewAPIDefinition(http.MethodPut, item, "Update "+name).
+		WithPathParam("id", name+" ID", true).
+		WithRequest(model).
+		WithResponse(model).
+		WithStatusResponse(http.StatusNotFound, "Not Found - The "+name+" does not exist", nil), handlers.Update)
+	add(NewAPIDefinition(http.MethodDelete, item, "Delete "+name).
+		WithPathParam("id", name+" ID", true).
+		WithStatusResponse(http.StatusNoContent, "Deleted", nil).
+		WithStatusResponse(http.StatusNotFound, "Not Found - The "+name+" does not exist", nil), handlers.Delete)
+	return defs
+}
+
+// resourceLabel names one item of a resource in summaries: the model's type name, or the resource name
+func resourceLabel(resource string, model interface{}) string {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t != nil && t.Name() != "" {
+		// Split CamelCase names into words: UserProfile becomes "user profile"
+		var b strings.Builder
+		prev := rune(0)
+		for _, r := range t.Name() {
+			if unicode.IsUpper(r) && unicode.IsLower(prev) {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			prev = r
+		}
+		return b.String()
+	}
+	return strings.TrimSuffix(strings.Trim(resource, "/"), "s")
+}
+
+// pageOf returns an empty page of the model, shaped like PagedResponse
+func pageOf(model interface{}) interface{} {
+	t := reflect.TypeOf(model)
+	if t == nil {
+		return PagedResponse[interface{}]{}
+	}
+	page := reflect.StructOf([]reflect.StructField{
+		{Name: "Items", Type: reflect.SliceOf(t), Tag: `json:"items"`},
+		{Name: "Total", Type: reflect.TypeOf(int64(0)), Tag: `json:"total" doc:"Total number of items across all pages"`},
+		{Name: "Page", Type: reflect.TypeOf(0), Tag: `json:"page,omitempty" doc:"Current page number"`},
+		{Name: "PerPage", Type: reflect.TypeOf(0), Tag: `json:"per_page,omitempty" doc:"Number of items per page"`},
+	})
+	return reflect.New(page).Elem().Interface()
+}
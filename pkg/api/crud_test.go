@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+type UserProfile struct {
+	ID   int    `json:"id"`
+	Name string `json:"name" binding:"required"`
+}
+
+// TestCRUDFor tests the standard resource definitions generated for a model
+func TestCRUDFor(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	defs := CRUDFor("users", UserProfile{}, CRUDHandlers{
+		List:   handler,
+		Get:    http.HandlerFunc(handler),
+		Create: handler,
+		Update: "native",
+		Delete: handler,
+	})
+	if len(defs) != 5 {
+		t.Fatalf("Expected 5 definitions, got %d", len(defs))
+	}
+
+	tests := []struct {
+		method  string
+		path    string
+		summary string
+		status  int
+	}{
+		{method: "GET", path: "/users", summary: "List users"},
+		{method: "GET", path: "/users/{id}", summary: "Get user profile", status: http.StatusNotFound},
+		{method: "POST", path: "/users", summary: "Create user profile", status: http.StatusCreated},
+		{method: "PUT", path: "/users/{id}", summary: "Update user profile", status: http.StatusNotFound},
+		{method: "DELETE", path: "/users/{id}", summary: "Delete user profile", status: http.StatusNoContent},
+	}
+	for i, tt := range tests {
+		def := defs[i]
+		if def.Method != tt.method || def.Path != tt.path || def.Summary != tt.summary {
+			t.Errorf("Definition %d: expected %s %s %q, got %s %s %q", i, tt.method, tt.path, tt.summary, def.Method, def.Path, def.Summary)
+		}
+		if len(def.Tags) != 1 || def.Tags[0] != "users" {
+			t.Errorf("Definition %d: expected the users tag, got %v", i, def.Tags)
+		}
+		if tt.status != 0 {
+			if _, ok := def.Responses[tt.status]; !ok {
+				t.Errorf("Definition %d: expected a %d response", i, tt.status)
+			}
+		}
+	}
+	if defs[3].NativeHandler != "native" || defs[0].Handler == nil {
+		t.Error("Expected standard and native handlers to be set")
+	}
+	if _, ok := defs[2].Responses[http.StatusCreated].Headers["Location"]; !ok {
+		t.Error("Expected a Location header on 201")
+	}
+
+	schema, err := SchemaFromStruct(defs[0].Response)
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	items := schema["properties"].(map[string]interface{})["items"].(map[string]interface{})
+	if items["type"] != "array" {
+		t.Errorf("Expected a page of items, got %v", schema)
+	}
+}
+
+// TestCRUDForPartial tests that operations without handlers are left out
+func TestCRUDForPartial(t *testing.T) {
+	defs := CRUDFor("/users/", &UserProfile{}, CRUDHandlers{Get: "native"})
+	if len(defs) != 1 || defs[0].Path != "/users/{id}" {
+		t.Errorf("Expected only the get definition, got %+v", defs)
+	}
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"strconv"
+)
+
+// Chain call: document the default value of a declared parameter, applied by adapters with defaults enabled
+func (api *APIDefinition) WithParamDefault(name string, value interface{}) *APIDefinition {
+	for i := range api.Params {
+		if api.Params[i].Name == name {
+			if api.Params[i].Schema == nil {
+				api.Params[i].Schema = map[string]interface{}{"type": "string"}
+			}
+			api.Params[i].Schema["default"] = value
+		}
+	}
+	return api
+}
+
+// Default returns the documented default value of the parameter
+func (p *Parameter) Default() (interface{}, bool) {
+	value, ok := p.Schema["default"]
+	return value, ok && value != nil
+}
+
+// typedDefault converts a `default` tag to the type of the field's schema, keeping it a string otherwise
+func typedDefault(schema map[string]interface{}, raw string) interface{} {
+	switch schema["type"] {
+	case "integer":
+		if value, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return value
+		}
+	case "number":
+		if value, err := strconv.ParseFloat(raw, 64); err == nil {
+			return value
+		}
+	case "boolean":
+		if value, err := strconv.ParseBool(raw); err == nil {
+			return value
+		}
+	}
+	return raw
+}
+
+// ApplyDefaults sets the documented defaults of absent optional properties in a decoded JSON value,
+// descending into nested objects and array items; the value is modified in place and returned
+func ApplyDefaults(schema map[string]interface{}, value interface{}) interface{} {
+	if schema == nil {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		props, _ := schema["properties"].(map[string]interface{})
+		required := requiredSet(schema)
+		for name, prop := range props {
+			propSchema, ok := prop.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if current, present := v[name]; present {
+				v[name] = ApplyDefaults(propSchema, current)
+				continue
+			}
+			if def, ok := propSchema["default"]; ok && !required[name] {
+				v[name] = def
+			}
+		}
+	case []interface{}:
+		items, _ := schema["items"].(map[string]interface{})
+		for i, item := range v {
+			v[i] = ApplyDefaults(items, item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+type searchRequest struct {
+	Query    string  `json:"query"`
+	Limit    int     `json:"limit" default:"20"`
+	Fuzzy    bool    `json:"fuzzy" default:"true"`
+	Sort     string  `json:"sort" default:"relevance"`
+	Boost    float64 `json:"boost,omitempty" default:"1.5"`
+	Required int     `json:"required" default:"3" binding:"required"`
+	Filters  []struct {
+		Field string `json:"field"`
+		Op    string `json:"op" default:"eq"`
+	} `json:"filters,omitempty"`
+}
+
+// TestDefaultTag tests typed default emission and the optionality of defaulted fields
+func TestDefaultTag(t *testing.T) {
+	schema, err := SchemaFromStruct(searchRequest{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	props := schema["properties"].(map[string]interface{})
+	tests := []struct {
+		name string
+		want interface{}
+	}{
+		{name: "limit", want: int64(20)},
+		{name: "fuzzy", want: true},
+		{name: "sort", want: "relevance"},
+		{name: "boost", want: 1.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := props[tt.name].(map[string]interface{})["default"]; got != tt.want {
+				t.Errorf("Expected default %v (%T), got %v (%T)", tt.want, tt.want, got, got)
+			}
+		})
+	}
+	if required := requiredSet(schema); required["limit"] || !required["query"] || !required["required"] {
+		t.Errorf("Expected defaulted fields to be optional unless bound as required, got %v", schema["required"])
+	}
+}
+
+// TestApplyDefaults tests filling absent optional properties of a decoded body
+func TestApplyDefaults(t *testing.T) {
+	schema, _ := SchemaFromStruct(searchRequest{})
+	value := map[string]interface{}{
+		"query":   "go",
+		"sort":    "date",
+		"filters": []interface{}{map[string]interface{}{"field": "lang"}},
+	}
+	ApplyDefaults(schema, value)
+	want := map[string]interface{}{
+		"query":   "go",
+		"limit":   int64(20),
+		"fuzzy":   true,
+		"sort":    "date",
+		"boost":   1.5,
+		"filters": []interface{}{map[string]interface{}{"field": "lang", "op": "eq"}},
+	}
+	if !reflect.DeepEqual(value, want) {
+		t.Errorf("Expected %v, got %v", want, value)
+	}
+}
+
+// TestWithParamDefault tests documenting parameter defaults
+func TestWithParamDefault(t *testing.T) {
+	def := NewAPIDefinition("GET", "/search", "Search").
+		WithQueryParam("sort", "Sort order", false).
+		WithParamDefault("sort", "relevance")
+	value, ok := def.Params[0].Default()
+	if !ok || value != "relevance" || def.Params[0].Schema["type"] != "string" {
+		t.Errorf("Expected string default, got %v", def.Params[0].Schema)
+	}
+}
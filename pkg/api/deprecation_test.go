@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestWithDeprecation tests deprecation with sunset configuration
+func TestWithDeprecation(t *testing.T) {
+	sunset := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	api := NewAPIDefinition("GET", "/v1/users", "List users").
+		WithDeprecation(sunset, "/v2/users")
+
+	if !api.Deprecated {
+		t.Error("Expected API to be deprecated")
+	}
+	if !api.Sunset.Equal(sunset) {
+		t.Errorf("Expected sunset %v, got %v", sunset, api.Sunset)
+	}
+	if api.Replacement != "/v2/users" {
+		t.Errorf("Expected replacement '/v2/users', got %s", api.Replacement)
+	}
+}
+
+// TestWithDeprecatedFalse tests that un-deprecating an API drops its sunset configuration
+func TestWithDeprecatedFalse(t *testing.T) {
+	api := NewAPIDefinition("GET", "/v1/users", "List users").
+		WithDeprecation(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), "/v2/users").
+		WithDeprecated(false)
+
+	if api.Deprecated || !api.Sunset.IsZero() || api.Replacement != "" {
+		t.Errorf("Expected no deprecation, got deprecated=%v sunset=%v replacement=%q", api.Deprecated, api.Sunset, api.Replacement)
+	}
+}
+
+// TestOperationExtensions tests that extensions are inlined into the operation JSON
+func TestOperationExtensions(t *testing.T) {
+	api := NewAPIDefinition("GET", "/users", "List users").
+		WithExtension("sunset", "2030-01-01").
+		WithExtension("x-internal", true)
+
+	if _, ok := api.Extensions["x-sunset"]; !ok {
+		t.Error("Expected x- prefix to be added")
+	}
+
+	op := Operation{Summary: "List users", Responses: map[string]Response{}, Extensions: api.Extensions}
+	data, err := json.Marshal(op)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded["x-sunset"] != "2030-01-01" || decoded["x-internal"] != true {
+		t.Errorf("Expected extensions to be inlined, got %s", data)
+	}
+	if decoded["summary"] != "List users" {
+		t.Errorf("Expected summary to be kept, got %s", data)
+	}
+	if _, ok := decoded["Extensions"]; ok {
+		t.Error("Expected Extensions field not to be serialized")
+	}
+}
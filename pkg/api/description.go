@@ -0,0 +1,76 @@
+package api
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// DescriptionSource references a markdown file holding an operation description
+type DescriptionSource struct {
+	FS   fs.FS  // File system to read from, or nil for the OS file system
+	Path string // Path of the file, relative to FS when set
+}
+
+// Chain call: load the description from a markdown file on disk when the spec is generated
+func (api *APIDefinition) WithDescriptionFile(path string) *APIDefinition {
+	api.DescSource = &DescriptionSource{Path: path}
+	return api
+}
+
+// Chain call: load the description from a file of an embedded or virtual file system
+// when the spec is generated, e.g. a go:embed directory of .md files next to the handlers
+func (api *APIDefinition) WithDescriptionFS(fsys fs.FS, path string) *APIDefinition {
+	api.DescSource = &DescriptionSource{FS: fsys, Path: path}
+	return api
+}
+
+// Load reads the referenced file, trimming surrounding blank lines
+func (s *DescriptionSource) Load() (string, error) {
+	var data []byte
+	var err error
+	if s.FS != nil {
+		data, err = fs.ReadFile(s.FS, s.Path)
+	} else {
+		data, err = os.ReadFile(s.Path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load description %s: %w", s.Path, err)
+	}
+	return strings.Trim(string(data), "\r\n"), nil
+}
+
+// ResolveDescription returns the operation description, loading it from its source file if one is set
+func (api *APIDefinition) ResolveDescription() (string, error) {
+	if api.DescSource == nil {
+		return api.Description, nil
+	}
+	return api.DescSource.Load()
+}
+
+// Markdown removes the common indentation and surrounding blank lines of a raw string literal,
+// so multi-line descriptions can be indented along with the code declaring them
+func Markdown(text string) string {
+	lines := strings.Split(strings.Trim(text, "\n"), "\n")
+
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		width := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < 0 || width < indent {
+			indent = width
+		}
+	}
+
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+			continue
+		}
+		lines[i] = line[indent:]
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), " \t\n")
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+// TestResolveDescription tests loading descriptions from files and file systems
+func TestResolveDescription(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "list_users.md")
+	if err := os.WriteFile(path, []byte("\n# Users\n\nLists users.\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	api := NewAPIDefinition("GET", "/users", "List users").WithDescriptionFile(path)
+	description, err := api.ResolveDescription()
+	if err != nil {
+		t.Fatalf("ResolveDescription failed: %v", err)
+	}
+	if description != "# Users\n\nLists users." {
+		t.Errorf("Unexpected description %q", description)
+	}
+
+	fsys := fstest.MapFS{"docs/get_user.md": {Data: []byte("Returns a user.")}}
+	api = NewAPIDefinition("GET", "/users/{id}", "Get user").WithDescriptionFS(fsys, "docs/get_user.md")
+	if description, err = api.ResolveDescription(); err != nil || description != "Returns a user." {
+		t.Errorf("Unexpected description %q (%v)", description, err)
+	}
+
+	api = NewAPIDefinition("GET", "/missing", "Missing").WithDescriptionFS(fsys, "docs/missing.md")
+	if _, err := api.ResolveDescription(); err == nil {
+		t.Error("Expected error for a missing description file")
+	}
+}
+
+// TestMarkdown tests removing the common indentation of raw string literals
+func TestMarkdown(t *testing.T) {
+	text := Markdown(`
+		Lists users.
+
+		- paged
+		    - nested
+	`)
+	want := "Lists users.\n\n- paged\n    - nested"
+	if text != want {
+		t.Errorf("Expected %q, got %q", want, text)
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SpecDiff summarizes operation-level differences between two OpenAPI documents
+// Operations are identified as "METHOD /path"
+type SpecDiff struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+}
+
+// Empty reports whether the documents have the same operations
+func (d *SpecDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffSpecs compares the operations of two serialized OpenAPI documents
+func DiffSpecs(oldDoc, newDoc []byte) (*SpecDiff, error) {
+	oldOps, err := indexOperations(oldDoc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read old document: %w", err)
+	}
+	newOps, err := indexOperations(newDoc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read new document: %w", err)
+	}
+
+	diff := &SpecDiff{}
+	for key, newOp := range newOps {
+		oldOp, ok := oldOps[key]
+		if !ok {
+			diff.Added = append(diff.Added, key)
+		} else if !bytes.Equal(oldOp, newOp) {
+			diff.Changed = append(diff.Changed, key)
+		}
+	}
+	for key := range oldOps {
+		if _, ok := newOps[key]; !ok {
+			diff.Removed = append(diff.Removed, key)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
+	return diff, nil
+}
+
+// indexOperations maps "METHOD /path" to the compact JSON of each operation
+func indexOperations(doc []byte) (map[string][]byte, error) {
+	ops := make(map[string][]byte)
+	if len(doc) == 0 {
+		return ops, nil
+	}
+
+	var parsed struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(doc, &parsed); err != nil {
+		return nil, err
+	}
+	for path, item := range parsed.Paths {
+		for method, op := range item {
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, op); err != nil {
+				return nil, err
+			}
+			ops[strings.ToUpper(method)+" "+path] = compact.Bytes()
+		}
+	}
+	return ops, nil
+}
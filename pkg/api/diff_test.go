@@ -0,0 +1,35 @@
+package api
+
+import "testing"
+
+// TestDiffSpecs tests operation-level document comparison
+func TestDiffSpecs(t *testing.T) {
+	oldDoc := []byte(`{"paths":{"/users":{"get":{"summary":"List"},"post":{"summary":"Create"}}}}`)
+	newDoc := []byte(`{"paths":{"/users":{"get":{"summary":"List users"}},"/users/{id}":{"get":{"summary":"Get"}}}}`)
+
+	diff, err := DiffSpecs(oldDoc, newDoc)
+	if err != nil {
+		t.Fatalf("DiffSpecs failed: %v", err)
+	}
+	if len(diff.Added) != 1 || diff.Added[0] != "GET /users/{id}" {
+		t.Errorf("Unexpected added %v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0] != "POST /users" {
+		t.Errorf("Unexpected removed %v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0] != "GET /users" {
+		t.Errorf("Unexpected changed %v", diff.Changed)
+	}
+
+	same, err := DiffSpecs(newDoc, newDoc)
+	if err != nil {
+		t.Fatalf("DiffSpecs failed: %v", err)
+	}
+	if !same.Empty() {
+		t.Errorf("Expected empty diff, got %+v", same)
+	}
+
+	if _, err := DiffSpecs([]byte("not json"), newDoc); err == nil {
+		t.Error("Expected error for invalid document")
+	}
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// envelopeDataField finds the field of an envelope struct receiving the payload: the field tagged
+// `envelope:"data"`, or else the field serialized as "data". Returns its index and JSON name
+func envelopeDataField(t reflect.Type) (int, string, bool) {
+	index, name := -1, ""
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		if field.Tag.Get("envelope") == "data" {
+			return i, jsonName, true
+		}
+		if jsonName == "data" && index < 0 {
+			index, name = i, jsonName
+		}
+	}
+	return index, name, index >= 0
+}
+
+// envelopeType returns the struct type of an envelope template
+func envelopeType(template interface{}) (reflect.Type, error) {
+	t := reflect.TypeOf(template)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("response envelope must be a struct, got %T", template)
+	}
+	index, _, ok := envelopeDataField(t)
+	if !ok || !t.Field(index).IsExported() {
+		return nil, fmt.Errorf("response envelope %s has no exported data field", t)
+	}
+	return t, nil
+}
+
+// EnvelopeSchema returns the schema of an envelope template whose data property is replaced by the payload schema
+func EnvelopeSchema(template interface{}, data map[string]interface{}) (map[string]interface{}, error) {
+	t, err := envelopeType(template)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := SafeSchemaFromStruct(template)
+	if err != nil {
+		return nil, err
+	}
+	_, name, _ := envelopeDataField(t)
+	props, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		props = make(map[string]interface{})
+		schema["properties"] = props
+	}
+	props[name] = data
+	return schema, nil
+}
+
+// WrapEnvelope returns a copy of the envelope template carrying data in its data field
+// The template's other fields (e.g. code and message) are kept as they are
+func WrapEnvelope(template, data interface{}) (interface{}, error) {
+	t, err := envelopeType(template)
+	if err != nil {
+		return nil, err
+	}
+	envelope := reflect.New(t).Elem()
+	if v := reflect.Indirect(reflect.ValueOf(template)); v.IsValid() {
+		envelope.Set(v)
+	}
+	index, _, _ := envelopeDataField(t)
+	field := envelope.Field(index)
+	if data != nil {
+		value := reflect.ValueOf(data)
+		if !value.Type().AssignableTo(field.Type()) {
+			return nil, fmt.Errorf("cannot assign %T to envelope field %s of type %s", data, t.Field(index).Name, field.Type())
+		}
+		field.Set(value)
+	}
+	return envelope.Interface(), nil
+}
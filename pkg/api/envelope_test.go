@@ -0,0 +1,75 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+type apiEnvelope struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data"`
+}
+
+type taggedEnvelope struct {
+	Status  string      `json:"status"`
+	Payload interface{} `json:"result" envelope:"data"`
+}
+
+type envelopeUser struct {
+	Name string `json:"name"`
+}
+
+// TestEnvelopeSchema tests documenting a payload schema inside an envelope template
+func TestEnvelopeSchema(t *testing.T) {
+	data := map[string]interface{}{"type": "string"}
+	tests := []struct {
+		name     string
+		template interface{}
+		property string
+		wantErr  bool
+	}{
+		{name: "data field", template: apiEnvelope{}, property: "data"},
+		{name: "tagged field", template: &taggedEnvelope{}, property: "result"},
+		{name: "no data field", template: envelopeUser{}, wantErr: true},
+		{name: "not a struct", template: "envelope", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := EnvelopeSchema(tt.template, data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %t, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+			props := schema["properties"].(map[string]interface{})
+			if !reflect.DeepEqual(props[tt.property], data) {
+				t.Errorf("Expected %s to hold the payload schema, got %v", tt.property, props)
+			}
+		})
+	}
+}
+
+// TestWrapEnvelope tests wrapping a payload in a copy of the envelope template
+func TestWrapEnvelope(t *testing.T) {
+	template := apiEnvelope{Message: "ok"}
+	wrapped, err := WrapEnvelope(template, envelopeUser{Name: "alice"})
+	if err != nil {
+		t.Fatalf("WrapEnvelope failed: %v", err)
+	}
+	want := apiEnvelope{Message: "ok", Data: envelopeUser{Name: "alice"}}
+	if !reflect.DeepEqual(wrapped, want) {
+		t.Errorf("Expected %+v, got %+v", want, wrapped)
+	}
+	if template.Data != nil {
+		t.Error("Expected the template to be left unchanged")
+	}
+
+	typed := struct {
+		Data []string `json:"data"`
+	}{}
+	if _, err := WrapEnvelope(typed, 42); err == nil {
+		t.Error("Expected an error for a payload not assignable to the data field")
+	}
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+	"net/textproto"
+	"strings"
+)
+
+// HeaderValues returns all values of a header, matching its name case-insensitively
+// Canonical keys are looked up directly; headers set under raw keys are found by a fold-case scan
+func HeaderValues(h http.Header, name string) []string {
+	if values, ok := h[textproto.CanonicalMIMEHeaderKey(name)]; ok {
+		return values
+	}
+	for key, values := range h {
+		if strings.EqualFold(key, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+// DecodeHeader extracts the parameter from request headers
+// Array parameters collect every value of repeated headers, splitting comma-separated lists
+// (the simple style); other parameters take the first value. Returns false if the header is absent
+func (p *Parameter) DecodeHeader(h http.Header) (interface{}, bool) {
+	values := HeaderValues(h, p.Name)
+	if len(values) == 0 {
+		return nil, false
+	}
+	if p.schemaType() != "array" {
+		return values[0], true
+	}
+	items := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items, true
+}
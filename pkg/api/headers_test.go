@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// TestDecodeHeader tests case-insensitive header lookup and decoding of multi-value headers
+func TestDecodeHeader(t *testing.T) {
+	scalar := Parameter{Name: "x-tenant-id", In: "header"}
+	array := Parameter{Name: "X-Tags", In: "header", Schema: map[string]interface{}{"type": "array"}}
+
+	tests := []struct {
+		name   string
+		param  Parameter
+		header http.Header
+		want   interface{}
+		wantOK bool
+	}{
+		{name: "canonical key", param: scalar, header: http.Header{"X-Tenant-Id": {"acme"}}, want: "acme", wantOK: true},
+		{name: "raw key", param: scalar, header: http.Header{"x-TENANT-id": {"acme"}}, want: "acme", wantOK: true},
+		{name: "first value", param: scalar, header: http.Header{"X-Tenant-Id": {"a", "b"}}, want: "a", wantOK: true},
+		{name: "absent", param: scalar, header: http.Header{}, wantOK: false},
+		{name: "repeated array", param: array, header: http.Header{"X-Tags": {"a", "b, c"}}, want: []string{"a", "b", "c"}, wantOK: true},
+		{name: "raw array key", param: array, header: http.Header{"x-tags": {"a,b"}}, want: []string{"a", "b"}, wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.param.DecodeHeader(tt.header)
+			if ok != tt.wantOK || (ok && !reflect.DeepEqual(got, tt.want)) {
+				t.Errorf("Expected %v (%t), got %v (%t)", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// HypermediaStyle selects how links are attached to response models
+type HypermediaStyle string
+
+const (
+	HypermediaHAL   HypermediaStyle = "hal"   // HAL _links and _embedded objects
+	HypermediaLinks HypermediaStyle = "links" // A links array of {rel, href, method}
+)
+
+// Hypermedia describes the links attached to the success response of a definition
+type Hypermedia struct {
+	Style    HypermediaStyle
+	Rels     []string               // Link relations the response carries (e.g., "self", "next")
+	Embedded map[string]interface{} // HAL embedded resources keyed by relation; each is a model or a slice of models
+}
+
+// HALLink is a link of a HAL _links object
+type HALLink struct {
+	Href      string `json:"href"`
+	Templated bool   `json:"templated,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Type      string `json:"type,omitempty"`
+}
+
+// ResourceLink is an item of a links array
+type ResourceLink struct {
+	Rel    string `json:"rel"`
+	Href   string `json:"href"`
+	Method string `json:"method,omitempty"`
+}
+
+// HALResource wraps a model for a HAL response: its JSON is the model's properties with _links and _embedded
+type HALResource struct {
+	Model    interface{}
+	Links    map[string]HALLink
+	Embedded map[string]interface{}
+}
+
+// LinkedResource wraps a model for a links array response: its JSON is the model's properties with links
+type LinkedResource struct {
+	Model interface{}
+	Links []ResourceLink
+}
+
+// Chain call: document the response as a HAL resource of model, with _links for rels and
+// _embedded resources keyed by relation; handlers respond with HALResource
+func (api *APIDefinition) WithHALResponse(model interface{}, rels []string, embedded map[string]interface{}) *APIDefinition {
+	api.Response = model
+	api.Hypermedia = &Hypermedia{Style: HypermediaHAL, Rels: rels, Embedded: embedded}
+	return api
+}
+
+// Chain call: document the response as model with a links array of the given relations;
+// handlers respond with LinkedResource
+func (api *APIDefinition) WithLinksResponse(model interface{}, rels ...string) *APIDefinition {
+	api.Response = model
+	api.Hypermedia = &Hypermedia{Style: HypermediaLinks, Rels: rels}
+	return api
+}
+
+// Schema returns the schema of a model schema carrying the hypermedia links
+func (h *Hypermedia) Schema(model map[string]interface{}) (map[string]interface{}, error) {
+	if h.Style == HypermediaLinks {
+		return LinksSchema(model, h.Rels), nil
+	}
+	embedded := make(map[string]interface{}, len(h.Embedded))
+	for rel, value := range h.Embedded {
+		schema, err := embeddedSchema(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate embedded %s schema: %w", rel, err)
+		}
+		embedded[rel] = schema
+	}
+	return HALSchema(model, h.Rels, embedded), nil
+}
+
+// HALSchema returns a copy of a model schema with a _links object holding rels and,
+// when embedded schemas are given, an _embedded object keyed by relation
+func HALSchema(model map[string]interface{}, rels []string, embedded map[string]interface{}) map[string]interface{} {
+	schema := withProperties(model)
+	props := schema["properties"].(map[string]interface{})
+
+	linkProps := make(map[string]interface{}, len(rels))
+	var required []string
+	for _, rel := range rels {
+		linkProps[rel] = halLinkSchema()
+		if rel == "self" {
+			required = append(required, rel)
+		}
+	}
+	links := map[string]interface{}{
+		"type":                 "object",
+		"properties":           linkProps,
+		"additionalProperties": halLinkSchema(),
+	}
+	if len(required) > 0 {
+		links["required"] = required
+	}
+	props["_links"] = links
+
+	if len(embedded) > 0 {
+		props["_embedded"] = map[string]interface{}{
+			"type":       "object",
+			"properties": embedded,
+		}
+	}
+	return schema
+}
+
+// LinksSchema returns a copy of a model schema with a links array whose relations are restricted to rels
+func LinksSchema(model map[string]interface{}, rels []string) map[string]interface{} {
+	schema := withProperties(model)
+	rel := map[string]interface{}{"type": "string"}
+	if len(rels) > 0 {
+		enum := make([]interface{}, len(rels))
+		for i, r := range rels {
+			enum[i] = r
+		}
+		rel["enum"] = enum
+	}
+	schema["properties"].(map[string]interface{})["links"] = map[string]interface{}{
+		"type": "array",
+		"items": map[string]interface{}{
+			"type":     "object",
+			"required": []string{"rel", "href"},
+			"properties": map[string]interface{}{
+				"rel":    rel,
+				"href":   map[string]interface{}{"type": "string", "format": "uri-reference"},
+				"method": map[string]interface{}{"type": "string"},
+			},
+		},
+	}
+	return schema
+}
+
+// withProperties returns a shallow copy of an object schema with a copied properties map
+func withProperties(model map[string]interface{}) map[string]interface{} {
+	schema := make(map[string]interface{}, len(model)+1)
+	for key, value := range model {
+		schema[key] = value
+	}
+	props := make(map[string]interface{})
+	if existing, ok := model["properties"].(map[string]interface{}); ok {
+		for name, prop := range existing {
+			props[name] = prop
+		}
+	}
+	schema["type"] = "object"
+	schema["properties"] = props
+	return schema
+}
+
+func halLinkSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []string{"href"},
+		"properties": map[string]interface{}{
+			"href":      map[string]interface{}{"type": "string"},
+			"templated": map[string]interface{}{"type": "boolean"},
+			"title":     map[string]interface{}{"type": "string"},
+			"type":      map[string]interface{}{"type": "string"},
+		},
+	}
+}
+
+// embeddedSchema returns the HAL schema of an embedded model, or an array of them for slices
+func embeddedSchema(value interface{}) (map[string]interface{}, error) {
+	items, isSlice := sliceElem(value)
+	schema, err := SafeSchemaFromStruct(items)
+	if err != nil {
+		return nil, err
+	}
+	resource := HALSchema(schema, []string{"self"}, nil)
+	if isSlice {
+		return map[string]interface{}{"type": "array", "items": resource}, nil
+	}
+	return resource, nil
+}
+
+// sliceElem returns a zero element of a slice value and true, or the value itself and false
+func sliceElem(value interface{}) (interface{}, bool) {
+	t := reflect.TypeOf(value)
+	if t == nil || (t.Kind() != reflect.Slice && t.Kind() != reflect.Array) {
+		return value, false
+	}
+	return reflect.Zero(t.Elem()).Interface(), true
+}
+
+// MarshalJSON writes the model's properties with _links and _embedded
+func (r HALResource) MarshalJSON() ([]byte, error) {
+	extra := map[string]interface{}{}
+	if len(r.Links) > 0 {
+		extra["_links"] = r.Links
+	}
+	if len(r.Embedded) > 0 {
+		extra["_embedded"] = r.Embedded
+	}
+	return mergeJSON(r.Model, extra)
+}
+
+// MarshalJSON writes the model's properties with links
+func (r LinkedResource) MarshalJSON() ([]byte, error) {
+	links := r.Links
+	if links == nil {
+		links = []ResourceLink{}
+	}
+	return mergeJSON(r.Model, map[string]interface{}{"links": links})
+}
+
+// mergeJSON marshals a model as an object with extra properties added
+func mergeJSON(model interface{}, extra map[string]interface{}) ([]byte, error) {
+	object := make(map[string]json.RawMessage)
+	if model != nil {
+		data, err := json.Marshal(model)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &object); err != nil {
+			return nil, fmt.Errorf("hypermedia model must marshal to a JSON object: %w", err)
+		}
+	}
+	for key, value := range extra {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		object[key] = data
+	}
+	return json.Marshal(object)
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type halOrder struct {
+	ID    int     `json:"id"`
+	Total float64 `json:"total"`
+}
+
+type halItem struct {
+	SKU string `json:"sku"`
+}
+
+// TestHALSchema tests documenting _links and _embedded around a model schema
+func TestHALSchema(t *testing.T) {
+	def := NewAPIDefinition("GET", "/orders/{id}", "Get order").
+		WithHALResponse(halOrder{}, []string{"self", "customer"}, map[string]interface{}{"items": []halItem{}})
+	model, err := SchemaFromStruct(def.Response)
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	schema, err := def.Hypermedia.Schema(model)
+	if err != nil {
+		t.Fatalf("Schema failed: %v", err)
+	}
+
+	props := schema["properties"].(map[string]interface{})
+	if _, ok := props["total"]; !ok {
+		t.Errorf("Expected the model properties to be kept, got %v", props)
+	}
+	links := props["_links"].(map[string]interface{})
+	if _, ok := links["properties"].(map[string]interface{})["customer"]; !ok || len(links["required"].([]string)) != 1 {
+		t.Errorf("Unexpected _links schema %v", links)
+	}
+	items := props["_embedded"].(map[string]interface{})["properties"].(map[string]interface{})["items"].(map[string]interface{})
+	item := items["items"].(map[string]interface{})["properties"].(map[string]interface{})
+	if items["type"] != "array" || item["sku"] == nil || item["_links"] == nil {
+		t.Errorf("Expected an array of embedded HAL resources, got %v", items)
+	}
+	if _, ok := model["properties"].(map[string]interface{})["_links"]; ok {
+		t.Error("Expected the model schema to be left unchanged")
+	}
+}
+
+// TestLinksSchema tests documenting a links array restricted to relations
+func TestLinksSchema(t *testing.T) {
+	def := NewAPIDefinition("GET", "/orders/{id}", "Get order").WithLinksResponse(halOrder{}, "self", "cancel")
+	model, _ := SchemaFromStruct(def.Response)
+	schema, err := def.Hypermedia.Schema(model)
+	if err != nil {
+		t.Fatalf("Schema failed: %v", err)
+	}
+	links := schema["properties"].(map[string]interface{})["links"].(map[string]interface{})
+	rel := links["items"].(map[string]interface{})["properties"].(map[string]interface{})["rel"].(map[string]interface{})
+	if links["type"] != "array" || len(rel["enum"].([]interface{})) != 2 {
+		t.Errorf("Unexpected links schema %v", links)
+	}
+}
+
+// TestHypermediaMarshal tests serializing models with their links
+func TestHypermediaMarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource interface{}
+		want     string
+	}{
+		{
+			name: "hal",
+			resource: HALResource{
+				Model:    halOrder{ID: 1, Total: 9.5},
+				Links:    map[string]HALLink{"self": {Href: "/orders/1"}},
+				Embedded: map[string]interface{}{"items": []halItem{{SKU: "a"}}},
+			},
+			want: `{"_embedded":{"items":[{"sku":"a"}]},"_links":{"self":{"href":"/orders/1"}},"id":1,"total":9.5}`,
+		},
+		{
+			name:     "links",
+			resource: LinkedResource{Model: halOrder{ID: 1}, Links: []ResourceLink{{Rel: "self", Href: "/orders/1"}}},
+			want:     `{"id":1,"links":[{"rel":"self","href":"/orders/1"}],"total":0}`,
+		},
+		{
+			name:     "no links",
+			resource: LinkedResource{Model: halOrder{ID: 1}},
+			want:     `{"id":1,"links":[],"total":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.resource)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, data)
+			}
+		})
+	}
+
+	if _, err := json.Marshal(HALResource{Model: []int{1}}); err == nil {
+		t.Error("Expected an error for a model that is not an object")
+	}
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// swaggerIgnored reports whether a struct field is excluded from generated schemas with `swaggerignore:"true"`
+// The field keeps its json tag, so it is still serialized
+func swaggerIgnored(field reflect.StructField) bool {
+	ignored, _ := strconv.ParseBool(field.Tag.Get("swaggerignore"))
+	return ignored
+}
+
+// IgnoredFields returns the JSON property names of fields tagged `swaggerignore:"true"` anywhere in v's type
+func IgnoredFields(v interface{}) []string {
+	if v == nil {
+		return nil
+	}
+	fields := make([]string, 0)
+	collectIgnoredFields(reflect.TypeOf(v), map[reflect.Type]bool{}, &fields)
+	return fields
+}
+
+func collectIgnoredFields(t reflect.Type, visited map[reflect.Type]bool, fields *[]string) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visited[t] {
+		return
+	}
+	visited[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		rawTag, hasTag := field.Tag.Lookup("json")
+		if rawTag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(rawTag, ",")
+		if swaggerIgnored(field) {
+			if field.Anonymous && name == "" {
+				// An ignored embedded struct drops all of its promoted fields
+				collectPromotedFields(field.Type, fields)
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if hasTag || field.IsExported() {
+				*fields = append(*fields, name)
+			}
+			continue
+		}
+		collectIgnoredFields(field.Type, visited, fields)
+	}
+}
+
+// collectPromotedFields appends the JSON property names an embedded struct contributes to its parent
+func collectPromotedFields(t reflect.Type, fields *[]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		rawTag := field.Tag.Get("json")
+		if rawTag == "-" || !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(rawTag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		*fields = append(*fields, name)
+	}
+}
+
+// IgnoreTree locates the swaggerignore fields of a type in its JSON form
+type IgnoreTree struct {
+	Fields     []string               // Properties removed from this object
+	Properties map[string]*IgnoreTree // Properties holding ignored fields deeper down
+	Elements   *IgnoreTree            // Array items and map values holding ignored fields
+}
+
+// IgnoreTreeOf returns where the swaggerignore fields of v's type sit in its JSON form, nil when it has none
+func IgnoreTreeOf(v interface{}) *IgnoreTree {
+	if len(IgnoredFields(v)) == 0 {
+		return nil
+	}
+	return ignoreTree(reflect.TypeOf(v), map[reflect.Type]*IgnoreTree{})
+}
+
+// ignoreTree builds the tree of a type; trees of recursive types refer back to themselves
+func ignoreTree(t reflect.Type, built map[reflect.Type]*IgnoreTree) *IgnoreTree {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if elements := ignoreTree(t.Elem(), built); elements != nil {
+			return &IgnoreTree{Elements: elements}
+		}
+		return nil
+	case reflect.Struct:
+	default:
+		return nil
+	}
+
+	if tree, ok := built[t]; ok {
+		return tree
+	}
+	tree := &IgnoreTree{Properties: make(map[string]*IgnoreTree)}
+	built[t] = tree
+	addIgnoredFields(tree, t, built, map[reflect.Type]bool{})
+	if len(tree.Fields) == 0 && len(tree.Properties) == 0 {
+		built[t] = nil
+		return nil
+	}
+	return tree
+}
+
+// addIgnoredFields adds the fields of a struct to its object's tree, flattening embedded structs
+func addIgnoredFields(tree *IgnoreTree, t reflect.Type, built map[reflect.Type]*IgnoreTree, flattened map[reflect.Type]bool) {
+	if flattened[t] {
+		return
+	}
+	flattened[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		rawTag := field.Tag.Get("json")
+		if rawTag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(rawTag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if swaggerIgnored(field) {
+					collectPromotedFields(embedded, &tree.Fields)
+				} else {
+					addIgnoredFields(tree, embedded, built, flattened)
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if swaggerIgnored(field) {
+			tree.Fields = append(tree.Fields, name)
+			continue
+		}
+		if child := ignoreTree(field.Type, built); child != nil {
+			tree.Properties[name] = child
+		}
+	}
+}
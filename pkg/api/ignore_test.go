@@ -0,0 +1,93 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+type replicatedAccount struct {
+	ID       string `json:"id"`
+	Revision int    `json:"revision" swaggerignore:"true"`
+	Owner    struct {
+		Name   string `json:"name"`
+		NodeID string `json:"node_id" swaggerignore:"true"`
+	} `json:"owner"`
+	Peers []struct {
+		Addr  string `json:"addr"`
+		Clock int64  `json:"clock" swaggerignore:"true"`
+	} `json:"peers"`
+}
+
+// TestSwaggerIgnoreSchema tests that swaggerignore fields are left out of generated schemas
+func TestSwaggerIgnoreSchema(t *testing.T) {
+	schema, err := SchemaFromStruct(replicatedAccount{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	props := schema["properties"].(map[string]interface{})
+	if _, ok := props["revision"]; ok {
+		t.Error("Expected revision to be excluded")
+	}
+	for _, name := range schema["required"].([]string) {
+		if name == "revision" {
+			t.Error("Expected revision not to be required")
+		}
+	}
+	owner := props["owner"].(map[string]interface{})["properties"].(map[string]interface{})
+	if _, ok := owner["node_id"]; ok {
+		t.Error("Expected owner.node_id to be excluded")
+	}
+	if _, ok := owner["name"]; !ok {
+		t.Error("Expected owner.name to be kept")
+	}
+}
+
+// TestIgnoredFields tests collecting swaggerignore property names across nested types
+func TestIgnoredFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  []string
+	}{
+		{name: "nested", value: &replicatedAccount{}, want: []string{"revision", "node_id", "clock"}},
+		{name: "slice", value: []replicatedAccount{}, want: []string{"revision", "node_id", "clock"}},
+		{name: "none", value: credentials{}, want: []string{}},
+		{name: "nil", value: nil, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IgnoredFields(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// replicatedNode is a recursive model with an ignored field
+type replicatedNode struct {
+	Name     string            `json:"name"`
+	Clock    int64             `json:"clock" swaggerignore:"true"`
+	Children []*replicatedNode `json:"children"`
+}
+
+// TestIgnoreTreeOf tests locating swaggerignore fields in the JSON form of a type
+func TestIgnoreTreeOf(t *testing.T) {
+	tree := IgnoreTreeOf(replicatedAccount{})
+	if tree == nil || !reflect.DeepEqual(tree.Fields, []string{"revision"}) {
+		t.Fatalf("Expected revision at the root, got %+v", tree)
+	}
+	if owner := tree.Properties["owner"]; owner == nil || !reflect.DeepEqual(owner.Fields, []string{"node_id"}) {
+		t.Errorf("Expected node_id under owner, got %+v", owner)
+	}
+	if peers := tree.Properties["peers"]; peers == nil || peers.Elements == nil || !reflect.DeepEqual(peers.Elements.Fields, []string{"clock"}) {
+		t.Errorf("Expected clock under peers items, got %+v", peers)
+	}
+
+	node := IgnoreTreeOf(map[string]replicatedNode{})
+	if node == nil || node.Elements == nil || node.Elements.Properties["children"].Elements != node.Elements {
+		t.Errorf("Expected a recursive tree under map values, got %+v", node)
+	}
+	if got := IgnoreTreeOf(credentials{}); got != nil {
+		t.Errorf("Expected no tree without ignored fields, got %+v", got)
+	}
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+)
+
+// JSONSchemaDialect is the $schema of exported standalone JSON Schema documents
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// ExportJSONSchemas generates a standalone draft 2020-12 JSON Schema document for each model, keyed by model name
+// OpenAPI-only keywords are translated: nullable becomes a "null" type, example becomes examples and
+// x-dependentRequired becomes dependentRequired
+func ExportJSONSchemas(types ...interface{}) (map[string]map[string]interface{}, error) {
+	defs, err := jsonSchemaDefs(types)
+	if err != nil {
+		return nil, err
+	}
+	for name, schema := range defs {
+		schema["$schema"] = JSONSchemaDialect
+		if _, ok := schema["title"]; !ok {
+			schema["title"] = name
+		}
+	}
+	return defs, nil
+}
+
+// ExportJSONSchemaBundle generates a single draft 2020-12 document holding every model under $defs
+func ExportJSONSchemaBundle(id string, types ...interface{}) (map[string]interface{}, error) {
+	defs, err := jsonSchemaDefs(types)
+	if err != nil {
+		return nil, err
+	}
+	bundled := make(map[string]interface{}, len(defs))
+	for name, schema := range defs {
+		bundled[name] = schema
+	}
+	bundle := map[string]interface{}{
+		"$schema": JSONSchemaDialect,
+		"$defs":   bundled,
+	}
+	if id != "" {
+		bundle["$id"] = id
+	}
+	return bundle, nil
+}
+
+// WriteJSONSchemas writes one <Model>.schema.json file per model into dir
+func WriteJSONSchemas(dir string, types ...interface{}) error {
+	documents, err := ExportJSONSchemas(types...)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create schema directory: %w", err)
+	}
+	for name, document := range documents {
+		data, err := json.MarshalIndent(document, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal schema %s: %w", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name+".schema.json"), data, 0o644); err != nil {
+			return fmt.Errorf("failed to write schema %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ToJSONSchema translates a generated OpenAPI 3.0 schema into a standalone draft 2020-12 JSON Schema,
+// e.g. to validate payloads with a JSON Schema validator
+func ToJSONSchema(schema map[string]interface{}) map[string]interface{} {
+	out := toJSONSchema(schema)
+	out["$schema"] = JSONSchemaDialect
+	return out
+}
+
+// invalidModelNameChars matches characters not allowed in exported model names (e.g. generic brackets)
+var invalidModelNameChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// jsonSchemaDefs generates the translated schema of each model, keyed by model name
+func jsonSchemaDefs(types []interface{}) (map[string]map[string]interface{}, error) {
+	defs := make(map[string]map[string]interface{}, len(types))
+	for _, v := range types {
+		t := reflect.TypeOf(v)
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t == nil || t.Name() == "" {
+			return nil, &ErrInvalidType{Type: fmt.Sprintf("%T (only named types can be exported)", v)}
+		}
+
+		schema, err := SafeSchemaFromStruct(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate schema for %s: %w", t.Name(), err)
+		}
+		name := invalidModelNameChars.ReplaceAllString(t.Name(), "_")
+		if _, ok := defs[name]; ok {
+			return nil, fmt.Errorf("duplicate model name %s", name)
+		}
+		defs[name] = toJSONSchema(schema)
+	}
+	return defs, nil
+}
+
+// toJSONSchema translates an OpenAPI 3.0 schema object into a JSON Schema 2020-12 schema
+func toJSONSchema(schema map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		switch key {
+		case "nullable", "discriminator":
+		case "example":
+			out["examples"] = []interface{}{copySchemaValue(value)}
+		case "x-dependentRequired":
+			out["dependentRequired"] = copySchemaValue(value)
+		case "properties":
+			props, _ := value.(map[string]interface{})
+			translated := make(map[string]interface{}, len(props))
+			for name, prop := range props {
+				translated[name] = toSubschema(prop)
+			}
+			out[key] = translated
+		case "items", "additionalProperties", "not":
+			out[key] = toSubschema(value)
+		case "oneOf", "anyOf", "allOf":
+			subschemas, _ := value.([]interface{})
+			translated := make([]interface{}, len(subschemas))
+			for i, subschema := range subschemas {
+				translated[i] = toSubschema(subschema)
+			}
+			out[key] = translated
+		default:
+			out[key] = copySchemaValue(value)
+		}
+	}
+	if nullable, _ := schema["nullable"].(bool); nullable {
+		if t, ok := schema["type"].(string); ok {
+			out["type"] = []interface{}{t, "null"}
+		}
+	}
+	return out
+}
+
+// toSubschema translates a nested schema, leaving boolean schemas untouched
+func toSubschema(v interface{}) interface{} {
+	if schema, ok := v.(map[string]interface{}); ok {
+		return toJSONSchema(schema)
+	}
+	return copySchemaValue(v)
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+type exportedAccount struct {
+	ID    int64  `json:"id"`
+	Owner string `json:"owner" example:"alice"`
+	Note  string `json:"note,omitempty"`
+}
+
+// TestExportJSONSchemas tests standalone draft 2020-12 documents per model
+func TestExportJSONSchemas(t *testing.T) {
+	documents, err := ExportJSONSchemas(exportedAccount{}, &User{})
+	if err != nil {
+		t.Fatalf("ExportJSONSchemas failed: %v", err)
+	}
+	account, ok := documents["exportedAccount"]
+	if !ok || len(documents) != 2 {
+		t.Fatalf("Expected one document per model, got %v", documents)
+	}
+	if account["$schema"] != JSONSchemaDialect || account["title"] != "exportedAccount" {
+		t.Errorf("Unexpected header %v %v", account["$schema"], account["title"])
+	}
+	owner := account["properties"].(map[string]interface{})["owner"].(map[string]interface{})
+	if _, ok := owner["example"]; ok || !reflect.DeepEqual(owner["examples"], []interface{}{"alice"}) {
+		t.Errorf("Expected example translated to examples, got %v", owner)
+	}
+
+	// The document must compile and validate instances as a 2020-12 schema
+	data, _ := json.Marshal(account)
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("account.json", strings.NewReader(string(data))); err != nil {
+		t.Fatalf("AddResource failed: %v", err)
+	}
+	schema, err := compiler.Compile("account.json")
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	if err := schema.Validate(map[string]interface{}{"id": 1, "owner": "alice"}); err != nil {
+		t.Errorf("Expected valid instance, got %v", err)
+	}
+	if err := schema.Validate(map[string]interface{}{"id": "x", "owner": "alice"}); err == nil {
+		t.Error("Expected invalid instance to be rejected")
+	}
+}
+
+// TestToJSONSchemaNullable tests translating nullable into a null type
+func TestToJSONSchemaNullable(t *testing.T) {
+	schema := toJSONSchema(map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"nullable": map[string]interface{}{"type": "string", "nullable": true},
+		},
+	})
+	prop := schema["properties"].(map[string]interface{})["nullable"].(map[string]interface{})
+	if !reflect.DeepEqual(prop["type"], []interface{}{"string", "null"}) {
+		t.Errorf("Expected nullable type, got %v", prop["type"])
+	}
+}
+
+// TestExportJSONSchemaBundle tests bundling models under $defs and writing files
+func TestExportJSONSchemaBundle(t *testing.T) {
+	bundle, err := ExportJSONSchemaBundle("https://example.com/models.json", exportedAccount{})
+	if err != nil {
+		t.Fatalf("ExportJSONSchemaBundle failed: %v", err)
+	}
+	if bundle["$id"] != "https://example.com/models.json" {
+		t.Errorf("Unexpected $id %v", bundle["$id"])
+	}
+	if _, ok := bundle["$defs"].(map[string]interface{})["exportedAccount"]; !ok {
+		t.Errorf("Expected model under $defs, got %v", bundle["$defs"])
+	}
+
+	if _, err := ExportJSONSchemas(map[string]string{}); err == nil {
+		t.Error("Expected error for an unnamed type")
+	}
+
+	dir := t.TempDir()
+	if err := WriteJSONSchemas(dir, exportedAccount{}); err != nil {
+		t.Fatalf("WriteJSONSchemas failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "exportedAccount.schema.json")); err != nil {
+		t.Errorf("Expected schema file: %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+package api
+
+// Catalog maps message keys to their translation in one locale
+type Catalog map[string]string
+
+// Chain call: translate the summary and description with the catalog entries <key>.summary and <key>.description
+func (api *APIDefinition) WithDescriptionKey(key string) *APIDefinition {
+	api.DescKey = key
+	return api
+}
+
+// Translate returns the translation of a key, or fallback when the catalog lacks it
+func (c Catalog) Translate(key, fallback string) string {
+	if text, ok := c[key]; ok && text != "" {
+		return text
+	}
+	return fallback
+}
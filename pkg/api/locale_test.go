@@ -0,0 +1,25 @@
+package api
+
+import "testing"
+
+// TestCatalogTranslate tests catalog lookups with fallbacks
+func TestCatalogTranslate(t *testing.T) {
+	catalog := Catalog{"users.get.summary": "ユーザーを取得", "users.get.description": ""}
+
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{name: "translated", key: "users.get.summary", want: "ユーザーを取得"},
+		{name: "empty translation", key: "users.get.description", want: "fallback"},
+		{name: "missing key", key: "users.list.summary", want: "fallback"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := catalog.Translate(tt.key, "fallback"); got != tt.want {
+				t.Errorf("Translate(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
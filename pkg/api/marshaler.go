@@ -0,0 +1,165 @@
+package api
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// SchemaProvider is implemented by types that declare the schema of their marshalled form,
+// typically alongside a custom MarshalJSON
+type SchemaProvider interface {
+	OpenAPISchema() map[string]interface{}
+}
+
+var (
+	typeSchemasMu sync.RWMutex
+	typeSchemas   = make(map[reflect.Type]map[string]interface{})
+)
+
+// RegisterTypeSchema declares the wire schema for a type, overriding reflection
+// Use this for third-party types with custom marshalling that cannot implement SchemaProvider
+func RegisterTypeSchema(v interface{}, schema map[string]interface{}) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return
+	}
+	typeSchemasMu.Lock()
+	defer typeSchemasMu.Unlock()
+	typeSchemas[t] = schema
+}
+
+var (
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	schemaProviderType  = reflect.TypeOf((*SchemaProvider)(nil)).Elem()
+	timerType           = reflect.TypeOf((*interface{ Time() time.Time })(nil)).Elem()
+)
+
+// registeredTypeSchema returns a copy of the schema registered for a type with RegisterTypeSchema
+func registeredTypeSchema(t reflect.Type) (map[string]interface{}, bool) {
+	typeSchemasMu.RLock()
+	schema, ok := typeSchemas[t]
+	typeSchemasMu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return copySchema(schema), true
+}
+
+// customTypeSchema returns the schema of a type's marshalled form when it differs from its Go shape
+// Lookup order: registry, SchemaProvider, then inference from MarshalJSON output and text (un)marshalers
+func customTypeSchema(t reflect.Type) (map[string]interface{}, bool) {
+	if t.Kind() == reflect.Ptr || t.Kind() == reflect.Interface {
+		return nil, false
+	}
+
+	if schema, ok := registeredTypeSchema(t); ok {
+		return schema, true
+	}
+
+	if implements(t, schemaProviderType) {
+		if provider, ok := reflect.New(t).Interface().(SchemaProvider); ok {
+			if schema := provider.OpenAPISchema(); schema != nil {
+				return copySchema(schema), true
+			}
+		}
+	}
+
+	// time.Time and Time() types keep their dedicated date-time handling
+	if t.String() == "time.Time" || (t.Kind() == reflect.Struct && implements(t, timerType)) {
+		return nil, false
+	}
+
+	if implements(t, jsonMarshalerType) {
+		return inferMarshalledSchema(t)
+	}
+	if implements(t, textMarshalerType) || implements(t, textUnmarshalerType) {
+		return textSchema(t), true
+	}
+	return nil, false
+}
+
+// textSchema returns the string schema of a type marshalled as text, formatted as a uuid for 16-byte arrays
+// such as uuid.UUID
+func textSchema(t reflect.Type) map[string]interface{} {
+	schema := map[string]interface{}{"type": "string"}
+	if t.Kind() == reflect.Array && t.Len() == 16 && t.Elem().Kind() == reflect.Uint8 {
+		schema["format"] = "uuid"
+	}
+	return schema
+}
+
+// implements reports whether t or *t implements iface
+func implements(t reflect.Type, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+// inferMarshalledSchema marshals the zero value and derives a schema from the JSON kind
+// Objects and failures fall back to reflection, since their shape can't be inferred reliably
+func inferMarshalledSchema(t reflect.Type) (schema map[string]interface{}, ok bool) {
+	defer func() {
+		if recover() != nil {
+			schema, ok = nil, false
+		}
+	}()
+
+	marshaler, isMarshaler := reflect.New(t).Interface().(json.Marshaler)
+	if !isMarshaler {
+		return nil, false
+	}
+	data, err := marshaler.MarshalJSON()
+	if err != nil {
+		return nil, false
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, false
+	}
+
+	switch decoded.(type) {
+	case string:
+		return map[string]interface{}{"type": "string"}, true
+	case bool:
+		return map[string]interface{}{"type": "boolean"}, true
+	case float64:
+		if isNumericKind(t.Kind()) && t.Kind() != reflect.Float32 && t.Kind() != reflect.Float64 {
+			return map[string]interface{}{"type": "integer"}, true
+		}
+		return map[string]interface{}{"type": "number"}, true
+	case []interface{}:
+		return map[string]interface{}{"type": "array", "items": map[string]interface{}{}}, true
+	}
+	return nil, false
+}
+
+// copySchema deep-copies a schema so callers can safely decorate it
+func copySchema(schema map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(schema))
+	for k, v := range schema {
+		out[k] = copySchemaValue(v)
+	}
+	return out
+}
+
+func copySchemaValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return copySchema(val)
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = copySchemaValue(item)
+		}
+		return out
+	case []string:
+		return append([]string(nil), val...)
+	}
+	return v
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type status int
+
+func (s status) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]string{"active", "inactive"}[s])
+}
+
+type money struct {
+	Amount   int64
+	Currency string
+}
+
+func (m money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("%d.%02d %s", m.Amount/100, m.Amount%100, m.Currency))
+}
+
+func (money) OpenAPISchema() map[string]interface{} {
+	return map[string]interface{}{"type": "string", "pattern": `^\d+\.\d{2} [A-Z]{3}$`}
+}
+
+type color struct{ R, G, B uint8 }
+
+func (c color) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)), nil
+}
+
+type opaque struct{ raw string }
+
+type invoice struct {
+	Status status  `json:"status"`
+	Total  money   `json:"total"`
+	Tint   color   `json:"tint"`
+	Ref    *opaque `json:"ref"`
+}
+
+// TestCustomMarshalerSchemas tests documenting types with custom JSON and text marshaling
+func TestCustomMarshalerSchemas(t *testing.T) {
+	RegisterTypeSchema(opaque{}, map[string]interface{}{"type": "string", "format": "uuid"})
+
+	schema, err := SchemaFromStruct(invoice{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	props := schema["properties"].(map[string]interface{})
+
+	want := map[string]map[string]interface{}{
+		"status": {"type": "string"},
+		"total":  {"type": "string", "pattern": `^\d+\.\d{2} [A-Z]{3}$`},
+		"tint":   {"type": "string"},
+		"ref":    {"type": "string", "format": "uuid"},
+	}
+	for name, expected := range want {
+		if got := props[name]; !reflect.DeepEqual(got, expected) {
+			t.Errorf("Expected %s schema %v, got %v", name, expected, got)
+		}
+	}
+}
+
+// TestRegisteredSchemaIsCopied tests that registered schemas are returned as copies
+func TestRegisteredSchemaIsCopied(t *testing.T) {
+	RegisterTypeSchema(opaque{}, map[string]interface{}{"type": "string"})
+
+	first, _ := createSchemaFromGoType(reflect.TypeOf(opaque{}))
+	first["description"] = "mutated"
+	second, _ := createSchemaFromGoType(reflect.TypeOf(opaque{}))
+	if _, ok := second["description"]; ok {
+		t.Error("Expected the registered schema not to change through a returned copy")
+	}
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+)
+
+// GET creates an API definition for a GET operation
+func GET(path, summary string) *APIDefinition {
+	return NewAPIDefinition(http.MethodGet, path, summary)
+}
+
+// POST creates an API definition for a POST operation
+func POST(path, summary string) *APIDefinition {
+	return NewAPIDefinition(http.MethodPost, path, summary)
+}
+
+// PUT creates an API definition for a PUT operation
+func PUT(path, summary string) *APIDefinition {
+	return NewAPIDefinition(http.MethodPut, path, summary)
+}
+
+// PATCH creates an API definition for a PATCH operation
+func PATCH(path, summary string) *APIDefinition {
+	return NewAPIDefinition(http.MethodPatch, path, summary)
+}
+
+// DELETE creates an API definition for a DELETE operation
+func DELETE(path, summary string) *APIDefinition {
+	return NewAPIDefinition(http.MethodDelete, path, summary)
+}
+
+// HEAD creates an API definition for a HEAD operation
+func HEAD(path, summary string) *APIDefinition {
+	return NewAPIDefinition(http.MethodHead, path, summary)
+}
+
+// OPTIONS creates an API definition for an OPTIONS operation
+func OPTIONS(path, summary string) *APIDefinition {
+	return NewAPIDefinition(http.MethodOptions, path, summary)
+}
+
+// RouteBuilder creates definitions for several methods on the same path
+type RouteBuilder struct {
+	path string
+}
+
+// Route starts a builder for the operations of a path
+//
+//	users := api.Route("/users/{id}")
+//	router.Register(users.GET("Get user").WithHandler(getUser))
+//	router.Register(users.DELETE("Delete user").WithHandler(deleteUser))
+func Route(path string) *RouteBuilder {
+	return &RouteBuilder{path: path}
+}
+
+// GET creates a GET definition on the route's path
+func (b *RouteBuilder) GET(summary string) *APIDefinition { return GET(b.path, summary) }
+
+// POST creates a POST definition on the route's path
+func (b *RouteBuilder) POST(summary string) *APIDefinition { return POST(b.path, summary) }
+
+// PUT creates a PUT definition on the route's path
+func (b *RouteBuilder) PUT(summary string) *APIDefinition { return PUT(b.path, summary) }
+
+// PATCH creates a PATCH definition on the route's path
+func (b *RouteBuilder) PATCH(summary string) *APIDefinition { return PATCH(b.path, summary) }
+
+// DELETE creates a DELETE definition on the route's path
+func (b *RouteBuilder) DELETE(summary string) *APIDefinition { return DELETE(b.path, summary) }
+
+// HEAD creates a HEAD definition on the route's path
+func (b *RouteBuilder) HEAD(summary string) *APIDefinition { return HEAD(b.path, summary) }
+
+// OPTIONS creates an OPTIONS definition on the route's path
+func (b *RouteBuilder) OPTIONS(summary string) *APIDefinition { return OPTIONS(b.path, summary) }
+
+// SupportedMethods lists the HTTP methods that can be registered and documented
+var SupportedMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodHead,
+	http.MethodOptions,
+}
+
+// IsSupportedMethod reports whether a method (in any case) can be registered
+func IsSupportedMethod(method string) bool {
+	return containsString(SupportedMethods, strings.ToUpper(method))
+}
+
+// SetOperation stores an operation under its method, returning false for unsupported methods
+func (p *PathItem) SetOperation(method string, op *Operation) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		p.Get = op
+	case http.MethodPost:
+		p.Post = op
+	case http.MethodPut:
+		p.Put = op
+	case http.MethodPatch:
+		p.Patch = op
+	case http.MethodDelete:
+		p.Delete = op
+	case http.MethodHead:
+		p.Head = op
+	case http.MethodOptions:
+		p.Options = op
+	default:
+		return false
+	}
+	return true
+}
+
+// Operation returns the operation stored under a method, or nil
+func (p PathItem) Operation(method string) *Operation {
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		return p.Get
+	case http.MethodPost:
+		return p.Post
+	case http.MethodPut:
+		return p.Put
+	case http.MethodPatch:
+		return p.Patch
+	case http.MethodDelete:
+		return p.Delete
+	case http.MethodHead:
+		return p.Head
+	case http.MethodOptions:
+		return p.Options
+	}
+	return nil
+}
+
+// Operations returns the non-nil operations of the path item
+func (p PathItem) Operations() []*Operation {
+	ops := make([]*Operation, 0, len(SupportedMethods))
+	for _, method := range SupportedMethods {
+		if op := p.Operation(method); op != nil {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+// TestMethodConstructors tests the per-method definition constructors
+func TestMethodConstructors(t *testing.T) {
+	users := Route("/users/{id}")
+	cases := []struct {
+		def    *APIDefinition
+		method string
+	}{
+		{GET("/users", "List users"), http.MethodGet},
+		{POST("/users", "Create user"), http.MethodPost},
+		{users.PUT("Replace user"), http.MethodPut},
+		{users.PATCH("Update user"), http.MethodPatch},
+		{users.DELETE("Delete user"), http.MethodDelete},
+		{users.HEAD("Check user"), http.MethodHead},
+		{users.OPTIONS("User options"), http.MethodOptions},
+	}
+	for _, tc := range cases {
+		if tc.def.Method != tc.method {
+			t.Errorf("%s: Expected method %q, got %q", tc.def.Summary, tc.method, tc.def.Method)
+		}
+	}
+	if got := users.GET("Get user").Path; got != "/users/{id}" {
+		t.Errorf("Expected route path /users/{id}, got %q", got)
+	}
+}
+
+// TestPathItemOperations tests storing and listing operations by method
+func TestPathItemOperations(t *testing.T) {
+	var item PathItem
+	get, head := &Operation{Summary: "get"}, &Operation{Summary: "head"}
+	if !item.SetOperation("get", get) || !item.SetOperation(http.MethodHead, head) {
+		t.Fatal("Expected SetOperation to accept supported methods")
+	}
+	if item.SetOperation("TRACE", &Operation{}) {
+		t.Error("Expected SetOperation to reject an unsupported method")
+	}
+	if item.Operation("GET") != get || item.Head != head {
+		t.Error("Expected operations to be stored under their methods")
+	}
+	if ops := item.Operations(); len(ops) != 2 {
+		t.Errorf("Expected 2 operations, got %d", len(ops))
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -9,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // APIDefinition stores complete API definition information
@@ -18,23 +20,53 @@ type APIDefinition struct {
 	OperationID   string                 // Unique operation ID
 	Summary       string                 // API summary
 	Description   string                 // API detailed description
+	DescSource    *DescriptionSource     // Markdown file loaded into the description at generation time
+	DescKey       string                 // Translation catalog key of the summary and description
 	Tags          []string               // API tag groups
 	Request       interface{}            // Request structure
+	RequestTypes  []string               // Request media types besides JSON, decoded by registered codecs
+	Query         interface{}            // Struct the query parameters are bound into
+	PathModel     interface{}            // Struct the path parameters are bound into
 	Response      interface{}            // Response structure
+	ResponseType  string                 // Media type of a binary response body (e.g., application/pdf)
+	Hypermedia    *Hypermedia            // Links attached to the success response model
+	Headers       map[string]Header      // Headers returned with the success response
+	Responses     map[int]StatusResponse // Additional documented responses keyed by status code
+	NoDefaults    bool                   // Whether the router's canned error responses are left out
+	Conditional   bool                   // Whether the adapter computes ETags and honors If-None-Match
+	RateLimit     *RateLimitPolicy       // Rate limit policy enforced by the router's Limiter
+	SLO           *SLOPolicy             // Latency and throughput objectives
+	Timeout       time.Duration          // Maximum time allowed for handling the request
+	MaxBodySize   int64                  // Maximum accepted request body size in bytes
+	PayloadLimits *PayloadLimits         // Request body shape limits overriding the router's
+	Batch         *BatchPolicy           // Whether the request body is an array of the request model
+	PatchFormats  []string               // Patch media types accepted instead of the JSON request model
+	CORS          *CORSPolicy            // Cross-origin policy overriding the router default
+	Internal      bool                   // Whether the API is hidden from public documentation profiles
+	Plans         []string               // Tenant plans the API is available on (all plans when empty)
+	Changelog     []ChangelogEntry       // Version history of the API
 	Params        []Parameter            // Path parameters, query parameters, etc.
+	Conditions    []Condition            // Cross-field rules over parameters and body properties
+	RequestRules  []RequestRule          // Rules over the whole request, including headers and the principal
+	RequestStream []string               // Media types of a request body the handler streams instead of the router decoding it
 	Handler       http.HandlerFunc       // Standard HTTP handler (fallback)
 	NativeHandler interface{}            // Framework-specific handler (e.g., gin.HandlerFunc, echo.HandlerFunc)
+	Middleware    []interface{}          // Operation-specific middlewares applied by the adapter in order
 	Deprecated    bool                   // Whether the API is deprecated
+	Sunset        time.Time              // Date after which a deprecated API will be removed
+	Replacement   string                 // Link to the API replacing a deprecated one
 	Security      []map[string][]string  // Security requirements
 	ExternalDocs  *ExternalDocumentation // External documentation
 	Examples      map[string]Example     // Request/response examples
 	Servers       []OpenAPIServer        // Operation-specific servers
 	Metadata      map[string]interface{} // Custom metadata for extensibility (e.g., permissions, roles, etc.)
+	Extensions    map[string]interface{} // Specification extensions (x-*) emitted on the operation
 }
 
 // ValidationRule defines a validation rule for a parameter
+// Prefer the typed constructors (MinLength, Minimum, Pattern, Enum, ...), whose rule types are unambiguous
 type ValidationRule struct {
-	Type    string      // Validation type (e.g., "required", "min", "max", "pattern")
+	Type    string      // Validation type (e.g., "minLength", "minimum", "pattern", "enum")
 	Value   interface{} // Validation value
 	Message string      // Error message
 }
@@ -54,6 +86,43 @@ type Parameter struct {
 	Examples        map[string]Example     `json:"examples,omitempty"`
 	Content         map[string]Content     `json:"content,omitempty"`
 	Validations     []ValidationRule       `json:"-"` // Validation rules
+	Ref             string                 `json:"-"` // Reference to a shared definition under components.parameters
+	Sensitive       bool                   `json:"-"` // Whether the value is masked in logs and recorded examples
+}
+
+// MarshalJSON emits only the $ref for parameters shared through components
+func (p Parameter) MarshalJSON() ([]byte, error) {
+	if p.Ref != "" {
+		return json.Marshal(map[string]string{"$ref": p.Ref})
+	}
+	type parameter Parameter
+	if p.Sensitive {
+		return marshalWithExtensions(parameter(p), map[string]interface{}{"x-sensitive": true})
+	}
+	return json.Marshal(parameter(p))
+}
+
+// UnmarshalJSON restores $ref parameters and the x-sensitive marker
+func (p *Parameter) UnmarshalJSON(data []byte) error {
+	var ref struct {
+		Ref       string `json:"$ref"`
+		Sensitive bool   `json:"x-sensitive"`
+	}
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return err
+	}
+	if ref.Ref != "" {
+		*p = Parameter{Ref: ref.Ref}
+		return nil
+	}
+	type parameter Parameter
+	var decoded parameter
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*p = Parameter(decoded)
+	p.Sensitive = ref.Sensitive
+	return nil
 }
 
 // Validate validates a parameter value against its validation rules
@@ -83,38 +152,54 @@ func (p *Parameter) Validate(value interface{}) error {
 
 	for _, rule := range p.Validations {
 		switch rule.Type {
-		case "min":
-			if minVal, ok := rule.Value.(float64); ok {
-				// For numeric values
+		case "min", "max":
+			// Legacy rules: numeric parameters and float bounds compare numbers, other integer bounds compare lengths
+			if bound, isNumber := numberValue(rule.Value); isNumber && p.isNumeric() {
 				numVal, parseErr := strconv.ParseFloat(strValue, 64)
-				if parseErr == nil {
-					if numVal < minVal {
-						return fmt.Errorf(rule.Message)
-					}
+				if parseErr == nil && ((rule.Type == "min" && numVal < bound) || (rule.Type == "max" && numVal > bound)) {
+					return fmt.Errorf(rule.Message)
+				}
+			} else if bound, isFloat := floatValue(rule.Value); isFloat {
+				numVal, parseErr := strconv.ParseFloat(strValue, 64)
+				if parseErr == nil && ((rule.Type == "min" && numVal < bound) || (rule.Type == "max" && numVal > bound)) {
+					return fmt.Errorf(rule.Message)
 				}
-			} else if minLen, ok := rule.Value.(int); ok {
-				// For string length
-				if len(strValue) < minLen {
+			} else if bound, isInt := intValue(rule.Value); isInt {
+				if (rule.Type == "min" && len(strValue) < bound) || (rule.Type == "max" && len(strValue) > bound) {
 					return fmt.Errorf(rule.Message)
 				}
 			}
 
-		case "max":
-			if maxVal, ok := rule.Value.(float64); ok {
-				// For numeric values
-				numVal, parseErr := strconv.ParseFloat(strValue, 64)
-				if parseErr == nil {
-					if numVal > maxVal {
-						return fmt.Errorf(rule.Message)
-					}
-				}
-			} else if maxLen, ok := rule.Value.(int); ok {
-				// For string length
-				if len(strValue) > maxLen {
+		case RuleMinLength, RuleMaxLength:
+			if bound, ok := intValue(rule.Value); ok {
+				length := utf8.RuneCountInString(strValue)
+				if (rule.Type == RuleMinLength && length < bound) || (rule.Type == RuleMaxLength && length > bound) {
 					return fmt.Errorf(rule.Message)
 				}
 			}
 
+		case RuleMinimum, RuleMaximum:
+			bound, ok := toNumber(rule.Value)
+			numVal, parseErr := strconv.ParseFloat(strValue, 64)
+			if !ok || parseErr != nil {
+				continue
+			}
+			if (rule.Type == RuleMinimum && numVal < bound) || (rule.Type == RuleMaximum && numVal > bound) {
+				return fmt.Errorf(rule.Message)
+			}
+
+		case RuleExclusiveMin, RuleExclusiveMax, RuleMultipleOf:
+			bound, ok := toNumber(rule.Value)
+			numVal, parseErr := strconv.ParseFloat(strValue, 64)
+			if !ok || parseErr != nil {
+				continue
+			}
+			if (rule.Type == RuleExclusiveMin && numVal <= bound) ||
+				(rule.Type == RuleExclusiveMax && numVal >= bound) ||
+				(rule.Type == RuleMultipleOf && !isMultipleOf(numVal, bound)) {
+				return fmt.Errorf(rule.Message)
+			}
+
 		case "pattern":
 			if pattern, ok := rule.Value.(string); ok {
 				matched, matchErr := regexp.MatchString(pattern, strValue)
@@ -156,7 +241,7 @@ func (p *Parameter) Validate(value interface{}) error {
 type OpenAPIDoc struct {
 	OpenAPI      string                 `json:"openapi"`
 	Info         OpenAPIInfo            `json:"info"`
-	Servers      []OpenAPIServer        `json:"servers"`
+	Servers      []OpenAPIServer        `json:"servers,omitempty"`
 	Paths        map[string]PathItem    `json:"paths"`
 	Components   *Components            `json:"components,omitempty"`
 	Security     []map[string][]string  `json:"security,omitempty"`
@@ -239,23 +324,26 @@ type OpenAPIInfo struct {
 }
 
 type OpenAPIServer struct {
-	URL         string `json:"url"`
-	Description string `json:"description"`
+	URL         string                    `json:"url"`
+	Description string                    `json:"description"`
+	Variables   map[string]ServerVariable `json:"variables,omitempty"`
 }
 
 type PathItem struct {
-	Get    *Operation `json:"get,omitempty"`
-	Post   *Operation `json:"post,omitempty"`
-	Put    *Operation `json:"put,omitempty"`
-	Delete *Operation `json:"delete,omitempty"`
-	Patch  *Operation `json:"patch,omitempty"`
+	Get     *Operation `json:"get,omitempty"`
+	Post    *Operation `json:"post,omitempty"`
+	Put     *Operation `json:"put,omitempty"`
+	Delete  *Operation `json:"delete,omitempty"`
+	Patch   *Operation `json:"patch,omitempty"`
+	Head    *Operation `json:"head,omitempty"`
+	Options *Operation `json:"options,omitempty"`
 }
 
 type Operation struct {
 	Summary      string                 `json:"summary"`
 	Description  string                 `json:"description"`
 	OperationID  string                 `json:"operationId,omitempty"`
-	Tags         []string               `json:"tags"`
+	Tags         []string               `json:"tags,omitempty"`
 	Parameters   []Parameter            `json:"parameters,omitempty"`
 	RequestBody  *RequestBody           `json:"requestBody,omitempty"`
 	Responses    map[string]Response    `json:"responses"`
@@ -263,6 +351,73 @@ type Operation struct {
 	Security     []map[string][]string  `json:"security,omitempty"`
 	Servers      []OpenAPIServer        `json:"servers,omitempty"`
 	ExternalDocs *ExternalDocumentation `json:"externalDocs,omitempty"`
+	Extensions   map[string]interface{} `json:"-"` // Specification extensions (x-*)
+}
+
+// MarshalJSON inlines specification extensions into the operation object
+func (o Operation) MarshalJSON() ([]byte, error) {
+	type operation Operation
+	return marshalWithExtensions(operation(o), o.Extensions)
+}
+
+// UnmarshalJSON collects x-* fields into Extensions
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	type operation Operation
+	var decoded operation
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data)
+	if err != nil {
+		return err
+	}
+	*o = Operation(decoded)
+	o.Extensions = extensions
+	return nil
+}
+
+// unmarshalExtensions returns the x-* fields of a JSON object, or nil if there are none
+func unmarshalExtensions(data []byte) (map[string]interface{}, error) {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	var extensions map[string]interface{}
+	for key, raw := range fields {
+		if !strings.HasPrefix(key, "x-") {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal extension %s: %w", key, err)
+		}
+		if extensions == nil {
+			extensions = make(map[string]interface{})
+		}
+		extensions[key] = value
+	}
+	return extensions, nil
+}
+
+// marshalWithExtensions marshals v and merges the given x-* extensions into the resulting object
+func marshalWithExtensions(v interface{}, extensions map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extensions) == 0 {
+		return data, err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range extensions {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal extension %s: %w", key, err)
+		}
+		fields[key] = raw
+	}
+	return json.Marshal(fields)
 }
 
 type RequestBody struct {
@@ -270,12 +425,30 @@ type RequestBody struct {
 }
 
 type Content struct {
-	Schema map[string]interface{} `json:"schema"`
+	Schema   map[string]interface{} `json:"schema"`
+	Examples map[string]Example     `json:"examples,omitempty"`
 }
 
 type Response struct {
 	Description string             `json:"description"`
+	Headers     map[string]Header  `json:"headers,omitempty"`
 	Content     map[string]Content `json:"content,omitempty"`
+	Links       map[string]Link    `json:"links,omitempty"`
+}
+
+// Link describes how values of a response are passed to another operation
+type Link struct {
+	OperationID string                 `json:"operationId,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"` // Runtime expressions keyed by parameter name
+	Description string                 `json:"description,omitempty"`
+}
+
+// StatusResponse describes an additional response documented for an operation
+type StatusResponse struct {
+	Description string            // Response description
+	Model       interface{}       // Optional response body structure
+	Headers     map[string]Header // Headers returned with the response
+	Links       map[string]Link   // Operations the response leads to
 }
 
 // Error types
@@ -378,6 +551,11 @@ func SafeSchemaFromStruct(v interface{}) (schema map[string]interface{}, err err
 		return nil, &ErrInvalidType{Type: "nil pointer element"}
 	}
 
+	// Registered schemas override reflection for top-level models too
+	if schema, ok := registeredTypeSchema(t); ok {
+		return schema, nil
+	}
+
 	// Handle different types
 	switch t.Kind() {
 	case reflect.Struct:
@@ -420,6 +598,20 @@ func SafeSchemaFromStruct(v interface{}) (schema map[string]interface{}, err err
 	}
 }
 
+// OmitEmptyBehavior controls how the `omitempty` json option is reflected in schemas
+type OmitEmptyBehavior int
+
+const (
+	// OmitEmptyOptional drops omitempty fields from the required list (default)
+	OmitEmptyOptional OmitEmptyBehavior = iota
+	// OmitEmptyNullable keeps omitempty fields required and marks them nullable
+	OmitEmptyNullable
+)
+
+// OmitEmptyMode selects the omitempty behavior used by SchemaFromStruct
+// `omitzero` fields are always optional, since they are absent from the payload when zero
+var OmitEmptyMode = OmitEmptyOptional
+
 // Generate schema from struct using reflection
 func SchemaFromStruct(v interface{}) (map[string]interface{}, error) {
 	if v == nil {
@@ -448,47 +640,99 @@ func SchemaFromStruct(v interface{}) (map[string]interface{}, error) {
 	props := make(map[string]interface{})
 	required := make([]string, 0)
 
+	if err := collectStructFields(t, props, &required); err != nil {
+		return nil, err
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": props,
+	}
+
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	if strict, declared := modelStrictness(t); declared {
+		schema["additionalProperties"] = !strict
+	}
+
+	return schema, nil
+}
+
+// collectStructFields adds the schemas of a struct's fields to props,
+// flattening embedded and `inline` structs the way encoding/json does
+func collectStructFields(t reflect.Type, props map[string]interface{}, required *[]string) error {
+	// Embedded fields are expanded after direct fields so that the shallower field wins on name clashes
+	var inlined []reflect.Type
+
 	// Safely iterate through fields
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
 
 		// Get JSON tag
-		jsonTag := field.Tag.Get("json")
-		if jsonTag == "" || jsonTag == "-" {
+		rawTag, hasTag := field.Tag.Lookup("json")
+		if rawTag == "-" || swaggerIgnored(field) {
 			continue
 		}
+		jsonTag, opts, _ := strings.Cut(rawTag, ",")
 
-		// Handle omitempty and required
+		if inline := embeddedStruct(field, jsonTag, opts); inline != nil {
+			inlined = append(inlined, inline)
+			continue
+		}
+		if !hasTag || !field.IsExported() {
+			continue
+		}
+		if jsonTag == "" {
+			jsonTag = field.Name
+		}
+
+		// Handle omitempty/omitzero and required
 		isRequired := true
-		if strings.Contains(jsonTag, ",") {
-			parts := strings.Split(jsonTag, ",")
-			jsonTag = parts[0]
-			for _, opt := range parts[1:] {
-				if opt == "omitempty" {
+		nullable := false
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "omitempty":
+				if OmitEmptyMode == OmitEmptyNullable {
+					nullable = true
+				} else {
 					isRequired = false
 				}
+			case "omitzero":
+				isRequired = false
 			}
 		}
 
-		// Get validation tag
-		validationTag := field.Tag.Get("validate")
-		if validationTag != "" {
-			validations := strings.Split(validationTag, ",")
-			for _, v := range validations {
-				if v == "required" {
-					isRequired = true
-					break
-				}
+		// Reference an external definition instead of describing the field's type
+		if ref := field.Tag.Get("ref"); ref != "" {
+			if applyValidationTags(map[string]interface{}{}, field) {
+				isRequired = true
 			}
+			props[jsonTag] = refSchema(field.Type, ref)
+			if isRequired {
+				*required = append(*required, jsonTag)
+			}
+			continue
+		}
+
+		// Fields with a documented default are optional
+		rawDefault, hasDefault := field.Tag.Lookup("default")
+		if hasDefault {
+			isRequired = false
 		}
 
 		// Generate schema based on field type
 		fieldSchema, err := createSchemaFromGoType(field.Type)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create schema for field %s: %w", field.Name, err)
+			return fmt.Errorf("failed to create schema for field %s: %w", field.Name, err)
 		}
 
 		if fieldSchema != nil {
+			// Translate validate/binding rules into constraints
+			if applyValidationTags(fieldSchema, field) {
+				isRequired = true
+			}
+
 			// Add description from doc tag if available
 			if desc := field.Tag.Get("doc"); desc != "" {
 				fieldSchema["description"] = desc
@@ -504,24 +748,82 @@ func SchemaFromStruct(v interface{}) (map[string]interface{}, error) {
 				fieldSchema["format"] = format
 			}
 
+			// Add default from default tag, typed like the field
+			if hasDefault {
+				fieldSchema["default"] = typedDefault(fieldSchema, rawDefault)
+			}
+
+			// Mark sensitive fields (passwords, tokens) as write-only
+			if sensitive, _ := strconv.ParseBool(field.Tag.Get("sensitive")); sensitive {
+				fieldSchema["writeOnly"] = true
+				fieldSchema["x-sensitive"] = true
+			}
+
+			if nullable {
+				fieldSchema["nullable"] = true
+			}
+
 			props[jsonTag] = fieldSchema
 
 			if isRequired {
-				required = append(required, jsonTag)
+				*required = append(*required, jsonTag)
 			}
 		}
 	}
 
-	schema := map[string]interface{}{
-		"type":       "object",
-		"properties": props,
+	for _, inline := range inlined {
+		embeddedProps := make(map[string]interface{})
+		embeddedRequired := make([]string, 0)
+		if err := collectStructFields(inline, embeddedProps, &embeddedRequired); err != nil {
+			return err
+		}
+		for name, schema := range embeddedProps {
+			if _, exists := props[name]; !exists {
+				props[name] = schema
+				if containsString(embeddedRequired, name) {
+					*required = append(*required, name)
+				}
+			}
+		}
 	}
 
-	if len(required) > 0 {
-		schema["required"] = required
+	return nil
+}
+
+// refSchema references an external definition for a field, as the items of slice and array fields
+func refSchema(t reflect.Type, ref string) map[string]interface{} {
+	if kind := fieldKind(t); kind == reflect.Slice || kind == reflect.Array {
+		return map[string]interface{}{"type": "array", "items": ExternalRef(ref)}
+	}
+	return ExternalRef(ref)
+}
+
+// embeddedStruct returns the struct type whose fields should be flattened into the parent:
+// untagged anonymous structs (encoding/json embedding) or fields tagged `json:",inline"`
+func embeddedStruct(field reflect.StructField, name, opts string) reflect.Type {
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || name != "" {
+		return nil
 	}
+	if field.Anonymous || containsString(strings.Split(opts, ","), "inline") {
+		if _, ok := customTypeSchema(t); ok || t.String() == "time.Time" {
+			return nil
+		}
+		return t
+	}
+	return nil
+}
 
-	return schema, nil
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
 }
 
 // Create schema based on Go type
@@ -531,6 +833,11 @@ func createSchemaFromGoType(t reflect.Type) (map[string]interface{}, error) {
 		return map[string]interface{}{"type": "string"}, nil
 	}
 
+	// Types with custom marshalling are described by their wire format
+	if schema, ok := customTypeSchema(t); ok {
+		return schema, nil
+	}
+
 	switch t.Kind() {
 	case reflect.String:
 		return map[string]interface{}{"type": "string"}, nil
@@ -636,6 +943,11 @@ func createSchemaFromGoType(t reflect.Type) (map[string]interface{}, error) {
 		return createSchemaFromGoType(elemType)
 
 	case reflect.Interface:
+		// Interfaces with registered implementations become a oneOf
+		if schema, ok, err := polymorphicSchema(t); ok || err != nil {
+			return schema, err
+		}
+
 		// For empty interfaces, we can't determine the type
 		return map[string]interface{}{
 			"type":                 "object",
@@ -790,9 +1102,11 @@ func NewValidationRule(ruleType string, value interface{}, message string) Valid
 	}
 }
 
-// Chain call: set request structure
+// Chain call: set request structure; fields tagged `path:"name"` declare path parameters and are filled in
+// from the path
 func (api *APIDefinition) WithRequest(req interface{}) *APIDefinition {
 	api.Request = req
+	api.declarePathParams(req)
 	return api
 }
 
@@ -802,6 +1116,53 @@ func (api *APIDefinition) WithResponse(resp interface{}) *APIDefinition {
 	return api
 }
 
+// WithBinaryResponse documents the success response as a raw file of the given media type
+// (e.g., "application/pdf", "image/png") instead of a JSON structure
+func (api *APIDefinition) WithBinaryResponse(contentType string) *APIDefinition {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	api.Response = nil
+	api.ResponseType = contentType
+	return api
+}
+
+// WithResponseHeader documents a header returned with the success response
+func (api *APIDefinition) WithResponseHeader(name string, header Header) *APIDefinition {
+	if api.Headers == nil {
+		api.Headers = make(map[string]Header)
+	}
+	api.Headers[name] = header
+	return api
+}
+
+// WithStatusResponse documents an additional response for the given status code
+func (api *APIDefinition) WithStatusResponse(status int, description string, model interface{}) *APIDefinition {
+	if api.Responses == nil {
+		api.Responses = make(map[int]StatusResponse)
+	}
+	resp := api.Responses[status]
+	resp.Description = description
+	resp.Model = model
+	api.Responses[status] = resp
+	return api
+}
+
+// WithNoDefaultResponses documents only the responses declared on the API,
+// leaving out the canned error responses the router adds to every operation
+func (api *APIDefinition) WithNoDefaultResponses() *APIDefinition {
+	api.NoDefaults = true
+	return api
+}
+
+// BinarySchema returns the schema used for raw binary payloads
+func BinarySchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":   "string",
+		"format": "binary",
+	}
+}
+
 // WithHandler sets the standard HTTP handler (used as fallback when no native handler is provided)
 // For framework-specific handlers (e.g., gin.HandlerFunc), use WithNativeHandler instead
 func (api *APIDefinition) WithHandler(handler http.HandlerFunc) *APIDefinition {
@@ -816,9 +1177,64 @@ func (api *APIDefinition) WithNativeHandler(handler interface{}) *APIDefinition
 	return api
 }
 
-// Chain call: mark as deprecated
+// WithMiddleware appends operation-specific middlewares (auth, quota, feature flags), run in order before the handler
+// Adapters accept func(http.Handler) http.Handler as well as their framework's middleware (e.g., gin.HandlerFunc)
+func (api *APIDefinition) WithMiddleware(handlers ...interface{}) *APIDefinition {
+	api.Middleware = append(api.Middleware, handlers...)
+	return api
+}
+
+// Chain call: mark as deprecated; WithDeprecation also announces a sunset date and replacement
+// Un-deprecating clears the sunset date and replacement set before
 func (api *APIDefinition) WithDeprecated(deprecated bool) *APIDefinition {
 	api.Deprecated = deprecated
+	if !deprecated {
+		api.Sunset = time.Time{}
+		api.Replacement = ""
+	}
+	return api
+}
+
+// WithTimeout sets the time allowed for handling the request and documents the 408 response
+// The request context carries the deadline; handlers should honor it
+func (api *APIDefinition) WithTimeout(timeout time.Duration) *APIDefinition {
+	api.Timeout = timeout
+	return api.WithStatusResponse(http.StatusRequestTimeout,
+		fmt.Sprintf("Request Timeout - The request was not handled within %s", timeout), nil)
+}
+
+// WithMaxBodySize limits the request body size and documents the 413 response
+func (api *APIDefinition) WithMaxBodySize(bytes int64) *APIDefinition {
+	api.MaxBodySize = bytes
+	return api.WithStatusResponse(http.StatusRequestEntityTooLarge,
+		fmt.Sprintf("Payload Too Large - The request body exceeds %d bytes", bytes), nil)
+}
+
+// WithInternal marks the API as internal so public environment profiles leave it out of the spec
+func (api *APIDefinition) WithInternal() *APIDefinition {
+	api.Internal = true
+	return api
+}
+
+// WithDeprecation marks the API as deprecated with a sunset date and a link to its replacement, superseding
+// WithDeprecated(true); a zero sunset or empty replacement is left out
+// Adapters emit Deprecation, Sunset (RFC 8594) and Link headers; the spec carries x-sunset metadata
+func (api *APIDefinition) WithDeprecation(sunset time.Time, replacement string) *APIDefinition {
+	api.Deprecated = true
+	api.Sunset = sunset
+	api.Replacement = replacement
+	return api
+}
+
+// Chain call: add specification extension (the x- prefix is added when missing)
+func (api *APIDefinition) WithExtension(key string, value interface{}) *APIDefinition {
+	if !strings.HasPrefix(key, "x-") {
+		key = "x-" + key
+	}
+	if api.Extensions == nil {
+		api.Extensions = make(map[string]interface{})
+	}
+	api.Extensions[key] = value
 	return api
 }
 
@@ -831,6 +1247,34 @@ func (api *APIDefinition) WithSecurity(scheme string, scopes []string) *APIDefin
 	return api
 }
 
+// Chain call: add a security requirement satisfied only when all the given schemes are (AND)
+func (api *APIDefinition) WithSecurityAnd(schemes map[string][]string) *APIDefinition {
+	requirement := make(map[string][]string, len(schemes))
+	for scheme, scopes := range schemes {
+		if scopes == nil {
+			scopes = []string{}
+		}
+		requirement[scheme] = scopes
+	}
+	api.Security = append(api.Security, requirement)
+	return api
+}
+
+// Chain call: add alternative security requirements, any one of which is sufficient (OR)
+// Each requirement may itself combine several schemes, e.g. "apiKey AND oauth2, or bearer"
+func (api *APIDefinition) WithSecurityOr(requirements ...map[string][]string) *APIDefinition {
+	for _, requirement := range requirements {
+		api.WithSecurityAnd(requirement)
+	}
+	return api
+}
+
+// Chain call: allow anonymous access alongside the other security requirements (empty requirement)
+func (api *APIDefinition) WithOptionalSecurity() *APIDefinition {
+	api.Security = append(api.Security, map[string][]string{})
+	return api
+}
+
 // Chain call: add external documentation
 func (api *APIDefinition) WithExternalDocs(description, url string) *APIDefinition {
 	api.ExternalDocs = &ExternalDocumentation{
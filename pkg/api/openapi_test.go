@@ -66,6 +66,48 @@ func TestAPIDefinitionChaining(t *testing.T) {
 	}
 }
 
+// TestSecurityCombinations tests AND/OR security requirement builders
+func TestSecurityCombinations(t *testing.T) {
+	api := NewAPIDefinition("GET", "/reports", "List reports").
+		WithSecurityOr(
+			map[string][]string{"apiKey": nil, "oauth2": {"read"}},
+			map[string][]string{"bearerAuth": {}},
+		).
+		WithOptionalSecurity()
+
+	if len(api.Security) != 3 {
+		t.Fatalf("Expected 3 requirements, got %d", len(api.Security))
+	}
+	if len(api.Security[0]) != 2 || api.Security[0]["apiKey"] == nil || api.Security[0]["oauth2"][0] != "read" {
+		t.Errorf("Unexpected AND requirement %v", api.Security[0])
+	}
+	if _, ok := api.Security[1]["bearerAuth"]; !ok {
+		t.Errorf("Unexpected OR requirement %v", api.Security[1])
+	}
+	if len(api.Security[2]) != 0 {
+		t.Errorf("Expected empty optional requirement, got %v", api.Security[2])
+	}
+}
+
+// TestWithBinaryResponse tests binary response configuration
+func TestWithBinaryResponse(t *testing.T) {
+	api := NewAPIDefinition("GET", "/files/{id}", "Download file").
+		WithResponse(User{}).
+		WithBinaryResponse("image/png")
+
+	if api.Response != nil {
+		t.Error("Expected structured response to be cleared")
+	}
+	if api.ResponseType != "image/png" {
+		t.Errorf("Expected response type 'image/png', got %s", api.ResponseType)
+	}
+
+	api = NewAPIDefinition("GET", "/files/{id}", "Download file").WithBinaryResponse("")
+	if api.ResponseType != "application/octet-stream" {
+		t.Errorf("Expected default response type 'application/octet-stream', got %s", api.ResponseType)
+	}
+}
+
 // TestSchemaFromStruct tests schema generation from struct
 func TestSchemaFromStruct(t *testing.T) {
 	user := User{}
@@ -455,3 +497,62 @@ func BenchmarkParameterValidation(b *testing.B) {
 		_ = param.Validate("test@example.com")
 	}
 }
+
+type auditFields struct {
+	CreatedBy string `json:"createdBy"`
+	Note      string `json:"note,omitempty"`
+}
+
+type jsonOptionsModel struct {
+	auditFields
+	Meta  auditFields `json:",inline"`
+	Name  string      `json:",omitempty"`
+	Count int         `json:"count,omitzero"`
+	Note  string      `json:"note"`
+}
+
+// TestJSONFieldOptions tests embedded, inline, omitempty and omitzero json tag options
+func TestJSONFieldOptions(t *testing.T) {
+	schema, err := SchemaFromStruct(jsonOptionsModel{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	props := schema["properties"].(map[string]interface{})
+
+	for _, name := range []string{"createdBy", "note", "Name", "count"} {
+		if _, ok := props[name]; !ok {
+			t.Errorf("Expected property %q, got %v", name, props)
+		}
+	}
+	for _, name := range []string{"auditFields", "Meta"} {
+		if _, ok := props[name]; ok {
+			t.Errorf("Expected no property %q, got %v", name, props)
+		}
+	}
+
+	required := schema["required"].([]string)
+	if !containsString(required, "createdBy") || !containsString(required, "note") {
+		t.Errorf("Expected createdBy and note to be required (outer field wins), got %v", required)
+	}
+	if containsString(required, "count") || containsString(required, "Name") {
+		t.Errorf("Expected omitzero/omitempty fields not to be required, got %v", required)
+	}
+}
+
+// TestOmitEmptyNullableMode tests documenting omitempty fields as required and nullable
+func TestOmitEmptyNullableMode(t *testing.T) {
+	OmitEmptyMode = OmitEmptyNullable
+	defer func() { OmitEmptyMode = OmitEmptyOptional }()
+
+	schema, err := SchemaFromStruct(auditFields{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	note := schema["properties"].(map[string]interface{})["note"].(map[string]interface{})
+	if note["nullable"] != true {
+		t.Errorf("Expected note to be nullable, got %v", note)
+	}
+	if !containsString(schema["required"].([]string), "note") {
+		t.Errorf("Expected note to stay required in nullable mode, got %v", schema["required"])
+	}
+}
@@ -0,0 +1,97 @@
+package api
+
+// PaginationStyle selects the set of paging parameters injected by WithPagination
+type PaginationStyle string
+
+const (
+	PaginationPage   PaginationStyle = "page"   // page & per_page parameters
+	PaginationOffset PaginationStyle = "offset" // offset & limit parameters
+	PaginationCursor PaginationStyle = "cursor" // cursor & limit parameters
+)
+
+// MaxPageSize is the upper bound documented and enforced for page size parameters
+var MaxPageSize = 100
+
+// PagedResponse is a generic envelope for list endpoints
+// Usage: WithResponse(api.PagedResponse[User]{})
+type PagedResponse[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int64  `json:"total" doc:"Total number of items across all pages"`
+	Page       int    `json:"page,omitempty" doc:"Current page number"`
+	PerPage    int    `json:"per_page,omitempty" doc:"Number of items per page"`
+	NextCursor string `json:"next_cursor,omitempty" doc:"Cursor to fetch the next page"`
+}
+
+// Chain call: add standard pagination parameters and response headers
+func (api *APIDefinition) WithPagination(style PaginationStyle) *APIDefinition {
+	switch style {
+	case PaginationOffset:
+		api.Params = append(api.Params,
+			pageParam("offset", "Number of items to skip", 0, 0),
+			pageSizeParam("limit"),
+		)
+	case PaginationCursor:
+		api.Params = append(api.Params,
+			Parameter{
+				Name:        "cursor",
+				In:          "query",
+				Description: "Opaque cursor returned by the previous page",
+				Schema:      map[string]interface{}{"type": "string"},
+			},
+			pageSizeParam("limit"),
+		)
+	default:
+		api.Params = append(api.Params,
+			pageParam("page", "Page number, starting at 1", 1, 1),
+			pageSizeParam("per_page"),
+		)
+	}
+
+	api.WithResponseHeader("Link", Header{
+		Description: "RFC 8288 links to the first, prev, next and last pages",
+		Schema:      map[string]interface{}{"type": "string"},
+	})
+	if style != PaginationCursor {
+		api.WithResponseHeader("X-Total-Count", Header{
+			Description: "Total number of items across all pages",
+			Schema:      map[string]interface{}{"type": "integer", "format": "int64"},
+		})
+	}
+	return api
+}
+
+func pageParam(name, description string, minimum, defaultValue int) Parameter {
+	return Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema: map[string]interface{}{
+			"type":    "integer",
+			"minimum": minimum,
+			"default": defaultValue,
+		},
+		Validations: []ValidationRule{
+			Pattern("^[0-9]+$", name+" must be a non-negative integer"),
+			Minimum(float64(minimum), name+" is out of range"),
+		},
+	}
+}
+
+func pageSizeParam(name string) Parameter {
+	return Parameter{
+		Name:        name,
+		In:          "query",
+		Description: "Maximum number of items per page",
+		Schema: map[string]interface{}{
+			"type":    "integer",
+			"minimum": 1,
+			"maximum": MaxPageSize,
+			"default": 20,
+		},
+		Validations: []ValidationRule{
+			Pattern("^[0-9]+$", name+" must be a positive integer"),
+			Minimum(1, name+" must be at least 1"),
+			Maximum(float64(MaxPageSize), name+" is too large"),
+		},
+	}
+}
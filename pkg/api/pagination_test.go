@@ -0,0 +1,67 @@
+package api
+
+import "testing"
+
+// TestWithPagination tests injection of paging parameters and headers
+func TestWithPagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		style      PaginationStyle
+		wantParams []string
+		wantTotal  bool
+	}{
+		{name: "page", style: PaginationPage, wantParams: []string{"page", "per_page"}, wantTotal: true},
+		{name: "offset", style: PaginationOffset, wantParams: []string{"offset", "limit"}, wantTotal: true},
+		{name: "cursor", style: PaginationCursor, wantParams: []string{"cursor", "limit"}, wantTotal: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewAPIDefinition("GET", "/users", "List users").WithPagination(tt.style)
+
+			if len(api.Params) != len(tt.wantParams) {
+				t.Fatalf("Expected %d params, got %d", len(tt.wantParams), len(api.Params))
+			}
+			for i, name := range tt.wantParams {
+				if api.Params[i].Name != name || api.Params[i].In != "query" {
+					t.Errorf("Expected query param %s, got %s in %s", name, api.Params[i].Name, api.Params[i].In)
+				}
+			}
+			if _, ok := api.Headers["Link"]; !ok {
+				t.Error("Expected Link response header")
+			}
+			if _, ok := api.Headers["X-Total-Count"]; ok != tt.wantTotal {
+				t.Errorf("Expected X-Total-Count present=%v", tt.wantTotal)
+			}
+		})
+	}
+}
+
+// TestPageSizeValidation tests runtime validation of the page size parameter
+func TestPageSizeValidation(t *testing.T) {
+	api := NewAPIDefinition("GET", "/users", "List users").WithPagination(PaginationPage)
+	perPage := api.Params[1]
+
+	for value, wantErr := range map[string]bool{"20": false, "0": true, "101": true, "abc": true} {
+		if err := perPage.Validate(value); (err != nil) != wantErr {
+			t.Errorf("Validate(%q) error = %v, wantErr %v", value, err, wantErr)
+		}
+	}
+}
+
+// TestPagedResponseSchema tests schema generation for the generic envelope
+func TestPagedResponseSchema(t *testing.T) {
+	schema, err := SchemaFromStruct(PagedResponse[User]{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+
+	props := schema["properties"].(map[string]interface{})
+	items, ok := props["items"].(map[string]interface{})
+	if !ok || items["type"] != "array" {
+		t.Fatalf("Expected items array, got %v", props["items"])
+	}
+	if items["items"].(map[string]interface{})["type"] != "object" {
+		t.Error("Expected items to reference the element object schema")
+	}
+}
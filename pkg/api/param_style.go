@@ -0,0 +1,114 @@
+package api
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Serialization styles for parameters (OpenAPI "style" keyword)
+const (
+	StyleForm           = "form"           // ids=1&ids=2 (explode) or ids=1,2
+	StyleSpaceDelimited = "spaceDelimited" // ids=1%202
+	StylePipeDelimited  = "pipeDelimited"  // ids=1|2
+	StyleDeepObject     = "deepObject"     // filter[name]=x&filter[role]=admin
+	StyleSimple         = "simple"         // 1,2 (path and header default)
+)
+
+// Chain call: set the serialization style of a declared parameter
+// deepObject always explodes, as the specification requires
+func (api *APIDefinition) WithParamStyle(name, style string, explode bool) *APIDefinition {
+	for i := range api.Params {
+		if api.Params[i].Name == name {
+			api.Params[i].Style = style
+			api.Params[i].Explode = explode || style == StyleDeepObject
+		}
+	}
+	return api
+}
+
+// schemaType returns the declared schema type of the parameter, or "" if none
+func (p *Parameter) schemaType() string {
+	t, _ := p.Schema["type"].(string)
+	return t
+}
+
+// isNumeric reports whether the parameter is declared as an integer or number
+func (p *Parameter) isNumeric() bool {
+	return p.schemaType() == "integer" || p.schemaType() == "number"
+}
+
+// DecodeQuery extracts the parameter from a query string according to its style
+// The result is a string for scalars, []string for arrays and map[string]string for objects
+// Returns false if the parameter is absent
+func (p *Parameter) DecodeQuery(query url.Values) (interface{}, bool) {
+	if p.Style == StyleDeepObject || (p.schemaType() == "object" && p.Style == "") {
+		prefix := p.Name + "["
+		object := make(map[string]string)
+		for key, values := range query {
+			if strings.HasPrefix(key, prefix) && strings.HasSuffix(key, "]") && len(values) > 0 {
+				object[key[len(prefix):len(key)-1]] = values[0]
+			}
+		}
+		if len(object) == 0 {
+			return nil, false
+		}
+		return object, true
+	}
+
+	values, ok := query[p.Name]
+	if !ok || len(values) == 0 {
+		return nil, false
+	}
+	if p.schemaType() != "array" {
+		return values[0], true
+	}
+
+	separator := ","
+	switch p.Style {
+	case StyleSpaceDelimited:
+		separator = " "
+	case StylePipeDelimited:
+		separator = "|"
+	}
+
+	// Exploded form arrays repeat the key; the other styles join items into one value
+	if p.Explode && (p.Style == "" || p.Style == StyleForm) {
+		return values, true
+	}
+	items := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, item := range strings.Split(value, separator) {
+			if item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items, true
+}
+
+// ValidateDecoded validates a value returned by DecodeQuery, applying the rules to each item
+func (p *Parameter) ValidateDecoded(value interface{}) error {
+	switch v := value.(type) {
+	case []string:
+		if p.Required && len(v) == 0 {
+			return p.Validate("")
+		}
+		if err := p.validateItems(v); err != nil {
+			return err
+		}
+		for _, item := range v {
+			if err := p.Validate(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]string:
+		for _, item := range v {
+			if err := p.Validate(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return p.Validate(value)
+}
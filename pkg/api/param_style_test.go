@@ -0,0 +1,137 @@
+package api
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestDecodeQuery tests decoding query parameters according to their serialization style
+func TestDecodeQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   Parameter
+		query   string
+		want    interface{}
+		present bool
+	}{
+		{
+			name:    "scalar",
+			param:   Parameter{Name: "q"},
+			query:   "q=go",
+			want:    "go",
+			present: true,
+		},
+		{
+			name:    "absent",
+			param:   Parameter{Name: "q"},
+			query:   "other=1",
+			present: false,
+		},
+		{
+			name:    "form array",
+			param:   Parameter{Name: "ids", Schema: map[string]interface{}{"type": "array"}},
+			query:   "ids=1,2,3",
+			want:    []string{"1", "2", "3"},
+			present: true,
+		},
+		{
+			name:    "exploded form array",
+			param:   Parameter{Name: "ids", Explode: true, Schema: map[string]interface{}{"type": "array"}},
+			query:   "ids=1&ids=2",
+			want:    []string{"1", "2"},
+			present: true,
+		},
+		{
+			name:    "pipe delimited",
+			param:   Parameter{Name: "ids", Style: StylePipeDelimited, Schema: map[string]interface{}{"type": "array"}},
+			query:   "ids=1|2|3",
+			want:    []string{"1", "2", "3"},
+			present: true,
+		},
+		{
+			name:    "space delimited",
+			param:   Parameter{Name: "ids", Style: StyleSpaceDelimited, Schema: map[string]interface{}{"type": "array"}},
+			query:   "ids=1%202",
+			want:    []string{"1", "2"},
+			present: true,
+		},
+		{
+			name:    "deep object",
+			param:   Parameter{Name: "filter", Style: StyleDeepObject, Explode: true},
+			query:   "filter[name]=x&filter[role]=admin",
+			want:    map[string]string{"name": "x", "role": "admin"},
+			present: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery failed: %v", err)
+			}
+			got, ok := tt.param.DecodeQuery(query)
+			if ok != tt.present {
+				t.Fatalf("Expected present=%v, got %v", tt.present, ok)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestWithParamStyle tests setting the style of a declared parameter
+func TestWithParamStyle(t *testing.T) {
+	api := NewAPIDefinition("GET", "/users", "List users").
+		WithParam("filter", "query", "Filter", false).
+		WithParamStyle("filter", StyleDeepObject, false)
+
+	if api.Params[0].Style != StyleDeepObject || !api.Params[0].Explode {
+		t.Errorf("Expected exploded deepObject style, got %+v", api.Params[0])
+	}
+}
+
+// TestValidateDecoded tests that validation rules apply to each decoded item
+func TestValidateDecoded(t *testing.T) {
+	param := Parameter{
+		Name:        "ids",
+		Validations: []ValidationRule{{Type: "max", Value: 10.0, Message: "too large"}},
+	}
+	if err := param.ValidateDecoded([]string{"1", "2"}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := param.ValidateDecoded([]string{"1", "20"}); err == nil {
+		t.Error("Expected error for item above max")
+	}
+}
+
+// TestArrayConstraints tests item count and uniqueness checks of array parameters
+func TestArrayConstraints(t *testing.T) {
+	def := NewAPIDefinition("GET", "/users", "List users").
+		WithQueryParam("ids", "User IDs", false).
+		WithArrayConstraints("ids", 1, 3, true)
+	param := def.Params[0]
+	if param.Schema["type"] != "array" || param.Schema["uniqueItems"] != true {
+		t.Fatalf("Unexpected schema %v", param.Schema)
+	}
+
+	tests := []struct {
+		name    string
+		items   []string
+		wantErr bool
+	}{
+		{name: "valid", items: []string{"1", "2"}},
+		{name: "too few", items: []string{}, wantErr: true},
+		{name: "too many", items: []string{"1", "2", "3", "4"}, wantErr: true},
+		{name: "duplicates", items: []string{"1", "1"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := param.ValidateDecoded(tt.items); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
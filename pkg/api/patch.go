@@ -0,0 +1,223 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// Media types of PATCH request bodies
+const (
+	MediaTypeMergePatch = "application/merge-patch+json" // RFC 7396 JSON Merge Patch
+	MediaTypeJSONPatch  = "application/json-patch+json"  // RFC 6902 JSON Patch
+)
+
+// PatchOperation is one operation of a JSON Patch document
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+	From  string      `json:"from,omitempty"`
+}
+
+// Chain call: accept a JSON Merge Patch of model, documented as a variant of its schema where
+// every property is optional and null removes it
+func (api *APIDefinition) WithJSONMergePatch(model interface{}) *APIDefinition {
+	api.Request = model
+	return api.withPatchFormat(MediaTypeMergePatch)
+}
+
+// Chain call: accept a JSON Patch document (an array of add, remove, replace, move, copy and test operations)
+// Paths are checked against the request model when one is set
+func (api *APIDefinition) WithJSONPatch() *APIDefinition {
+	return api.withPatchFormat(MediaTypeJSONPatch)
+}
+
+func (api *APIDefinition) withPatchFormat(mediaType string) *APIDefinition {
+	for _, format := range api.PatchFormats {
+		if format == mediaType {
+			return api
+		}
+	}
+	api.PatchFormats = append(api.PatchFormats, mediaType)
+	return api
+}
+
+// PatchMediaType returns the patch format of a definition matching a Content-Type header, or ""
+func PatchMediaType(def *APIDefinition, contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	for _, format := range def.PatchFormats {
+		if format == mediaType {
+			return format
+		}
+	}
+	return ""
+}
+
+// MergePatchSchema returns a copy of a schema in which no property is required and every property accepts null
+func MergePatchSchema(schema map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		if key == "required" {
+			continue
+		}
+		out[key] = value
+	}
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		patched := make(map[string]interface{}, len(props))
+		for name, prop := range props {
+			if propSchema, ok := prop.(map[string]interface{}); ok {
+				propSchema = MergePatchSchema(propSchema)
+				propSchema["nullable"] = true
+				patched[name] = propSchema
+				continue
+			}
+			patched[name] = prop
+		}
+		out["properties"] = patched
+	}
+	return out
+}
+
+// JSONPatchSchema returns the schema of a JSON Patch document
+func JSONPatchSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "array",
+		"items": map[string]interface{}{
+			"type":     "object",
+			"required": []string{"op", "path"},
+			"properties": map[string]interface{}{
+				"op": map[string]interface{}{
+					"type": "string",
+					"enum": []interface{}{"add", "remove", "replace", "move", "copy", "test"},
+				},
+				"path":  map[string]interface{}{"type": "string", "description": "JSON pointer to the target location"},
+				"value": map[string]interface{}{"description": "Value for add, replace and test"},
+				"from":  map[string]interface{}{"type": "string", "description": "JSON pointer to the source location for move and copy"},
+			},
+		},
+	}
+}
+
+// PatchValidator checks patch documents sent to a definition
+type PatchValidator struct {
+	mergePatch *jsonschema.Schema // Compiled merge patch schema, nil without a request model
+	properties map[string]bool    // Top-level properties JSON Patch paths may target, nil when open
+}
+
+// NewPatchValidator compiles the patch schemas of a definition
+func NewPatchValidator(def *APIDefinition) (*PatchValidator, error) {
+	v := &PatchValidator{}
+	if def.Request == nil {
+		return v, nil
+	}
+	schema, err := SafeSchemaFromStruct(def.Request)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(ToJSONSchema(MergePatchSchema(schema)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal merge patch schema: %w", err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("patch.json", bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to load merge patch schema: %w", err)
+	}
+	if v.mergePatch, err = compiler.Compile("patch.json"); err != nil {
+		return nil, fmt.Errorf("failed to compile merge patch schema: %w", err)
+	}
+	if props, ok := schema["properties"].(map[string]interface{}); ok && schema["additionalProperties"] == nil {
+		v.properties = make(map[string]bool, len(props))
+		for name := range props {
+			v.properties[name] = true
+		}
+	}
+	return v, nil
+}
+
+// Validate checks a patch document of the given media type, returning it decoded:
+// map[string]interface{} for merge patches and []PatchOperation for JSON Patch documents
+func (v *PatchValidator) Validate(mediaType string, data []byte) (interface{}, error) {
+	switch mediaType {
+	case MediaTypeMergePatch:
+		return v.validateMergePatch(data)
+	case MediaTypeJSONPatch:
+		return v.validateJSONPatch(data)
+	}
+	return nil, fmt.Errorf("unsupported patch format: %s", mediaType)
+}
+
+func (v *PatchValidator) validateMergePatch(data []byte) (interface{}, error) {
+	var patch map[string]interface{}
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return nil, fmt.Errorf("merge patch must be a JSON object: %v", err)
+	}
+	if v.mergePatch != nil {
+		if err := v.mergePatch.Validate(patch); err != nil {
+			return nil, err
+		}
+	}
+	return patch, nil
+}
+
+func (v *PatchValidator) validateJSONPatch(data []byte) (interface{}, error) {
+	var raw []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("JSON Patch must be an array of operations: %v", err)
+	}
+	ops := make([]PatchOperation, len(raw))
+	for i, fields := range raw {
+		op := &ops[i]
+		if err := json.Unmarshal(fields["op"], &op.Op); err != nil {
+			return nil, fmt.Errorf("operation %d: missing or invalid op", i)
+		}
+		if err := json.Unmarshal(fields["path"], &op.Path); err != nil {
+			return nil, fmt.Errorf("operation %d: missing or invalid path", i)
+		}
+		if err := v.checkPointer(op.Path); err != nil {
+			return nil, fmt.Errorf("operation %d: %v", i, err)
+		}
+		switch op.Op {
+		case "add", "replace", "test":
+			value, ok := fields["value"]
+			if !ok {
+				return nil, fmt.Errorf("operation %d: %s requires a value", i, op.Op)
+			}
+			_ = json.Unmarshal(value, &op.Value)
+		case "move", "copy":
+			if err := json.Unmarshal(fields["from"], &op.From); err != nil {
+				return nil, fmt.Errorf("operation %d: %s requires from", i, op.Op)
+			}
+			if err := v.checkPointer(op.From); err != nil {
+				return nil, fmt.Errorf("operation %d: %v", i, err)
+			}
+		case "remove":
+		default:
+			return nil, fmt.Errorf("operation %d: unknown op %q", i, op.Op)
+		}
+	}
+	return ops, nil
+}
+
+// checkPointer checks the syntax of a JSON pointer and that it targets a documented property
+func (v *PatchValidator) checkPointer(pointer string) error {
+	if pointer == "" {
+		return nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+	if v.properties == nil {
+		return nil
+	}
+	segment := strings.SplitN(pointer[1:], "/", 2)[0]
+	segment = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+	if !v.properties[segment] {
+		return fmt.Errorf("path %q targets an undocumented property", pointer)
+	}
+	return nil
+}
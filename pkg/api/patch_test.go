@@ -0,0 +1,81 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+type patchUser struct {
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email" binding:"required,email"`
+	Age   int    `json:"age"`
+}
+
+// TestMergePatchSchema tests the optional, nullable variant of a model schema
+func TestMergePatchSchema(t *testing.T) {
+	schema, err := SchemaFromStruct(patchUser{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	patch := MergePatchSchema(schema)
+	if _, ok := patch["required"]; ok {
+		t.Errorf("Expected no required properties, got %v", patch["required"])
+	}
+	name := patch["properties"].(map[string]interface{})["name"].(map[string]interface{})
+	if name["nullable"] != true || name["type"] != "string" {
+		t.Errorf("Expected a nullable string property, got %v", name)
+	}
+	if _, ok := schema["required"]; !ok {
+		t.Error("Expected the model schema to be left unchanged")
+	}
+}
+
+// TestPatchValidator tests validating merge patches and JSON Patch documents against a model
+func TestPatchValidator(t *testing.T) {
+	def := NewAPIDefinition("PATCH", "/users/{id}", "Patch user").WithJSONMergePatch(patchUser{}).WithJSONPatch()
+	if !reflect.DeepEqual(def.PatchFormats, []string{MediaTypeMergePatch, MediaTypeJSONPatch}) {
+		t.Fatalf("Unexpected patch formats %v", def.PatchFormats)
+	}
+	validator, err := NewPatchValidator(def)
+	if err != nil {
+		t.Fatalf("NewPatchValidator failed: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		mediaType string
+		body      string
+		wantErr   bool
+	}{
+		{name: "merge patch", mediaType: MediaTypeMergePatch, body: `{"name":"alice"}`},
+		{name: "merge patch removal", mediaType: MediaTypeMergePatch, body: `{"age":null}`},
+		{name: "merge patch wrong type", mediaType: MediaTypeMergePatch, body: `{"age":"old"}`, wantErr: true},
+		{name: "merge patch not an object", mediaType: MediaTypeMergePatch, body: `[1]`, wantErr: true},
+		{name: "json patch", mediaType: MediaTypeJSONPatch, body: `[{"op":"replace","path":"/name","value":"bob"},{"op":"remove","path":"/age"},{"op":"copy","from":"/name","path":"/email"}]`},
+		{name: "json patch null value", mediaType: MediaTypeJSONPatch, body: `[{"op":"add","path":"/age","value":null}]`},
+		{name: "json patch missing value", mediaType: MediaTypeJSONPatch, body: `[{"op":"add","path":"/name"}]`, wantErr: true},
+		{name: "json patch missing from", mediaType: MediaTypeJSONPatch, body: `[{"op":"move","path":"/name"}]`, wantErr: true},
+		{name: "json patch unknown op", mediaType: MediaTypeJSONPatch, body: `[{"op":"merge","path":"/name"}]`, wantErr: true},
+		{name: "json patch invalid pointer", mediaType: MediaTypeJSONPatch, body: `[{"op":"remove","path":"name"}]`, wantErr: true},
+		{name: "json patch undocumented path", mediaType: MediaTypeJSONPatch, body: `[{"op":"remove","path":"/role"}]`, wantErr: true},
+		{name: "json patch not an array", mediaType: MediaTypeJSONPatch, body: `{"op":"remove","path":"/age"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := validator.Validate(tt.mediaType, []byte(tt.body)); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestPatchMediaType tests matching Content-Type headers against accepted patch formats
+func TestPatchMediaType(t *testing.T) {
+	def := NewAPIDefinition("PATCH", "/users/{id}", "Patch user").WithJSONPatch()
+	if got := PatchMediaType(def, "application/json-patch+json; charset=utf-8"); got != MediaTypeJSONPatch {
+		t.Errorf("Expected %s, got %q", MediaTypeJSONPatch, got)
+	}
+	if got := PatchMediaType(def, MediaTypeMergePatch); got != "" {
+		t.Errorf("Expected no match, got %q", got)
+	}
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Chain call: declare path parameters from the fields of a struct tagged `path:"name"`, replacing parameters
+// of the same name; adapters convert the values into a new instance and reject unconvertible ones with a
+// documented 400 response
+func (api *APIDefinition) WithPathModel(model interface{}) *APIDefinition {
+	api.PathModel = model
+	api.declarePathParams(model)
+	if _, ok := api.Responses[http.StatusBadRequest]; !ok {
+		api.WithStatusResponse(http.StatusBadRequest, "Invalid path parameters", nil)
+	}
+	return api
+}
+
+// declarePathParams declares the `path` tagged fields of a model as path parameters, replacing parameters
+// of the same name
+func (api *APIDefinition) declarePathParams(model interface{}) {
+	for _, param := range PathParamsFromStruct(model) {
+		replaced := false
+		for i, existing := range api.Params {
+			if existing.In == "path" && existing.Name == param.Name {
+				api.Params[i], replaced = param, true
+			}
+		}
+		if !replaced {
+			api.Params = append(api.Params, param)
+		}
+	}
+}
+
+// PathParamsFromStruct returns the path parameters declared by the `path` tags of a struct's fields
+func PathParamsFromStruct(model interface{}) []Parameter {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var params []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("path"), ",")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		schema, err := createSchemaFromGoType(field.Type)
+		if err != nil {
+			continue
+		}
+		applyValidationTags(schema, field)
+		params = append(params, Parameter{
+			Name:        name,
+			In:          "path",
+			Description: field.Tag.Get("doc"),
+			Required:    true,
+			Schema:      schema,
+		})
+	}
+	return params
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"testing"
+)
+
+type orderPath struct {
+	OrderID int64  `path:"id" binding:"min=1" doc:"Order ID"`
+	Item    string `path:"item"`
+	Hidden  string `json:"hidden"`
+}
+
+// TestPathParamsFromStruct tests deriving typed path parameters from a path model
+func TestPathParamsFromStruct(t *testing.T) {
+	def := NewAPIDefinition("GET", "/orders/{id}/items/{item}", "Get item").
+		WithPathParam("id", "Untyped ID", true).
+		WithPathModel(orderPath{})
+	if len(def.Params) != 2 {
+		t.Fatalf("Expected 2 parameters, got %+v", def.Params)
+	}
+	id, item := def.Params[0], def.Params[1]
+
+	if id.Name != "id" || id.Schema["type"] != "integer" || id.Schema["minimum"] != 1 || id.Description != "Order ID" {
+		t.Errorf("Expected the typed id parameter to replace the declared one, got %+v", id)
+	}
+	if item.Name != "item" || item.In != "path" || !item.Required || item.Schema["type"] != "string" {
+		t.Errorf("Unexpected item parameter %+v", item)
+	}
+	if _, ok := def.Responses[400]; !ok || def.PathModel == nil {
+		t.Errorf("Expected a 400 response and the path model to be kept, got %+v", def.Responses)
+	}
+}
+
+// traceID is a 16-byte identifier decoded from text, like uuid.UUID
+type traceID [16]byte
+
+// UnmarshalText accepts any identifier
+func (id *traceID) UnmarshalText(text []byte) error {
+	return nil
+}
+
+// skuCode is a text-decoded identifier without a text marshaler
+type skuCode struct{ value string }
+
+// UnmarshalText keeps the code as is
+func (c *skuCode) UnmarshalText(text []byte) error {
+	c.value = string(text)
+	return nil
+}
+
+type tracePath struct {
+	Trace traceID `path:"trace"`
+	SKU   skuCode `path:"sku"`
+}
+
+// TestPathParamsFromTextTypes tests documenting text-decoded fields as strings
+func TestPathParamsFromTextTypes(t *testing.T) {
+	params := PathParamsFromStruct(tracePath{})
+	if len(params) != 2 {
+		t.Fatalf("Expected 2 parameters, got %+v", params)
+	}
+	if params[0].Schema["type"] != "string" || params[0].Schema["format"] != "uuid" {
+		t.Errorf("Expected a uuid string, got %v", params[0].Schema)
+	}
+	if params[1].Schema["type"] != "string" || params[1].Schema["format"] != nil {
+		t.Errorf("Expected a plain string, got %v", params[1].Schema)
+	}
+
+	def := NewAPIDefinition("PUT", "/traces/{trace}/{sku}", "Update trace").WithRequest(tracePath{})
+	if len(def.Params) != 2 || def.Params[0].Name != "trace" || def.Params[1].Name != "sku" {
+		t.Errorf("Expected the request model to declare its path parameters, got %+v", def.Params)
+	}
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// PayloadLimits bounds the shape of JSON request bodies so hostile payloads are rejected before they are decoded
+// Zero values leave a limit off
+type PayloadLimits struct {
+	MaxBodySize     int64 // Maximum body size in bytes (413 Payload Too Large)
+	MaxDepth        int   // Maximum nesting depth of objects and arrays
+	MaxArrayLength  int   // Maximum number of elements of any array
+	MaxStringLength int   // Maximum length in bytes of any string, object keys included
+}
+
+// IsZero reports whether no limit is set
+func (l PayloadLimits) IsZero() bool {
+	return l == PayloadLimits{}
+}
+
+// Describe returns the description of the 400 response documenting the shape limits
+func (l PayloadLimits) Describe() string {
+	description := "Bad Request - The request body exceeds"
+	sep := " "
+	if l.MaxDepth > 0 {
+		description += fmt.Sprintf("%sa nesting depth of %d", sep, l.MaxDepth)
+		sep = ", "
+	}
+	if l.MaxArrayLength > 0 {
+		description += fmt.Sprintf("%s%d array elements", sep, l.MaxArrayLength)
+		sep = ", "
+	}
+	if l.MaxStringLength > 0 {
+		description += fmt.Sprintf("%sstrings of %d bytes", sep, l.MaxStringLength)
+	}
+	return description + " or is invalid"
+}
+
+// Chain call: limit the size and shape of the request body, documenting the 413 and 400 responses
+// Limits set here replace the router-wide limits for this API
+func (api *APIDefinition) WithPayloadLimits(limits PayloadLimits) *APIDefinition {
+	api.PayloadLimits = &limits
+	if limits.MaxBodySize > 0 {
+		api.WithMaxBodySize(limits.MaxBodySize)
+	}
+	if limits.MaxDepth > 0 || limits.MaxArrayLength > 0 || limits.MaxStringLength > 0 {
+		api.WithStatusResponse(http.StatusBadRequest, limits.Describe(), nil)
+	}
+	return api
+}
+
+// ErrPayloadLimit is wrapped by the errors of CheckPayload for bodies exceeding a limit
+var ErrPayloadLimit = errors.New("request body exceeds limits")
+
+// CheckPayload scans a JSON document token by token, without decoding it, and returns an error wrapping
+// ErrPayloadLimit when it exceeds the depth, array length or string length limits
+// Malformed documents are not reported; decoding reports them
+func CheckPayload(data []byte, limits PayloadLimits) error {
+	if limits.MaxDepth <= 0 && limits.MaxArrayLength <= 0 && limits.MaxStringLength <= 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	// counts holds the element count of each open array, -1 for objects
+	var counts []int
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			// End of the document, or a syntax error left to decoding
+			return nil
+		}
+
+		// Count the value as an element of the enclosing array
+		if depth := len(counts); depth > 0 && counts[depth-1] >= 0 {
+			if delim, ok := token.(json.Delim); !ok || delim == '{' || delim == '[' {
+				counts[depth-1]++
+				if limits.MaxArrayLength > 0 && counts[depth-1] > limits.MaxArrayLength {
+					return fmt.Errorf("%w: array has more than %d elements", ErrPayloadLimit, limits.MaxArrayLength)
+				}
+			}
+		}
+
+		switch v := token.(type) {
+		case json.Delim:
+			switch v {
+			case '{', '[':
+				count := 0
+				if v == '{' {
+					count = -1
+				}
+				counts = append(counts, count)
+				if limits.MaxDepth > 0 && len(counts) > limits.MaxDepth {
+					return fmt.Errorf("%w: nesting depth exceeds %d", ErrPayloadLimit, limits.MaxDepth)
+				}
+			case '}', ']':
+				counts = counts[:len(counts)-1]
+			}
+		case string:
+			if limits.MaxStringLength > 0 && len(v) > limits.MaxStringLength {
+				return fmt.Errorf("%w: string longer than %d bytes", ErrPayloadLimit, limits.MaxStringLength)
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestCheckPayload tests depth, array length and string length limits
+func TestCheckPayload(t *testing.T) {
+	limits := PayloadLimits{MaxDepth: 3, MaxArrayLength: 3, MaxStringLength: 5}
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{name: "within limits", body: `{"a":[1,2,{"b":"abc"}],"c":"hello"}`},
+		{name: "too deep", body: `{"a":{"b":{"c":{}}}}`, wantErr: true},
+		{name: "too many elements", body: `{"a":[1,2,3,4]}`, wantErr: true},
+		{name: "nested elements count once", body: `[[1,2,3],[1,2,3],[1,2,3]]`},
+		{name: "long string", body: `{"a":"abcdef"}`, wantErr: true},
+		{name: "long key", body: `{"abcdef":1}`, wantErr: true},
+		{name: "malformed", body: `{"a":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckPayload([]byte(tt.body), limits)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, ErrPayloadLimit) {
+				t.Errorf("Expected ErrPayloadLimit, got %v", err)
+			}
+		})
+	}
+}
+
+// TestWithPayloadLimits tests the documented responses of payload limits
+func TestWithPayloadLimits(t *testing.T) {
+	def := NewAPIDefinition("POST", "/imports", "Import").
+		WithPayloadLimits(PayloadLimits{MaxBodySize: 1 << 20, MaxDepth: 8, MaxArrayLength: 1000})
+	if def.MaxBodySize != 1<<20 || def.PayloadLimits.MaxDepth != 8 {
+		t.Fatalf("Unexpected limits %+v", def.PayloadLimits)
+	}
+	if _, ok := def.Responses[413]; !ok {
+		t.Error("Expected 413 response")
+	}
+	if description := def.Responses[400].Description; !strings.Contains(description, "nesting depth of 8, 1000 array elements") {
+		t.Errorf("Unexpected 400 description %q", description)
+	}
+}
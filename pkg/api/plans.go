@@ -0,0 +1,63 @@
+package api
+
+// Chain call: restrict the operation to tenant plans, documented as the x-plans extension
+func (api *APIDefinition) WithPlans(plans ...string) *APIDefinition {
+	api.Plans = append(api.Plans, plans...)
+	return api
+}
+
+// InPlan reports whether the operation is available on a plan; operations without plans are available on all
+func (api *APIDefinition) InPlan(plan string) bool {
+	return len(api.Plans) == 0 || containsString(api.Plans, plan)
+}
+
+// FilterPlan removes the operations whose x-plans extension excludes a plan, paths left without operations
+// and the components the remaining operations no longer reference
+func FilterPlan(doc *OpenAPIDoc, plan string) error {
+	schemes := make(map[string]bool)
+	addSchemes(schemes, doc.Security)
+	for path, item := range doc.Paths {
+		for _, method := range SupportedMethods {
+			op := item.Operation(method)
+			if op == nil {
+				continue
+			}
+			if !operationInPlan(op, plan) {
+				item.SetOperation(method, nil)
+				continue
+			}
+			addSchemes(schemes, op.Security)
+		}
+		if len(item.Operations()) == 0 {
+			delete(doc.Paths, path)
+			continue
+		}
+		doc.Paths[path] = item
+	}
+	if doc.Components == nil {
+		return nil
+	}
+
+	components, err := usedComponents(doc.Paths, doc.Components, schemes)
+	if err != nil {
+		return err
+	}
+	doc.Components = components
+	return nil
+}
+
+// operationInPlan reports whether an operation's x-plans extension, if any, lists a plan
+func operationInPlan(op *Operation, plan string) bool {
+	switch plans := op.Extensions["x-plans"].(type) {
+	case []string:
+		return containsString(plans, plan)
+	case []interface{}:
+		for _, p := range plans {
+			if p == plan {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
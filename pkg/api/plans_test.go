@@ -0,0 +1,103 @@
+package api
+
+import (
+	"sort"
+	"testing"
+)
+
+// TestInPlan tests plan availability of definitions
+func TestInPlan(t *testing.T) {
+	open := NewAPIDefinition("GET", "/status", "Status")
+	pro := NewAPIDefinition("GET", "/reports", "Reports").WithPlans("pro", "enterprise")
+
+	tests := []struct {
+		name string
+		def  *APIDefinition
+		plan string
+		want bool
+	}{
+		{name: "unrestricted", def: open, plan: "free", want: true},
+		{name: "listed plan", def: pro, plan: "enterprise", want: true},
+		{name: "other plan", def: pro, plan: "free", want: false},
+		{name: "no plan", def: pro, plan: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.def.InPlan(tt.plan); got != tt.want {
+				t.Errorf("Expected InPlan(%q) to be %v, got %v", tt.plan, tt.want, got)
+			}
+		})
+	}
+}
+
+// TestFilterPlan tests removing operations outside a plan, and the components only they used, from a document
+func TestFilterPlan(t *testing.T) {
+	ref := func(name string) map[string]interface{} {
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	}
+	response := func(name string) map[string]Response {
+		return map[string]Response{"200": {Description: "OK", Content: map[string]Content{"application/json": {Schema: ref(name)}}}}
+	}
+	newDoc := func() *OpenAPIDoc {
+		return &OpenAPIDoc{
+			Paths: map[string]PathItem{
+				"/status": {Get: &Operation{}},
+				"/reports": {
+					Get: &Operation{Extensions: map[string]interface{}{"x-plans": []string{"free", "pro"}}, Responses: response("Report")},
+					Post: &Operation{
+						Extensions: map[string]interface{}{"x-plans": []interface{}{"pro"}},
+						Security:   []map[string][]string{{"apiKey": {}}},
+					},
+				},
+				"/exports": {Get: &Operation{Extensions: map[string]interface{}{"x-plans": []string{"pro"}}, Responses: response("Export")}},
+			},
+			Components: &Components{
+				Schemas: map[string]interface{}{
+					"Report": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"total": ref("Money")}},
+					"Money":  map[string]interface{}{"type": "number"},
+					"Export": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"file": ref("File")}},
+					"File":   map[string]interface{}{"type": "string"},
+				},
+				SecuritySchemes: map[string]SecurityScheme{"apiKey": {Type: "apiKey", Name: "X-API-Key", In: "header"}},
+			},
+		}
+	}
+
+	doc := newDoc()
+	if err := FilterPlan(doc, "free"); err != nil {
+		t.Fatalf("FilterPlan failed: %v", err)
+	}
+	var paths []string
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	if len(paths) != 2 || paths[0] != "/reports" || paths[1] != "/status" {
+		t.Fatalf("Expected /reports and /status, got %v", paths)
+	}
+	if reports := doc.Paths["/reports"]; reports.Get == nil || reports.Post != nil {
+		t.Errorf("Expected only GET /reports on the free plan, got %+v", reports)
+	}
+	var schemas []string
+	for name := range doc.Components.Schemas {
+		schemas = append(schemas, name)
+	}
+	sort.Strings(schemas)
+	if len(schemas) != 2 || schemas[0] != "Money" || schemas[1] != "Report" {
+		t.Errorf("Expected the Money and Report schemas on the free plan, got %v", schemas)
+	}
+	if len(doc.Components.SecuritySchemes) != 0 {
+		t.Errorf("Expected no security schemes on the free plan, got %v", doc.Components.SecuritySchemes)
+	}
+
+	doc = newDoc()
+	if err := FilterPlan(doc, "pro"); err != nil {
+		t.Fatalf("FilterPlan failed: %v", err)
+	}
+	if len(doc.Paths) != 3 || doc.Paths["/reports"].Post == nil {
+		t.Errorf("Expected every operation on the pro plan, got %+v", doc.Paths)
+	}
+	if len(doc.Components.Schemas) != 4 || len(doc.Components.SecuritySchemes) != 1 {
+		t.Errorf("Expected every component on the pro plan, got %+v", doc.Components)
+	}
+}
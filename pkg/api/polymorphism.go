@@ -0,0 +1,104 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// DiscriminatorProperty is the property used to tell registered implementations apart
+var DiscriminatorProperty = "type"
+
+// Discriminated is implemented by types that choose their own discriminator value
+// Types without it use their Go type name
+type Discriminated interface {
+	DiscriminatorValue() string
+}
+
+var (
+	implementationsMu sync.RWMutex
+	implementations   = make(map[reflect.Type][]reflect.Type)
+)
+
+// RegisterImplementations declares the concrete types an interface field may hold,
+// so its schema becomes a oneOf over them with a discriminator
+// Pass the interface as a typed nil pointer: RegisterImplementations((*EventPayload)(nil), Created{}, Deleted{})
+func RegisterImplementations(iface interface{}, impls ...interface{}) error {
+	t := reflect.TypeOf(iface)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+		return &ErrInvalidType{Type: fmt.Sprintf("%v (expected pointer to interface)", t)}
+	}
+	ifaceType := t.Elem()
+
+	types := make([]reflect.Type, 0, len(impls))
+	for _, impl := range impls {
+		implType := reflect.TypeOf(impl)
+		if implType == nil || !implType.Implements(ifaceType) {
+			return &ErrInvalidType{Type: fmt.Sprintf("%v does not implement %v", implType, ifaceType)}
+		}
+		types = append(types, implType)
+	}
+
+	implementationsMu.Lock()
+	defer implementationsMu.Unlock()
+	implementations[ifaceType] = append(implementations[ifaceType], types...)
+	return nil
+}
+
+// polymorphicSchema builds the oneOf schema for an interface with registered implementations
+func polymorphicSchema(ifaceType reflect.Type) (map[string]interface{}, bool, error) {
+	implementationsMu.RLock()
+	impls := implementations[ifaceType]
+	implementationsMu.RUnlock()
+	if len(impls) == 0 {
+		return nil, false, nil
+	}
+
+	oneOf := make([]interface{}, 0, len(impls))
+	for _, implType := range impls {
+		schema, err := createSchemaFromGoType(implType)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to create schema for implementation %v: %w", implType, err)
+		}
+
+		value := discriminatorValue(implType)
+		props, _ := schema["properties"].(map[string]interface{})
+		if props == nil {
+			props = make(map[string]interface{})
+			schema["properties"] = props
+		}
+		props[DiscriminatorProperty] = map[string]interface{}{
+			"type": "string",
+			"enum": []interface{}{value},
+		}
+		required, _ := schema["required"].([]string)
+		if !containsString(required, DiscriminatorProperty) {
+			schema["required"] = append(required, DiscriminatorProperty)
+		}
+		schema["title"] = value
+		oneOf = append(oneOf, schema)
+	}
+
+	return map[string]interface{}{
+		"oneOf": oneOf,
+		"discriminator": map[string]interface{}{
+			"propertyName": DiscriminatorProperty,
+		},
+	}, true, nil
+}
+
+// discriminatorValue returns the discriminator value of an implementation type
+// Pointer types are asked through a new instance so value-receiver methods are not called on nil
+func discriminatorValue(t reflect.Type) string {
+	instance := reflect.Zero(t)
+	if t.Kind() == reflect.Ptr {
+		instance = reflect.New(t.Elem())
+	}
+	if d, ok := instance.Interface().(Discriminated); ok {
+		return d.DiscriminatorValue()
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
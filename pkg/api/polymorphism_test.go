@@ -0,0 +1,103 @@
+package api
+
+import (
+	"errors"
+	"testing"
+)
+
+type eventPayload interface{ isEventPayload() }
+
+type userCreated struct {
+	UserID string `json:"userId"`
+}
+
+func (userCreated) isEventPayload() {}
+
+type userDeleted struct {
+	UserID string `json:"userId"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func (userDeleted) isEventPayload()            {}
+func (userDeleted) DiscriminatorValue() string { return "user.deleted" }
+
+type event struct {
+	ID      string       `json:"id"`
+	Payload eventPayload `json:"payload"`
+}
+
+// TestRegisterImplementations tests documenting interface fields as discriminated oneOf schemas
+func TestRegisterImplementations(t *testing.T) {
+	if err := RegisterImplementations((*eventPayload)(nil), userCreated{}, userDeleted{}); err != nil {
+		t.Fatalf("RegisterImplementations failed: %v", err)
+	}
+
+	schema, err := SchemaFromStruct(event{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	payload := schema["properties"].(map[string]interface{})["payload"].(map[string]interface{})
+
+	disc := payload["discriminator"].(map[string]interface{})
+	if disc["propertyName"] != "type" {
+		t.Errorf("Expected discriminator property type, got %v", disc["propertyName"])
+	}
+
+	oneOf := payload["oneOf"].([]interface{})
+	if len(oneOf) != 2 {
+		t.Fatalf("Expected 2 oneOf entries, got %d", len(oneOf))
+	}
+	wantValues := []string{"userCreated", "user.deleted"}
+	for i, entry := range oneOf {
+		branch := entry.(map[string]interface{})
+		typeProp := branch["properties"].(map[string]interface{})["type"].(map[string]interface{})
+		if enum := typeProp["enum"].([]interface{}); enum[0] != wantValues[i] {
+			t.Errorf("Expected discriminator %s in branch %d, got %v", wantValues[i], i, enum[0])
+		}
+		if !containsString(branch["required"].([]string), "type") {
+			t.Errorf("Expected branch %d to require the discriminator", i)
+		}
+	}
+}
+
+// TestRegisterImplementationsRejectsInvalidTypes tests rejecting non-interfaces and non-implementations
+func TestRegisterImplementationsRejectsInvalidTypes(t *testing.T) {
+	var invalid *ErrInvalidType
+	if err := RegisterImplementations(userCreated{}, userDeleted{}); !errors.As(err, &invalid) {
+		t.Errorf("Expected ErrInvalidType for a non-interface, got %v", err)
+	}
+	if err := RegisterImplementations((*eventPayload)(nil), "not a payload"); !errors.As(err, &invalid) {
+		t.Errorf("Expected ErrInvalidType for a non-implementation, got %v", err)
+	}
+}
+
+type shapePayload interface{ isShapePayload() }
+
+type circleShape struct {
+	Radius float64 `json:"radius"`
+}
+
+func (circleShape) isShapePayload()            {}
+func (circleShape) DiscriminatorValue() string { return "circle" }
+
+type drawing struct {
+	Shape shapePayload `json:"shape"`
+}
+
+// TestRegisterPointerImplementations tests value-receiver discriminators of implementations registered as pointers
+func TestRegisterPointerImplementations(t *testing.T) {
+	if err := RegisterImplementations((*shapePayload)(nil), &circleShape{}); err != nil {
+		t.Fatalf("RegisterImplementations failed: %v", err)
+	}
+
+	schema, err := SchemaFromStruct(drawing{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	shape := schema["properties"].(map[string]interface{})["shape"].(map[string]interface{})
+	branch := shape["oneOf"].([]interface{})[0].(map[string]interface{})
+	typeProp := branch["properties"].(map[string]interface{})["type"].(map[string]interface{})
+	if enum := typeProp["enum"].([]interface{}); enum[0] != "circle" {
+		t.Errorf("Expected discriminator circle, got %v", enum[0])
+	}
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Chain call: declare query parameters from the fields of a struct tagged `query:"name"`
+// Slices become repeated (?tag=a&tag=b) array parameters and structs or maps deepObject parameters
+// (?filter[role]=admin); validator tags document constraints and adapters bind requests into a new instance
+func (api *APIDefinition) WithQueryModel(model interface{}) *APIDefinition {
+	api.Query = model
+	api.Params = append(api.Params, QueryParamsFromStruct(model)...)
+	return api
+}
+
+// QueryParamsFromStruct returns the query parameters declared by the `query` tags of a struct's fields
+func QueryParamsFromStruct(model interface{}) []Parameter {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var params []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("query"), ",")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		schema, err := createSchemaFromGoType(field.Type)
+		if err != nil {
+			continue
+		}
+		param := Parameter{
+			Name:        name,
+			In:          "query",
+			Description: field.Tag.Get("doc"),
+			Required:    applyValidationTags(schema, field),
+			Schema:      schema,
+		}
+		switch fieldKind(field.Type) {
+		case reflect.Slice, reflect.Array:
+			param.Style, param.Explode = StyleForm, true
+		case reflect.Struct, reflect.Map:
+			if schema["type"] == "object" {
+				param.Style, param.Explode = StyleDeepObject, true
+			}
+		}
+		params = append(params, param)
+	}
+	return params
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"testing"
+)
+
+type searchQuery struct {
+	Tags   []string `query:"tag" binding:"max=5,dive,min=2"`
+	Filter struct {
+		Role   string `json:"role"`
+		Active bool   `json:"active"`
+	} `query:"filter"`
+	Limit  int    `query:"limit" binding:"required,min=1" doc:"Page size"`
+	Hidden string `json:"hidden"`
+}
+
+// TestQueryParamsFromStruct tests deriving array, deepObject and scalar parameters from a query model
+func TestQueryParamsFromStruct(t *testing.T) {
+	def := NewAPIDefinition("GET", "/search", "Search").WithQueryModel(searchQuery{})
+	if len(def.Params) != 3 {
+		t.Fatalf("Expected 3 parameters, got %+v", def.Params)
+	}
+	tag, filter, limit := def.Params[0], def.Params[1], def.Params[2]
+
+	if tag.Name != "tag" || tag.Schema["type"] != "array" || tag.Style != StyleForm || !tag.Explode {
+		t.Errorf("Unexpected array parameter %+v", tag)
+	}
+	if tag.Schema["maxItems"] != 5 || tag.Schema["items"].(map[string]interface{})["minLength"] != 2 {
+		t.Errorf("Expected array and item constraints, got %v", tag.Schema)
+	}
+	if filter.Style != StyleDeepObject || filter.Schema["type"] != "object" {
+		t.Errorf("Unexpected object parameter %+v", filter)
+	}
+	if !limit.Required || limit.Schema["type"] != "integer" || limit.Description != "Page size" {
+		t.Errorf("Unexpected scalar parameter %+v", limit)
+	}
+	if def.Query == nil {
+		t.Error("Expected the query model to be kept")
+	}
+}
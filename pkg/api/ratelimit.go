@@ -0,0 +1,64 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RateLimitPolicy describes how many requests an operation accepts per time window
+type RateLimitPolicy struct {
+	Requests int           // Number of requests allowed per window
+	Window   time.Duration // Length of the window
+	Scope    string        // Optional bucket name shared by several operations (defaults to the operation)
+}
+
+// Chain call: set rate limit policy and document 429 responses and X-RateLimit-* headers
+func (api *APIDefinition) WithRateLimit(policy RateLimitPolicy) *APIDefinition {
+	api.RateLimit = &policy
+
+	for name, header := range rateLimitHeaders() {
+		api.WithResponseHeader(name, header)
+	}
+
+	api.WithStatusResponse(http.StatusTooManyRequests,
+		fmt.Sprintf("Too Many Requests - Limit of %d requests per %s exceeded", policy.Requests, policy.Window), nil)
+	resp := api.Responses[http.StatusTooManyRequests]
+	resp.Headers = rateLimitHeaders()
+	resp.Headers["Retry-After"] = Header{
+		Description: "Seconds to wait before retrying",
+		Schema:      map[string]interface{}{"type": "integer"},
+	}
+	api.Responses[http.StatusTooManyRequests] = resp
+	return api
+}
+
+// Extension returns the x-ratelimit extension describing the policy, consumed by gateway exporters
+func (p *RateLimitPolicy) Extension() map[string]interface{} {
+	ext := map[string]interface{}{
+		"requests": p.Requests,
+		"window":   int(p.Window / time.Second),
+	}
+	if p.Scope != "" {
+		ext["scope"] = p.Scope
+	}
+	return ext
+}
+
+func rateLimitHeaders() map[string]Header {
+	integer := map[string]interface{}{"type": "integer"}
+	return map[string]Header{
+		"X-RateLimit-Limit": {
+			Description: "Number of requests allowed in the current window",
+			Schema:      integer,
+		},
+		"X-RateLimit-Remaining": {
+			Description: "Number of requests remaining in the current window",
+			Schema:      integer,
+		},
+		"X-RateLimit-Reset": {
+			Description: "Seconds until the current window resets",
+			Schema:      integer,
+		},
+	}
+}
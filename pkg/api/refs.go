@@ -0,0 +1,338 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ExternalRef returns a schema referencing a definition in another document, e.g.
+// "https://schemas.example.com/common.json#/components/schemas/Address" or "common.json#/Address"
+// Use it with RegisterTypeSchema or SchemaProvider; struct fields can use the `ref` tag instead
+func ExternalRef(ref string) map[string]interface{} {
+	return map[string]interface{}{"$ref": ref}
+}
+
+// RefMode selects what a RefResolver does with external references
+type RefMode int
+
+const (
+	// RefKeep leaves external references in the document, failing on references that don't resolve
+	RefKeep RefMode = iota
+	// RefBundle inlines the referenced definitions, producing a self-contained document
+	RefBundle
+)
+
+// RefLoader reads the document at a location: an http(s) URL or a file path
+type RefLoader func(location string) ([]byte, error)
+
+// RefResolver resolves the external $refs of generated documents; it is safe for concurrent use
+type RefResolver struct {
+	Mode    RefMode
+	BaseDir string    // Directory relative file references are resolved against
+	Loader  RefLoader // Reads referenced documents (defaults to LoadRef)
+	mu      sync.Mutex
+	docs    map[string]interface{} // Decoded documents by location, read-only once cached
+}
+
+// NewRefResolver creates a resolver for references relative to baseDir
+func NewRefResolver(mode RefMode, baseDir string) *RefResolver {
+	return &RefResolver{Mode: mode, BaseDir: baseDir, Loader: LoadRef}
+}
+
+// RefTimeout bounds each http(s) request of LoadRef
+const RefTimeout = 10 * time.Second
+
+// refClient fetches the http(s) locations of LoadRef
+var refClient = &http.Client{Timeout: RefTimeout}
+
+// LoadRef fetches http(s) URLs, within RefTimeout, and reads other locations from the filesystem
+func LoadRef(location string) ([]byte, error) {
+	if !isURL(location) {
+		return os.ReadFile(location)
+	}
+	resp, err := refClient.Get(location)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Resolve checks every external reference of a document and, in RefBundle mode, inlines them
+// References inside referenced documents, including their local (#/...) ones, are followed too
+func (r *RefResolver) Resolve(doc *OpenAPIDoc) error {
+	resolve := func(schema map[string]interface{}) (map[string]interface{}, error) {
+		if schema == nil {
+			return nil, nil
+		}
+		resolved, err := r.resolveValue(schema, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		if r.Mode != RefBundle {
+			return schema, nil
+		}
+		out, ok := resolved.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("external reference resolved to a non-object schema")
+		}
+		return out, nil
+	}
+	return walkSchemas(doc, resolve)
+}
+
+// resolveValue returns a copy of a schema value with external references inlined
+// base is the location of the document the value comes from ("" for the generated document)
+func (r *RefResolver) resolveValue(value interface{}, base string, stack []string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok && (base != "" || !strings.HasPrefix(ref, "#")) {
+			return r.resolveRef(ref, base, stack)
+		}
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			resolved, err := r.resolveValue(item, base, stack)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = resolved
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			resolved, err := r.resolveValue(item, base, stack)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = resolved
+		}
+		return out, nil
+	}
+	return value, nil
+}
+
+// resolveRef loads the target of a reference and resolves the references it contains in turn
+func (r *RefResolver) resolveRef(ref, base string, stack []string) (interface{}, error) {
+	location, pointer, _ := strings.Cut(ref, "#")
+	if location == "" {
+		location = base
+	} else {
+		location = r.locate(location, base)
+	}
+	target := location + "#" + pointer
+	for _, seen := range stack {
+		if seen == target {
+			return nil, fmt.Errorf("circular external reference: %s", target)
+		}
+	}
+
+	document, err := r.load(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", ref, err)
+	}
+	value, err := resolvePointer(document, pointer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	return r.resolveValue(value, location, append(stack, target))
+}
+
+// locate resolves a referenced location against the document it appears in
+func (r *RefResolver) locate(location, base string) string {
+	switch {
+	case isURL(location):
+		return location
+	case isURL(base):
+		baseURL, err := url.Parse(base)
+		if err != nil {
+			return location
+		}
+		ref, err := url.Parse(location)
+		if err != nil {
+			return location
+		}
+		return baseURL.ResolveReference(ref).String()
+	case filepath.IsAbs(location):
+		return location
+	case base != "":
+		return filepath.Join(filepath.Dir(base), location)
+	}
+	return filepath.Join(r.BaseDir, location)
+}
+
+// load reads and decodes a referenced document once
+func (r *RefResolver) load(location string) (interface{}, error) {
+	r.mu.Lock()
+	document, ok := r.docs[location]
+	r.mu.Unlock()
+	if ok {
+		return document, nil
+	}
+	loader := r.Loader
+	if loader == nil {
+		loader = LoadRef
+	}
+	data, err := loader(location)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid JSON document: %w", err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.docs == nil {
+		r.docs = make(map[string]interface{})
+	}
+	r.docs[location] = document
+	return document, nil
+}
+
+// resolvePointer returns the value a JSON pointer (RFC 6901) designates in a document
+func resolvePointer(document interface{}, pointer string) (interface{}, error) {
+	if pointer == "" || pointer == "/" {
+		return document, nil
+	}
+	value := document
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("pointer %s does not designate an object member", pointer)
+		}
+		if value, ok = object[token]; !ok {
+			return nil, fmt.Errorf("%s not found", pointer)
+		}
+	}
+	return value, nil
+}
+
+// isURL reports whether a location is an http(s) URL
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+// walkSchemas replaces every schema of a document with the result of fn
+// Content and header maps are copied since generated operations share them with the registered definitions
+func walkSchemas(doc *OpenAPIDoc, fn func(map[string]interface{}) (map[string]interface{}, error)) error {
+	contents := func(content map[string]Content) (map[string]Content, error) {
+		if content == nil {
+			return nil, nil
+		}
+		out := make(map[string]Content, len(content))
+		for mediaType, c := range content {
+			schema, err := fn(c.Schema)
+			if err != nil {
+				return nil, err
+			}
+			c.Schema = schema
+			out[mediaType] = c
+		}
+		return out, nil
+	}
+	headers := func(headers map[string]Header) (map[string]Header, error) {
+		if headers == nil {
+			return nil, nil
+		}
+		out := make(map[string]Header, len(headers))
+		for name, h := range headers {
+			schema, err := fn(h.Schema)
+			if err != nil {
+				return nil, err
+			}
+			h.Schema = schema
+			out[name] = h
+		}
+		return out, nil
+	}
+	param := func(p *Parameter) (err error) {
+		if p.Schema, err = fn(p.Schema); err != nil {
+			return err
+		}
+		p.Content, err = contents(p.Content)
+		return err
+	}
+	responses := func(responses map[string]Response) error {
+		for status, resp := range responses {
+			var err error
+			if resp.Content, err = contents(resp.Content); err != nil {
+				return err
+			}
+			if resp.Headers, err = headers(resp.Headers); err != nil {
+				return err
+			}
+			responses[status] = resp
+		}
+		return nil
+	}
+
+	for _, item := range doc.Paths {
+		for _, op := range item.Operations() {
+			for i := range op.Parameters {
+				if err := param(&op.Parameters[i]); err != nil {
+					return err
+				}
+			}
+			if op.RequestBody != nil {
+				content, err := contents(op.RequestBody.Content)
+				if err != nil {
+					return err
+				}
+				op.RequestBody = &RequestBody{Content: content}
+			}
+			if err := responses(op.Responses); err != nil {
+				return err
+			}
+		}
+	}
+
+	c := doc.Components
+	if c == nil {
+		return nil
+	}
+	for name, value := range c.Schemas {
+		schema, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		resolved, err := fn(schema)
+		if err != nil {
+			return err
+		}
+		c.Schemas[name] = resolved
+	}
+	for name, p := range c.Parameters {
+		if err := param(&p); err != nil {
+			return err
+		}
+		c.Parameters[name] = p
+	}
+	for name, body := range c.RequestBodies {
+		content, err := contents(body.Content)
+		if err != nil {
+			return err
+		}
+		c.RequestBodies[name] = RequestBody{Content: content}
+	}
+	if err := responses(c.Responses); err != nil {
+		return err
+	}
+	resolved, err := headers(c.Headers)
+	if err != nil {
+		return err
+	}
+	c.Headers = resolved
+	return nil
+}
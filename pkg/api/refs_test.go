@@ -0,0 +1,187 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// refCustomer references shared schemas by the `ref` tag
+type refCustomer struct {
+	Name      string        `json:"name"`
+	Address   refAddress    `json:"address" ref:"common.json#/Address"`
+	Previous  []refAddress  `json:"previous,omitempty" ref:"common.json#/Address"`
+	Reference *refReference `json:"reference,omitempty" ref:"https://schemas.example.com/ref.json"`
+}
+
+type refAddress struct {
+	Street string `json:"street"`
+}
+
+type refReference struct{}
+
+// TestRefTag tests emitting external references from struct tags
+func TestRefTag(t *testing.T) {
+	schema, err := SchemaFromStruct(refCustomer{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	props := schema["properties"].(map[string]interface{})
+
+	tests := []struct {
+		field string
+		want  map[string]interface{}
+	}{
+		{field: "address", want: ExternalRef("common.json#/Address")},
+		{field: "previous", want: map[string]interface{}{"type": "array", "items": ExternalRef("common.json#/Address")}},
+		{field: "reference", want: ExternalRef("https://schemas.example.com/ref.json")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			if !reflect.DeepEqual(props[tt.field], tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, props[tt.field])
+			}
+		})
+	}
+	if required := schema["required"].([]string); !reflect.DeepEqual(required, []string{"name", "address"}) {
+		t.Errorf("Expected name and address to be required, got %v", required)
+	}
+}
+
+// refDoc creates a document whose response schema is the given schema
+func refDoc(schema map[string]interface{}) *OpenAPIDoc {
+	return &OpenAPIDoc{Paths: map[string]PathItem{
+		"/customers": {Get: &Operation{Responses: map[string]Response{
+			"200": {Description: "Success", Content: map[string]Content{"application/json": {Schema: schema}}},
+		}}},
+	}}
+}
+
+// responseSchema returns the response schema of a document created by refDoc
+func responseSchema(doc *OpenAPIDoc) map[string]interface{} {
+	return doc.Paths["/customers"].Get.Responses["200"].Content["application/json"].Schema
+}
+
+// TestRefResolver tests keeping and bundling external references from files and URLs
+func TestRefResolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/schemas/money.json":
+			_, _ = w.Write([]byte(`{"Money": {"type": "object", "properties": {"currency": {"$ref": "currency.json"}}}}`))
+		case "/schemas/currency.json":
+			_, _ = w.Write([]byte(`{"type": "string", "pattern": "^[A-Z]{3}$"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"common.json": `{"Address": {"type": "object", "properties": {"country": {"$ref": "#/Country"}, "balance": {"$ref": "` +
+			server.URL + `/schemas/money.json#/Money"}}}, "Country": {"type": "string"}}`,
+		"cycle.json": `{"Node": {"type": "object", "properties": {"next": {"$ref": "#/Node"}}}}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bundled := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"country": map[string]interface{}{"type": "string"},
+			"balance": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"currency": map[string]interface{}{"type": "string", "pattern": "^[A-Z]{3}$"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		mode    RefMode
+		schema  map[string]interface{}
+		want    map[string]interface{}
+		wantErr string
+	}{
+		{name: "keep", mode: RefKeep, schema: ExternalRef("common.json#/Address"), want: ExternalRef("common.json#/Address")},
+		{name: "bundle", mode: RefBundle, schema: ExternalRef("common.json#/Address"), want: bundled},
+		{name: "bundle nested", mode: RefBundle,
+			schema: map[string]interface{}{"type": "array", "items": ExternalRef("common.json#/Country")},
+			want:   map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}},
+		{name: "local refs kept", mode: RefBundle,
+			schema: map[string]interface{}{"$ref": "#/components/schemas/Customer"},
+			want:   map[string]interface{}{"$ref": "#/components/schemas/Customer"}},
+		{name: "missing file", mode: RefKeep, schema: ExternalRef("missing.json#/Address"), wantErr: "failed to load"},
+		{name: "missing definition", mode: RefBundle, schema: ExternalRef("common.json#/Phone"), wantErr: "not found"},
+		{name: "cycle", mode: RefBundle, schema: ExternalRef("cycle.json#/Node"), wantErr: "circular"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := refDoc(tt.schema)
+			err := NewRefResolver(tt.mode, dir).Resolve(doc)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve failed: %v", err)
+			}
+			if got := responseSchema(doc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestRefResolverConcurrent tests resolving documents concurrently with a shared document cache
+func TestRefResolverConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	loads := 0
+	resolver := NewRefResolver(RefBundle, "")
+	resolver.Loader = func(location string) ([]byte, error) {
+		mu.Lock()
+		loads++
+		mu.Unlock()
+		return []byte(`{"Country": {"type": "string"}}`), nil
+	}
+
+	var wg sync.WaitGroup
+	docs := make([]*OpenAPIDoc, 8)
+	errs := make([]error, len(docs))
+	for i := range docs {
+		docs[i] = refDoc(map[string]interface{}{"$ref": "common.json#/Country"})
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = resolver.Resolve(docs[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for i, doc := range docs {
+		if errs[i] != nil {
+			t.Fatalf("Resolve failed: %v", errs[i])
+		}
+		if got := responseSchema(doc); !reflect.DeepEqual(got, map[string]interface{}{"type": "string"}) {
+			t.Errorf("Expected the inlined schema, got %v", got)
+		}
+	}
+	if loads < 1 || loads > len(docs) {
+		t.Errorf("Expected between 1 and %d loads, got %d", len(docs), loads)
+	}
+	if refClient.Timeout != RefTimeout {
+		t.Errorf("Expected LoadRef requests to time out after %v, got %v", RefTimeout, refClient.Timeout)
+	}
+}
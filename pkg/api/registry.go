@@ -0,0 +1,58 @@
+package api
+
+import "sync"
+
+// Registry collects API definitions declared next to their domain code, so feature packages
+// can add them from init or setup functions and the router registers them all at startup
+type Registry struct {
+	mu   sync.Mutex
+	defs []*APIDefinition
+}
+
+// NewRegistry creates an empty registry, e.g. to inject into feature packages instead of using DefaultRegistry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// DefaultRegistry is the global registry used by Add and AddGroup
+var DefaultRegistry = NewRegistry()
+
+// Add appends definitions to the registry
+func (r *Registry) Add(defs ...*APIDefinition) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, def := range defs {
+		if def != nil {
+			r.defs = append(r.defs, def)
+		}
+	}
+}
+
+// AddGroup appends definitions tagged with tag unless they declare tags themselves
+func (r *Registry) AddGroup(tag string, defs ...*APIDefinition) {
+	for _, def := range defs {
+		if def != nil && len(def.Tags) == 0 {
+			def.Tags = []string{tag}
+		}
+	}
+	r.Add(defs...)
+}
+
+// Definitions returns the registered definitions in the order they were added
+func (r *Registry) Definitions() []*APIDefinition {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defs := make([]*APIDefinition, len(r.defs))
+	copy(defs, r.defs)
+	return defs
+}
+
+// Add appends definitions to DefaultRegistry
+func Add(defs ...*APIDefinition) {
+	DefaultRegistry.Add(defs...)
+}
+
+// AddGroup appends definitions tagged with tag to DefaultRegistry
+func AddGroup(tag string, defs ...*APIDefinition) {
+	DefaultRegistry.AddGroup(tag, defs...)
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestRegistry tests collecting definitions in order, tagging groups and concurrent adds
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	registry.Add(NewAPIDefinition("GET", "/health", "Health"), nil)
+	registry.AddGroup("users",
+		NewAPIDefinition("GET", "/users", "List users"),
+		NewAPIDefinition("GET", "/admin/users", "List all users").WithTags("admin"),
+	)
+
+	defs := registry.Definitions()
+	if len(defs) != 3 || defs[0].Path != "/health" || defs[2].Path != "/admin/users" {
+		t.Fatalf("Expected definitions in the order they were added, got %d", len(defs))
+	}
+	if len(defs[0].Tags) != 0 || defs[1].Tags[0] != "users" || defs[2].Tags[0] != "admin" {
+		t.Errorf("Expected only untagged group members to be tagged, got %v %v %v", defs[0].Tags, defs[1].Tags, defs[2].Tags)
+	}
+
+	defs[0] = nil
+	if registry.Definitions()[0] == nil {
+		t.Error("Expected Definitions to return a copy")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			registry.Add(NewAPIDefinition("GET", "/items", "List items"))
+		}()
+	}
+	wg.Wait()
+	if got := len(registry.Definitions()); got != 13 {
+		t.Errorf("Expected 13 definitions, got %d", got)
+	}
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RequestContext is what a request rule sees of a request
+type RequestContext struct {
+	Context   context.Context   // Request context
+	Values    map[string]string // Parameters and top-level body properties present, by name
+	Header    http.Header       // Request headers
+	Principal interface{}       // Principal set with ContextWithPrincipal, nil for anonymous requests
+}
+
+// RequestRule is a validation rule over a whole request: parameter and body values, headers and the caller
+type RequestRule struct {
+	Description string                     // Documented in the operation's x-request-rules
+	Status      int                        // Status of rejected requests, 400 when zero
+	Check       func(RequestContext) error // Returns why a request is rejected
+}
+
+// Chain call: enforce a rule over the whole request, after parameter validation
+func (api *APIDefinition) WithRequestRule(rule RequestRule) *APIDefinition {
+	api.RequestRules = append(api.RequestRules, rule)
+	return api
+}
+
+// Chain call: require field to be after other when both are present, comparing dates, times or numbers
+func (api *APIDefinition) WithAfter(field, other string) *APIDefinition {
+	return api.WithRequestRule(RequestRule{
+		Description: fmt.Sprintf("%s must be after %s", field, other),
+		Check: func(req RequestContext) error {
+			value, ok := req.Values[field]
+			bound, hasBound := req.Values[other]
+			if !ok || !hasBound {
+				return nil
+			}
+			if after, comparable := isAfter(value, bound); comparable && !after {
+				return fmt.Errorf("%s must be after %s", field, other)
+			}
+			return nil
+		},
+	})
+}
+
+// Chain call: reject requests setting field with 403 unless allowed accepts the principal, e.g. admin-only fields;
+// who names the allowed callers in the documentation and error, e.g. "admins"
+func (api *APIDefinition) WithRestrictedField(field, who string, allowed func(principal interface{}) bool) *APIDefinition {
+	return api.WithRequestRule(RequestRule{
+		Description: fmt.Sprintf("only %s may set %s", who, field),
+		Status:      http.StatusForbidden,
+		Check: func(req RequestContext) error {
+			if _, ok := req.Values[field]; ok && !allowed(req.Principal) {
+				return fmt.Errorf("only %s may set %s", who, field)
+			}
+			return nil
+		},
+	})
+}
+
+// Evaluate checks a request against the rule, returning the status and reason of a rejection
+func (rule RequestRule) Evaluate(req RequestContext) (int, error) {
+	if err := rule.Check(req); err != nil {
+		if rule.Status == 0 {
+			return http.StatusBadRequest, err
+		}
+		return rule.Status, err
+	}
+	return 0, nil
+}
+
+// afterLayouts are the date and time formats WithAfter compares
+var afterLayouts = []string{time.RFC3339Nano, "2006-01-02"}
+
+// isAfter reports whether value is after bound, comparing them as times or numbers when both parse
+func isAfter(value, bound string) (after bool, comparable bool) {
+	for _, layout := range afterLayouts {
+		v, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		if b, err := time.Parse(layout, bound); err == nil {
+			return v.After(b), true
+		}
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false, false
+	}
+	b, err := strconv.ParseFloat(bound, 64)
+	if err != nil {
+		return false, false
+	}
+	return v > b, true
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+// TestRequestRules tests rules over request values and the principal
+func TestRequestRules(t *testing.T) {
+	isAdmin := func(principal interface{}) bool { return principal == "admin" }
+	def := NewAPIDefinition("POST", "/reports", "Create report").
+		WithAfter("end_date", "start_date").
+		WithAfter("max", "min").
+		WithRestrictedField("owner", "admins", isAdmin)
+
+	tests := []struct {
+		name       string
+		values     map[string]string
+		principal  interface{}
+		wantStatus int
+	}{
+		{name: "ordered dates", values: map[string]string{"start_date": "2024-01-01", "end_date": "2024-02-01"}},
+		{name: "reversed dates", values: map[string]string{"start_date": "2024-02-01", "end_date": "2024-01-01"}, wantStatus: http.StatusBadRequest},
+		{name: "reversed times", values: map[string]string{"start_date": "2024-01-01T10:00:00Z", "end_date": "2024-01-01T09:00:00Z"}, wantStatus: http.StatusBadRequest},
+		{name: "reversed numbers", values: map[string]string{"min": "10", "max": "9.5"}, wantStatus: http.StatusBadRequest},
+		{name: "one bound", values: map[string]string{"end_date": "2024-01-01"}},
+		{name: "incomparable", values: map[string]string{"start_date": "soon", "end_date": "2024-01-01"}},
+		{name: "restricted field by admin", values: map[string]string{"owner": "bob"}, principal: "admin"},
+		{name: "restricted field by user", values: map[string]string{"owner": "bob"}, principal: "user", wantStatus: http.StatusForbidden},
+		{name: "restricted field anonymously", values: map[string]string{"owner": "bob"}, wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := RequestContext{Values: tt.values, Principal: tt.principal}
+			status := 0
+			for _, rule := range def.RequestRules {
+				if status, _ = rule.Evaluate(req); status != 0 {
+					break
+				}
+			}
+			if status != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, status)
+			}
+		})
+	}
+}
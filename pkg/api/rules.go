@@ -0,0 +1,127 @@
+package api
+
+import (
+	"math"
+	"reflect"
+)
+
+// Validation rule types with unambiguous values, created by the typed constructors
+const (
+	RuleMinLength    = "minLength"    // String must have at least Value characters
+	RuleMaxLength    = "maxLength"    // String must have at most Value characters
+	RuleMinimum      = "minimum"      // Number must be at least Value
+	RuleMaximum      = "maximum"      // Number must be at most Value
+	RuleExclusiveMin = "exclusiveMin" // Value must be greater than the bound
+	RuleExclusiveMax = "exclusiveMax" // Value must be less than the bound
+	RuleMultipleOf   = "multipleOf"   // Value must be a multiple of a positive number
+	RulePattern      = "pattern"      // String must match a regular expression
+	RuleEnum         = "enum"         // Value must be one of a list
+)
+
+// MinLength creates a rule requiring at least n characters
+func MinLength(n int, message string) ValidationRule {
+	return ValidationRule{Type: RuleMinLength, Value: n, Message: message}
+}
+
+// MaxLength creates a rule allowing at most n characters
+func MaxLength(n int, message string) ValidationRule {
+	return ValidationRule{Type: RuleMaxLength, Value: n, Message: message}
+}
+
+// Minimum creates a rule requiring a number of at least n
+func Minimum(n float64, message string) ValidationRule {
+	return ValidationRule{Type: RuleMinimum, Value: n, Message: message}
+}
+
+// Maximum creates a rule requiring a number of at most n
+func Maximum(n float64, message string) ValidationRule {
+	return ValidationRule{Type: RuleMaximum, Value: n, Message: message}
+}
+
+// Pattern creates a rule requiring a match of the regular expression
+func Pattern(expr, message string) ValidationRule {
+	return ValidationRule{Type: RulePattern, Value: expr, Message: message}
+}
+
+// Enum creates a rule requiring one of the values
+func Enum(message string, values ...interface{}) ValidationRule {
+	return ValidationRule{Type: RuleEnum, Value: values, Message: message}
+}
+
+// isMultipleOf reports whether value is a multiple of divisor, tolerating floating point error
+func isMultipleOf(value, divisor float64) bool {
+	if divisor <= 0 {
+		return true
+	}
+	quotient := value / divisor
+	return math.Abs(quotient-math.Round(quotient)) < 1e-9
+}
+
+// floatValue returns a rule value of a floating point type
+func floatValue(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// intValue returns a rule value of any integer type
+func intValue(value interface{}) (int, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(v.Uint()), true
+	}
+	return 0, false
+}
+
+// numberValue returns a rule value of any float or integer type as a float64
+func numberValue(value interface{}) (float64, bool) {
+	if f, ok := floatValue(value); ok {
+		return f, true
+	}
+	if i, ok := intValue(value); ok {
+		return float64(i), true
+	}
+	return 0, false
+}
+
+// RuleKeywords returns the schema keywords documenting the parameter's validation rules
+// Legacy min/max rules are documented as bounds for numeric schemas and as lengths otherwise
+func (p *Parameter) RuleKeywords() map[string]interface{} {
+	keywords := make(map[string]interface{})
+	numeric := p.isNumeric()
+	for _, rule := range p.Validations {
+		switch rule.Type {
+		case RuleMinLength, RuleMaxLength, RuleMinimum, RuleMaximum, RulePattern:
+			keywords[rule.Type] = rule.Value
+		case RuleEnum:
+			if values, ok := rule.Value.([]interface{}); ok {
+				keywords["enum"] = values
+			}
+		case RuleExclusiveMin:
+			keywords["minimum"], keywords["exclusiveMinimum"] = rule.Value, true
+		case RuleExclusiveMax:
+			keywords["maximum"], keywords["exclusiveMaximum"] = rule.Value, true
+		case RuleMultipleOf:
+			if n, ok := toNumber(rule.Value); ok && n > 0 {
+				keywords["multipleOf"] = rule.Value
+			}
+		case "min", "max":
+			if _, isNumber := numberValue(rule.Value); isNumber && numeric {
+				keywords[rule.Type+"imum"] = rule.Value
+			} else if _, isInt := intValue(rule.Value); isInt && !numeric {
+				keywords[rule.Type+"Length"] = rule.Value
+			}
+		case "email":
+			keywords["format"] = "email"
+		case "url":
+			keywords["format"] = "uri"
+		}
+	}
+	return keywords
+}
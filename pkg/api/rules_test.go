@@ -0,0 +1,158 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestExclusiveAndMultipleOfRules tests runtime checks of exclusive bounds and multipleOf with int and float values
+func TestExclusiveAndMultipleOfRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    ValidationRule
+		value   string
+		wantErr bool
+	}{
+		{name: "above int bound", rule: NewValidationRule(RuleExclusiveMin, 0, "must be positive"), value: "1"},
+		{name: "at int bound", rule: NewValidationRule(RuleExclusiveMin, 0, "must be positive"), value: "0", wantErr: true},
+		{name: "below float bound", rule: NewValidationRule(RuleExclusiveMax, 9.5, "too large"), value: "9.4"},
+		{name: "at float bound", rule: NewValidationRule(RuleExclusiveMax, 9.5, "too large"), value: "9.5", wantErr: true},
+		{name: "int multiple", rule: NewValidationRule(RuleMultipleOf, 5, "not a multiple"), value: "25"},
+		{name: "not an int multiple", rule: NewValidationRule(RuleMultipleOf, 5, "not a multiple"), value: "26", wantErr: true},
+		{name: "float multiple", rule: NewValidationRule(RuleMultipleOf, 0.1, "not a multiple"), value: "0.3"},
+		{name: "not a float multiple", rule: NewValidationRule(RuleMultipleOf, 0.25, "not a multiple"), value: "0.3", wantErr: true},
+		{name: "non-numeric value", rule: NewValidationRule(RuleExclusiveMin, 0, "must be positive"), value: "abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param := Parameter{Name: "n", In: "query", Validations: []ValidationRule{tt.rule}}
+			if err := param.Validate(tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestRuleKeywords tests documenting exclusive bounds and multipleOf rules
+func TestRuleKeywords(t *testing.T) {
+	param := Parameter{Validations: []ValidationRule{
+		NewValidationRule(RuleExclusiveMin, 0, ""),
+		NewValidationRule(RuleExclusiveMax, 100.0, ""),
+		NewValidationRule(RuleMultipleOf, 5, ""),
+		NewValidationRule("pattern", "^[0-9]+$", ""),
+	}}
+	want := map[string]interface{}{
+		"minimum":          0,
+		"exclusiveMinimum": true,
+		"maximum":          100.0,
+		"exclusiveMaximum": true,
+		"multipleOf":       5,
+		"pattern":          "^[0-9]+$",
+	}
+	if got := param.RuleKeywords(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+// TestTypedRules tests the typed rule constructors at runtime and in the documented schema
+func TestTypedRules(t *testing.T) {
+	param := Parameter{
+		Name:   "code",
+		In:     "query",
+		Schema: map[string]interface{}{"type": "string"},
+		Validations: []ValidationRule{
+			MinLength(2, "code is too short"),
+			MaxLength(4, "code is too long"),
+			Pattern("^[a-zé]+$", "code must be lowercase"),
+			Enum("unknown code", "ab", "abc", "éé"),
+		},
+	}
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: "ab"},
+		{value: "éé"},
+		{value: "a", wantErr: true},
+		{value: "abcde", wantErr: true},
+		{value: "AB", wantErr: true},
+		{value: "abcd", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if err := param.Validate(tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	want := map[string]interface{}{
+		"minLength": 2,
+		"maxLength": 4,
+		"pattern":   "^[a-zé]+$",
+		"enum":      []interface{}{"ab", "abc", "éé"},
+	}
+	if got := param.RuleKeywords(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+// TestLegacyRuleKeywords tests documenting legacy min/max rules by the parameter schema type
+func TestLegacyRuleKeywords(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   map[string]interface{}
+	}{
+		{name: "integer", schema: "integer", want: map[string]interface{}{"minimum": 1, "maximum": 100}},
+		{name: "number", schema: "number", want: map[string]interface{}{"minimum": 1, "maximum": 100}},
+		{name: "string", schema: "string", want: map[string]interface{}{"minLength": 1, "maxLength": 100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param := Parameter{
+				Schema: map[string]interface{}{"type": tt.schema},
+				Validations: []ValidationRule{
+					NewValidationRule("min", 1, ""),
+					NewValidationRule("max", 100, ""),
+				},
+			}
+			if got := param.RuleKeywords(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestNumericRules tests Minimum/Maximum and legacy min/max rules of any numeric type
+func TestNumericRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    ValidationRule
+		schema  string
+		value   string
+		wantErr bool
+	}{
+		{name: "minimum", rule: Minimum(1, "too small"), value: "0", wantErr: true},
+		{name: "maximum", rule: Maximum(10, "too large"), value: "10"},
+		{name: "legacy float32 bound", rule: NewValidationRule("max", float32(5), "too large"), value: "6", wantErr: true},
+		{name: "legacy int64 length", rule: NewValidationRule("max", int64(3), "too long"), value: "abcd", wantErr: true},
+		{name: "legacy uint length", rule: NewValidationRule("min", uint(2), "too short"), value: "a", wantErr: true},
+		{name: "legacy int min on integer", rule: NewValidationRule("min", 1, "too small"), schema: "integer", value: "0", wantErr: true},
+		{name: "legacy int min on integer passes", rule: NewValidationRule("min", 1, "too small"), schema: "integer", value: "5"},
+		{name: "legacy int max on integer", rule: NewValidationRule("max", 100, "too large"), schema: "integer", value: "150", wantErr: true},
+		{name: "legacy uint max on number", rule: NewValidationRule("max", uint(10), "too large"), schema: "number", value: "9.5"},
+		{name: "legacy int max on string", rule: NewValidationRule("max", 3, "too long"), schema: "string", value: "1000", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param := Parameter{Name: "n", In: "query", Validations: []ValidationRule{tt.rule}}
+			if tt.schema != "" {
+				param.Schema = map[string]interface{}{"type": tt.schema}
+			}
+			if err := param.Validate(tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
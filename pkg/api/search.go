@@ -0,0 +1,176 @@
+package api
+
+import (
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Search result kinds
+const (
+	SearchKindOperation = "operation"
+	SearchKindSchema    = "schema"
+)
+
+// SearchResult is an operation or component schema matching a search query
+type SearchResult struct {
+	Kind        string   `json:"kind"`                  // SearchKindOperation or SearchKindSchema
+	Method      string   `json:"method,omitempty"`      // HTTP method of an operation
+	Path        string   `json:"path,omitempty"`        // Path of an operation
+	OperationID string   `json:"operationId,omitempty"` // operationId of an operation
+	Summary     string   `json:"summary,omitempty"`     // Summary of an operation
+	Tags        []string `json:"tags,omitempty"`        // Tags of an operation
+	Schema      string   `json:"schema,omitempty"`      // Name of a component schema
+	Link        string   `json:"link"`                  // Deep link: #/tag/operationId for operations, a JSON pointer for schemas
+	Score       int      `json:"score"`                 // Relevance, higher is better
+}
+
+// searchField is a lowercased field of an entry with the weight of matches in it
+type searchField struct {
+	text   string
+	weight int
+}
+
+// searchEntry is an indexed operation or schema
+type searchEntry struct {
+	result SearchResult
+	fields []searchField
+}
+
+// SearchIndex is an in-memory index over the summaries, paths, tags, operationIds and schema names of a document,
+// for finding operations in specs too large for client-side filtering
+type SearchIndex struct {
+	entries []searchEntry
+}
+
+// NewSearchIndex indexes the operations and component schemas of a document
+// The model type names of the definitions (matched by method and path) are indexed with their operations
+func NewSearchIndex(doc *OpenAPIDoc, defs []APIDefinition) *SearchIndex {
+	models := make(map[string][]string, len(defs))
+	for _, def := range defs {
+		key := strings.ToUpper(def.Method) + " " + def.Path
+		for _, model := range []interface{}{def.Request, def.Query, def.Response} {
+			if name := modelName(model); name != "" {
+				models[key] = append(models[key], name)
+			}
+		}
+	}
+
+	index := &SearchIndex{}
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		for _, method := range SupportedMethods {
+			op := doc.Paths[path].Operation(method)
+			if op == nil {
+				continue
+			}
+			tag := "default"
+			if len(op.Tags) > 0 {
+				tag = op.Tags[0]
+			}
+			entry := searchEntry{
+				result: SearchResult{
+					Kind:        SearchKindOperation,
+					Method:      method,
+					Path:        path,
+					OperationID: op.OperationID,
+					Summary:     op.Summary,
+					Tags:        op.Tags,
+					Link:        "#/" + url.PathEscape(tag) + "/" + url.PathEscape(op.OperationID),
+				},
+				fields: []searchField{
+					{strings.ToLower(op.OperationID), 4},
+					{strings.ToLower(path), 3},
+					{strings.ToLower(strings.Join(op.Tags, " ")), 3},
+					{strings.ToLower(op.Summary), 2},
+					{strings.ToLower(strings.Join(models[method+" "+path], " ")), 2},
+					{strings.ToLower(method), 1},
+				},
+			}
+			index.entries = append(index.entries, entry)
+		}
+	}
+
+	if doc.Components != nil {
+		names := make([]string, 0, len(doc.Components.Schemas))
+		for name := range doc.Components.Schemas {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			index.entries = append(index.entries, searchEntry{
+				result: SearchResult{
+					Kind:   SearchKindSchema,
+					Schema: name,
+					Link:   "#/components/schemas/" + strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1"),
+				},
+				fields: []searchField{{strings.ToLower(name), 4}},
+			})
+		}
+	}
+	return index
+}
+
+// modelName returns the type name of a model, dereferencing pointers and slices
+func modelName(model interface{}) string {
+	t := reflect.TypeOf(model)
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return t.Name()
+}
+
+// Search returns up to limit entries matching every whitespace-separated term of the query, best matches first
+// Terms match case-insensitively anywhere in a field; a non-positive limit returns all matches
+func (i *SearchIndex) Search(query string, limit int) []SearchResult {
+	terms := strings.Fields(strings.ToLower(query))
+	results := make([]SearchResult, 0)
+	if len(terms) == 0 {
+		return results
+	}
+	for _, entry := range i.entries {
+		score := 0
+		for _, term := range terms {
+			termScore := 0
+			for _, field := range entry.fields {
+				if strings.Contains(field.text, term) {
+					termScore += field.weight
+					if field.text == term {
+						termScore += field.weight
+					}
+				}
+			}
+			if termScore == 0 {
+				score = 0
+				break
+			}
+			score += termScore
+		}
+		if score > 0 {
+			result := entry.result
+			result.Score = score
+			results = append(results, result)
+		}
+	}
+	// Entries are indexed in path order, so equal scores keep a stable order
+	sort.SliceStable(results, func(a, b int) bool {
+		return results[a].Score > results[b].Score
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// Len returns the number of indexed operations and schemas
+func (i *SearchIndex) Len() int {
+	return len(i.entries)
+}
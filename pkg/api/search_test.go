@@ -0,0 +1,93 @@
+package api
+
+import (
+	"testing"
+)
+
+type searchUser struct {
+	Name string `json:"name"`
+}
+
+// searchDoc returns a document with a few operations and a component schema
+func searchDoc() *OpenAPIDoc {
+	return &OpenAPIDoc{
+		Paths: map[string]PathItem{
+			"/users": {
+				Get:  &Operation{OperationID: "listUsers", Summary: "List users", Tags: []string{"users"}},
+				Post: &Operation{OperationID: "createUser", Summary: "Create user", Tags: []string{"users"}},
+			},
+			"/orders/{id}": {
+				Get: &Operation{OperationID: "getOrder", Summary: "Get order"},
+			},
+		},
+		Components: &Components{
+			Schemas: map[string]interface{}{"Order": map[string]interface{}{"type": "object"}},
+		},
+	}
+}
+
+// TestSearchIndex tests matching, ranking and deep links of search results
+func TestSearchIndex(t *testing.T) {
+	defs := []APIDefinition{{Method: "post", Path: "/users", Request: &searchUser{}}}
+	index := NewSearchIndex(searchDoc(), defs)
+	if index.Len() != 4 {
+		t.Fatalf("Len() = %d, want 3 operations and 1 schema", index.Len())
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string // operationIds, or schema names
+	}{
+		{"summary word", "list", 0, []string{"listUsers"}},
+		{"all terms must match", "users create", 0, []string{"createUser"}},
+		{"case insensitive path", "/ORDERS", 0, []string{"getOrder"}},
+		{"model type name", "searchuser", 0, []string{"createUser"}},
+		{"matches in several fields rank first", "order", 0, []string{"getOrder", "Order"}},
+		{"limit", "users", 1, []string{"listUsers"}},
+		{"no match", "invoice", 0, []string{}},
+		{"empty query", "  ", 0, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := index.Search(tt.query, tt.limit)
+			got := make([]string, 0, len(results))
+			for _, r := range results {
+				if r.Kind == SearchKindSchema {
+					got = append(got, r.Schema)
+				} else {
+					got = append(got, r.OperationID)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+// TestSearchIndexLinks tests the deep links of operations and schemas
+func TestSearchIndexLinks(t *testing.T) {
+	index := NewSearchIndex(searchDoc(), nil)
+	links := map[string]string{}
+	for _, r := range index.Search("o", 0) {
+		links[r.OperationID+r.Schema] = r.Link
+	}
+	if links["getOrder"] != "#/default/getOrder" {
+		t.Errorf("untagged operation link = %q, want #/default/getOrder", links["getOrder"])
+	}
+	if links["createUser"] != "#/users/createUser" {
+		t.Errorf("tagged operation link = %q, want #/users/createUser", links["createUser"])
+	}
+	if links["Order"] != "#/components/schemas/Order" {
+		t.Errorf("schema link = %q, want #/components/schemas/Order", links["Order"])
+	}
+}
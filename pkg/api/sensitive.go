@@ -0,0 +1,47 @@
+package api
+
+// Chain call: mark declared parameters as sensitive so their values are masked in logs and examples
+func (api *APIDefinition) WithSensitiveParams(names ...string) *APIDefinition {
+	for i := range api.Params {
+		for _, name := range names {
+			if api.Params[i].Name == name {
+				api.Params[i].Sensitive = true
+			}
+		}
+	}
+	return api
+}
+
+// SensitiveFields returns the JSON property names marked with the sensitive tag anywhere in v's schema
+func SensitiveFields(v interface{}) []string {
+	if v == nil {
+		return nil
+	}
+	schema, err := SafeSchemaFromStruct(v)
+	if err != nil {
+		return nil
+	}
+	fields := make([]string, 0)
+	collectSensitiveFields(schema, &fields)
+	return fields
+}
+
+func collectSensitiveFields(schema map[string]interface{}, fields *[]string) {
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		for name, prop := range props {
+			propSchema, ok := prop.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if propSchema["x-sensitive"] == true {
+				*fields = append(*fields, name)
+			}
+			collectSensitiveFields(propSchema, fields)
+		}
+	}
+	for _, key := range []string{"items", "additionalProperties"} {
+		if nested, ok := schema[key].(map[string]interface{}); ok {
+			collectSensitiveFields(nested, fields)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password" sensitive:"true"`
+	Profile  struct {
+		APIToken string `json:"api_token" sensitive:"true"`
+	} `json:"profile"`
+}
+
+// TestSensitiveSchema tests writeOnly and x-sensitive emission for sensitive fields
+func TestSensitiveSchema(t *testing.T) {
+	schema, err := SchemaFromStruct(credentials{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	props := schema["properties"].(map[string]interface{})
+	password := props["password"].(map[string]interface{})
+	if password["writeOnly"] != true || password["x-sensitive"] != true {
+		t.Errorf("Expected password to be writeOnly and x-sensitive, got %v", password)
+	}
+	if _, ok := props["username"].(map[string]interface{})["writeOnly"]; ok {
+		t.Error("Expected username not to be writeOnly")
+	}
+
+	fields := SensitiveFields(credentials{})
+	if len(fields) != 2 {
+		t.Errorf("Expected 2 sensitive fields, got %v", fields)
+	}
+}
+
+// TestSensitiveParams tests sensitive parameter marking and serialization
+func TestSensitiveParams(t *testing.T) {
+	api := NewAPIDefinition("GET", "/session", "Get session").
+		WithHeaderParam("X-Session-Token", "Session token", true).
+		WithSensitiveParams("X-Session-Token")
+
+	if !api.Params[0].Sensitive {
+		t.Fatal("Expected parameter to be sensitive")
+	}
+	data, err := json.Marshal(api.Params[0])
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded["x-sensitive"] != true || decoded["name"] != "X-Session-Token" {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ServerVariable substitutes a {name} placeholder of a server URL
+type ServerVariable struct {
+	Enum        []string `json:"enum,omitempty"`
+	Default     string   `json:"default"`
+	Description string   `json:"description,omitempty"`
+}
+
+// serverVariablePattern matches the {name} placeholders of a server URL
+var serverVariablePattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// WithServerVariables adds an operation-specific server whose URL is templated by variables,
+// e.g. "https://{region}.uploads.example.com" for uploads served by another host
+func (api *APIDefinition) WithServerVariables(url, description string, variables map[string]ServerVariable) *APIDefinition {
+	api.Servers = append(api.Servers, OpenAPIServer{
+		URL:         url,
+		Description: description,
+		Variables:   variables,
+	})
+	return api
+}
+
+// ValidateServer checks that a server URL is absolute with a host or relative to the document (e.g. "/uploads"),
+// and that every placeholder is declared by a variable whose default is among its enum values
+func ValidateServer(server OpenAPIServer) error {
+	if server.URL == "" {
+		return fmt.Errorf("server URL cannot be empty")
+	}
+	placeholders := make(map[string]bool)
+	for _, match := range serverVariablePattern.FindAllStringSubmatch(server.URL, -1) {
+		name := match[1]
+		placeholders[name] = true
+		variable, ok := server.Variables[name]
+		if !ok {
+			return fmt.Errorf("server %s: variable %q is not declared", server.URL, name)
+		}
+		if variable.Default == "" {
+			return fmt.Errorf("server %s: variable %q has no default", server.URL, name)
+		}
+		if len(variable.Enum) > 0 && !containsString(variable.Enum, variable.Default) {
+			return fmt.Errorf("server %s: default %q of variable %q is not one of %s", server.URL, variable.Default, name, strings.Join(variable.Enum, ", "))
+		}
+	}
+	for name := range server.Variables {
+		if !placeholders[name] {
+			return fmt.Errorf("server %s: variable %q is not used by the URL", server.URL, name)
+		}
+	}
+
+	// Check the URL with every placeholder at its default
+	resolved := serverVariablePattern.ReplaceAllStringFunc(server.URL, func(placeholder string) string {
+		return server.Variables[placeholder[1:len(placeholder)-1]].Default
+	})
+	parsed, err := url.Parse(resolved)
+	if err != nil {
+		return fmt.Errorf("server %s: %w", server.URL, err)
+	}
+	if parsed.IsAbs() && parsed.Host == "" {
+		return fmt.Errorf("server %s: absolute URL has no host", server.URL)
+	}
+	return nil
+}
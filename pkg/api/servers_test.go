@@ -0,0 +1,31 @@
+package api
+
+import "testing"
+
+// TestValidateServer tests checking absolute, relative and templated server URLs
+func TestValidateServer(t *testing.T) {
+	region := map[string]ServerVariable{"region": {Default: "eu", Enum: []string{"eu", "us"}}}
+	tests := []struct {
+		name    string
+		server  OpenAPIServer
+		wantErr bool
+	}{
+		{"absolute", OpenAPIServer{URL: "https://uploads.example.com/v1"}, false},
+		{"relative", OpenAPIServer{URL: "/uploads"}, false},
+		{"templated", OpenAPIServer{URL: "https://{region}.uploads.example.com", Variables: region}, false},
+		{"empty", OpenAPIServer{}, true},
+		{"no host", OpenAPIServer{URL: "https:/uploads"}, true},
+		{"undeclared variable", OpenAPIServer{URL: "https://{region}.example.com"}, true},
+		{"unused variable", OpenAPIServer{URL: "https://example.com", Variables: region}, true},
+		{"default outside enum", OpenAPIServer{URL: "https://{region}.example.com", Variables: map[string]ServerVariable{"region": {Default: "ap", Enum: []string{"eu"}}}}, true},
+		{"no default", OpenAPIServer{URL: "https://{region}.example.com", Variables: map[string]ServerVariable{"region": {}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateServer(tt.server); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateServer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
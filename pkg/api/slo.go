@@ -0,0 +1,27 @@
+package api
+
+import "time"
+
+// SLOPolicy describes the performance an operation is expected to deliver
+type SLOPolicy struct {
+	LatencyP99 time.Duration // 99th percentile latency objective
+	RPS        float64       // Sustained throughput objective in requests per second
+}
+
+// Chain call: set latency and throughput objectives, documented as the x-slo extension
+func (api *APIDefinition) WithSLO(latencyP99 time.Duration, rps float64) *APIDefinition {
+	api.SLO = &SLOPolicy{LatencyP99: latencyP99, RPS: rps}
+	return api
+}
+
+// Extension returns the x-slo extension describing the objectives
+func (p *SLOPolicy) Extension() map[string]interface{} {
+	ext := make(map[string]interface{})
+	if p.LatencyP99 > 0 {
+		ext["latencyP99Ms"] = float64(p.LatencyP99) / float64(time.Millisecond)
+	}
+	if p.RPS > 0 {
+		ext["rps"] = p.RPS
+	}
+	return ext
+}
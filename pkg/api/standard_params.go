@@ -0,0 +1,88 @@
+package api
+
+import "strings"
+
+// Names of the built-in parameters shared under components.parameters
+const (
+	IdempotencyKeyParam = "IdempotencyKey"
+	RequestIDParam      = "RequestID"
+	IfMatchParam        = "IfMatch"
+	IfNoneMatchParam    = "IfNoneMatch"
+)
+
+// standardParameters holds the reusable header definitions keyed by component name
+var standardParameters = map[string]Parameter{
+	IdempotencyKeyParam: {
+		Name:        "Idempotency-Key",
+		In:          "header",
+		Description: "Unique key that makes retries of this request safe; repeated requests with the same key return the original result",
+		Schema:      map[string]interface{}{"type": "string", "maxLength": 255},
+		Validations: []ValidationRule{MaxLength(255, "Idempotency-Key cannot exceed 255 characters")},
+	},
+	RequestIDParam: {
+		Name:        "X-Request-ID",
+		In:          "header",
+		Description: "Client supplied identifier used to correlate the request across services",
+		Schema:      map[string]interface{}{"type": "string"},
+	},
+	IfMatchParam: {
+		Name:        "If-Match",
+		In:          "header",
+		Description: "Only perform the operation if the resource ETag matches",
+		Schema:      map[string]interface{}{"type": "string"},
+	},
+	IfNoneMatchParam: {
+		Name:        "If-None-Match",
+		In:          "header",
+		Description: "Only return the resource if its ETag does not match",
+		Schema:      map[string]interface{}{"type": "string"},
+	},
+}
+
+// ParameterRef returns the JSON reference to a parameter under components.parameters
+func ParameterRef(name string) string {
+	return "#/components/parameters/" + name
+}
+
+// ParameterRefName returns the component name of a parameter reference
+func ParameterRefName(ref string) string {
+	return strings.TrimPrefix(ref, "#/components/parameters/")
+}
+
+// StandardParameter returns a copy of a built-in parameter definition
+func StandardParameter(name string) (Parameter, bool) {
+	param, ok := standardParameters[name]
+	return param, ok
+}
+
+// Chain call: attach a built-in parameter by component name
+// When required is true the middleware rejects requests that omit it
+func (api *APIDefinition) WithStandardParam(name string, required bool) *APIDefinition {
+	param, ok := StandardParameter(name)
+	if !ok {
+		return api
+	}
+	param.Required = required
+	if required {
+		// Required and optional variants are shared as separate components
+		name += "Required"
+	}
+	param.Ref = ParameterRef(name)
+	api.Params = append(api.Params, param)
+	return api
+}
+
+// Chain call: attach the Idempotency-Key header
+func (api *APIDefinition) WithIdempotencyKey(required bool) *APIDefinition {
+	return api.WithStandardParam(IdempotencyKeyParam, required)
+}
+
+// Chain call: attach the X-Request-ID header
+func (api *APIDefinition) WithRequestID() *APIDefinition {
+	return api.WithStandardParam(RequestIDParam, false)
+}
+
+// Chain call: attach the If-Match precondition header
+func (api *APIDefinition) WithIfMatch(required bool) *APIDefinition {
+	return api.WithStandardParam(IfMatchParam, required)
+}
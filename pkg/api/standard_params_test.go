@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestWithIdempotencyKey tests attaching the shared Idempotency-Key header
+func TestWithIdempotencyKey(t *testing.T) {
+	api := NewAPIDefinition("POST", "/payments", "Create payment").
+		WithIdempotencyKey(true).
+		WithRequestID()
+
+	if len(api.Params) != 2 {
+		t.Fatalf("Expected 2 params, got %d", len(api.Params))
+	}
+
+	key := api.Params[0]
+	if key.Name != "Idempotency-Key" || key.In != "header" || !key.Required {
+		t.Errorf("Unexpected Idempotency-Key parameter: %+v", key)
+	}
+	if key.Ref != "#/components/parameters/IdempotencyKeyRequired" {
+		t.Errorf("Unexpected ref %s", key.Ref)
+	}
+	if api.Params[1].Ref != ParameterRef(RequestIDParam) {
+		t.Errorf("Unexpected ref %s", api.Params[1].Ref)
+	}
+}
+
+// TestParameterRefMarshal tests that referenced parameters serialize as $ref only
+func TestParameterRefMarshal(t *testing.T) {
+	api := NewAPIDefinition("PUT", "/users/{id}", "Update user").WithIfMatch(false)
+
+	data, err := json.Marshal(api.Params[0])
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"$ref":"#/components/parameters/IfMatch"}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+
+	plain, err := json.Marshal(Parameter{Name: "id", In: "path", Required: true})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(plain, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded["name"] != "id" || decoded["in"] != "path" {
+		t.Errorf("Unexpected JSON: %s", plain)
+	}
+}
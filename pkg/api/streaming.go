@@ -0,0 +1,37 @@
+package api
+
+import "strings"
+
+// MediaTypeOctetStream is the media type of streamed request bodies declaring none
+const MediaTypeOctetStream = "application/octet-stream"
+
+// WithStreamingRequest hands the request body to the handler unread so it can stream large uploads
+// The router still bounds its size but never decodes, validates or buffers it; the body is documented under
+// each media type (application/octet-stream by default), multipart types using the request model when set
+func (api *APIDefinition) WithStreamingRequest(mediaTypes ...string) *APIDefinition {
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{MediaTypeOctetStream}
+	}
+	api.RequestStream = mediaTypes
+	return api
+}
+
+// StreamsRequest reports whether the handler reads the request body itself
+func (api *APIDefinition) StreamsRequest() bool {
+	return len(api.RequestStream) > 0
+}
+
+// StreamedBodySchema returns the documented schema of a streamed body of the given media type:
+// the model schema for multipart forms when one is given, a binary string otherwise
+func StreamedBodySchema(mediaType string, model map[string]interface{}) map[string]interface{} {
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return BinarySchema()
+	}
+	if model != nil {
+		return model
+	}
+	return map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": BinarySchema(),
+	}
+}
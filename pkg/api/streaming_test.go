@@ -0,0 +1,34 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestStreamedBodySchema tests the documented schemas of streamed request bodies
+func TestStreamedBodySchema(t *testing.T) {
+	model := map[string]interface{}{"type": "object", "properties": map[string]interface{}{"file": BinarySchema()}}
+	tests := []struct {
+		name      string
+		mediaType string
+		model     map[string]interface{}
+		want      map[string]interface{}
+	}{
+		{"octet stream", MediaTypeOctetStream, model, BinarySchema()},
+		{"multipart with model", "multipart/form-data", model, model},
+		{"multipart without model", "multipart/form-data", nil, map[string]interface{}{"type": "object", "additionalProperties": BinarySchema()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StreamedBodySchema(tt.mediaType, tt.model); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StreamedBodySchema() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	def := NewAPIDefinition("PUT", "/files/{name}", "Upload file").WithStreamingRequest()
+	if !def.StreamsRequest() || !reflect.DeepEqual(def.RequestStream, []string{MediaTypeOctetStream}) {
+		t.Errorf("WithStreamingRequest() media types = %v", def.RequestStream)
+	}
+}
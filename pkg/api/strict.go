@@ -0,0 +1,125 @@
+package api
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// StrictModel is implemented by models declaring whether they accept properties they don't document
+// Strict models are documented with additionalProperties: false and adapters reject unknown request fields;
+// returning false keeps a model open when the router defaults to strict request validation
+type StrictModel interface {
+	Strict() bool
+}
+
+var strictModelType = reflect.TypeOf((*StrictModel)(nil)).Elem()
+
+// modelStrictness reports whether a struct type declares its additionalProperties, either by implementing
+// StrictModel or with a blank marker field: _ struct{} `additionalProperties:"false"`
+func modelStrictness(t reflect.Type) (strict, declared bool) {
+	if t.Implements(strictModelType) || reflect.PtrTo(t).Implements(strictModelType) {
+		return reflect.New(t).Interface().(StrictModel).Strict(), true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Name != "_" {
+			continue
+		}
+		if tag, ok := field.Tag.Lookup("additionalProperties"); ok {
+			allowed, err := strconv.ParseBool(tag)
+			if err == nil {
+				return !allowed, true
+			}
+		}
+	}
+	return false, false
+}
+
+// ApplyStrict marks every object schema that doesn't declare additionalProperties as closed
+// (additionalProperties: false), the router-wide default for strict request validation
+func ApplyStrict(schema map[string]interface{}) {
+	if schema == nil {
+		return
+	}
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		if _, declared := schema["additionalProperties"]; !declared {
+			schema["additionalProperties"] = false
+		}
+		for _, prop := range props {
+			if propSchema, ok := prop.(map[string]interface{}); ok {
+				ApplyStrict(propSchema)
+			}
+		}
+	}
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		ApplyStrict(items)
+	}
+	if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+		ApplyStrict(additional)
+	}
+}
+
+// IsStrict reports whether a schema, or one nested in it, rejects undocumented properties
+func IsStrict(schema map[string]interface{}) bool {
+	if schema == nil {
+		return false
+	}
+	if schema["additionalProperties"] == false {
+		return true
+	}
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		for _, prop := range props {
+			if propSchema, ok := prop.(map[string]interface{}); ok && IsStrict(propSchema) {
+				return true
+			}
+		}
+	}
+	for _, key := range []string{"items", "additionalProperties"} {
+		if nested, ok := schema[key].(map[string]interface{}); ok && IsStrict(nested) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnknownProperties returns the paths (e.g. profile.nickname or tags[1].color) of the properties of a decoded
+// JSON value that objects closed with additionalProperties: false don't document
+func UnknownProperties(schema map[string]interface{}, value interface{}) []string {
+	var unknown []string
+	collectUnknownProperties(schema, value, "", &unknown)
+	return unknown
+}
+
+func collectUnknownProperties(schema map[string]interface{}, value interface{}, path string, unknown *[]string) {
+	if schema == nil {
+		return
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		props, _ := schema["properties"].(map[string]interface{})
+		additional, _ := schema["additionalProperties"].(map[string]interface{})
+		for _, name := range sortedKeys(v) {
+			propPath := name
+			if path != "" {
+				propPath = path + "." + name
+			}
+			if propSchema, ok := props[name].(map[string]interface{}); ok {
+				collectUnknownProperties(propSchema, v[name], propPath, unknown)
+				continue
+			}
+			if _, ok := props[name]; ok {
+				continue
+			}
+			if schema["additionalProperties"] == false {
+				*unknown = append(*unknown, propPath)
+				continue
+			}
+			collectUnknownProperties(additional, v[name], propPath, unknown)
+		}
+	case []interface{}:
+		items, _ := schema["items"].(map[string]interface{})
+		for i, item := range v {
+			collectUnknownProperties(items, item, path+"["+strconv.Itoa(i)+"]", unknown)
+		}
+	}
+}
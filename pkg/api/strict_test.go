@@ -0,0 +1,110 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+type strictAddress struct {
+	Street string `json:"street"`
+}
+
+func (strictAddress) Strict() bool { return true }
+
+type taggedSignup struct {
+	_       struct{}      `additionalProperties:"false"`
+	Email   string        `json:"email"`
+	Address strictAddress `json:"address"`
+	Tags    []struct {
+		Name string `json:"name"`
+	} `json:"tags"`
+}
+
+type openPayload struct {
+	Kind string `json:"kind"`
+}
+
+func (*openPayload) Strict() bool { return false }
+
+// TestModelStrictness tests additionalProperties emission from StrictModel and the marker field
+func TestModelStrictness(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{name: "interface", value: strictAddress{}, want: false},
+		{name: "marker field", value: taggedSignup{}, want: false},
+		{name: "pointer receiver opt-out", value: openPayload{}, want: true},
+		{name: "undeclared", value: credentials{}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := SchemaFromStruct(tt.value)
+			if err != nil {
+				t.Fatalf("SchemaFromStruct failed: %v", err)
+			}
+			if got := schema["additionalProperties"]; got != tt.want {
+				t.Errorf("Expected additionalProperties %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestApplyStrict tests closing schemas that don't declare additionalProperties
+func TestApplyStrict(t *testing.T) {
+	schema, _ := SchemaFromStruct(struct {
+		Open    openPayload `json:"open"`
+		Profile struct {
+			Name string `json:"name"`
+		} `json:"profile"`
+	}{})
+	if IsStrict(schema) {
+		t.Fatal("Expected schema not to be strict before ApplyStrict")
+	}
+	ApplyStrict(schema)
+	props := schema["properties"].(map[string]interface{})
+	if schema["additionalProperties"] != false || props["profile"].(map[string]interface{})["additionalProperties"] != false {
+		t.Errorf("Expected undeclared objects to be closed, got %v", schema)
+	}
+	if props["open"].(map[string]interface{})["additionalProperties"] != true {
+		t.Error("Expected the opted-out model to stay open")
+	}
+}
+
+// TestUnknownProperties tests reporting undocumented properties of closed objects
+func TestUnknownProperties(t *testing.T) {
+	schema, _ := SchemaFromStruct(taggedSignup{})
+	tests := []struct {
+		name  string
+		value interface{}
+		want  []string
+	}{
+		{
+			name:  "known only",
+			value: map[string]interface{}{"email": "a@b.c", "address": map[string]interface{}{"street": "Main"}},
+		},
+		{
+			name:  "root and nested",
+			value: map[string]interface{}{"admin": true, "address": map[string]interface{}{"street": "Main", "zip": "1"}},
+			want:  []string{"address.zip", "admin"},
+		},
+		{
+			name:  "open items",
+			value: map[string]interface{}{"tags": []interface{}{map[string]interface{}{"name": "a", "color": "red"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnknownProperties(schema, tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	ApplyStrict(schema)
+	value := map[string]interface{}{"tags": []interface{}{map[string]interface{}{"name": "a", "color": "red"}}}
+	if got := UnknownProperties(schema, value); !reflect.DeepEqual(got, []string{"tags[0].color"}) {
+		t.Errorf("Expected items to be closed by ApplyStrict, got %v", got)
+	}
+}
@@ -0,0 +1,229 @@
+package api
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// DefaultTag names the group of operations without tags, as Swagger UI does
+const DefaultTag = "default"
+
+// DocumentTags returns the tags used by the operations of a document in order, DefaultTag standing for untagged ones
+func DocumentTags(doc *OpenAPIDoc) []string {
+	seen := make(map[string]bool)
+	for _, item := range doc.Paths {
+		for _, op := range item.Operations() {
+			for _, tag := range operationTags(op) {
+				seen[tag] = true
+			}
+		}
+	}
+	tags := make([]string, 0, len(seen))
+	for tag := range seen {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// operationTags returns the tags of an operation, DefaultTag when it has none
+func operationTags(op *Operation) []string {
+	if len(op.Tags) == 0 {
+		return []string{DefaultTag}
+	}
+	return op.Tags
+}
+
+// FilterTag returns a copy of the document holding only the operations tagged with tag and the components they
+// reach through references and security requirements, so it is a valid document on its own
+func FilterTag(doc *OpenAPIDoc, tag string) (*OpenAPIDoc, error) {
+	filtered := &OpenAPIDoc{
+		OpenAPI:      doc.OpenAPI,
+		Info:         doc.Info,
+		Servers:      doc.Servers,
+		Paths:        make(map[string]PathItem),
+		Security:     doc.Security,
+		ExternalDocs: doc.ExternalDocs,
+	}
+	schemes := make(map[string]bool)
+	addSchemes(schemes, doc.Security)
+	for path, item := range doc.Paths {
+		var kept PathItem
+		for _, method := range SupportedMethods {
+			op := item.Operation(method)
+			if op == nil || !containsString(operationTags(op), tag) {
+				continue
+			}
+			kept.SetOperation(method, op)
+			addSchemes(schemes, op.Security)
+		}
+		if len(kept.Operations()) > 0 {
+			filtered.Paths[path] = kept
+		}
+	}
+	for _, t := range doc.Tags {
+		if t.Name == tag {
+			filtered.Tags = []Tag{t}
+		}
+	}
+	if doc.Components == nil {
+		return filtered, nil
+	}
+
+	components, err := usedComponents(filtered.Paths, doc.Components, schemes)
+	if err != nil {
+		return nil, err
+	}
+	filtered.Components = components
+	return filtered, nil
+}
+
+// addSchemes records the security schemes named by security requirements
+func addSchemes(schemes map[string]bool, requirements []map[string][]string) {
+	for _, requirement := range requirements {
+		for name := range requirement {
+			schemes[name] = true
+		}
+	}
+}
+
+// usedComponents returns the components reached from the paths through references, and the named security
+// schemes
+func usedComponents(paths map[string]PathItem, all *Components, schemes map[string]bool) (*Components, error) {
+	reachable, err := reachableComponents(paths, all)
+	if err != nil {
+		return nil, err
+	}
+	components := &Components{}
+	for name, schema := range all.Schemas {
+		if reachable["schemas/"+name] {
+			if components.Schemas == nil {
+				components.Schemas = make(map[string]interface{})
+			}
+			components.Schemas[name] = schema
+		}
+	}
+	for name, scheme := range all.SecuritySchemes {
+		if schemes[name] {
+			if components.SecuritySchemes == nil {
+				components.SecuritySchemes = make(map[string]SecurityScheme)
+			}
+			components.SecuritySchemes[name] = scheme
+		}
+	}
+	for name, param := range all.Parameters {
+		if reachable["parameters/"+name] {
+			if components.Parameters == nil {
+				components.Parameters = make(map[string]Parameter)
+			}
+			components.Parameters[name] = param
+		}
+	}
+	for name, body := range all.RequestBodies {
+		if reachable["requestBodies/"+name] {
+			if components.RequestBodies == nil {
+				components.RequestBodies = make(map[string]RequestBody)
+			}
+			components.RequestBodies[name] = body
+		}
+	}
+	for name, resp := range all.Responses {
+		if reachable["responses/"+name] {
+			if components.Responses == nil {
+				components.Responses = make(map[string]Response)
+			}
+			components.Responses[name] = resp
+		}
+	}
+	for name, header := range all.Headers {
+		if reachable["headers/"+name] {
+			if components.Headers == nil {
+				components.Headers = make(map[string]Header)
+			}
+			components.Headers[name] = header
+		}
+	}
+	for name, example := range all.Examples {
+		if reachable["examples/"+name] {
+			if components.Examples == nil {
+				components.Examples = make(map[string]Example)
+			}
+			components.Examples[name] = example
+		}
+	}
+	return components, nil
+}
+
+// reachableComponents returns the kind/name keys of the components referenced from the paths, directly or
+// through other components
+func reachableComponents(paths map[string]PathItem, components *Components) (map[string]bool, error) {
+	var root interface{}
+	if err := decodeGeneric(paths, &root); err != nil {
+		return nil, err
+	}
+	var all map[string]map[string]interface{}
+	if err := decodeGeneric(components, &all); err != nil {
+		return nil, err
+	}
+
+	reachable := make(map[string]bool)
+	queue := []interface{}{root}
+	for len(queue) > 0 {
+		value := queue[0]
+		queue = queue[1:]
+		for _, ref := range collectRefs(value, nil) {
+			key, ok := componentKey(ref)
+			if !ok || reachable[key] {
+				continue
+			}
+			reachable[key] = true
+			kind, name, _ := strings.Cut(key, "/")
+			if target, ok := all[kind][name]; ok {
+				queue = append(queue, target)
+			}
+		}
+	}
+	return reachable, nil
+}
+
+// decodeGeneric converts a value to its generic JSON form
+func decodeGeneric(value interface{}, out interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// collectRefs appends the $ref values found in a generic JSON value
+func collectRefs(value interface{}, refs []string) []string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			refs = append(refs, ref)
+		}
+		for _, item := range v {
+			refs = collectRefs(item, refs)
+		}
+	case []interface{}:
+		for _, item := range v {
+			refs = collectRefs(item, refs)
+		}
+	}
+	return refs
+}
+
+// componentKey turns a local reference (#/components/schemas/User) into its kind/name key
+func componentKey(ref string) (string, bool) {
+	rest, ok := strings.CutPrefix(ref, "#/components/")
+	if !ok {
+		return "", false
+	}
+	kind, name, ok := strings.Cut(rest, "/")
+	if !ok {
+		return "", false
+	}
+	name = strings.ReplaceAll(strings.ReplaceAll(name, "~1", "/"), "~0", "~")
+	return kind + "/" + name, true
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+// tagDoc returns a document whose tags reach different components
+func tagDoc() *OpenAPIDoc {
+	ref := func(name string) map[string]interface{} {
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	}
+	jsonContent := func(schema map[string]interface{}) map[string]Content {
+		return map[string]Content{"application/json": {Schema: schema}}
+	}
+	return &OpenAPIDoc{
+		OpenAPI: "3.0.0",
+		Info:    OpenAPIInfo{Title: "Test API", Version: "1.0.0"},
+		Paths: map[string]PathItem{
+			"/users": {
+				Get: &Operation{
+					Tags:      []string{"users"},
+					Responses: map[string]Response{"200": {Description: "OK", Content: jsonContent(ref("User"))}},
+					Security:  []map[string][]string{{"bearer": {}}},
+				},
+				Post: &Operation{
+					Tags:       []string{"users", "admin"},
+					Parameters: []Parameter{{Ref: "#/components/parameters/Tenant"}},
+					Responses:  map[string]Response{"201": {Description: "Created"}},
+				},
+			},
+			"/orders": {
+				Get: &Operation{
+					Tags:      []string{"orders"},
+					Responses: map[string]Response{"200": {Description: "OK", Content: jsonContent(ref("Order"))}},
+				},
+			},
+			"/health": {
+				Get: &Operation{Responses: map[string]Response{"200": {Description: "OK"}}},
+			},
+		},
+		Components: &Components{
+			Schemas: map[string]interface{}{
+				"User":    map[string]interface{}{"type": "object", "properties": map[string]interface{}{"address": ref("Address")}},
+				"Address": map[string]interface{}{"type": "object"},
+				"Order":   map[string]interface{}{"type": "object"},
+			},
+			Parameters: map[string]Parameter{
+				"Tenant": {Name: "X-Tenant", In: "header", Schema: map[string]interface{}{"type": "string"}},
+			},
+			SecuritySchemes: map[string]SecurityScheme{
+				"bearer": {Type: "http", Scheme: "bearer"},
+				"apiKey": {Type: "apiKey", Name: "X-API-Key", In: "header"},
+			},
+		},
+		Tags: []Tag{{Name: "users", Description: "User management"}, {Name: "orders"}},
+	}
+}
+
+// TestDocumentTags tests listing the tags of a document
+func TestDocumentTags(t *testing.T) {
+	want := []string{"admin", DefaultTag, "orders", "users"}
+	if got := DocumentTags(tagDoc()); !reflect.DeepEqual(got, want) {
+		t.Errorf("DocumentTags() = %v, want %v", got, want)
+	}
+}
+
+// TestFilterTag tests that tag documents keep their operations and the components they reach
+func TestFilterTag(t *testing.T) {
+	tests := []struct {
+		tag         string
+		wantOps     map[string][]string // path -> methods
+		wantSchemas []string
+		wantParams  []string
+		wantSchemes []string
+		wantTags    int
+	}{
+		{
+			tag:         "users",
+			wantOps:     map[string][]string{"/users": {"GET", "POST"}},
+			wantSchemas: []string{"Address", "User"},
+			wantParams:  []string{"Tenant"},
+			wantSchemes: []string{"bearer"},
+			wantTags:    1,
+		},
+		{
+			tag:         "admin",
+			wantOps:     map[string][]string{"/users": {"POST"}},
+			wantParams:  []string{"Tenant"},
+			wantSchemas: []string{},
+			wantSchemes: []string{},
+		},
+		{
+			tag:         "orders",
+			wantOps:     map[string][]string{"/orders": {"GET"}},
+			wantSchemas: []string{"Order"},
+			wantParams:  []string{},
+			wantSchemes: []string{},
+			wantTags:    1,
+		},
+		{
+			tag:         DefaultTag,
+			wantOps:     map[string][]string{"/health": {"GET"}},
+			wantSchemas: []string{},
+			wantParams:  []string{},
+			wantSchemes: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			doc := tagDoc()
+			filtered, err := FilterTag(doc, tt.tag)
+			if err != nil {
+				t.Fatalf("FilterTag() error = %v", err)
+			}
+
+			gotOps := make(map[string][]string)
+			for path, item := range filtered.Paths {
+				for _, method := range SupportedMethods {
+					if item.Operation(method) != nil {
+						gotOps[path] = append(gotOps[path], method)
+					}
+				}
+			}
+			if !reflect.DeepEqual(gotOps, tt.wantOps) {
+				t.Errorf("operations = %v, want %v", gotOps, tt.wantOps)
+			}
+			if got := sortedKeys(filtered.Components.Schemas); !reflect.DeepEqual(got, tt.wantSchemas) {
+				t.Errorf("schemas = %v, want %v", got, tt.wantSchemas)
+			}
+			params := make([]string, 0)
+			for name := range filtered.Components.Parameters {
+				params = append(params, name)
+			}
+			if !reflect.DeepEqual(params, tt.wantParams) {
+				t.Errorf("parameters = %v, want %v", params, tt.wantParams)
+			}
+			schemes := make([]string, 0)
+			for name := range filtered.Components.SecuritySchemes {
+				schemes = append(schemes, name)
+			}
+			if !reflect.DeepEqual(schemes, tt.wantSchemes) {
+				t.Errorf("security schemes = %v, want %v", schemes, tt.wantSchemes)
+			}
+			if len(filtered.Tags) != tt.wantTags {
+				t.Errorf("tags = %v, want %d", filtered.Tags, tt.wantTags)
+			}
+			if err := ValidateDoc(filtered); err != nil {
+				t.Errorf("filtered document is invalid: %v", err)
+			}
+
+			// The source document is left unchanged
+			if len(doc.Paths) != 3 || len(doc.Components.Schemas) != 3 {
+				t.Error("FilterTag() modified the source document")
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// validationTags lists the struct tags whose rules are translated into schema constraints:
+// `validate` (go-playground/validator) and `binding` (gin)
+var validationTags = []string{"validate", "binding"}
+
+// applyValidationTags translates validator rules on a field into schema keywords
+// Rules after `dive` constrain the items of slices (or the values of maps), as in go-playground/validator;
+// map `keys`...`endkeys` rules have no schema counterpart and are skipped
+// Returns true if any rule marks the field as required
+func applyValidationTags(schema map[string]interface{}, field reflect.StructField) bool {
+	required := false
+	for _, tagName := range validationTags {
+		tag := field.Tag.Get(tagName)
+		if tag == "" || tag == "-" {
+			continue
+		}
+		target, t := schema, field.Type
+		dived, inKeys := false, false
+		for _, rule := range strings.Split(tag, ",") {
+			name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+			switch {
+			case inKeys:
+				inKeys = name != "endkeys"
+			case name == "keys":
+				inKeys = true
+			case name == "dive":
+				dived = true
+				if target != nil {
+					target, t = diveSchema(target, t)
+				}
+			case name == "required" && !dived:
+				required = true
+			case target != nil:
+				applyValidationRule(target, fieldKind(t), name, param)
+			}
+		}
+	}
+	return required
+}
+
+// diveSchema returns the schema and type of the items (or map values) a `dive` rule descends into,
+// or nil if the type has none
+func diveSchema(schema map[string]interface{}, t reflect.Type) (map[string]interface{}, reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	key := ""
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		key = "items"
+	case reflect.Map:
+		key = "additionalProperties"
+	default:
+		return nil, t
+	}
+	nested, ok := schema[key].(map[string]interface{})
+	if !ok || nested["$ref"] != nil {
+		return nil, t
+	}
+	return nested, t.Elem()
+}
+
+// fieldKind returns the kind of a field type, looking through pointers
+func fieldKind(t reflect.Type) reflect.Kind {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind()
+}
+
+// applyValidationRule sets the schema keyword corresponding to a single validator rule
+func applyValidationRule(schema map[string]interface{}, kind reflect.Kind, name, param string) {
+	switch name {
+	case "min", "gte":
+		if n, ok := parseNumber(param); ok {
+			schema[boundKeyword(kind, true)] = n
+		}
+	case "max", "lte":
+		if n, ok := parseNumber(param); ok {
+			schema[boundKeyword(kind, false)] = n
+		}
+	case "gt", "lt":
+		n, ok := parseNumber(param)
+		if !ok {
+			return
+		}
+		lower := name == "gt"
+		if isNumericKind(kind) {
+			// OpenAPI 3.0 exclusive bounds are flags on minimum/maximum
+			if lower {
+				schema["minimum"], schema["exclusiveMinimum"] = n, true
+			} else {
+				schema["maximum"], schema["exclusiveMaximum"] = n, true
+			}
+		} else if count, isInt := n.(int); isInt {
+			// Lengths and counts are whole numbers, so exclusive bounds become inclusive ones
+			if lower {
+				schema[boundKeyword(kind, true)] = count + 1
+			} else {
+				schema[boundKeyword(kind, false)] = count - 1
+			}
+		}
+	case "len":
+		if n, ok := parseNumber(param); ok {
+			if isNumericKind(kind) {
+				schema["enum"] = []interface{}{n}
+			} else {
+				schema[boundKeyword(kind, true)] = n
+				schema[boundKeyword(kind, false)] = n
+			}
+		}
+	case "unique":
+		if kind == reflect.Slice || kind == reflect.Array {
+			schema["uniqueItems"] = true
+		}
+	case "oneof":
+		values := make([]interface{}, 0)
+		for _, value := range strings.Fields(param) {
+			if isNumericKind(kind) {
+				if n, ok := parseNumber(value); ok {
+					values = append(values, n)
+					continue
+				}
+			}
+			values = append(values, value)
+		}
+		schema["enum"] = values
+	case "email":
+		schema["format"] = "email"
+	case "url", "uri", "http_url":
+		schema["format"] = "uri"
+	case "uuid", "uuid4":
+		schema["format"] = "uuid"
+	case "ipv4":
+		schema["format"] = "ipv4"
+	case "ipv6":
+		schema["format"] = "ipv6"
+	case "hostname":
+		schema["format"] = "hostname"
+	case "datetime":
+		schema["format"] = "date-time"
+	case "alpha":
+		schema["pattern"] = "^[a-zA-Z]+$"
+	case "alphanum":
+		schema["pattern"] = "^[a-zA-Z0-9]+$"
+	case "numeric":
+		schema["pattern"] = "^[-+]?[0-9]+(\\.[0-9]+)?$"
+	}
+}
+
+// boundKeyword returns the lower or upper bound keyword matching the constrained value's kind
+func boundKeyword(kind reflect.Kind, lower bool) string {
+	prefix := "max"
+	if lower {
+		prefix = "min"
+	}
+	switch {
+	case kind == reflect.String:
+		return prefix + "Length"
+	case kind == reflect.Slice || kind == reflect.Array:
+		return prefix + "Items"
+	case kind == reflect.Map:
+		return prefix + "Properties"
+	case lower:
+		return "minimum"
+	default:
+		return "maximum"
+	}
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// parseNumber parses a rule parameter, returning an int for whole numbers
+func parseNumber(s string) (interface{}, bool) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, false
+	}
+	if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		return int(f), true
+	}
+	return f, true
+}
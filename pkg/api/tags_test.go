@@ -0,0 +1,141 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+type bindingTagged struct {
+	Name   string   `json:"name" binding:"required,min=3,max=20"`
+	Age    int      `json:"age,omitempty" binding:"gte=1,lte=130"`
+	Role   string   `json:"role,omitempty" binding:"oneof=admin user"`
+	Level  int      `json:"level,omitempty" validate:"oneof=1 2 3"`
+	Email  string   `json:"email,omitempty" binding:"email"`
+	Tags   []string `json:"tags,omitempty" binding:"max=5"`
+	Code   string   `json:"code,omitempty" validate:"len=4"`
+	Ignore string   `json:"ignore,omitempty" binding:"-"`
+}
+
+// TestBindingTagConstraints tests translating binding and validate tags into schema keywords
+func TestBindingTagConstraints(t *testing.T) {
+	schema, err := SchemaFromStruct(bindingTagged{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	props := schema["properties"].(map[string]interface{})
+	prop := func(name string) map[string]interface{} {
+		return props[name].(map[string]interface{})
+	}
+
+	required, _ := schema["required"].([]string)
+	if !reflect.DeepEqual(required, []string{"name"}) {
+		t.Errorf("Expected required [name], got %v", required)
+	}
+
+	cases := []struct {
+		field, key string
+		want       interface{}
+	}{
+		{"name", "minLength", 3},
+		{"name", "maxLength", 20},
+		{"age", "minimum", 1},
+		{"age", "maximum", 130},
+		{"role", "enum", []interface{}{"admin", "user"}},
+		{"level", "enum", []interface{}{1, 2, 3}},
+		{"email", "format", "email"},
+		{"tags", "maxItems", 5},
+		{"code", "minLength", 4},
+		{"code", "maxLength", 4},
+	}
+	for _, tc := range cases {
+		if got := prop(tc.field)[tc.key]; !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Expected %s.%s %#v, got %#v", tc.field, tc.key, tc.want, got)
+		}
+	}
+}
+
+type arrayTagged struct {
+	IDs    []int               `json:"ids" binding:"required,min=1,max=10,unique,dive,gte=1"`
+	Emails []string            `json:"emails,omitempty" validate:"dive,required,email"`
+	Matrix [][]string          `json:"matrix,omitempty" binding:"max=3,dive,max=4,dive,len=2"`
+	Labels map[string]string   `json:"labels,omitempty" binding:"dive,keys,alpha,endkeys,max=8"`
+	Refs   []bindingTagged     `json:"refs,omitempty" binding:"dive"`
+	Scores map[string][]string `json:"scores,omitempty" binding:"max=2,dive,unique"`
+}
+
+// TestArrayTagConstraints tests array-level keywords and dive rules applied to items
+func TestArrayTagConstraints(t *testing.T) {
+	schema, err := SchemaFromStruct(arrayTagged{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	props := schema["properties"].(map[string]interface{})
+	path := func(keys ...string) map[string]interface{} {
+		current := props[keys[0]].(map[string]interface{})
+		for _, key := range keys[1:] {
+			current = current[key].(map[string]interface{})
+		}
+		return current
+	}
+
+	if required, _ := schema["required"].([]string); !reflect.DeepEqual(required, []string{"ids"}) {
+		t.Errorf("Expected required [ids], got %v", required)
+	}
+
+	cases := []struct {
+		path []string
+		key  string
+		want interface{}
+	}{
+		{[]string{"ids"}, "minItems", 1},
+		{[]string{"ids"}, "maxItems", 10},
+		{[]string{"ids"}, "uniqueItems", true},
+		{[]string{"ids", "items"}, "minimum", 1},
+		{[]string{"emails", "items"}, "format", "email"},
+		{[]string{"matrix"}, "maxItems", 3},
+		{[]string{"matrix", "items"}, "maxItems", 4},
+		{[]string{"matrix", "items", "items"}, "minLength", 2},
+		{[]string{"labels", "additionalProperties"}, "maxLength", 8},
+		{[]string{"labels", "additionalProperties"}, "pattern", nil},
+		{[]string{"scores"}, "maxProperties", 2},
+		{[]string{"scores", "additionalProperties"}, "uniqueItems", true},
+	}
+	for _, tc := range cases {
+		if got := path(tc.path...)[tc.key]; !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Expected %v.%s %#v, got %#v", tc.path, tc.key, tc.want, got)
+		}
+	}
+}
+
+type exclusiveTagged struct {
+	Price float64  `json:"price" binding:"gt=0,lt=1000"`
+	Name  string   `json:"name" binding:"gt=2,lt=10"`
+	Tags  []string `json:"tags" binding:"gt=0"`
+}
+
+// TestExclusiveTagConstraints tests gt/lt as exclusive numeric bounds and adjusted length and count bounds
+func TestExclusiveTagConstraints(t *testing.T) {
+	schema, err := SchemaFromStruct(exclusiveTagged{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	props := schema["properties"].(map[string]interface{})
+	cases := []struct {
+		field, key string
+		want       interface{}
+	}{
+		{"price", "minimum", 0},
+		{"price", "exclusiveMinimum", true},
+		{"price", "maximum", 1000},
+		{"price", "exclusiveMaximum", true},
+		{"name", "minLength", 3},
+		{"name", "maxLength", 9},
+		{"name", "exclusiveMinimum", nil},
+		{"tags", "minItems", 1},
+	}
+	for _, tc := range cases {
+		if got := props[tc.field].(map[string]interface{})[tc.key]; !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Expected %s.%s %#v, got %#v", tc.field, tc.key, tc.want, got)
+		}
+	}
+}
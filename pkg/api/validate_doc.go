@@ -0,0 +1,142 @@
+package api
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// Official OpenAPI meta-schemas from https://spec.openapis.org
+var (
+	//go:embed schemas/oas-3.0.json
+	oas30MetaSchema string
+	//go:embed schemas/oas-3.1.json
+	oas31MetaSchema string
+)
+
+const (
+	oas30MetaSchemaURL = "https://spec.openapis.org/oas/3.0/schema/2021-09-28"
+	oas31MetaSchemaURL = "https://spec.openapis.org/oas/3.1/schema/2022-10-07"
+)
+
+var (
+	metaSchemasOnce sync.Once
+	metaSchemas     map[string]*jsonschema.Schema // Keyed by "3.0" / "3.1"
+	metaSchemasErr  error
+)
+
+// DocProblem is a single meta-schema violation
+type DocProblem struct {
+	Location string // JSON pointer into the document
+	Message  string
+}
+
+// DocValidationError lists every meta-schema violation of a document
+type DocValidationError struct {
+	Problems []DocProblem
+}
+
+func (e *DocValidationError) Error() string {
+	lines := make([]string, 0, len(e.Problems))
+	for _, p := range e.Problems {
+		lines = append(lines, fmt.Sprintf("%s: %s", p.Location, p.Message))
+	}
+	return "openapi document is invalid:\n" + strings.Join(lines, "\n")
+}
+
+// ValidateDoc validates a document against the official OpenAPI JSON Schema for its version
+// Returns a *DocValidationError listing the violations
+func ValidateDoc(doc *OpenAPIDoc) error {
+	if doc == nil {
+		return fmt.Errorf("document cannot be nil")
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal document: %w", err)
+	}
+	return ValidateDocJSON(data)
+}
+
+// ValidateDocJSON validates a serialized OpenAPI 3.0 or 3.1 document against the official meta-schema
+func ValidateDocJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var instance interface{}
+	if err := decoder.Decode(&instance); err != nil {
+		return fmt.Errorf("failed to parse document: %w", err)
+	}
+
+	version := ""
+	if obj, ok := instance.(map[string]interface{}); ok {
+		version, _ = obj["openapi"].(string)
+	}
+	schema, err := metaSchema(version)
+	if err != nil {
+		return err
+	}
+
+	err = schema.Validate(instance)
+	if verr, ok := err.(*jsonschema.ValidationError); ok {
+		return docValidationError(verr)
+	}
+	return err
+}
+
+// metaSchema returns the compiled meta-schema for an "openapi" version string
+func metaSchema(version string) (*jsonschema.Schema, error) {
+	metaSchemasOnce.Do(func() {
+		metaSchemas = make(map[string]*jsonschema.Schema)
+		for minor, source := range map[string][2]string{
+			"3.0": {oas30MetaSchemaURL, oas30MetaSchema},
+			"3.1": {oas31MetaSchemaURL, oas31MetaSchema},
+		} {
+			compiler := jsonschema.NewCompiler()
+			if err := compiler.AddResource(source[0], strings.NewReader(source[1])); err != nil {
+				metaSchemasErr = fmt.Errorf("failed to load OpenAPI %s meta-schema: %w", minor, err)
+				return
+			}
+			schema, err := compiler.Compile(source[0])
+			if err != nil {
+				metaSchemasErr = fmt.Errorf("failed to compile OpenAPI %s meta-schema: %w", minor, err)
+				return
+			}
+			metaSchemas[minor] = schema
+		}
+	})
+	if metaSchemasErr != nil {
+		return nil, metaSchemasErr
+	}
+
+	for minor, schema := range metaSchemas {
+		if version == minor || strings.HasPrefix(version, minor+".") {
+			return schema, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported openapi version %q", version)
+}
+
+// docValidationError flattens the validator's error tree into its leaf violations
+func docValidationError(err *jsonschema.ValidationError) *DocValidationError {
+	result := &DocValidationError{}
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			location := e.InstanceLocation
+			if location == "" {
+				location = "/"
+			}
+			result.Problems = append(result.Problems, DocProblem{Location: location, Message: e.Message})
+			return
+		}
+		for _, cause := range e.Causes {
+			walk(cause)
+		}
+	}
+	walk(err)
+	return result
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// minimalDoc returns a valid document with a single operation
+func minimalDoc(version string) *OpenAPIDoc {
+	return &OpenAPIDoc{
+		OpenAPI: version,
+		Info:    OpenAPIInfo{Title: "Test", Version: "1.0.0"},
+		Paths: map[string]PathItem{
+			"/users": {Get: &Operation{
+				Summary:   "List users",
+				Tags:      []string{"users"},
+				Responses: map[string]Response{"200": {Description: "OK"}},
+			}},
+		},
+	}
+}
+
+// TestValidateDoc tests accepting valid 3.0 and 3.1 documents
+func TestValidateDoc(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		if err := ValidateDoc(minimalDoc(version)); err != nil {
+			t.Errorf("%s: Expected a valid document, got %v", version, err)
+		}
+	}
+}
+
+// TestValidateDocReportsProblems tests locating schema violations
+func TestValidateDocReportsProblems(t *testing.T) {
+	doc := minimalDoc("3.0.3")
+	op := doc.Paths["/users"].Get
+	op.Parameters = []Parameter{{Name: "limit", In: "body", Schema: map[string]interface{}{"type": "integer"}}}
+
+	err := ValidateDoc(doc)
+	var verr *DocValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected DocValidationError, got %v", err)
+	}
+	found := false
+	for _, problem := range verr.Problems {
+		if strings.HasPrefix(problem.Location, "/paths/~1users/get/parameters/0") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected a problem at the invalid parameter, got %v", verr)
+	}
+}
+
+// TestValidateDocUnsupportedVersion tests rejecting documents of unsupported versions
+func TestValidateDocUnsupportedVersion(t *testing.T) {
+	if err := ValidateDocJSON([]byte(`{"openapi":"2.0"}`)); err == nil || !strings.Contains(err.Error(), "unsupported openapi version") {
+		t.Errorf("Expected an unsupported version error, got %v", err)
+	}
+}
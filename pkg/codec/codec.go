@@ -0,0 +1,57 @@
+// Package codec provides request body codecs for MessagePack, Protocol Buffers and CBOR
+// Register them with api.RegisterCodec and accept their media types with WithRequestTypes
+package codec
+
+import (
+	"fmt"
+
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+var (
+	// MsgPack decodes application/msgpack bodies, mapping fields by their codec or json tags
+	MsgPack api.Codec = handleCodec{api.MediaTypeMsgPack, &codec.MsgpackHandle{}}
+	// CBOR decodes application/cbor bodies, mapping fields by their codec or json tags
+	CBOR api.Codec = handleCodec{api.MediaTypeCBOR, &codec.CborHandle{}}
+	// Protobuf decodes application/x-protobuf bodies into request models generated by protoc-gen-go
+	Protobuf api.Codec = protobufCodec{}
+)
+
+// RegisterAll registers MsgPack, CBOR and Protobuf
+func RegisterAll() {
+	for _, c := range []api.Codec{MsgPack, CBOR, Protobuf} {
+		api.RegisterCodec(c)
+	}
+}
+
+// handleCodec decodes with a ugorji codec handle
+type handleCodec struct {
+	contentType string
+	handle      codec.Handle
+}
+
+func (c handleCodec) ContentType() string {
+	return c.contentType
+}
+
+func (c handleCodec) Decode(data []byte, v interface{}) error {
+	return codec.NewDecoderBytes(data, c.handle).Decode(v)
+}
+
+// protobufCodec decodes the wire format of protocol buffer messages
+type protobufCodec struct{}
+
+func (protobufCodec) ContentType() string {
+	return api.MediaTypeProtobuf
+}
+
+func (protobufCodec) Decode(data []byte, v interface{}) error {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%T is not a protocol buffer message", v)
+	}
+	return proto.Unmarshal(data, message)
+}
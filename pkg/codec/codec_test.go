@@ -0,0 +1,74 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type createUser struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+// encode encodes v with a ugorji codec handle
+func encode(t *testing.T, handle codec.Handle, v interface{}) []byte {
+	var data []byte
+	if err := codec.NewEncoderBytes(&data, handle).Encode(v); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	return data
+}
+
+// TestCodecs tests decoding request bodies with the built-in codecs
+func TestCodecs(t *testing.T) {
+	payload := map[string]interface{}{"name": "Ada", "age": 36}
+	tests := []struct {
+		name  string
+		codec api.Codec
+		data  []byte
+	}{
+		{"msgpack", MsgPack, encode(t, &codec.MsgpackHandle{}, payload)},
+		{"cbor", CBOR, encode(t, &codec.CborHandle{}, payload)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var user createUser
+			if err := tt.codec.Decode(tt.data, &user); err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if user != (createUser{Name: "Ada", Age: 36}) {
+				t.Errorf("Unexpected user %+v", user)
+			}
+			if err := tt.codec.Decode([]byte{0xc1}, &user); err == nil {
+				t.Error("Expected an error for a malformed body")
+			}
+		})
+	}
+
+	data, err := proto.Marshal(wrapperspb.String("Ada"))
+	if err != nil {
+		t.Fatalf("proto.Marshal failed: %v", err)
+	}
+	var message wrapperspb.StringValue
+	if err := Protobuf.Decode(data, &message); err != nil || message.GetValue() != "Ada" {
+		t.Errorf("Unexpected protobuf decoding %q: %v", message.GetValue(), err)
+	}
+	if err := Protobuf.Decode(data, &createUser{}); err == nil {
+		t.Error("Expected an error for a non-message model")
+	}
+}
+
+// TestRegisterAll tests looking up the registered codecs by Content-Type
+func TestRegisterAll(t *testing.T) {
+	RegisterAll()
+	for _, contentType := range []string{"application/msgpack", "application/cbor", "application/x-protobuf; proto=User"} {
+		if _, ok := api.CodecFor(contentType); !ok {
+			t.Errorf("Expected a codec for %s", contentType)
+		}
+	}
+}
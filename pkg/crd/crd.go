@@ -0,0 +1,222 @@
+// Package crd converts Go structs into the structural OpenAPI v3 schemas embedded in Kubernetes
+// CustomResourceDefinition manifests (spec.versions[].schema.openAPIV3Schema), reusing the schema
+// reflection engine so operators built alongside REST APIs share their models
+//
+// Keywords Kubernetes rejects are pruned, free-form values are marked x-kubernetes-preserve-unknown-fields,
+// and fields can carry further x-kubernetes-* markers through the `crd` struct tag:
+//
+//	Ports []Port `json:"ports" crd:"listType=map,listMapKey=name"`
+//	Port  Value  `json:"port" crd:"intOrString"`
+//	Raw   Object `json:"raw" crd:"preserveUnknownFields,embeddedResource"`
+//	Tags  map[string]string `json:"tags" crd:"mapType=atomic"`
+package crd
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// Schema generates the structural schema of a Go model
+func Schema(v interface{}) (map[string]interface{}, error) {
+	schema, err := api.SafeSchemaFromStruct(v)
+	if err != nil {
+		return nil, err
+	}
+	if t := reflect.TypeOf(v); t != nil {
+		applyMarkers(t, schema)
+	}
+	return structural(schema), nil
+}
+
+// ResourceSchema generates the openAPIV3Schema of a custom resource with the given spec and status models
+// Status may be nil for resources without a status subresource
+func ResourceSchema(spec, status interface{}) (map[string]interface{}, error) {
+	props := map[string]interface{}{
+		"apiVersion": map[string]interface{}{"type": "string"},
+		"kind":       map[string]interface{}{"type": "string"},
+		"metadata":   map[string]interface{}{"type": "object"},
+	}
+	specSchema, err := Schema(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate spec schema: %w", err)
+	}
+	props["spec"] = specSchema
+	if status != nil {
+		statusSchema, err := Schema(status)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate status schema: %w", err)
+		}
+		props["status"] = statusSchema
+	}
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": props,
+	}, nil
+}
+
+// supportedKeywords are the schema keywords Kubernetes accepts in CRD validation schemas
+var supportedKeywords = map[string]bool{
+	"type": true, "format": true, "title": true, "description": true, "default": true, "example": true,
+	"enum": true, "nullable": true, "externalDocs": true,
+	"maximum": true, "exclusiveMaximum": true, "minimum": true, "exclusiveMinimum": true, "multipleOf": true,
+	"maxLength": true, "minLength": true, "pattern": true,
+	"maxItems": true, "minItems": true, "items": true,
+	"maxProperties": true, "minProperties": true, "required": true, "properties": true, "additionalProperties": true,
+	"allOf": true, "oneOf": true, "anyOf": true, "not": true,
+}
+
+// structural prunes a schema into a Kubernetes structural schema
+func structural(schema map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		if supportedKeywords[key] || strings.HasPrefix(key, "x-kubernetes-") {
+			out[key] = value
+		}
+	}
+	if value, ok := schema["const"]; ok {
+		out["enum"] = []interface{}{value}
+	}
+
+	// Kubernetes only allows value validations in oneOf/anyOf/allOf branches, while generated branches
+	// are full schemas: they are dropped, and untyped polymorphic values are left open instead
+	for _, key := range []string{"oneOf", "anyOf", "allOf"} {
+		if _, ok := out[key]; ok {
+			delete(out, key)
+			if _, typed := out["type"]; !typed {
+				return preserveUnknown(out)
+			}
+		}
+	}
+	delete(out, "not")
+
+	if props, ok := out["properties"].(map[string]interface{}); ok {
+		pruned := make(map[string]interface{}, len(props))
+		for name, prop := range props {
+			if propSchema, ok := prop.(map[string]interface{}); ok {
+				pruned[name] = structural(propSchema)
+			}
+		}
+		out["properties"] = pruned
+		out["type"] = "object"
+		// properties and additionalProperties are mutually exclusive
+		delete(out, "additionalProperties")
+	}
+
+	switch additional := out["additionalProperties"].(type) {
+	case bool:
+		delete(out, "additionalProperties")
+		if additional {
+			out["x-kubernetes-preserve-unknown-fields"] = true
+		}
+	case map[string]interface{}:
+		values := structural(additional)
+		// A map of open values is itself an open object
+		if values["x-kubernetes-preserve-unknown-fields"] == true && values["properties"] == nil && values["additionalProperties"] == nil {
+			delete(out, "additionalProperties")
+			out["x-kubernetes-preserve-unknown-fields"] = true
+		} else {
+			out["additionalProperties"] = values
+		}
+	}
+
+	if items, ok := out["items"].(map[string]interface{}); ok {
+		out["items"] = structural(items)
+	}
+
+	// Every node needs a type unless it is explicitly left open or int-or-string
+	if _, ok := out["type"]; !ok {
+		if out["x-kubernetes-int-or-string"] != true {
+			return preserveUnknown(out)
+		}
+	}
+	return out
+}
+
+// preserveUnknown turns a node into an open value that keeps unknown fields, keeping its documentation
+func preserveUnknown(schema map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{"x-kubernetes-preserve-unknown-fields": true}
+	if t, ok := schema["type"]; ok {
+		out["type"] = t
+	}
+	for _, key := range []string{"description", "title", "nullable"} {
+		if value, ok := schema[key]; ok {
+			out[key] = value
+		}
+	}
+	return out
+}
+
+// applyMarkers walks a Go type alongside its schema, adding the x-kubernetes-* markers of `crd` tags
+func applyMarkers(t reflect.Type, schema map[string]interface{}) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			applyMarkers(t.Elem(), items)
+		}
+	case reflect.Map:
+		if values, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+			applyMarkers(t.Elem(), values)
+		}
+	case reflect.Struct:
+		props, ok := schema["properties"].(map[string]interface{})
+		if !ok {
+			return
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" && (field.Anonymous || strings.Contains(opts, "inline")) {
+				// Embedded fields are flattened into the parent's properties
+				applyMarkers(field.Type, schema)
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			prop, ok := props[name].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			applyMarkers(field.Type, prop)
+			applyTag(prop, field.Tag.Get("crd"))
+		}
+	}
+}
+
+// applyTag adds the markers listed in a `crd` struct tag to a property schema
+func applyTag(schema map[string]interface{}, tag string) {
+	if tag == "" {
+		return
+	}
+	for _, marker := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(marker), "=")
+		switch key {
+		case "listType":
+			schema["x-kubernetes-list-type"] = value
+		case "listMapKey":
+			keys, _ := schema["x-kubernetes-list-map-keys"].([]string)
+			schema["x-kubernetes-list-map-keys"] = append(keys, value)
+		case "mapType":
+			schema["x-kubernetes-map-type"] = value
+		case "intOrString":
+			for _, k := range []string{"type", "format", "properties", "required"} {
+				delete(schema, k)
+			}
+			schema["x-kubernetes-int-or-string"] = true
+		case "preserveUnknownFields":
+			schema["x-kubernetes-preserve-unknown-fields"] = true
+		case "embeddedResource":
+			schema["x-kubernetes-embedded-resource"] = true
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package crd
+
+import (
+	"reflect"
+	"testing"
+)
+
+type port struct {
+	Name   string `json:"name"`
+	Number int    `json:"number"`
+}
+
+type embeddedMeta struct {
+	Labels map[string]string `json:"labels,omitempty" crd:"mapType=atomic"`
+}
+
+type widgetSpec struct {
+	embeddedMeta `json:",inline"`
+	Replicas     int                    `json:"replicas" validate:"min=1"`
+	Ports        []port                 `json:"ports" crd:"listType=map,listMapKey=name"`
+	Target       string                 `json:"target" crd:"intOrString"`
+	Config       map[string]interface{} `json:"config,omitempty"`
+	Password     string                 `json:"password" sensitive:"true"`
+}
+
+type widgetStatus struct {
+	Ready bool `json:"ready"`
+}
+
+// TestSchema tests pruning and markers of a structural schema
+func TestSchema(t *testing.T) {
+	schema, err := Schema(widgetSpec{})
+	if err != nil {
+		t.Fatalf("Schema failed: %v", err)
+	}
+	props := schema["properties"].(map[string]interface{})
+
+	ports := props["ports"].(map[string]interface{})
+	if ports["x-kubernetes-list-type"] != "map" || !reflect.DeepEqual(ports["x-kubernetes-list-map-keys"], []string{"name"}) {
+		t.Errorf("Expected list markers, got %v", ports)
+	}
+
+	target := props["target"].(map[string]interface{})
+	if _, ok := target["type"]; ok || target["x-kubernetes-int-or-string"] != true {
+		t.Errorf("Expected untyped int-or-string, got %v", target)
+	}
+
+	config := props["config"].(map[string]interface{})
+	if _, ok := config["additionalProperties"]; ok || config["x-kubernetes-preserve-unknown-fields"] != true {
+		t.Errorf("Expected free-form object to preserve unknown fields, got %v", config)
+	}
+
+	labels := props["labels"].(map[string]interface{})
+	if labels["x-kubernetes-map-type"] != "atomic" {
+		t.Errorf("Expected marker on inlined field, got %v", labels)
+	}
+
+	password := props["password"].(map[string]interface{})
+	for _, key := range []string{"writeOnly", "x-sensitive"} {
+		if _, ok := password[key]; ok {
+			t.Errorf("Expected unsupported keyword %s to be pruned", key)
+		}
+	}
+}
+
+// TestStructuralPolymorphic tests that untyped oneOf nodes are left open
+func TestStructuralPolymorphic(t *testing.T) {
+	schema := structural(map[string]interface{}{
+		"description": "Payload",
+		"oneOf": []interface{}{
+			map[string]interface{}{"type": "object", "properties": map[string]interface{}{}},
+		},
+		"discriminator": map[string]interface{}{"propertyName": "type"},
+	})
+	want := map[string]interface{}{"description": "Payload", "x-kubernetes-preserve-unknown-fields": true}
+	if !reflect.DeepEqual(schema, want) {
+		t.Errorf("Expected %v, got %v", want, schema)
+	}
+}
+
+// TestResourceSchema tests the root schema of a custom resource
+func TestResourceSchema(t *testing.T) {
+	schema, err := ResourceSchema(widgetSpec{}, widgetStatus{})
+	if err != nil {
+		t.Fatalf("ResourceSchema failed: %v", err)
+	}
+	props := schema["properties"].(map[string]interface{})
+	for _, name := range []string{"apiVersion", "kind", "metadata", "spec", "status"} {
+		if _, ok := props[name]; !ok {
+			t.Errorf("Expected %s property", name)
+		}
+	}
+}
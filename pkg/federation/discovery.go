@@ -0,0 +1,332 @@
+package federation
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Annotations (Kubernetes) and tag keys (Consul, as key=value) services announce their document with
+const (
+	AnnotationPath   = "openapi.path"   // Path of the service's document, e.g. /swagger.json (required)
+	AnnotationPrefix = "openapi.prefix" // Gateway prefix of the service, /<service name> by default
+	AnnotationPort   = "openapi.port"   // Port serving the document, the service's first port by default
+	AnnotationScheme = "openapi.scheme" // Scheme of the document URL, http by default
+)
+
+// DiscoveryTimeout bounds each request of the discoverers' default clients
+const DiscoveryTimeout = 10 * time.Second
+
+// Discoverer finds the services to aggregate
+type Discoverer interface {
+	Discover(ctx context.Context) ([]Service, error)
+}
+
+// WithDiscovery adds discoverers consulted on every refresh; services added with AddService win over
+// discovered services of the same name, and earlier discoverers over later ones
+// Chain call: federation.NewServer(title, version).WithDiscovery(federation.NewConsulDiscovery(addr))
+func (s *Server) WithDiscovery(discoverers ...Discoverer) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.discoverers = append(s.discoverers, discoverers...)
+	s.discovered = append(s.discovered, make([][]Service, len(discoverers))...)
+	return s
+}
+
+// discover runs the discoverers, keeping the previous services of those that fail
+func (s *Server) discover(ctx context.Context) []error {
+	s.mu.RLock()
+	discoverers := append([]Discoverer(nil), s.discoverers...)
+	s.mu.RUnlock()
+
+	var errs []error
+	for i, discoverer := range discoverers {
+		services, err := discoverer.Discover(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("discovery: %w", err))
+			continue
+		}
+		s.mu.Lock()
+		s.discovered[i] = services
+		s.mu.Unlock()
+	}
+	return errs
+}
+
+// currentServices returns the added services followed by the discovered ones
+func (s *Server) currentServices() []Service {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.servicesLocked()
+}
+
+// servicesLocked returns the added and discovered services, named once; s.mu must be held
+func (s *Server) servicesLocked() []Service {
+	services := append([]Service(nil), s.services...)
+	seen := make(map[string]bool, len(services))
+	for _, service := range services {
+		seen[service.Name] = true
+	}
+	for _, found := range s.discovered {
+		for _, service := range found {
+			if !seen[service.Name] {
+				services = append(services, service)
+				seen[service.Name] = true
+			}
+		}
+	}
+	return services
+}
+
+// announcedService builds a service from its announcement, false when it announces no document
+func announcedService(name, host string, port int, announcement map[string]string) (Service, bool) {
+	path, ok := announcement[AnnotationPath]
+	if !ok || path == "" {
+		return Service{}, false
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if value, err := strconv.Atoi(announcement[AnnotationPort]); err == nil {
+		port = value
+	}
+	scheme := announcement[AnnotationScheme]
+	if scheme == "" {
+		scheme = "http"
+	}
+	prefix, ok := announcement[AnnotationPrefix]
+	if !ok {
+		prefix = "/" + name
+	}
+	return Service{
+		Name:   name,
+		URL:    scheme + "://" + net.JoinHostPort(host, strconv.Itoa(port)) + path,
+		Prefix: prefix,
+	}, true
+}
+
+// KubernetesDiscovery finds Kubernetes services annotated with openapi.path
+type KubernetesDiscovery struct {
+	apiURL    string
+	token     string
+	namespace string
+	client    *http.Client
+}
+
+// NewKubernetesDiscovery discovers the services of namespace through the API server at apiURL, authenticating
+// with a bearer token when set; with an empty namespace every namespace is listed and services are named
+// <namespace>.<name> so equally named services of different namespaces don't collide
+func NewKubernetesDiscovery(apiURL, token, namespace string) *KubernetesDiscovery {
+	return &KubernetesDiscovery{
+		apiURL:    strings.TrimSuffix(apiURL, "/"),
+		token:     token,
+		namespace: namespace,
+		client:    &http.Client{Timeout: DiscoveryTimeout},
+	}
+}
+
+// Files of the service account mounted into pods
+const (
+	serviceAccountToken = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	serviceAccountCA    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+)
+
+// NewInClusterKubernetesDiscovery discovers the services of namespace from inside a pod, with its service
+// account; the account needs to list services
+func NewInClusterKubernetesDiscovery(namespace string) (*KubernetesDiscovery, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("not running in a Kubernetes cluster")
+	}
+	token, err := os.ReadFile(serviceAccountToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account token: %w", err)
+	}
+	ca, err := os.ReadFile(serviceAccountCA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("invalid service account CA")
+	}
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}},
+		Timeout:   DiscoveryTimeout,
+	}
+	discovery := NewKubernetesDiscovery("https://"+net.JoinHostPort(host, port), strings.TrimSpace(string(token)), namespace)
+	return discovery.WithClient(client), nil
+}
+
+// WithClient sets the HTTP client of API server requests, one with a DiscoveryTimeout timeout by default
+// Chain call: federation.NewKubernetesDiscovery(url, token, namespace).WithClient(client)
+func (d *KubernetesDiscovery) WithClient(client *http.Client) *KubernetesDiscovery {
+	d.client = client
+	return d
+}
+
+// Discover implements Discoverer; services are reached through their cluster DNS name
+func (d *KubernetesDiscovery) Discover(ctx context.Context) ([]Service, error) {
+	location := d.apiURL + "/api/v1/services"
+	if d.namespace != "" {
+		location = d.apiURL + "/api/v1/namespaces/" + url.PathEscape(d.namespace) + "/services"
+	}
+	header := http.Header{}
+	if d.token != "" {
+		header.Set("Authorization", "Bearer "+d.token)
+	}
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name        string            `json:"name"`
+				Namespace   string            `json:"namespace"`
+				Annotations map[string]string `json:"annotations"`
+			} `json:"metadata"`
+			Spec struct {
+				Ports []struct {
+					Port int `json:"port"`
+				} `json:"ports"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	if err := getJSON(ctx, d.client, location, header, &list); err != nil {
+		return nil, fmt.Errorf("failed to list Kubernetes services: %w", err)
+	}
+
+	services := make([]Service, 0)
+	for _, item := range list.Items {
+		port := 80
+		if len(item.Spec.Ports) > 0 {
+			port = item.Spec.Ports[0].Port
+		}
+		name := item.Metadata.Name
+		if d.namespace == "" {
+			name = item.Metadata.Namespace + "." + name
+		}
+		host := item.Metadata.Name + "." + item.Metadata.Namespace + ".svc"
+		if service, ok := announcedService(name, host, port, item.Metadata.Annotations); ok {
+			services = append(services, service)
+		}
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
+	return services, nil
+}
+
+// ConsulDiscovery finds Consul services tagged openapi.path=<path>
+type ConsulDiscovery struct {
+	address string
+	token   string
+	client  *http.Client
+}
+
+// NewConsulDiscovery discovers services through the Consul agent at address (e.g., http://localhost:8500)
+func NewConsulDiscovery(address string) *ConsulDiscovery {
+	return &ConsulDiscovery{address: strings.TrimSuffix(address, "/"), client: &http.Client{Timeout: DiscoveryTimeout}}
+}
+
+// WithToken sets the ACL token of Consul requests
+// Chain call: federation.NewConsulDiscovery(address).WithToken(token)
+func (d *ConsulDiscovery) WithToken(token string) *ConsulDiscovery {
+	d.token = token
+	return d
+}
+
+// WithClient sets the HTTP client of Consul requests, one with a DiscoveryTimeout timeout by default
+// Chain call: federation.NewConsulDiscovery(address).WithClient(client)
+func (d *ConsulDiscovery) WithClient(client *http.Client) *ConsulDiscovery {
+	d.client = client
+	return d
+}
+
+// Discover implements Discoverer; each service is reached through its first passing instance
+func (d *ConsulDiscovery) Discover(ctx context.Context) ([]Service, error) {
+	header := http.Header{}
+	if d.token != "" {
+		header.Set("X-Consul-Token", d.token)
+	}
+	var catalog map[string][]string
+	if err := getJSON(ctx, d.client, d.address+"/v1/catalog/services", header, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to list Consul services: %w", err)
+	}
+
+	services := make([]Service, 0)
+	for _, name := range sortedKeys(catalog) {
+		if _, ok := parseTags(catalog[name])[AnnotationPath]; !ok {
+			continue
+		}
+		var instances []struct {
+			Node struct {
+				Address string `json:"Address"`
+			} `json:"Node"`
+			Service struct {
+				Address string   `json:"Address"`
+				Port    int      `json:"Port"`
+				Tags    []string `json:"Tags"`
+			} `json:"Service"`
+		}
+		location := d.address + "/v1/health/service/" + url.PathEscape(name) + "?passing=true"
+		if err := getJSON(ctx, d.client, location, header, &instances); err != nil {
+			return nil, fmt.Errorf("failed to look up Consul service %s: %w", name, err)
+		}
+		if len(instances) == 0 {
+			continue
+		}
+		instance := instances[0]
+		host := instance.Service.Address
+		if host == "" {
+			host = instance.Node.Address
+		}
+		if service, ok := announcedService(name, host, instance.Service.Port, parseTags(instance.Service.Tags)); ok {
+			services = append(services, service)
+		}
+	}
+	return services, nil
+}
+
+// parseTags reads key=value tags
+func parseTags(tags []string) map[string]string {
+	values := make(map[string]string)
+	for _, tag := range tags {
+		if key, value, ok := strings.Cut(tag, "="); ok {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// getJSON fetches and decodes a JSON resource
+func getJSON(ctx context.Context, client *http.Client, location string, header http.Header, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, location)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
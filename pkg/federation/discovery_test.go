@@ -0,0 +1,179 @@
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestKubernetesDiscovery tests discovering annotated Kubernetes services
+func TestKubernetesDiscovery(t *testing.T) {
+	var gotPath, gotAuth string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth = r.URL.Path, r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"items": [
+			{"metadata": {"name": "orders", "namespace": "shop", "annotations": {"openapi.path": "/swagger.json"}},
+			 "spec": {"ports": [{"port": 8080}, {"port": 9090}]}},
+			{"metadata": {"name": "billing", "namespace": "shop",
+			  "annotations": {"openapi.path": "docs/openapi.json", "openapi.prefix": "/pay", "openapi.port": "9000", "openapi.scheme": "https"}},
+			 "spec": {"ports": [{"port": 443}]}},
+			{"metadata": {"name": "redis", "namespace": "shop"}, "spec": {"ports": [{"port": 6379}]}}
+		]}`))
+	}))
+	defer apiServer.Close()
+
+	tests := []struct {
+		namespace string
+		wantPath  string
+		want      []Service
+	}{
+		{"shop", "/api/v1/namespaces/shop/services", []Service{
+			{Name: "billing", URL: "https://billing.shop.svc:9000/docs/openapi.json", Prefix: "/pay"},
+			{Name: "orders", URL: "http://orders.shop.svc:8080/swagger.json", Prefix: "/orders"},
+		}},
+		{"", "/api/v1/services", []Service{
+			{Name: "shop.billing", URL: "https://billing.shop.svc:9000/docs/openapi.json", Prefix: "/pay"},
+			{Name: "shop.orders", URL: "http://orders.shop.svc:8080/swagger.json", Prefix: "/shop.orders"},
+		}},
+	}
+
+	for _, tt := range tests {
+		services, err := NewKubernetesDiscovery(apiServer.URL, "token", tt.namespace).Discover(context.Background())
+		if err != nil {
+			t.Fatalf("Discover failed: %v", err)
+		}
+		if gotPath != tt.wantPath || gotAuth != "Bearer token" {
+			t.Errorf("Expected an authorized request to %s, got %s (%s)", tt.wantPath, gotPath, gotAuth)
+		}
+		if !reflect.DeepEqual(services, tt.want) {
+			t.Errorf("Expected services %+v, got %+v", tt.want, services)
+		}
+	}
+	if client := NewKubernetesDiscovery(apiServer.URL, "", "").client; client.Timeout != DiscoveryTimeout {
+		t.Errorf("Expected default client timeout %v, got %v", DiscoveryTimeout, client.Timeout)
+	}
+}
+
+// TestConsulDiscovery tests aggregating services found through Consul tags
+func TestConsulDiscovery(t *testing.T) {
+	orders := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(ordersDoc))
+	}))
+	defer orders.Close()
+	u, _ := url.Parse(orders.URL)
+	host, port, _ := net.SplitHostPort(u.Host)
+	portNumber, _ := strconv.Atoi(port)
+	consul := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Consul-Token") != "acl" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/catalog/services":
+			_, _ = w.Write([]byte(`{"consul": [], "orders": ["v1", "openapi.path=/"], "billing": ["openapi.path=/swagger.json"]}`))
+		case "/v1/health/service/orders":
+			if r.URL.Query().Get("passing") != "true" {
+				t.Error("Expected the health query to ask for passing instances")
+			}
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{
+				"Node":    map[string]string{"Address": "10.0.0.1"},
+				"Service": map[string]interface{}{"Address": host, "Port": portNumber, "Tags": []string{"openapi.path=/", "openapi.prefix=/orders"}},
+			}})
+		case "/v1/health/service/billing":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer consul.Close()
+
+	services, err := NewConsulDiscovery(consul.URL).WithToken("acl").Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	// billing has no passing instance
+	if want := []Service{{Name: "orders", URL: orders.URL + "/", Prefix: "/orders"}}; !reflect.DeepEqual(services, want) {
+		t.Fatalf("Expected services %+v, got %+v", want, services)
+	}
+	if _, err := NewConsulDiscovery(consul.URL).Discover(context.Background()); err == nil {
+		t.Error("Expected discovery without token to fail")
+	}
+
+	server := NewServer("Developer Portal", "1.0.0").WithDiscovery(NewConsulDiscovery(consul.URL).WithToken("acl"))
+	if err := server.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	if !strings.Contains(string(server.Document()), `"/orders/api/orders"`) {
+		t.Errorf("Expected the combined document to include the discovered service, got:\n%s", server.Document())
+	}
+}
+
+// flakyDiscovery returns its services until it is broken
+type flakyDiscovery struct {
+	services []Service
+	broken   bool
+}
+
+func (d *flakyDiscovery) Discover(context.Context) ([]Service, error) {
+	if d.broken {
+		return nil, errors.New("registry unreachable")
+	}
+	return d.services, nil
+}
+
+// TestServerDiscovery tests merging added and discovered services
+func TestServerDiscovery(t *testing.T) {
+	services := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orders":
+			_, _ = w.Write([]byte(ordersDoc))
+		case "/billing":
+			_, _ = w.Write([]byte(billingDoc))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer services.Close()
+	discovery := &flakyDiscovery{services: []Service{
+		{Name: "orders", URL: "http://ignored.invalid/", Prefix: "/ignored"},
+		{Name: "billing", URL: services.URL + "/billing", Prefix: "/billing"},
+	}}
+
+	server := NewServer("Developer Portal", "1.0.0").
+		AddService(Service{Name: "orders", URL: services.URL + "/orders", Prefix: "/orders"}).
+		WithDiscovery(discovery)
+	if err := server.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	names := func() []string {
+		var names []string
+		for _, status := range server.Status() {
+			names = append(names, status.Name+" "+status.URL)
+		}
+		return names
+	}
+	want := []string{"orders " + services.URL + "/orders", "billing " + services.URL + "/billing"}
+	if got := names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected services %v, got %v", want, got)
+	}
+
+	// Services discovered before stay while discovery fails
+	discovery.broken = true
+	if err := server.Refresh(context.Background()); err == nil || !strings.Contains(err.Error(), "registry unreachable") {
+		t.Errorf("Expected the discovery failure, got %v", err)
+	}
+	if got := names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected services %v after a failed discovery, got %v", want, got)
+	}
+	if !strings.Contains(string(server.Document()), `"/billing/v1/invoices"`) {
+		t.Error("Expected the discovered service to be kept after a failed discovery")
+	}
+}
@@ -0,0 +1,506 @@
+// Package federation aggregates the documents of several services into one developer portal document
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// Service is a service whose document is aggregated
+type Service struct {
+	Name   string // Identifies the service in conflicts, renamed components and operations' x-service
+	URL    string // Location of the service's swagger.json
+	Prefix string // Path prefix the gateway routes to the service (e.g., /orders), prepended to its paths
+}
+
+// Conflict reports definitions two services declare differently and how the combined document settles them
+type Conflict struct {
+	Kind       string   `json:"kind"` // "operation", "operationId" or the component kind, e.g. "schemas"
+	Name       string   `json:"name"`
+	Services   []string `json:"services"`   // Owner of the kept definition first
+	Resolution string   `json:"resolution"` // What the combined document does about it
+}
+
+// ServiceStatus is the state of a service's document at the last refresh
+type ServiceStatus struct {
+	Name        string    `json:"name"`
+	URL         string    `json:"url"`
+	Operations  int       `json:"operations"`
+	LastRefresh time.Time `json:"lastRefresh"`     // Last successful fetch, zero before the first
+	Error       string    `json:"error,omitempty"` // Error of the last fetch; the previous document stays in use
+}
+
+// Server periodically fetches the documents of its services and serves their combination
+type Server struct {
+	title       string
+	version     string
+	description string
+	serverURL   string
+	client      *http.Client
+
+	mu          sync.RWMutex
+	services    []Service
+	discoverers []Discoverer
+	discovered  [][]Service                // Services last found by each discoverer
+	docs        map[string]*api.OpenAPIDoc // Last fetched document by service
+	status      map[string]*ServiceStatus
+	combined    []byte
+	conflicts   []Conflict
+}
+
+// NewServer creates a federation server publishing the combined document under title and version
+func NewServer(title, version string) *Server {
+	return &Server{
+		title:     title,
+		version:   version,
+		serverURL: "/",
+		client:    http.DefaultClient,
+		docs:      make(map[string]*api.OpenAPIDoc),
+		status:    make(map[string]*ServiceStatus),
+	}
+}
+
+// WithDescription sets the description of the combined document
+// Chain call: federation.NewServer(title, version).WithDescription("All public APIs")
+func (s *Server) WithDescription(description string) *Server {
+	s.description = description
+	return s
+}
+
+// WithServerURL sets the gateway URL the prefixed paths are served from, "/" by default
+// Chain call: federation.NewServer(title, version).WithServerURL("https://api.example.com")
+func (s *Server) WithServerURL(serverURL string) *Server {
+	s.serverURL = serverURL
+	return s
+}
+
+// WithClient sets the HTTP client fetching service documents, http.DefaultClient by default
+// Chain call: federation.NewServer(title, version).WithClient(&http.Client{Timeout: 5 * time.Second})
+func (s *Server) WithClient(client *http.Client) *Server {
+	s.client = client
+	return s
+}
+
+// AddService adds a service to aggregate from the next refresh on
+// Chain call: federation.NewServer(title, version).AddService(orders).AddService(billing)
+func (s *Server) AddService(service Service) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.services = append(s.services, service)
+	return s
+}
+
+// Refresh discovers services, fetches every service document and rebuilds the combined document
+// Services failing to answer keep their previous document, and discoverers failing keep their previous
+// services; their errors are returned joined
+func (s *Server) Refresh(ctx context.Context) error {
+	discoveryErrs := s.discover(ctx)
+	services := s.currentServices()
+
+	docs := make([]*api.OpenAPIDoc, len(services))
+	errs := make([]error, len(services))
+	var wg sync.WaitGroup
+	for i, service := range services {
+		wg.Add(1)
+		go func(i int, service Service) {
+			defer wg.Done()
+			docs[i], errs[i] = s.fetch(ctx, service.URL)
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("service %s: %w", service.Name, errs[i])
+			}
+		}(i, service)
+	}
+	wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now().UTC()
+	for i, service := range services {
+		status, ok := s.status[service.Name]
+		if !ok {
+			status = &ServiceStatus{Name: service.Name}
+			s.status[service.Name] = status
+		}
+		status.URL = service.URL
+		if errs[i] != nil {
+			status.Error = errs[i].Error()
+			continue
+		}
+		s.docs[service.Name] = docs[i]
+		status.Error = ""
+		status.LastRefresh = now
+	}
+
+	combined, conflicts, err := s.combine(services)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(combined, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal combined document: %w", err)
+	}
+	s.combined = data
+	s.conflicts = conflicts
+	return errors.Join(append(discoveryErrs, errs...)...)
+}
+
+// Start refreshes the combined document every interval until ctx is done; call Refresh first to serve it
+// right away
+func (s *Server) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = s.Refresh(ctx)
+			}
+		}
+	}()
+}
+
+// fetch reads and decodes a service document
+func (s *Server) fetch(ctx context.Context, location string) (*api.OpenAPIDoc, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, location)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var doc api.OpenAPIDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid document at %s: %w", location, err)
+	}
+	return &doc, nil
+}
+
+// Document returns the combined document, nil before the first refresh
+func (s *Server) Document() []byte {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.combined
+}
+
+// Conflicts returns the conflicts found by the last refresh
+func (s *Server) Conflicts() []Conflict {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Conflict(nil), s.conflicts...)
+}
+
+// Status returns the state of every service: added ones in order, then discovered ones
+func (s *Server) Status() []ServiceStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	services := s.servicesLocked()
+	statuses := make([]ServiceStatus, 0, len(services))
+	for _, service := range services {
+		status := ServiceStatus{Name: service.Name, URL: service.URL}
+		if known, ok := s.status[service.Name]; ok {
+			status = *known
+		}
+		if doc := s.docs[service.Name]; doc != nil {
+			status.Operations = countOperations(doc)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// ServeHTTP serves the combined document (e.g., mount at /swagger.json)
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	data := s.Document()
+	if data == nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "combined document not available yet"})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_, _ = w.Write(data)
+}
+
+// StatusHandler serves the service states and conflicts of the last refresh as JSON
+func (s *Server) StatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"services": s.Status(), "conflicts": s.Conflicts()})
+	})
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// combine builds the combined document from the last document of each service, in service order
+// Earlier services keep their definitions; later conflicting components and operationIds are renamed
+// to <service>.<name>, and later duplicate operations are dropped
+func (s *Server) combine(services []Service) (*api.OpenAPIDoc, []Conflict, error) {
+	combined := &api.OpenAPIDoc{
+		OpenAPI: "3.0.0",
+		Info:    api.OpenAPIInfo{Title: s.title, Version: s.version, Description: s.description},
+		Servers: []api.OpenAPIServer{{URL: s.serverURL}},
+		Paths:   make(map[string]api.PathItem),
+	}
+	var conflicts []Conflict
+	components := make(map[string]map[string]interface{})
+	owners := make(map[string]string)          // Service owning each kind/name component
+	operationOwners := make(map[string]string) // Service owning each operation and operationId
+	tags := make(map[string]bool)
+
+	for _, service := range services {
+		doc := s.docs[service.Name]
+		if doc == nil {
+			continue
+		}
+		raw, err := toGeneric(doc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("service %s: %w", service.Name, err)
+		}
+
+		// Components declared differently by an earlier service are renamed in this one
+		renames := make(map[string]string)
+		serviceComponents, _ := raw["components"].(map[string]interface{})
+		for _, kind := range sortedKeys(serviceComponents) {
+			entries, _ := serviceComponents[kind].(map[string]interface{})
+			for _, name := range sortedKeys(entries) {
+				existing, ok := components[kind][name]
+				if !ok || jsonEqual(existing, entries[name]) {
+					continue
+				}
+				renamed := service.Name + "." + name
+				renames[kind+"/"+name] = renamed
+				conflicts = append(conflicts, Conflict{
+					Kind:       kind,
+					Name:       name,
+					Services:   []string{owners[kind+"/"+name], service.Name},
+					Resolution: "renamed to " + renamed,
+				})
+			}
+		}
+		renameComponents(raw, renames)
+
+		var renamed api.OpenAPIDoc
+		if err := fromGeneric(raw, &renamed); err != nil {
+			return nil, nil, fmt.Errorf("service %s: %w", service.Name, err)
+		}
+		serviceComponents, _ = raw["components"].(map[string]interface{})
+		for kind, value := range serviceComponents {
+			entries, _ := value.(map[string]interface{})
+			for name, entry := range entries {
+				if components[kind] == nil {
+					components[kind] = make(map[string]interface{})
+				}
+				if _, ok := components[kind][name]; !ok {
+					components[kind][name] = entry
+					owners[kind+"/"+name] = service.Name
+				}
+			}
+		}
+
+		base := strings.TrimSuffix(service.Prefix, "/")
+		if len(renamed.Servers) > 0 {
+			base += serverPath(renamed.Servers[0].URL)
+		}
+		for _, path := range sortedKeys(renamed.Paths) {
+			item := renamed.Paths[path]
+			target := base + path
+			merged := combined.Paths[target]
+			for _, method := range api.SupportedMethods {
+				op := item.Operation(method)
+				if op == nil {
+					continue
+				}
+				key := method + " " + target
+				if owner, ok := operationOwners[key]; ok {
+					conflicts = append(conflicts, Conflict{
+						Kind:       "operation",
+						Name:       key,
+						Services:   []string{owner, service.Name},
+						Resolution: "kept the operation of " + owner,
+					})
+					continue
+				}
+				operationOwners[key] = service.Name
+				if op.OperationID != "" {
+					if owner, ok := operationOwners["#"+op.OperationID]; ok {
+						renamedID := service.Name + "." + op.OperationID
+						conflicts = append(conflicts, Conflict{
+							Kind:       "operationId",
+							Name:       op.OperationID,
+							Services:   []string{owner, service.Name},
+							Resolution: "renamed to " + renamedID,
+						})
+						op.OperationID = renamedID
+					}
+					operationOwners["#"+op.OperationID] = service.Name
+				}
+				// The combined document has no global security, so operations carry their service's
+				if op.Security == nil && renamed.Security != nil {
+					op.Security = renamed.Security
+				}
+				if op.Extensions == nil {
+					op.Extensions = make(map[string]interface{})
+				}
+				op.Extensions["x-service"] = service.Name
+				merged.SetOperation(method, op)
+			}
+			if len(merged.Operations()) > 0 {
+				combined.Paths[target] = merged
+			}
+		}
+
+		for _, tag := range renamed.Tags {
+			if !tags[tag.Name] {
+				combined.Tags = append(combined.Tags, tag)
+				tags[tag.Name] = true
+			}
+		}
+	}
+
+	if len(components) > 0 {
+		combined.Components = &api.Components{}
+		if err := fromGeneric(components, combined.Components); err != nil {
+			return nil, nil, err
+		}
+	}
+	return combined, conflicts, nil
+}
+
+// renameComponents applies kind/name -> new name renames to the components, $refs and security
+// requirements of a generic document
+func renameComponents(raw map[string]interface{}, renames map[string]string) {
+	if len(renames) == 0 {
+		return
+	}
+	if components, ok := raw["components"].(map[string]interface{}); ok {
+		for kind, value := range components {
+			entries, _ := value.(map[string]interface{})
+			for name, entry := range entries {
+				if renamed, ok := renames[kind+"/"+name]; ok {
+					delete(entries, name)
+					entries[renamed] = entry
+				}
+			}
+		}
+	}
+	renameRefs(raw, renames)
+}
+
+// renameRefs rewrites the #/components references and security requirement names of a generic value
+func renameRefs(value interface{}, renames map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if ref, ok := item.(string); ok && key == "$ref" {
+				if target, ok := strings.CutPrefix(ref, "#/components/"); ok {
+					kind, name, _ := strings.Cut(target, "/")
+					if renamed, ok := renames[kind+"/"+name]; ok {
+						v[key] = "#/components/" + kind + "/" + renamed
+					}
+				}
+				continue
+			}
+			if key == "security" {
+				if requirements, ok := item.([]interface{}); ok {
+					for _, requirement := range requirements {
+						schemes, _ := requirement.(map[string]interface{})
+						for name, scopes := range schemes {
+							if renamed, ok := renames["securitySchemes/"+name]; ok {
+								delete(schemes, name)
+								schemes[renamed] = scopes
+							}
+						}
+					}
+					continue
+				}
+			}
+			renameRefs(item, renames)
+		}
+	case []interface{}:
+		for _, item := range v {
+			renameRefs(item, renames)
+		}
+	}
+}
+
+// toGeneric converts a document to generic JSON values
+func toGeneric(doc *api.OpenAPIDoc) (map[string]interface{}, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]interface{}
+	err = json.Unmarshal(data, &raw)
+	return raw, err
+}
+
+// fromGeneric converts generic JSON values back to a typed value
+func fromGeneric(value interface{}, out interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// jsonEqual reports whether two generic values serialize identically
+func jsonEqual(a, b interface{}) bool {
+	left, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	right, err := json.Marshal(b)
+	return err == nil && string(left) == string(right)
+}
+
+// serverPath returns the path of a server URL without its trailing slash
+func serverPath(server string) string {
+	if u, err := url.Parse(server); err == nil {
+		server = u.Path
+	}
+	return strings.TrimSuffix(server, "/")
+}
+
+// countOperations returns the number of operations of a document
+func countOperations(doc *api.OpenAPIDoc) int {
+	count := 0
+	for _, item := range doc.Paths {
+		count += len(item.Operations())
+	}
+	return count
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
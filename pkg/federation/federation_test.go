@@ -0,0 +1,175 @@
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+const ordersDoc = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Orders", "version": "1.0.0"},
+  "servers": [{"url": "/api"}],
+  "security": [{"bearer": []}],
+  "tags": [{"name": "orders"}],
+  "paths": {
+    "/orders": {"get": {"summary": "List orders", "operationId": "listOrders", "tags": ["orders"],
+      "responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Address"}}}}}}}
+  },
+  "components": {
+    "schemas": {"Address": {"type": "object"}, "Error": {"type": "object"}},
+    "securitySchemes": {"bearer": {"type": "http", "scheme": "bearer"}}
+  }
+}`
+
+const billingDoc = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Billing", "version": "2.0.0"},
+  "servers": [{"url": "https://billing.internal/v1"}],
+  "paths": {
+    "/invoices": {"get": {"summary": "List invoices", "operationId": "listOrders", "security": [{"bearer": []}],
+      "responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Address"}}}}}}}
+  },
+  "components": {
+    "schemas": {"Address": {"type": "string"}, "Error": {"type": "object"}},
+    "securitySchemes": {"bearer": {"type": "apiKey", "name": "X-Key", "in": "header"}}
+  }
+}`
+
+const ordersV2Doc = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Orders v2", "version": "2.0.0"},
+  "servers": [{"url": "/api"}],
+  "paths": {
+    "/orders": {"get": {"summary": "List orders again", "responses": {"200": {"description": "OK"}}}}
+  }
+}`
+
+// TestServer tests combining service documents with prefixes and conflict handling
+func TestServer(t *testing.T) {
+	var ordersHealthy atomic.Bool
+	ordersHealthy.Store(true)
+	services := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orders/":
+			if !ordersHealthy.Load() {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write([]byte(ordersDoc))
+		case "/billing":
+			_, _ = w.Write([]byte(billingDoc))
+		case "/orders-v2":
+			_, _ = w.Write([]byte(ordersV2Doc))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer services.Close()
+
+	server := NewServer("Developer Portal", "1.0.0").
+		WithServerURL("https://api.example.com").
+		AddService(Service{Name: "orders", URL: services.URL + "/orders/", Prefix: "/orders"}).
+		AddService(Service{Name: "billing", URL: services.URL + "/billing", Prefix: "/billing/"}).
+		AddService(Service{Name: "orders-v2", URL: services.URL + "/orders-v2", Prefix: "/orders"}).
+		AddService(Service{Name: "broken", URL: services.URL + "/missing"})
+
+	// Nothing is served before the first refresh
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger.json", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 before refresh, got %d", w.Code)
+	}
+
+	err := server.Refresh(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "service broken") {
+		t.Errorf("Expected an error naming the broken service, got %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if err := api.ValidateDocJSON(w.Body.Bytes()); err != nil {
+		t.Errorf("Expected a valid combined document, got %v", err)
+	}
+	var doc api.OpenAPIDoc
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to decode the combined document: %v", err)
+	}
+
+	listOrders := doc.Paths["/orders/api/orders"].Get
+	listInvoices := doc.Paths["/billing/v1/invoices"].Get
+	if listOrders == nil || listInvoices == nil || len(doc.Paths) != 2 {
+		t.Fatalf("Expected the orders and billing paths, got %v", doc.Paths)
+	}
+	if listOrders.Summary != "List orders" || listOrders.Extensions["x-service"] != "orders" {
+		t.Errorf("Expected the orders operation at GET /orders/api/orders, got %+v", listOrders)
+	}
+	if !reflect.DeepEqual(listOrders.Security, []map[string][]string{{"bearer": {}}}) {
+		t.Errorf("Expected the service's global security on orders, got %v", listOrders.Security)
+	}
+	if listInvoices.OperationID != "billing.listOrders" {
+		t.Errorf("Expected billing operationId billing.listOrders, got %q", listInvoices.OperationID)
+	}
+	if ref := listInvoices.Responses["200"].Content["application/json"].Schema["$ref"]; ref != "#/components/schemas/billing.Address" {
+		t.Errorf("Expected billing $ref to billing.Address, got %v", ref)
+	}
+	if !reflect.DeepEqual(listInvoices.Security, []map[string][]string{{"billing.bearer": {}}}) {
+		t.Errorf("Expected billing security on billing.bearer, got %v", listInvoices.Security)
+	}
+	if len(doc.Components.Schemas) != 3 || len(doc.Components.SecuritySchemes) != 2 {
+		t.Errorf("Expected 3 schemas and 2 security schemes, got %+v", doc.Components)
+	}
+
+	wantConflicts := []Conflict{
+		{Kind: "schemas", Name: "Address", Services: []string{"orders", "billing"}, Resolution: "renamed to billing.Address"},
+		{Kind: "securitySchemes", Name: "bearer", Services: []string{"orders", "billing"}, Resolution: "renamed to billing.bearer"},
+		{Kind: "operationId", Name: "listOrders", Services: []string{"orders", "billing"}, Resolution: "renamed to billing.listOrders"},
+		{Kind: "operation", Name: "GET /orders/api/orders", Services: []string{"orders", "orders-v2"}, Resolution: "kept the operation of orders"},
+	}
+	if got := server.Conflicts(); !reflect.DeepEqual(got, wantConflicts) {
+		t.Errorf("Expected conflicts %+v, got %+v", wantConflicts, got)
+	}
+
+	// A service failing later keeps its last document
+	ordersHealthy.Store(false)
+	if err := server.Refresh(context.Background()); err == nil || !strings.Contains(err.Error(), "service orders") {
+		t.Errorf("Expected an error naming the orders service, got %v", err)
+	}
+	if !strings.Contains(string(server.Document()), "/orders/api/orders") {
+		t.Error("Expected orders operations to be kept after a failed refresh")
+	}
+
+	statuses := server.Status()
+	if len(statuses) != 4 {
+		t.Fatalf("Expected 4 service statuses, got %+v", statuses)
+	}
+	if statuses[0].Error == "" || statuses[0].Operations != 1 || statuses[0].LastRefresh.IsZero() {
+		t.Errorf("Expected an orders error with the previous document, got %+v", statuses[0])
+	}
+	if statuses[1].Error != "" || statuses[1].Operations != 1 {
+		t.Errorf("Expected a healthy billing status, got %+v", statuses[1])
+	}
+	if statuses[3].Error == "" || !statuses[3].LastRefresh.IsZero() {
+		t.Errorf("Expected a broken status without refresh, got %+v", statuses[3])
+	}
+
+	w = httptest.NewRecorder()
+	server.StatusHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/status", nil))
+	var status struct {
+		Services  []ServiceStatus `json:"services"`
+		Conflicts []Conflict      `json:"conflicts"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil || len(status.Services) != 4 || len(status.Conflicts) != 4 {
+		t.Errorf("Expected 4 services and 4 conflicts, got %s (%v)", w.Body.String(), err)
+	}
+}
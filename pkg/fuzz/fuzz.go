@@ -0,0 +1,451 @@
+// Package fuzz generates malformed and boundary-value requests from API definitions and checks that the
+// server never answers them with a 5xx or panics, so input handling gaps are caught in go test:
+//
+//	func TestNever500(t *testing.T) {
+//		fuzz.Run(t, engine, router.GetDefinitions(), fuzz.Options{BasePath: "/api"})
+//	}
+package fuzz
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// Case is a single generated request
+type Case struct {
+	Name   string // What the case exercises, e.g. "query limit: overflow"
+	Method string
+	Target string // Request URI with path parameters and query filled in
+	Header http.Header
+	Body   []byte
+}
+
+// Request builds the HTTP request of the case
+func (c Case) Request() *http.Request {
+	req := httptest.NewRequest(c.Method, c.Target, bytes.NewReader(c.Body))
+	for name, values := range c.Header {
+		req.Header[name] = values
+	}
+	return req
+}
+
+// Options configures the generated requests
+type Options struct {
+	BasePath     string              // Prefix of the registered routes, e.g. /api
+	Prepare      func(*http.Request) // Decorates every request, e.g. with credentials
+	MaxStringLen int                 // Length of oversized strings (default 10000)
+	Iterations   int                 // Random requests per operation in Check (default 20)
+	Seed         int64               // Seed of the random requests in Check, reported with violations
+	Routes       []string            // "METHOD /path" routes served by the handler, checked by CheckRouting
+}
+
+// Run sends the cases of every definition to the handler, reporting 5xx responses and panics as test errors
+func Run(t testing.TB, handler http.Handler, defs []api.APIDefinition, opts Options) {
+	t.Helper()
+	for _, def := range defs {
+		for _, c := range Cases(def, opts) {
+			req := c.Request()
+			if opts.Prepare != nil {
+				opts.Prepare(req)
+			}
+			target := truncate(c.Target, 100)
+			if status, body, panicked := serve(handler, req); panicked != nil {
+				t.Errorf("%s %s [%s]: handler panicked: %v", c.Method, target, c.Name, panicked)
+			} else if status >= 500 {
+				t.Errorf("%s %s [%s]: got status %d: %s", c.Method, target, c.Name, status, body)
+			}
+		}
+	}
+}
+
+// serve runs one request, capturing panics
+func serve(handler http.Handler, req *http.Request) (status int, body string, panicked interface{}) {
+	w := httptest.NewRecorder()
+	if panicked := serveRecorded(handler, w, req); panicked != nil {
+		return 0, "", panicked
+	}
+	return w.Code, truncate(w.Body.String(), 200), nil
+}
+
+// serveRecorded runs one request into a recorder, returning the recovered panic if any
+func serveRecorded(handler http.Handler, w *httptest.ResponseRecorder, req *http.Request) (panicked interface{}) {
+	defer func() {
+		panicked = recover()
+	}()
+	handler.ServeHTTP(w, req)
+	return nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n] + "..."
+	}
+	return s
+}
+
+// Cases generates the malformed and boundary-value requests of an operation:
+// a valid baseline, then one mutation at a time of each parameter and body property
+func Cases(def api.APIDefinition, opts Options) []Case {
+	if opts.MaxStringLen <= 0 {
+		opts.MaxStringLen = 10000
+	}
+	g := &generator{def: def, opts: opts}
+	return g.cases()
+}
+
+// generator builds the cases of one operation
+type generator struct {
+	def  api.APIDefinition
+	opts Options
+}
+
+// request holds the mutable parts of a request while cases are derived from the baseline
+type request struct {
+	path   map[string]string
+	query  url.Values
+	header http.Header
+	body   interface{}
+	raw    []byte // Raw body overriding body, for malformed JSON
+}
+
+func (g *generator) cases() []Case {
+	var cases []Case
+	add := func(name string, mutate func(r *request)) {
+		r := g.baseline()
+		mutate(r)
+		cases = append(cases, g.build(name, r))
+	}
+
+	add("baseline", func(r *request) {})
+
+	for _, param := range g.def.Params {
+		param := param
+		location := fmt.Sprintf("%s %s", param.In, param.Name)
+		if param.Required && param.In != "path" {
+			add(location+": missing", func(r *request) { r.set(param, nil) })
+		}
+		for _, value := range g.malformedValues(param) {
+			value := value
+			add(fmt.Sprintf("%s: %s", location, value.name), func(r *request) { r.set(param, &value.value) })
+		}
+	}
+
+	schema := g.requestSchema()
+	if schema != nil {
+		add("body: empty", func(r *request) { r.body = nil; r.raw = []byte{} })
+		add("body: invalid JSON", func(r *request) { r.raw = []byte(`{"`) })
+		add("body: wrong type", func(r *request) { r.raw = []byte(`[]`) })
+		add("body: deeply nested", func(r *request) {
+			r.raw = []byte(strings.Repeat(`{"a":`, 1000) + "1" + strings.Repeat("}", 1000))
+		})
+
+		props, _ := schema["properties"].(map[string]interface{})
+		for _, name := range sortedKeys(props) {
+			name := name
+			propSchema, _ := props[name].(map[string]interface{})
+			if containsString(requiredList(schema), name) {
+				add(fmt.Sprintf("body %s: missing", name), func(r *request) { delete(r.object(), name) })
+			}
+			for _, value := range g.malformedProperties(propSchema) {
+				value := value
+				add(fmt.Sprintf("body %s: %s", name, value.name), func(r *request) { r.object()[name] = value.value })
+			}
+		}
+	}
+	return cases
+}
+
+// set replaces a parameter value, or removes it when value is nil
+func (r *request) set(param api.Parameter, value *string) {
+	switch param.In {
+	case "path":
+		if value != nil {
+			r.path[param.Name] = *value
+		}
+	case "query":
+		if value == nil {
+			r.query.Del(param.Name)
+		} else {
+			r.query.Set(param.Name, *value)
+		}
+	case "header":
+		if value == nil {
+			r.header.Del(param.Name)
+		} else {
+			r.header.Set(param.Name, *value)
+		}
+	case "cookie":
+		cookies := r.header.Values("Cookie")
+		r.header.Del("Cookie")
+		for _, cookie := range cookies {
+			if !strings.HasPrefix(cookie, param.Name+"=") {
+				r.header.Add("Cookie", cookie)
+			}
+		}
+		if value != nil {
+			r.header.Add("Cookie", (&http.Cookie{Name: param.Name, Value: *value}).String())
+		}
+	}
+}
+
+// object returns the body as a JSON object, replacing non-object bodies
+func (r *request) object() map[string]interface{} {
+	obj, ok := r.body.(map[string]interface{})
+	if !ok {
+		obj = make(map[string]interface{})
+		r.body = obj
+	}
+	return obj
+}
+
+// baseline builds a request whose parameters and body satisfy the documented constraints
+func (g *generator) baseline() *request {
+	r := &request{
+		path:   make(map[string]string),
+		query:  url.Values{},
+		header: http.Header{},
+	}
+	for _, param := range g.def.Params {
+		value := fmt.Sprint(validValue(paramSchema(param)))
+		if param.Example != nil {
+			value = fmt.Sprint(param.Example)
+		}
+		r.set(param, &value)
+	}
+	if schema := g.requestSchema(); schema != nil {
+		r.body = validValue(schema)
+	}
+	return r
+}
+
+// build renders a request into a case
+func (g *generator) build(name string, r *request) Case {
+	path := pathParamPattern.ReplaceAllStringFunc(g.def.Path, func(segment string) string {
+		name := strings.Trim(segment, "{}:")
+		return url.PathEscape(r.path[name])
+	})
+	target := g.opts.BasePath + path
+	if len(r.query) > 0 {
+		target += "?" + r.query.Encode()
+	}
+
+	c := Case{Name: name, Method: strings.ToUpper(g.def.Method), Target: target, Header: r.header}
+	switch {
+	case r.raw != nil:
+		c.Body = r.raw
+	case r.body != nil:
+		c.Body, _ = json.Marshal(r.body)
+	}
+	if c.Body != nil {
+		c.Header.Set("Content-Type", "application/json")
+	}
+	return c
+}
+
+var pathParamPattern = regexp.MustCompile(`\{[^}]+\}|:[^/]+`)
+
+// requestSchema returns the schema of the request body, or nil for operations without one
+func (g *generator) requestSchema() map[string]interface{} {
+	if g.def.Request == nil {
+		return nil
+	}
+	schema, err := api.RequestSchema(&g.def)
+	if err != nil {
+		return nil
+	}
+	return schema
+}
+
+// paramSchema returns the declared schema of a parameter, defaulting to a string
+func paramSchema(param api.Parameter) map[string]interface{} {
+	if param.Schema != nil {
+		return param.Schema
+	}
+	return map[string]interface{}{"type": "string"}
+}
+
+// mutation is a named malformed value
+type mutation struct {
+	name  string
+	value string
+}
+
+// malformedValues lists the malformed and boundary values of a parameter
+func (g *generator) malformedValues(param api.Parameter) []mutation {
+	values := []mutation{
+		{"empty", ""},
+		{"oversized", strings.Repeat("a", g.opts.MaxStringLen)},
+		{"special characters", "'\"<>%00\\;--"},
+		{"unicode", "‮\U0001F600\u0000"},
+	}
+	switch schemaType(paramSchema(param)) {
+	case "integer", "number":
+		values = append(values,
+			mutation{"wrong type", "abc"},
+			mutation{"overflow", "99999999999999999999999999"},
+			mutation{"negative", "-1"},
+			mutation{"fraction", "1.5"},
+		)
+	case "boolean":
+		values = append(values, mutation{"wrong type", "maybe"})
+	}
+	if param.In == "path" {
+		// Path segments cannot be empty without changing the route
+		values = values[1:]
+	}
+	return values
+}
+
+// propertyMutation is a named malformed body property value
+type propertyMutation struct {
+	name  string
+	value interface{}
+}
+
+// malformedProperties lists the malformed and boundary values of a body property
+func (g *generator) malformedProperties(schema map[string]interface{}) []propertyMutation {
+	values := []propertyMutation{{"null", nil}}
+	switch schemaType(schema) {
+	case "string":
+		values = append(values,
+			propertyMutation{"wrong type", 12345},
+			propertyMutation{"oversized", strings.Repeat("a", g.opts.MaxStringLen)},
+			propertyMutation{"empty", ""},
+		)
+	case "integer", "number":
+		values = append(values,
+			propertyMutation{"wrong type", "abc"},
+			propertyMutation{"overflow", json.Number("99999999999999999999999999")},
+			propertyMutation{"negative", -1},
+			propertyMutation{"huge", 1.7976931348623157e308},
+		)
+	case "boolean":
+		values = append(values, propertyMutation{"wrong type", "yes"})
+	case "array":
+		values = append(values,
+			propertyMutation{"wrong type", "abc"},
+			propertyMutation{"oversized", make([]interface{}, g.opts.MaxStringLen)},
+		)
+	case "object":
+		values = append(values, propertyMutation{"wrong type", []interface{}{1}})
+	}
+	return values
+}
+
+// validValue generates a value satisfying a schema's type, enum and bounds
+func validValue(schema map[string]interface{}) interface{} {
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[0]
+	}
+	if example, ok := schema["example"]; ok {
+		return example
+	}
+	switch schemaType(schema) {
+	case "integer", "number":
+		if minimum, ok := toFloat(schema["minimum"]); ok {
+			return minimum
+		}
+		return 1
+	case "boolean":
+		return true
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		if items == nil {
+			return []interface{}{}
+		}
+		return []interface{}{validValue(items)}
+	case "object":
+		obj := make(map[string]interface{})
+		props, _ := schema["properties"].(map[string]interface{})
+		for name, prop := range props {
+			if propSchema, ok := prop.(map[string]interface{}); ok {
+				obj[name] = validValue(propSchema)
+			}
+		}
+		return obj
+	}
+	return validString(schema)
+}
+
+// validString generates a string matching the schema's format and length bounds
+func validString(schema map[string]interface{}) string {
+	format, _ := schema["format"].(string)
+	value := "a"
+	switch format {
+	case "date-time":
+		value = "2024-01-01T00:00:00Z"
+	case "date":
+		value = "2024-01-01"
+	case "email":
+		value = "user@example.com"
+	case "uri", "url":
+		value = "https://example.com"
+	case "uuid":
+		value = "123e4567-e89b-12d3-a456-426614174000"
+	}
+	if minLength, ok := toFloat(schema["minLength"]); ok && len(value) < int(minLength) {
+		value += strings.Repeat("a", int(minLength)-len(value))
+	}
+	return value
+}
+
+func schemaType(schema map[string]interface{}) string {
+	t, _ := schema["type"].(string)
+	return t
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func requiredList(schema map[string]interface{}) []string {
+	switch r := schema["required"].(type) {
+	case []string:
+		return r
+	case []interface{}:
+		list := make([]string, 0, len(r))
+		for _, item := range r {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,192 @@
+package fuzz
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+	ginSwagger "github.com/smartcat999/go-swagger/pkg/gin"
+)
+
+type createUserRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Age   int    `json:"age"`
+	Admin bool   `json:"admin"`
+}
+
+// recorder captures the errors reported by Run
+type recorder struct {
+	testing.TB
+	mu     sync.Mutex
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// newRouter registers a user lookup and creation; fragile makes handlers fail on inputs they do not expect
+func newRouter(t *testing.T, fragile bool) (*gin.Engine, *ginSwagger.APIRouter) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := ginSwagger.NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	getUser := api.NewAPIDefinition("GET", "/users/{id}", "Get user").
+		WithPathParam("id", "User ID", true).
+		WithQueryParam("limit", "Page size", false)
+	getUser.Params[1].Schema = map[string]interface{}{"type": "integer"}
+	ginSwagger.WithGinHandler(getUser, func(c *gin.Context) {
+		if fragile && len(c.Param("id")) > 255 {
+			panic("id too long")
+		}
+		if limit := c.Query("limit"); limit != "" {
+			if _, err := strconv.Atoi(limit); err != nil {
+				if fragile {
+					c.Status(http.StatusInternalServerError)
+					return
+				}
+				c.Status(http.StatusBadRequest)
+				return
+			}
+		}
+		c.Status(http.StatusOK)
+	})
+
+	createUser := api.NewAPIDefinition("POST", "/users", "Create user").
+		WithRequest(createUserRequest{})
+	ginSwagger.WithGinHandler(createUser, func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	for _, def := range []*api.APIDefinition{getUser, createUser} {
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	return engine, router
+}
+
+// TestCases tests the generated mutations of parameters and body properties
+func TestCases(t *testing.T) {
+	def := api.NewAPIDefinition("POST", "/users/{id}", "Update user").
+		WithPathParam("id", "User ID", true).
+		WithQueryParam("limit", "Page size", true).
+		WithRequest(createUserRequest{})
+	def.Params[1].Schema = map[string]interface{}{"type": "integer"}
+
+	cases := Cases(*def, Options{BasePath: "/api", MaxStringLen: 300})
+	byName := make(map[string]Case)
+	for _, c := range cases {
+		byName[c.Name] = c
+	}
+
+	tests := []struct {
+		name        string
+		wantTarget  string
+		wantBody    string
+		wantInBody  string
+		wantNoQuery bool
+	}{
+		{name: "baseline", wantTarget: "/api/users/a?limit=1"},
+		{name: "query limit: missing", wantNoQuery: true},
+		{name: "query limit: overflow", wantTarget: "/api/users/a?limit=99999999999999999999999999"},
+		{name: "query limit: wrong type", wantTarget: "/api/users/a?limit=abc"},
+		{name: "path id: oversized", wantTarget: "/api/users/" + strings.Repeat("a", 300) + "?limit=1"},
+		{name: "body: invalid JSON", wantBody: `{"`},
+		{name: "body: wrong type", wantBody: `[]`},
+		{name: "body name: missing", wantBody: `{"admin":true,"age":1}`},
+		{name: "body age: wrong type", wantInBody: `"age":"abc"`},
+		{name: "body age: overflow", wantInBody: `"age":99999999999999999999999999`},
+		{name: "body admin: null", wantInBody: `"admin":null`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ok := byName[tt.name]
+			if !ok {
+				t.Fatalf("Missing case %q", tt.name)
+			}
+			if tt.wantTarget != "" && c.Target != tt.wantTarget {
+				t.Errorf("Expected target %q, got %q", tt.wantTarget, c.Target)
+			}
+			if tt.wantNoQuery && strings.Contains(c.Target, "?") {
+				t.Errorf("Expected no query, got %q", c.Target)
+			}
+			if tt.wantBody != "" && string(c.Body) != tt.wantBody {
+				t.Errorf("Expected body %s, got %s", tt.wantBody, c.Body)
+			}
+			if tt.wantInBody != "" && !strings.Contains(string(c.Body), tt.wantInBody) {
+				t.Errorf("Expected body to contain %s, got %s", tt.wantInBody, c.Body)
+			}
+		})
+	}
+
+	if _, ok := byName["path id: missing"]; ok {
+		t.Error("Expected no missing case for path parameters")
+	}
+}
+
+// TestRun tests that 5xx responses and panics are reported while robust handlers pass
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		fragile    bool
+		wantErrors []string
+	}{
+		{name: "robust handlers"},
+		{name: "fragile handlers", fragile: true, wantErrors: []string{
+			"[query limit: wrong type]: got status 500",
+			"[path id: oversized]: handler panicked",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, router := newRouter(t, tt.fragile)
+			rec := &recorder{TB: t}
+			Run(rec, engine, router.GetDefinitions(), Options{BasePath: "/api"})
+
+			if len(tt.wantErrors) == 0 && len(rec.errors) > 0 {
+				t.Errorf("Expected no errors, got %v", rec.errors)
+			}
+			for _, want := range tt.wantErrors {
+				found := false
+				for _, err := range rec.errors {
+					if strings.Contains(err, want) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("Expected an error containing %q, got %v", want, rec.errors)
+				}
+			}
+		})
+	}
+}
+
+// TestRunPrepare tests decorating the generated requests
+func TestRunPrepare(t *testing.T) {
+	engine, router := newRouter(t, false)
+	var authorized int
+	Run(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer token" {
+			authorized++
+		}
+		engine.ServeHTTP(w, r)
+	}), router.GetDefinitions(), Options{
+		BasePath: "/api",
+		Prepare:  func(r *http.Request) { r.Header.Set("Authorization", "Bearer token") },
+	})
+	if authorized == 0 {
+		t.Error("Expected requests to be prepared")
+	}
+}
@@ -0,0 +1,470 @@
+package fuzz
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// Violation is a response breaking the documented contract of its operation
+type Violation struct {
+	Method string
+	Target string
+	Status int
+	Reason string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s %s: status %d: %s", v.Method, truncate(v.Target, 100), v.Status, v.Reason)
+}
+
+// Check sends random requests satisfying the documented parameter and body schemas to every operation of
+// the document and reports each contract violation as a test error: server errors, undocumented statuses
+// and JSON bodies that don't match the documented response schema
+func Check(t testing.TB, handler http.Handler, doc *api.OpenAPIDoc, opts Options) {
+	t.Helper()
+	violations, err := Violations(handler, doc, opts)
+	if err != nil {
+		t.Fatalf("failed to check contract: %v", err)
+	}
+	for _, v := range violations {
+		t.Errorf("%s (seed %d)", v, opts.Seed)
+	}
+}
+
+// Violations runs the checks of Check and returns the violations found
+func Violations(handler http.Handler, doc *api.OpenAPIDoc, opts Options) ([]Violation, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("document cannot be nil")
+	}
+	if opts.Iterations <= 0 {
+		opts.Iterations = 20
+	}
+	c := &checker{
+		handler: handler,
+		doc:     doc,
+		opts:    opts,
+		rng:     rand.New(rand.NewSource(opts.Seed)),
+		schemas: make(map[string]*jsonschema.Schema),
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var violations []Violation
+	for _, path := range paths {
+		item := doc.Paths[path]
+		for _, method := range api.SupportedMethods {
+			op := item.Operation(method)
+			if op == nil {
+				continue
+			}
+			for i := 0; i < opts.Iterations; i++ {
+				v, err := c.checkOnce(method, path, op)
+				if err != nil {
+					return nil, fmt.Errorf("failed to check %s %s: %w", method, path, err)
+				}
+				if v != nil {
+					violations = append(violations, *v)
+					// One violation per operation is enough to report it
+					break
+				}
+			}
+		}
+	}
+	return violations, nil
+}
+
+// checker generates requests and validates responses for one document
+type checker struct {
+	handler http.Handler
+	doc     *api.OpenAPIDoc
+	opts    Options
+	rng     *rand.Rand
+	schemas map[string]*jsonschema.Schema // Compiled response schemas keyed by operation and status
+}
+
+// checkOnce sends one random request to an operation and validates the response
+func (c *checker) checkOnce(method, path string, op *api.Operation) (*Violation, error) {
+	req, err := c.request(method, path, op)
+	if err != nil {
+		return nil, err
+	}
+	if c.opts.Prepare != nil {
+		c.opts.Prepare(req)
+	}
+	v := &Violation{Method: method, Target: req.URL.RequestURI()}
+
+	w := httptest.NewRecorder()
+	if panicked := serveRecorded(c.handler, w, req); panicked != nil {
+		v.Reason = fmt.Sprintf("handler panicked: %v", panicked)
+		return v, nil
+	}
+	v.Status = w.Code
+	if w.Code >= 500 {
+		v.Reason = "server error: " + truncate(w.Body.String(), 200)
+		return v, nil
+	}
+
+	key, resp, ok := documentedResponse(op, w.Code)
+	if !ok {
+		v.Reason = "undocumented status"
+		return v, nil
+	}
+	content, ok := resp.Content["application/json"]
+	if !ok || content.Schema == nil || w.Body.Len() == 0 || !strings.Contains(w.Header().Get("Content-Type"), "json") {
+		return nil, nil
+	}
+
+	schema, err := c.compile(method+" "+path+" "+key, content.Schema)
+	if err != nil {
+		return nil, err
+	}
+	var body interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		v.Reason = "invalid JSON body: " + err.Error()
+		return v, nil
+	}
+	if err := schema.Validate(body); err != nil {
+		v.Reason = "body does not match the documented schema: " + err.Error()
+		return v, nil
+	}
+	return nil, nil
+}
+
+// documentedResponse finds the response documented for a status: exact, range (2XX) or default
+func documentedResponse(op *api.Operation, status int) (string, api.Response, bool) {
+	for _, key := range []string{strconv.Itoa(status), fmt.Sprintf("%dXX", status/100), "default"} {
+		if resp, ok := op.Responses[key]; ok {
+			return key, resp, true
+		}
+	}
+	return "", api.Response{}, false
+}
+
+// compile compiles a documented response schema into a JSON Schema validator
+func (c *checker) compile(key string, schema map[string]interface{}) (*jsonschema.Schema, error) {
+	if compiled, ok := c.schemas[key]; ok {
+		return compiled, nil
+	}
+	data, err := json.Marshal(api.ToJSONSchema(c.resolveRefs(schema)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response schema: %w", err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("response.json", bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to load response schema: %w", err)
+	}
+	compiled, err := compiler.Compile("response.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile response schema: %w", err)
+	}
+	c.schemas[key] = compiled
+	return compiled, nil
+}
+
+// resolveRefs inlines references to components.schemas
+func (c *checker) resolveRefs(schema map[string]interface{}) map[string]interface{} {
+	return resolveValue(c.doc, schema, 0).(map[string]interface{})
+}
+
+func resolveValue(doc *api.OpenAPIDoc, v interface{}, depth int) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["$ref"].(string); ok && depth < 32 {
+			if target, ok := componentSchema(doc, ref); ok {
+				return resolveValue(doc, target, depth+1)
+			}
+		}
+		out := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			out[key] = resolveValue(doc, item, depth)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(value))
+		for i, item := range value {
+			out[i] = resolveValue(doc, item, depth)
+		}
+		return out
+	}
+	return v
+}
+
+// componentSchema looks up a #/components/schemas reference
+func componentSchema(doc *api.OpenAPIDoc, ref string) (map[string]interface{}, bool) {
+	name := strings.TrimPrefix(ref, "#/components/schemas/")
+	if name == ref || doc.Components == nil {
+		return nil, false
+	}
+	schema, ok := doc.Components.Schemas[name].(map[string]interface{})
+	return schema, ok
+}
+
+// request generates a random request satisfying the operation's documented parameters and body
+func (c *checker) request(method, path string, op *api.Operation) (*http.Request, error) {
+	query := url.Values{}
+	header := http.Header{}
+	pathValues := make(map[string]string)
+
+	for _, param := range op.Parameters {
+		if param.Ref != "" && c.doc.Components != nil {
+			param = c.doc.Components.Parameters[strings.TrimPrefix(param.Ref, "#/components/parameters/")]
+		}
+		if !param.Required && param.In != "path" && c.rng.Intn(2) == 0 {
+			continue
+		}
+		schema := param.Schema
+		if schema == nil {
+			schema = map[string]interface{}{"type": "string"}
+		}
+		value := c.randomValue(c.resolveRefs(schema), 0)
+		switch param.In {
+		case "path":
+			pathValues[param.Name] = formatParam(value)
+		case "query":
+			if items, ok := value.([]interface{}); ok {
+				for _, item := range items {
+					query.Add(param.Name, formatParam(item))
+				}
+			} else {
+				query.Set(param.Name, formatParam(value))
+			}
+		case "header":
+			header.Set(param.Name, formatParam(value))
+		case "cookie":
+			header.Add("Cookie", (&http.Cookie{Name: param.Name, Value: formatParam(value)}).String())
+		}
+	}
+
+	target := c.opts.BasePath + pathParamPattern.ReplaceAllStringFunc(path, func(segment string) string {
+		value := pathValues[strings.Trim(segment, "{}:")]
+		if value == "" {
+			value = "a"
+		}
+		return url.PathEscape(value)
+	})
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var body []byte
+	if op.RequestBody != nil {
+		if content, ok := op.RequestBody.Content["application/json"]; ok && content.Schema != nil {
+			data, err := json.Marshal(c.randomValue(c.resolveRefs(content.Schema), 0))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			}
+			body = data
+			header.Set("Content-Type", "application/json")
+		}
+	}
+
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	return req, nil
+}
+
+// formatParam renders a generated value as a parameter string
+func formatParam(v interface{}) string {
+	if f, ok := v.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// randomValue generates a random value satisfying a schema's type, enum, bounds and required properties
+func (c *checker) randomValue(schema map[string]interface{}, depth int) interface{} {
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[c.rng.Intn(len(enum))]
+	}
+	if value, ok := schema["const"]; ok {
+		return value
+	}
+	if alternatives, ok := schema["oneOf"].([]interface{}); ok && len(alternatives) > 0 {
+		if alternative, ok := alternatives[c.rng.Intn(len(alternatives))].(map[string]interface{}); ok {
+			return c.randomValue(alternative, depth+1)
+		}
+	}
+	if nullable, _ := schema["nullable"].(bool); nullable && c.rng.Intn(5) == 0 {
+		return nil
+	}
+
+	switch schemaType(schema) {
+	case "integer":
+		min, max := c.bounds(schema, true)
+		if max <= min {
+			return min
+		}
+		return min + float64(c.rng.Int63n(int64(math.Min(max-min, 1e9))+1))
+	case "number":
+		min, max := c.bounds(schema, false)
+		return min + c.rng.Float64()*(max-min)
+	case "boolean":
+		return c.rng.Intn(2) == 0
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		minItems, maxItems := lengthBounds(schema, "minItems", "maxItems", 3)
+		n := minItems + c.rng.Intn(maxItems-minItems+1)
+		if items == nil || depth > 5 {
+			n = minItems
+		}
+		list := make([]interface{}, n)
+		for i := range list {
+			list[i] = c.randomValue(items, depth+1)
+		}
+		return list
+	case "object":
+		obj := make(map[string]interface{})
+		props, _ := schema["properties"].(map[string]interface{})
+		required := requiredList(schema)
+		for _, name := range sortedKeys(props) {
+			propSchema, _ := props[name].(map[string]interface{})
+			if !containsString(required, name) && (depth > 5 || c.rng.Intn(2) == 0) {
+				continue
+			}
+			obj[name] = c.randomValue(propSchema, depth+1)
+		}
+		return obj
+	case "string":
+		return c.randomString(schema)
+	}
+	return c.randomString(schema)
+}
+
+// bounds returns the inclusive range of a numeric schema, defaulting to [-1000, 1000]
+func (c *checker) bounds(schema map[string]interface{}, integer bool) (float64, float64) {
+	min, max := -1000.0, 1000.0
+	if v, ok := toFloat(schema["minimum"]); ok {
+		min = v
+		if exclusive, _ := schema["exclusiveMinimum"].(bool); exclusive {
+			min = nextAbove(v, integer)
+		}
+		if max < min {
+			max = min + 1000
+		}
+	}
+	if v, ok := toFloat(schema["maximum"]); ok {
+		max = v
+		if exclusive, _ := schema["exclusiveMaximum"].(bool); exclusive {
+			max = -nextAbove(-v, integer)
+		}
+		if min > max {
+			min = max - 1000
+		}
+	}
+	if integer {
+		min, max = math.Ceil(min), math.Floor(max)
+	}
+	return min, max
+}
+
+// nextAbove returns the smallest value strictly greater than v
+func nextAbove(v float64, integer bool) float64 {
+	if integer {
+		return math.Floor(v) + 1
+	}
+	return math.Nextafter(v, math.Inf(1))
+}
+
+// lengthBounds returns the length range of a string or array schema
+func lengthBounds(schema map[string]interface{}, minKey, maxKey string, defaultSpan int) (int, int) {
+	min, max := 0, defaultSpan
+	if v, ok := toFloat(schema[minKey]); ok {
+		min = int(v)
+		if max < min {
+			max = min + defaultSpan
+		}
+	}
+	if v, ok := toFloat(schema[maxKey]); ok {
+		max = int(v)
+		if min > max {
+			min = max
+		}
+	}
+	return min, max
+}
+
+// formatValues are valid samples of the string formats the generator knows
+var formatValues = map[string][]string{
+	"date-time": {"2024-01-01T00:00:00Z", "1999-12-31T23:59:59.999+02:00"},
+	"date":      {"2024-01-01", "2000-02-29"},
+	"email":     {"user@example.com", "a.b+c@example.org"},
+	"uri":       {"https://example.com", "https://example.com/a?b=c"},
+	"url":       {"https://example.com"},
+	"uuid":      {"123e4567-e89b-12d3-a456-426614174000"},
+	"ipv4":      {"192.0.2.1"},
+	"ipv6":      {"2001:db8::1"},
+	"hostname":  {"example.com"},
+	"byte":      {"aGVsbG8="},
+}
+
+const randomStringChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 -_.é日"
+
+// randomString generates a string satisfying a schema's format, pattern and length bounds
+func (c *checker) randomString(schema map[string]interface{}) string {
+	format, _ := schema["format"].(string)
+	if samples, ok := formatValues[format]; ok {
+		return samples[c.rng.Intn(len(samples))]
+	}
+	chars := []rune(randomStringChars)
+	minLength, maxLength := lengthBounds(schema, "minLength", "maxLength", 20)
+	if pattern, ok := schema["pattern"].(string); ok {
+		if example, ok := schema["example"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && re.MatchString(example) {
+				return example
+			}
+		}
+		// Beyond examples, only single character class patterns such as ^[a-zA-Z]+$ are generated
+		if m := charClassPattern.FindStringSubmatch(pattern); m != nil {
+			chars = expandClass(m[1])
+			if m[2] == "+" && minLength == 0 {
+				minLength = 1
+			}
+		}
+	}
+
+	n := minLength + c.rng.Intn(maxLength-minLength+1)
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		b.WriteRune(chars[c.rng.Intn(len(chars))])
+	}
+	return b.String()
+}
+
+var charClassPattern = regexp.MustCompile(`^\^\[([^\]^\\]+)\]([+*])\$$`)
+
+// expandClass lists the characters of a character class body such as a-zA-Z0-9_
+func expandClass(class string) []rune {
+	runes := []rune(class)
+	var chars []rune
+	for i := 0; i < len(runes); i++ {
+		if i+2 < len(runes) && runes[i+1] == '-' {
+			for r := runes[i]; r <= runes[i+2]; r++ {
+				chars = append(chars, r)
+			}
+			i += 2
+			continue
+		}
+		chars = append(chars, runes[i])
+	}
+	return chars
+}
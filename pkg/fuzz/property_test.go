@@ -0,0 +1,156 @@
+package fuzz
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/smartcat999/go-swagger/pkg/api"
+	ginSwagger "github.com/smartcat999/go-swagger/pkg/gin"
+)
+
+type item struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	Price float64  `json:"price"`
+	Tags  []string `json:"tags"`
+}
+
+type itemFilter struct {
+	Kind  string `json:"kind" validate:"oneof=book music"`
+	Count int    `json:"count" validate:"min=1,max=5"`
+	Code  string `json:"code" validate:"alphanum,max=8"`
+	Email string `json:"email" validate:"email"`
+}
+
+// TestRandomValue tests that generated values respect schema constraints
+func TestRandomValue(t *testing.T) {
+	c := &checker{doc: &api.OpenAPIDoc{}, schemas: make(map[string]*jsonschema.Schema)}
+	schema, err := api.SafeSchemaFromStruct(itemFilter{})
+	if err != nil {
+		t.Fatalf("SafeSchemaFromStruct failed: %v", err)
+	}
+	compiled, err := c.compile("filter", schema)
+	if err != nil {
+		t.Fatalf("compile failed: %v", err)
+	}
+
+	for seed := int64(0); seed < 50; seed++ {
+		c.rng = rand.New(rand.NewSource(seed))
+		data, _ := json.Marshal(c.randomValue(schema, 0))
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if err := compiled.Validate(value); err != nil {
+			t.Errorf("seed %d: generated %v does not match its schema: %v", seed, value, err)
+		}
+	}
+}
+
+// TestBounds tests the numeric ranges derived from minimum, maximum and their exclusive forms
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  map[string]interface{}
+		integer bool
+		wantMin float64
+		wantMax float64
+	}{
+		{name: "defaults", schema: map[string]interface{}{}, integer: true, wantMin: -1000, wantMax: 1000},
+		{name: "inclusive", schema: map[string]interface{}{"minimum": 1.0, "maximum": 5.0}, integer: true, wantMin: 1, wantMax: 5},
+		{name: "exclusive", schema: map[string]interface{}{"minimum": 1.0, "exclusiveMinimum": true, "maximum": 5.0, "exclusiveMaximum": true}, integer: true, wantMin: 2, wantMax: 4},
+		{name: "minimum above default range", schema: map[string]interface{}{"minimum": 5000.0}, integer: true, wantMin: 5000, wantMax: 6000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			min, max := (&checker{}).bounds(tt.schema, tt.integer)
+			if min != tt.wantMin || max != tt.wantMax {
+				t.Errorf("Expected [%v, %v], got [%v, %v]", tt.wantMin, tt.wantMax, min, max)
+			}
+		})
+	}
+}
+
+// TestCheck tests detecting server errors and responses that break the documented schema
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    gin.HandlerFunc
+		wantReason string
+	}{
+		{
+			name: "conforming response",
+			handler: func(c *gin.Context) {
+				c.JSON(http.StatusOK, item{ID: 1, Name: "pen", Price: 1.5, Tags: []string{"office"}})
+			},
+		},
+		{
+			name: "wrong property type",
+			handler: func(c *gin.Context) {
+				c.JSON(http.StatusOK, map[string]interface{}{"id": "one", "name": "pen"})
+			},
+			wantReason: "body does not match the documented schema",
+		},
+		{
+			name: "undocumented status",
+			handler: func(c *gin.Context) {
+				c.Status(http.StatusTeapot)
+			},
+			wantReason: "undocumented status",
+		},
+		{
+			name: "server error",
+			handler: func(c *gin.Context) {
+				c.Status(http.StatusInternalServerError)
+			},
+			wantReason: "server error",
+		},
+		{
+			name: "panic",
+			handler: func(c *gin.Context) {
+				panic("boom")
+			},
+			wantReason: "handler panicked",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			engine := gin.New()
+			router := ginSwagger.NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+			def := api.NewAPIDefinition("POST", "/items/{id}", "Update item").
+				WithPathParam("id", "Item ID", true).
+				WithRequest(item{}).
+				WithResponse(item{})
+			ginSwagger.WithGinHandler(def, tt.handler)
+			if err := router.Register(def); err != nil {
+				t.Fatalf("Register failed: %v", err)
+			}
+			doc, err := router.GenerateSwagger()
+			if err != nil {
+				t.Fatalf("GenerateSwagger failed: %v", err)
+			}
+
+			violations, err := Violations(engine, doc, Options{BasePath: "/api", Iterations: 5})
+			if err != nil {
+				t.Fatalf("Violations failed: %v", err)
+			}
+			if tt.wantReason == "" {
+				if len(violations) > 0 {
+					t.Errorf("Expected no violations, got %v", violations)
+				}
+				return
+			}
+			if len(violations) != 1 || !strings.Contains(violations[0].Reason, tt.wantReason) {
+				t.Errorf("Expected one violation %q, got %v", tt.wantReason, violations)
+			}
+		})
+	}
+}
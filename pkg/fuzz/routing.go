@@ -0,0 +1,153 @@
+package fuzz
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// routingProbePath is requested to learn how the handler answers unrouted requests
+const routingProbePath = "/__routing-probe__/unmatched"
+
+// probedMethods are sent to documented paths to find operations answering without being documented
+var probedMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// RoutingMismatch is a documented operation the handler does not route, or a route it serves undocumented
+type RoutingMismatch struct {
+	Method string
+	Path   string // Documented path, or the registered route for undocumented ones
+	Reason string
+}
+
+func (m RoutingMismatch) String() string {
+	return fmt.Sprintf("%s %s: %s", m.Method, m.Path, m.Reason)
+}
+
+// CheckRouting sends a synthetic request to every documented operation and to the undocumented methods of each
+// documented path, reporting operations the handler does not route and undocumented ones it answers as test
+// errors; routes listed in Options.Routes (e.g. from engine.Routes()) are also checked against the document,
+// catching handlers registered on the engine outside the API router
+func CheckRouting(t testing.TB, handler http.Handler, doc *api.OpenAPIDoc, opts Options) {
+	t.Helper()
+	mismatches, err := RoutingMismatches(handler, doc, opts)
+	if err != nil {
+		t.Fatalf("failed to check routing: %v", err)
+	}
+	for _, m := range mismatches {
+		t.Error(m)
+	}
+}
+
+// RoutingMismatches runs the checks of CheckRouting and returns the mismatches found
+func RoutingMismatches(handler http.Handler, doc *api.OpenAPIDoc, opts Options) ([]RoutingMismatch, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("document cannot be nil")
+	}
+	r := &routingChecker{handler: handler, doc: doc, opts: opts}
+	r.unmatched = r.fingerprint(http.MethodGet, opts.BasePath+routingProbePath)
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var mismatches []RoutingMismatch
+	documented := make(map[string]bool)
+	for _, path := range paths {
+		item := doc.Paths[path]
+		target := r.target(path, item)
+		for _, method := range api.SupportedMethods {
+			if item.Operation(method) == nil {
+				continue
+			}
+			documented[method+" "+routeShape(path)] = true
+			if !r.routed(method, target) {
+				mismatches = append(mismatches, RoutingMismatch{Method: method, Path: path, Reason: "documented operation is not routed"})
+			}
+		}
+		for _, method := range probedMethods {
+			if item.Operation(method) == nil && r.routed(method, target) {
+				mismatches = append(mismatches, RoutingMismatch{Method: method, Path: path, Reason: "undocumented operation responds"})
+			}
+		}
+	}
+
+	for _, route := range opts.Routes {
+		method, path, ok := strings.Cut(route, " ")
+		if !ok || !strings.HasPrefix(path, opts.BasePath) {
+			continue
+		}
+		method = strings.ToUpper(method)
+		if !documented[method+" "+routeShape(strings.TrimPrefix(path, opts.BasePath))] {
+			mismatches = append(mismatches, RoutingMismatch{Method: method, Path: path, Reason: "route is not documented"})
+		}
+	}
+	return mismatches, nil
+}
+
+// routingChecker sends the synthetic requests of one document
+type routingChecker struct {
+	handler   http.Handler
+	doc       *api.OpenAPIDoc
+	opts      Options
+	unmatched string // Fingerprint of the handler's answer to unrouted requests
+}
+
+// routed reports whether the handler answers a request other than as an unrouted one
+func (r *routingChecker) routed(method, target string) bool {
+	fingerprint := r.fingerprint(method, target)
+	return fingerprint != r.unmatched && !strings.HasPrefix(fingerprint, fmt.Sprint(http.StatusMethodNotAllowed))
+}
+
+// fingerprint sends a bodiless request and summarizes the answer by status, content type and body;
+// a panicking handler was routed and is fingerprinted as such
+func (r *routingChecker) fingerprint(method, target string) string {
+	req := httptest.NewRequest(method, target, nil)
+	if r.opts.Prepare != nil {
+		r.opts.Prepare(req)
+	}
+	w := httptest.NewRecorder()
+	if panicked := serveRecorded(r.handler, w, req); panicked != nil {
+		return "panic"
+	}
+	return fmt.Sprintf("%d %s %s", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+}
+
+// target fills the path parameters of a documented path with values valid for their schemas
+func (r *routingChecker) target(path string, item api.PathItem) string {
+	schemas := make(map[string]map[string]interface{})
+	for _, method := range api.SupportedMethods {
+		op := item.Operation(method)
+		if op == nil {
+			continue
+		}
+		for _, param := range op.Parameters {
+			if param.Ref != "" && r.doc.Components != nil {
+				param = r.doc.Components.Parameters[strings.TrimPrefix(param.Ref, "#/components/parameters/")]
+			}
+			if param.In == "path" {
+				schemas[param.Name] = paramSchema(param)
+			}
+		}
+	}
+	return r.opts.BasePath + pathParamPattern.ReplaceAllStringFunc(path, func(segment string) string {
+		value := "a"
+		if schema, ok := schemas[strings.Trim(segment, "{}:")]; ok {
+			value = fmt.Sprint(validValue(schema))
+		}
+		return url.PathEscape(value)
+	})
+}
+
+// routeShape replaces the parameters of a path, in {name} or :name form, so that routes and documented paths compare
+func routeShape(path string) string {
+	path = strings.ReplaceAll(path, "*", ":")
+	return pathParamPattern.ReplaceAllString(path, "{}")
+}
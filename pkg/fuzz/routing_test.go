@@ -0,0 +1,79 @@
+package fuzz
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+	ginSwagger "github.com/smartcat999/go-swagger/pkg/gin"
+)
+
+// TestRoutingMismatches tests detecting documented operations that are not routed and routes added outside the router
+func TestRoutingMismatches(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := ginSwagger.NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	notFound := func(c *gin.Context) { c.JSON(http.StatusNotFound, gin.H{"error": "item not found"}) }
+	for _, def := range []*api.APIDefinition{
+		api.NewAPIDefinition("GET", "/items", "List items").WithResponse([]item{}),
+		api.NewAPIDefinition("GET", "/items/{id}", "Get item").
+			WithPathParam("id", "Item ID", true, api.Minimum(1, "must be positive")).
+			WithNativeHandler(notFound),
+		api.NewAPIDefinition("POST", "/items", "Create item").WithRequest(item{}),
+	} {
+		if def.NativeHandler == nil {
+			def.WithNativeHandler(func(c *gin.Context) { c.Status(http.StatusOK) })
+		}
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	// Matching registrations and document
+	var routes []string
+	for _, route := range engine.Routes() {
+		routes = append(routes, route.Method+" "+route.Path)
+	}
+	mismatches, err := RoutingMismatches(engine, doc, Options{BasePath: "/api", Routes: routes})
+	if err != nil {
+		t.Fatalf("RoutingMismatches failed: %v", err)
+	}
+	if len(mismatches) != 0 {
+		t.Errorf("Expected no mismatches, got %v", mismatches)
+	}
+
+	// Handlers registered on the engine directly, and operations documented but never registered
+	engine.DELETE("/api/items/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	engine.GET("/api/internal/stats", func(c *gin.Context) { c.Status(http.StatusOK) })
+	doc.Paths["/orders"] = api.PathItem{Get: &api.Operation{Summary: "List orders"}}
+	routes = routes[:0]
+	for _, route := range engine.Routes() {
+		routes = append(routes, route.Method+" "+route.Path)
+	}
+	mismatches, err = RoutingMismatches(engine, doc, Options{BasePath: "/api", Routes: routes})
+	if err != nil {
+		t.Fatalf("RoutingMismatches failed: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, m := range mismatches {
+		got[m.String()] = true
+	}
+	for _, want := range []string{
+		"DELETE /items/{id}: undocumented operation responds",
+		"GET /orders: documented operation is not routed",
+		"DELETE /api/items/:id: route is not documented",
+		"GET /api/internal/stats: route is not documented",
+	} {
+		if !got[want] {
+			t.Errorf("Expected mismatch %q, got %v", want, mismatches)
+		}
+	}
+	if len(mismatches) != 4 {
+		t.Errorf("Expected 4 mismatches, got %v", mismatches)
+	}
+}
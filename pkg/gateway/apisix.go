@@ -0,0 +1,81 @@
+package gateway
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// APISIXOptions configures the APISIX routes export
+type APISIXOptions struct {
+	Nodes  map[string]int // Upstream nodes ("host:port") and their weights
+	Scheme string         // Upstream scheme: "http" (default) or "https"
+}
+
+// APISIXRoutes returns APISIX standalone configuration (the "routes" of apisix.yaml) with a route
+// per operation; path templates become :param segments, which requires the radixtree_uri_with_parameter router
+// x-ratelimit and x-cors become limit-count and cors plugins
+func APISIXRoutes(doc *api.OpenAPIDoc, opts APISIXOptions) (map[string]interface{}, error) {
+	if len(opts.Nodes) == 0 {
+		return nil, fmt.Errorf("at least one upstream node is required")
+	}
+	scheme := opts.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	generic, err := genericDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	routeConfigs := make([]interface{}, 0)
+	for _, r := range routes(generic) {
+		config := map[string]interface{}{
+			"id":      r.operationID(),
+			"uri":     pathParamPattern.ReplaceAllString(r.fullPath, ":$1"),
+			"methods": []string{r.method},
+			"upstream": map[string]interface{}{
+				"type":   "roundrobin",
+				"scheme": scheme,
+				"nodes":  opts.Nodes,
+			},
+		}
+		if summary, ok := r.operation["summary"].(string); ok && summary != "" {
+			config["desc"] = summary
+		}
+
+		plugins := make(map[string]interface{})
+		if limit := r.extension("x-ratelimit"); limit != nil {
+			plugins["limit-count"] = map[string]interface{}{
+				"count":         number(limit["requests"]),
+				"time_window":   number(limit["window"]),
+				"key":           "remote_addr",
+				"rejected_code": 429,
+			}
+		}
+		if cors := r.extension("x-cors"); cors != nil {
+			corsConfig := map[string]interface{}{
+				"allow_origins":    strings.Join(stringList(cors["allowOrigins"]), ","),
+				"allow_methods":    strings.Join(corsMethods(cors, r), ","),
+				"allow_credential": cors["allowCredentials"] == true,
+			}
+			if headers := stringList(cors["allowHeaders"]); len(headers) > 0 {
+				corsConfig["allow_headers"] = strings.Join(headers, ",")
+			}
+			if exposed := stringList(cors["exposeHeaders"]); len(exposed) > 0 {
+				corsConfig["expose_headers"] = strings.Join(exposed, ",")
+			}
+			if maxAge := number(cors["maxAge"]); maxAge > 0 {
+				corsConfig["max_age"] = maxAge
+			}
+			plugins["cors"] = corsConfig
+		}
+		if len(plugins) > 0 {
+			config["plugins"] = plugins
+		}
+		routeConfigs = append(routeConfigs, config)
+	}
+
+	return map[string]interface{}{"routes": routeConfigs}, nil
+}
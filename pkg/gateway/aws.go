@@ -0,0 +1,61 @@
+package gateway
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// AWSIntegrationExtension is the operation extension holding an AWS API Gateway integration
+const AWSIntegrationExtension = "x-amazon-apigateway-integration"
+
+// AWSOptions configures the AWS API Gateway export
+type AWSOptions struct {
+	BackendURL      string // Base URL of the service behind the gateway, e.g. https://internal.example.com
+	IntegrationType string // Integration type: "http_proxy" (default) or "http"
+	TimeoutMillis   int    // Integration timeout; the gateway default applies when zero
+}
+
+// AWSAPIGateway returns the document in AWS API Gateway import format: every operation gets an
+// x-amazon-apigateway-integration proxying to the backend, with path parameters mapped through
+// Integrations declared on the API with WithExtension are kept as is
+func AWSAPIGateway(doc *api.OpenAPIDoc, opts AWSOptions) (map[string]interface{}, error) {
+	if opts.BackendURL == "" {
+		return nil, fmt.Errorf("backend URL is required")
+	}
+	integrationType := opts.IntegrationType
+	if integrationType == "" {
+		integrationType = "http_proxy"
+	}
+
+	generic, err := genericDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	backend := strings.TrimRight(opts.BackendURL, "/")
+	for _, r := range routes(generic) {
+		if _, ok := r.operation[AWSIntegrationExtension]; ok {
+			continue
+		}
+
+		integration := map[string]interface{}{
+			"type":                integrationType,
+			"httpMethod":          r.method,
+			"uri":                 backend + r.fullPath,
+			"passthroughBehavior": "when_no_match",
+		}
+		if opts.TimeoutMillis > 0 {
+			integration["timeoutInMillis"] = opts.TimeoutMillis
+		}
+		if params := pathParamPattern.FindAllStringSubmatch(r.path, -1); len(params) > 0 {
+			mapping := make(map[string]interface{}, len(params))
+			for _, param := range params {
+				mapping["integration.request.path."+param[1]] = "method.request.path." + param[1]
+			}
+			integration["requestParameters"] = mapping
+		}
+		r.operation[AWSIntegrationExtension] = integration
+	}
+	return generic, nil
+}
@@ -0,0 +1,134 @@
+// Package gateway translates generated documents into API gateway configuration, so the same
+// definitions drive gateway provisioning: AWS API Gateway imports, Kong declarative config and APISIX routes
+//
+// Operation extensions are carried over where the gateway has an equivalent: x-cors becomes a CORS
+// plugin or configuration and x-ratelimit a rate limiting plugin
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// route is an operation of the document flattened with its location
+type route struct {
+	method    string
+	path      string // Document path, e.g. /users/{id}
+	fullPath  string // Path including the server base path, e.g. /api/users/{id}
+	operation map[string]interface{}
+}
+
+// operationID returns the operationId of the route, or a name derived from its method and path
+func (r route) operationID() string {
+	if id, ok := r.operation["operationId"].(string); ok && id != "" {
+		return id
+	}
+	return strings.ToLower(r.method) + "_" + strings.Trim(invalidNameChars.ReplaceAllString(r.path, "_"), "_")
+}
+
+// extension returns an object-valued operation extension
+func (r route) extension(name string) map[string]interface{} {
+	ext, _ := r.operation[name].(map[string]interface{})
+	return ext
+}
+
+var (
+	invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9-]+`)
+	pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+)
+
+// genericDocument converts a document into its JSON form so extensions have uniform types
+func genericDocument(doc *api.OpenAPIDoc) (map[string]interface{}, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("document cannot be nil")
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal document: %w", err)
+	}
+	var generic map[string]interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+	return generic, nil
+}
+
+// basePath returns the path of the first relative or absolute server URL, e.g. /api
+func basePath(doc map[string]interface{}) string {
+	servers, _ := doc["servers"].([]interface{})
+	if len(servers) == 0 {
+		return ""
+	}
+	server, _ := servers[0].(map[string]interface{})
+	url, _ := server["url"].(string)
+	if i := strings.Index(url, "://"); i >= 0 {
+		url = url[i+3:]
+		if j := strings.Index(url, "/"); j >= 0 {
+			url = url[j:]
+		} else {
+			url = ""
+		}
+	}
+	return strings.TrimRight(url, "/")
+}
+
+// routes lists the operations of a generic document in path and method order
+func routes(doc map[string]interface{}) []route {
+	base := basePath(doc)
+	paths, _ := doc["paths"].(map[string]interface{})
+	names := make([]string, 0, len(paths))
+	for path := range paths {
+		names = append(names, path)
+	}
+	sort.Strings(names)
+
+	var result []route
+	for _, path := range names {
+		item, _ := paths[path].(map[string]interface{})
+		for _, method := range api.SupportedMethods {
+			operation, ok := item[strings.ToLower(method)].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			result = append(result, route{
+				method:    method,
+				path:      path,
+				fullPath:  base + path,
+				operation: operation,
+			})
+		}
+	}
+	return result
+}
+
+// stringList converts a JSON array of strings
+func stringList(v interface{}) []string {
+	items, _ := v.([]interface{})
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// number converts a JSON number to an int
+func number(v interface{}) int {
+	n, _ := v.(float64)
+	return int(n)
+}
+
+// corsMethods returns the allowed methods of a CORS extension, defaulting to the route's method
+func corsMethods(cors map[string]interface{}, r route) []string {
+	if methods := stringList(cors["allowMethods"]); len(methods) > 0 {
+		return methods
+	}
+	return []string{r.method, http.MethodOptions}
+}
@@ -0,0 +1,147 @@
+package gateway
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+	ginSwagger "github.com/smartcat999/go-swagger/pkg/gin"
+)
+
+func generatedDoc(t *testing.T) *api.OpenAPIDoc {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := ginSwagger.NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+
+	noop := func(c *gin.Context) {}
+	defs := []*api.APIDefinition{
+		api.GET("/users", "List users").
+			WithOperationID("listUsers").
+			WithRateLimit(api.RateLimitPolicy{Requests: 100, Window: time.Minute}).
+			WithCORS(api.CORSPolicy{AllowOrigins: []string{"https://app.example.com"}, MaxAge: time.Hour}),
+		api.GET("/users/{id}", "Get user").
+			WithOperationID("getUser").
+			WithPathParam("id", "User ID", true),
+		api.POST("/users", "Create user").
+			WithOperationID("createUser").
+			WithExtension(AWSIntegrationExtension, map[string]interface{}{"type": "mock"}),
+	}
+	for _, def := range defs {
+		if err := router.Register(def.WithNativeHandler(noop)); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	return doc
+}
+
+func operation(doc map[string]interface{}, path, method string) map[string]interface{} {
+	return doc["paths"].(map[string]interface{})[path].(map[string]interface{})[method].(map[string]interface{})
+}
+
+// TestAWSAPIGateway tests adding proxy integrations to every operation
+func TestAWSAPIGateway(t *testing.T) {
+	out, err := AWSAPIGateway(generatedDoc(t), AWSOptions{BackendURL: "https://backend.internal/", TimeoutMillis: 5000})
+	if err != nil {
+		t.Fatalf("AWSAPIGateway failed: %v", err)
+	}
+
+	integration := operation(out, "/users/{id}", "get")[AWSIntegrationExtension].(map[string]interface{})
+	if integration["uri"] != "https://backend.internal/api/users/{id}" || integration["type"] != "http_proxy" {
+		t.Errorf("Unexpected integration %v", integration)
+	}
+	want := map[string]interface{}{"integration.request.path.id": "method.request.path.id"}
+	if !reflect.DeepEqual(integration["requestParameters"], want) {
+		t.Errorf("Unexpected parameter mapping %v", integration["requestParameters"])
+	}
+
+	declared := operation(out, "/users", "post")[AWSIntegrationExtension].(map[string]interface{})
+	if declared["type"] != "mock" {
+		t.Errorf("Expected declared integration to be kept, got %v", declared)
+	}
+
+	if _, err := AWSAPIGateway(generatedDoc(t), AWSOptions{}); err == nil {
+		t.Error("Expected error without backend URL")
+	}
+}
+
+// TestKongConfig tests the declarative config with routes and plugins
+func TestKongConfig(t *testing.T) {
+	out, err := KongConfig(generatedDoc(t), KongOptions{ServiceName: "users", UpstreamURL: "http://users.internal:8080"})
+	if err != nil {
+		t.Fatalf("KongConfig failed: %v", err)
+	}
+
+	service := out["services"].([]interface{})[0].(map[string]interface{})
+	routes := service["routes"].([]interface{})
+	if len(routes) != 3 {
+		t.Fatalf("Expected 3 routes, got %d", len(routes))
+	}
+	byName := make(map[string]map[string]interface{})
+	for _, r := range routes {
+		config := r.(map[string]interface{})
+		byName[config["name"].(string)] = config
+	}
+
+	path := byName["getUser"]["paths"].([]string)[0]
+	if path != "~/api/users/[^/]+$" || !regexp.MustCompile(path[1:]).MatchString("/api/users/42") {
+		t.Errorf("Unexpected route path %s", path)
+	}
+
+	plugins := byName["listUsers"]["plugins"].([]interface{})
+	limit := plugins[0].(map[string]interface{})
+	if limit["name"] != "rate-limiting" || limit["config"].(map[string]interface{})["minute"] != 100 {
+		t.Errorf("Unexpected rate limiting plugin %v", limit)
+	}
+	cors := plugins[1].(map[string]interface{})["config"].(map[string]interface{})
+	if !reflect.DeepEqual(cors["origins"], []string{"https://app.example.com"}) || cors["max_age"] != 3600 {
+		t.Errorf("Unexpected cors plugin %v", cors)
+	}
+}
+
+// TestKongRateLimit tests converting windows Kong cannot express
+func TestKongRateLimit(t *testing.T) {
+	if got := kongRateLimit(10, 30); got["minute"] != 20 {
+		t.Errorf("Expected 20 per minute, got %v", got)
+	}
+	if got := kongRateLimit(5, 3600); got["hour"] != 5 {
+		t.Errorf("Expected 5 per hour, got %v", got)
+	}
+}
+
+// TestAPISIXRoutes tests routes with parameterized URIs and plugins
+func TestAPISIXRoutes(t *testing.T) {
+	out, err := APISIXRoutes(generatedDoc(t), APISIXOptions{Nodes: map[string]int{"users.internal:8080": 1}})
+	if err != nil {
+		t.Fatalf("APISIXRoutes failed: %v", err)
+	}
+
+	routes := out["routes"].([]interface{})
+	byID := make(map[string]map[string]interface{})
+	for _, r := range routes {
+		config := r.(map[string]interface{})
+		byID[config["id"].(string)] = config
+	}
+	if byID["getUser"]["uri"] != "/api/users/:id" {
+		t.Errorf("Unexpected uri %v", byID["getUser"]["uri"])
+	}
+
+	plugins := byID["listUsers"]["plugins"].(map[string]interface{})
+	limit := plugins["limit-count"].(map[string]interface{})
+	if limit["count"] != 100 || limit["time_window"] != 60 {
+		t.Errorf("Unexpected limit-count plugin %v", limit)
+	}
+	if plugins["cors"].(map[string]interface{})["allow_origins"] != "https://app.example.com" {
+		t.Errorf("Unexpected cors plugin %v", plugins["cors"])
+	}
+
+	if _, err := APISIXRoutes(generatedDoc(t), APISIXOptions{}); err == nil {
+		t.Error("Expected error without upstream nodes")
+	}
+}
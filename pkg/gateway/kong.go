@@ -0,0 +1,108 @@
+package gateway
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// KongOptions configures the Kong declarative config export
+type KongOptions struct {
+	ServiceName string // Name of the Kong service fronting the API
+	UpstreamURL string // URL of the service behind the gateway, e.g. http://users.internal:8080
+}
+
+// KongConfig returns a Kong (3.x) declarative configuration with one service and a route per operation
+// Routes match the full path with a regex and keep it when proxying; x-ratelimit and x-cors
+// become rate-limiting and cors plugins on the route
+func KongConfig(doc *api.OpenAPIDoc, opts KongOptions) (map[string]interface{}, error) {
+	if opts.ServiceName == "" || opts.UpstreamURL == "" {
+		return nil, fmt.Errorf("service name and upstream URL are required")
+	}
+	generic, err := genericDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	routeConfigs := make([]interface{}, 0)
+	for _, r := range routes(generic) {
+		config := map[string]interface{}{
+			"name":       r.operationID(),
+			"methods":    []string{r.method},
+			"paths":      []string{kongPath(r.fullPath)},
+			"strip_path": false,
+		}
+
+		var plugins []interface{}
+		if limit := r.extension("x-ratelimit"); limit != nil {
+			plugins = append(plugins, map[string]interface{}{
+				"name":   "rate-limiting",
+				"config": kongRateLimit(number(limit["requests"]), number(limit["window"])),
+			})
+		}
+		if cors := r.extension("x-cors"); cors != nil {
+			corsConfig := map[string]interface{}{
+				"origins":     stringList(cors["allowOrigins"]),
+				"methods":     corsMethods(cors, r),
+				"credentials": cors["allowCredentials"] == true,
+			}
+			if headers := stringList(cors["allowHeaders"]); len(headers) > 0 {
+				corsConfig["headers"] = headers
+			}
+			if exposed := stringList(cors["exposeHeaders"]); len(exposed) > 0 {
+				corsConfig["exposed_headers"] = exposed
+			}
+			if maxAge := number(cors["maxAge"]); maxAge > 0 {
+				corsConfig["max_age"] = maxAge
+			}
+			plugins = append(plugins, map[string]interface{}{"name": "cors", "config": corsConfig})
+		}
+		if len(plugins) > 0 {
+			config["plugins"] = plugins
+		}
+		routeConfigs = append(routeConfigs, config)
+	}
+
+	return map[string]interface{}{
+		"_format_version": "3.0",
+		"services": []interface{}{
+			map[string]interface{}{
+				"name":   opts.ServiceName,
+				"url":    opts.UpstreamURL,
+				"routes": routeConfigs,
+			},
+		},
+	}, nil
+}
+
+// kongPath converts a templated path into an anchored Kong regex path, e.g. ~/users/[^/]+$
+func kongPath(path string) string {
+	return "~" + pathParamPattern.ReplaceAllString(regexpQuotePath(path), "[^/]+") + "$"
+}
+
+// regexpQuotePath escapes regex metacharacters outside path templates
+func regexpQuotePath(path string) string {
+	var b strings.Builder
+	for _, c := range path {
+		if strings.ContainsRune(`.+*?()|[]^$\`, c) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// kongRateLimit expresses a limit in the closest Kong rate-limiting window
+// Windows Kong cannot express exactly are converted to an equivalent per-minute rate
+func kongRateLimit(requests, window int) map[string]interface{} {
+	windows := map[int]string{1: "second", 60: "minute", 3600: "hour", 86400: "day"}
+	if name, ok := windows[window]; ok {
+		return map[string]interface{}{name: requests, "policy": "local"}
+	}
+	perMinute := 1
+	if window > 0 && requests*60/window > 1 {
+		perMinute = requests * 60 / window
+	}
+	return map[string]interface{}{"minute": perMinute, "policy": "local"}
+}
@@ -0,0 +1,252 @@
+package gin
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// ginParamsKey keys the gin path parameters in the requests handed to core handlers
+type ginParamsKey struct{}
+
+// engineContextKey keys the gin context in the requests handed to the core request steps
+type engineContextKey struct{}
+
+// engineAdapter binds the framework-neutral core router to a gin engine
+// Operations run the core request steps as the first handler of their engine route, followed by the
+// operation middlewares and the handler, so gin middlewares and handlers see validated requests
+type engineAdapter struct {
+	engine *gin.Engine
+	routes map[string]*engineRoute
+}
+
+// engineRoute is the engine route backing one method and path
+// The handlers are swapped in place when a later registration wins, since gin routes can't be replaced
+type engineRoute struct {
+	handlers gin.HandlersChain
+}
+
+// NewAdapter returns an adapter registering core router routes on a gin engine
+func NewAdapter(engine *gin.Engine) router.Adapter {
+	return &engineAdapter{engine: engine}
+}
+
+// RegisterRoute binds a handler to a method and an OpenAPI path
+func (a *engineAdapter) RegisterRoute(method, path string, handler http.Handler) error {
+	ginPath := convertOpenAPIPathToGin(path)
+	if a.hasRoute(method, ginPath) {
+		return fmt.Errorf("%w: %s %s", router.ErrRouteExists, method, path)
+	}
+	a.engine.Handle(method, ginPath, func(c *gin.Context) {
+		ctx := context.WithValue(c.Request.Context(), ginParamsKey{}, c.Params)
+		handler.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
+	})
+	return nil
+}
+
+// RegisterOperation binds the request steps, the operation middlewares and the handler of a definition as
+// the handler chain of an engine route, replacing the chain of an operation registered earlier
+func (a *engineAdapter) RegisterOperation(method, path string, def *api.APIDefinition, steps func(next http.Handler) http.Handler) error {
+	if err := checkMiddleware(def.Middleware); err != nil {
+		return err
+	}
+	chain := append(gin.HandlersChain{runSteps(steps)}, middlewareChain(def.Middleware)...)
+	chain = append(chain, func(c *gin.Context) {
+		callHandler(c, def)
+	})
+
+	ginPath := convertOpenAPIPathToGin(path)
+	key := routeKey(method, ginPath)
+	if existing, ok := a.routes[key]; ok {
+		// The engine route keeps its number of handlers; shorter chains are padded with no-ops
+		if len(chain) > len(existing.handlers) {
+			return fmt.Errorf("cannot replace %s %s with more middlewares than the first registration", method, path)
+		}
+		for len(chain) < len(existing.handlers) {
+			chain = append(chain, func(*gin.Context) {})
+		}
+		existing.handlers = chain
+		return nil
+	}
+	if a.hasRoute(method, ginPath) {
+		return fmt.Errorf("%w: %s %s", router.ErrRouteExists, method, path)
+	}
+
+	route := &engineRoute{handlers: chain}
+	if a.routes == nil {
+		a.routes = make(map[string]*engineRoute)
+	}
+	a.routes[key] = route
+	slots := make(gin.HandlersChain, len(chain))
+	for i := range chain {
+		i := i
+		slots[i] = func(c *gin.Context) {
+			route.handlers[i](c)
+		}
+	}
+	a.engine.Handle(method, ginPath, slots...)
+	return nil
+}
+
+// hasRoute reports whether the engine already routes the method and gin path
+func (a *engineAdapter) hasRoute(method, ginPath string) bool {
+	key := routeKey(method, ginPath)
+	for _, route := range a.engine.Routes() {
+		if routeKey(route.Method, route.Path) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// ParamExtractor reads the path parameters matched by gin
+func (a *engineAdapter) ParamExtractor() router.ParamExtractor {
+	return func(r *http.Request, name string) string {
+		if c := contextOf(r); c != nil {
+			return c.Param(name)
+		}
+		params, _ := r.Context().Value(ginParamsKey{}).(gin.Params)
+		return params.ByName(name)
+	}
+}
+
+// runSteps runs the core request steps, continuing the gin chain with the request and writer they pass on
+// The chain is aborted when the steps answer the request themselves
+func runSteps(steps func(next http.Handler) http.Handler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writer := c.Writer
+		continued := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			continued = true
+			engineWriter := newEngineWriter(writer, w)
+			c.Request = req
+			c.Writer = engineWriter
+			c.Next()
+			engineWriter.flush()
+		})
+		ctx := context.WithValue(c.Request.Context(), engineContextKey{}, c)
+		steps(next).ServeHTTP(writer, c.Request.WithContext(ctx))
+		c.Writer = writer
+		if !continued {
+			c.Abort()
+		}
+	}
+}
+
+// contextOf returns the gin context of a request handed to the core request steps, or nil
+func contextOf(r *http.Request) *gin.Context {
+	c, _ := r.Context().Value(engineContextKey{}).(*gin.Context)
+	return c
+}
+
+// withEngineWriter runs fn with the gin context writing to w, e.g. for hooks answering requests from the
+// core request steps
+func withEngineWriter(c *gin.Context, w http.ResponseWriter, fn func()) {
+	writer := c.Writer
+	engineWriter := newEngineWriter(writer, w)
+	c.Writer = engineWriter
+	fn()
+	engineWriter.flush()
+	c.Writer = writer
+}
+
+// engineWriter exposes a core response writer as a gin.ResponseWriter
+// Like gin's own writer, the status is only sent with the body, so headers can be set after c.Status
+type engineWriter struct {
+	http.ResponseWriter
+	engine    gin.ResponseWriter // Writer of the engine, for CloseNotify and Pusher
+	status    int
+	statusSet bool
+	size      int // -1 until the status has been sent
+}
+
+func newEngineWriter(engine gin.ResponseWriter, w http.ResponseWriter) *engineWriter {
+	return &engineWriter{ResponseWriter: w, engine: engine, status: http.StatusOK, size: -1}
+}
+
+func (w *engineWriter) WriteHeader(code int) {
+	if code > 0 && !w.Written() {
+		w.status = code
+		w.statusSet = true
+	}
+}
+
+func (w *engineWriter) WriteHeaderNow() {
+	if !w.Written() {
+		w.size = 0
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+}
+
+func (w *engineWriter) Write(data []byte) (int, error) {
+	w.WriteHeaderNow()
+	n, err := w.ResponseWriter.Write(data)
+	w.size += n
+	return n, err
+}
+
+func (w *engineWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *engineWriter) Status() int {
+	return w.status
+}
+
+func (w *engineWriter) Size() int {
+	return w.size
+}
+
+func (w *engineWriter) Written() bool {
+	return w.size != -1
+}
+
+func (w *engineWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	if w.size < 0 {
+		w.size = 0
+	}
+	return hijacker.Hijack()
+}
+
+func (w *engineWriter) CloseNotify() <-chan bool {
+	return w.engine.CloseNotify()
+}
+
+func (w *engineWriter) Flush() {
+	w.WriteHeaderNow()
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *engineWriter) Pusher() http.Pusher {
+	return w.engine.Pusher()
+}
+
+// flush sends a status set without a body, as gin does once the handlers return
+func (w *engineWriter) flush() {
+	if w.statusSet {
+		w.WriteHeaderNow()
+	}
+}
+
+var ginParamPattern = regexp.MustCompile(`[:*][^/]+`)
+
+// routeKey identifies an engine route; parameter names are ignored since gin treats
+// /users/:id and /users/:userId as the same route
+func routeKey(method, fullPath string) string {
+	return operationKey(method, ginParamPattern.ReplaceAllString(fullPath, ":"))
+}
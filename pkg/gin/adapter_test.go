@@ -0,0 +1,45 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// TestAdapter tests running the core router on a gin engine
+func TestAdapter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	core := router.New(NewAdapter(engine), "/api", "Test API", "1.0.0", "Test")
+
+	def := api.NewAPIDefinition("GET", "/users/{id}", "Get user").
+		WithPathParam("id", "User ID", true, api.NewValidationRule("pattern", "^[0-9]+$", "id must be numeric")).
+		WithHandler(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		})
+	if err := core.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+	}{
+		{name: "valid", target: "/api/users/1", wantStatus: http.StatusOK},
+		{name: "invalid path parameter", target: "/api/users/abc", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest("GET", tt.target, nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type tagBatch struct {
+	Tags []string `json:"tags" binding:"required,min=1,max=3,unique,dive,min=2"`
+}
+
+// TestArrayValidation tests array constraints on multi-value query parameters and body arrays
+func TestArrayValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	apiDef := api.NewAPIDefinition("POST", "/tags", "Tag items").
+		WithQueryParam("ids", "Item IDs", false).
+		WithArrayConstraints("ids", 0, 2, true).
+		WithRequest(tagBatch{}).
+		WithNativeHandler(func(c *gin.Context) { c.Status(http.StatusOK) })
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		body       string
+		wantStatus int
+	}{
+		{name: "valid", target: "/api/tags?ids=1&ids=2", body: `{"tags":["go","api"]}`, wantStatus: http.StatusOK},
+		{name: "too many query items", target: "/api/tags?ids=1&ids=2&ids=3", body: `{"tags":["go"]}`, wantStatus: http.StatusBadRequest},
+		{name: "duplicate query items", target: "/api/tags?ids=1&ids=1", body: `{"tags":["go"]}`, wantStatus: http.StatusBadRequest},
+		{name: "duplicate body items", target: "/api/tags", body: `{"tags":["go","go"]}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid body item", target: "/api/tags", body: `{"tags":["g"]}`, wantStatus: http.StatusBadRequest},
+		{name: "empty body array", target: "/api/tags", body: `{"tags":[]}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	tags := doc.Paths["/tags"].Post.RequestBody.Content["application/json"].Schema["properties"].(map[string]interface{})["tags"].(map[string]interface{})
+	if tags["uniqueItems"] != true || tags["items"].(map[string]interface{})["minLength"] != 2 {
+		t.Errorf("Unexpected tags schema %v", tags)
+	}
+}
+
+type userListQuery struct {
+	Tags   []string `query:"tag" binding:"max=2,dive,min=2"`
+	Filter struct {
+		Role string `json:"role"`
+	} `query:"filter"`
+}
+
+// TestQueryModel tests binding repeated and deepObject query parameters into a query model
+func TestQueryModel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	apiDef := api.NewAPIDefinition("GET", "/users", "List users").
+		WithQueryModel(userListQuery{}).
+		WithNativeHandler(func(c *gin.Context) {
+			q := QueryModel(c).(*userListQuery)
+			c.String(http.StatusOK, "%s %s", strings.Join(q.Tags, ","), q.Filter.Role)
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "bound", target: "/api/users?tag=go&tag=api&filter[role]=admin", wantStatus: http.StatusOK, wantBody: "go,api admin"},
+		{name: "invalid item", target: "/api/users?tag=g", wantStatus: http.StatusBadRequest},
+		{name: "too many items", target: "/api/users?tag=go&tag=api&tag=db", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest("GET", tt.target, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("Expected %q, got %q", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	params := doc.Paths["/users"].Get.Parameters
+	if len(params) != 2 || params[0].Schema["type"] != "array" || params[1].Style != "deepObject" {
+		t.Errorf("Unexpected query parameters %+v", params)
+	}
+}
@@ -0,0 +1,42 @@
+package gin
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestAsyncResponse tests documenting a long-running operation linked to its status endpoint
+func TestAsyncResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	handler := func(c *gin.Context) { c.Status(http.StatusAccepted) }
+	defs := []*api.APIDefinition{
+		api.NewAPIDefinition("POST", "/reports", "Generate report").
+			WithAsyncResponse(api.OperationStatus{}).
+			WithNativeHandler(handler),
+		api.AsyncStatusDefinition(api.OperationStatus{}).WithNativeHandler(handler),
+	}
+	for _, def := range defs {
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	accepted := doc.Paths["/reports"].Post.Responses["202"]
+	link, ok := accepted.Links["status"]
+	if !ok || doc.Paths[api.AsyncStatusPath].Get.OperationID != link.OperationID {
+		t.Errorf("Expected the 202 response to link to the status operation, got %+v", accepted)
+	}
+	if err := api.ValidateDoc(doc); err != nil {
+		t.Errorf("Expected a valid document: %v", err)
+	}
+}
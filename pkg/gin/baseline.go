@@ -0,0 +1,75 @@
+package gin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// LoadBaseline sets the previously published document that GenerateSwagger checks the registered definitions
+// against; removed or narrowed operations fail generation when enforce is set and are reported as
+// breaking-change warnings otherwise
+func (r *APIRouter) LoadBaseline(data []byte, enforce bool) error {
+	var doc struct {
+		Paths map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to read baseline document: %w", err)
+	}
+	r.baseline = data
+	r.enforceBaseline = enforce
+	return nil
+}
+
+// LoadBaselineFile loads the baseline document from a JSON file, see LoadBaseline
+func (r *APIRouter) LoadBaselineFile(path string, enforce bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read baseline document: %w", err)
+	}
+	return r.LoadBaseline(data, enforce)
+}
+
+// CheckBaseline returns the changes of the registered definitions that break the baseline, e.g. to fail a
+// startup check before serving; it returns nothing when no baseline is loaded
+func (r *APIRouter) CheckBaseline() ([]api.BreakingChange, error) {
+	if r.baseline == nil {
+		return nil, nil
+	}
+	doc, err := r.buildDocument(nil)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal OpenAPI document: %w", err)
+	}
+	return api.BreakingChanges(r.baseline, data)
+}
+
+// checkBaseline compares the generated document with the baseline, failing on breaking changes when enforced
+func (r *APIRouter) checkBaseline(data []byte, report *GenerationReport) error {
+	if r.baseline == nil {
+		return nil
+	}
+	changes, err := api.BreakingChanges(r.baseline, data)
+	if err != nil {
+		return err
+	}
+	if r.enforceBaseline && len(changes) > 0 {
+		errs := make([]error, len(changes))
+		for i, change := range changes {
+			errs[i] = errors.New(change.String())
+		}
+		return fmt.Errorf("breaking changes against the baseline document: %w", errors.Join(errs...))
+	}
+	for _, change := range changes {
+		method, path, _ := strings.Cut(change.Operation, " ")
+		report.Warnings = append(report.Warnings, GenerationWarning{Kind: WarningBreakingChange, Method: method, Path: path, Message: change.Message})
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package gin
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestBaseline tests guarding the published document against breaking changes
+func TestBaseline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := func(c *gin.Context) {}
+	newRouter := func(definitions ...*api.APIDefinition) *APIRouter {
+		router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+		for _, def := range definitions {
+			if err := router.Register(def.WithNativeHandler(handler)); err != nil {
+				t.Fatalf("Register failed: %v", err)
+			}
+		}
+		return router
+	}
+	listUsers := func() *api.APIDefinition {
+		return api.NewAPIDefinition("GET", "/users", "List users").WithQueryParam("q", "Search", false).WithResponse([]UserResponse{})
+	}
+	createUser := func() *api.APIDefinition {
+		return api.NewAPIDefinition("POST", "/users", "Create user").WithRequest(UserResponse{}).WithResponse(UserResponse{})
+	}
+
+	published, err := newRouter(listUsers(), createUser()).GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	baseline, err := json.Marshal(published)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		definitions []*api.APIDefinition
+		enforce     bool
+		wantErr     bool
+		wantChanges int
+	}{
+		{"unchanged", []*api.APIDefinition{listUsers(), createUser()}, true, false, 0},
+		{"added operation", []*api.APIDefinition{listUsers(), createUser(), api.NewAPIDefinition("GET", "/orders", "List orders")}, true, false, 0},
+		{"removed operation enforced", []*api.APIDefinition{listUsers()}, true, true, 1},
+		{"removed operation reported", []*api.APIDefinition{listUsers()}, false, false, 1},
+		{"narrowed parameter reported", []*api.APIDefinition{listUsers().WithQueryParam("tenant", "Tenant", true), createUser()}, false, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newRouter(tt.definitions...)
+			if err := router.LoadBaseline(baseline, tt.enforce); err != nil {
+				t.Fatalf("LoadBaseline failed: %v", err)
+			}
+
+			changes, err := router.CheckBaseline()
+			if err != nil {
+				t.Fatalf("CheckBaseline failed: %v", err)
+			}
+			if len(changes) != tt.wantChanges {
+				t.Errorf("Expected %d changes, got %v", tt.wantChanges, changes)
+			}
+
+			if _, err := router.GenerateSwagger(); (err != nil) != tt.wantErr {
+				t.Fatalf("Expected generation error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+			var warnings int
+			for _, warning := range router.GenerateReport().Warnings {
+				if warning.Kind == WarningBreakingChange {
+					warnings++
+				}
+			}
+			if warnings != tt.wantChanges {
+				t.Errorf("Expected %d breaking-change warnings, got %d", tt.wantChanges, warnings)
+			}
+		})
+	}
+
+	if err := newRouter().LoadBaseline([]byte("not json"), true); err == nil {
+		t.Error("Expected LoadBaseline to reject an invalid document")
+	}
+}
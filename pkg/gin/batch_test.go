@@ -0,0 +1,81 @@
+package gin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type batchUser struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// TestBatchRequest tests validating batch bodies and answering with per-item results
+func TestBatchRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	apiDef := api.NewAPIDefinition("POST", "/users/batch", "Create users").
+		WithBatchRequest(batchUser{}, 2).
+		WithNativeHandler(func(c *gin.Context) {
+			var users []batchUser
+			if err := c.ShouldBindJSON(&users); err != nil {
+				c.Status(http.StatusInternalServerError)
+				return
+			}
+			response := api.BatchResponse{}
+			for i, user := range users {
+				response.Results = append(response.Results, api.BatchItemResult{Index: i, Status: http.StatusCreated, Data: user})
+			}
+			c.JSON(http.StatusMultiStatus, response)
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "valid", body: `[{"name":"alice"},{"name":"bob"}]`, wantStatus: http.StatusMultiStatus},
+		{name: "empty", body: `[]`, wantStatus: http.StatusBadRequest},
+		{name: "too many", body: `[{"name":"a"},{"name":"b"},{"name":"c"}]`, wantStatus: http.StatusBadRequest},
+		{name: "invalid item", body: `[{"name":"alice"},{}]`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/users/batch", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusMultiStatus {
+				var response api.BatchResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || len(response.Results) != 2 {
+					t.Errorf("Expected 2 item results, got %s", w.Body.String())
+				}
+			}
+		})
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	op := doc.Paths["/users/batch"].Post
+	if schema := op.RequestBody.Content["application/json"].Schema; schema["type"] != "array" || schema["maxItems"] != 2 {
+		t.Errorf("Expected an array request body, got %v", schema)
+	}
+	if _, ok := op.Responses["207"].Content["application/json"]; !ok {
+		t.Errorf("Expected a 207 response schema, got %v", op.Responses["207"])
+	}
+}
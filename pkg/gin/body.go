@@ -0,0 +1,11 @@
+package gin
+
+import (
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// SetBodyHandling selects whether handlers can re-read validated request bodies (router.RestoreBody, the
+// default) or only find their decoded value with RequestBody (router.DecodedBodyOnly)
+func (r *APIRouter) SetBodyHandling(mode router.BodyHandling) {
+	r.core.SetBodyHandling(mode)
+}
@@ -0,0 +1,63 @@
+package gin
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// TestBodyHandling tests that native handlers can re-read validated bodies or only find their decoded value
+func TestBodyHandling(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	body := `{"username":"john","email":"john@example.com"}`
+	tests := []struct {
+		name    string
+		mode    router.BodyHandling
+		wantRaw string
+	}{
+		{name: "restore", mode: router.RestoreBody, wantRaw: body},
+		{name: "decoded only", mode: router.DecodedBodyOnly, wantRaw: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			apiRouter := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+			apiRouter.SetBodyHandling(tt.mode)
+			var raw string
+			var decoded *CreateUserRequest
+			apiDef := api.NewAPIDefinition("POST", "/users", "Create user").
+				WithRequest(CreateUserRequest{}).
+				WithNativeHandler(func(c *gin.Context) {
+					data, _ := io.ReadAll(c.Request.Body)
+					raw = string(data)
+					decoded, _ = RequestBody(c).(*CreateUserRequest)
+					c.Status(http.StatusCreated)
+				})
+			if err := apiRouter.Register(apiDef); err != nil {
+				t.Fatalf("Register failed: %v", err)
+			}
+
+			req := httptest.NewRequest("POST", "/api/users", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			if w.Code != http.StatusCreated {
+				t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+			}
+			if raw != tt.wantRaw {
+				t.Errorf("Handler read %q, want %q", raw, tt.wantRaw)
+			}
+			if decoded == nil || decoded.Username != "john" {
+				t.Errorf("Expected the decoded body, got %v", decoded)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package gin
+
+// EnableRequestDecompression accepts gzip and deflate encoded request bodies, decompressing them before
+// validation and rejecting bodies inflating beyond maxSize bytes; operations document x-request-encodings
+func (r *APIRouter) EnableRequestDecompression(maxSize int64) {
+	r.core.EnableRequestDecompression(maxSize)
+}
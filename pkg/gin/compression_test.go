@@ -0,0 +1,71 @@
+package gin
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestRequestDecompression tests validating gzip encoded bodies and rejecting oversized or unsupported ones
+func TestRequestDecompression(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.EnableRequestDecompression(128)
+
+	apiDef := api.NewAPIDefinition("POST", "/users", "Create user").
+		WithRequest(CreateUserRequest{}).
+		WithNativeHandler(func(c *gin.Context) {
+			c.Status(http.StatusCreated)
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	compress := func(body string) []byte {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		w.Write([]byte(body))
+		w.Close()
+		return buf.Bytes()
+	}
+	tests := []struct {
+		name       string
+		encoding   string
+		body       []byte
+		wantStatus int
+	}{
+		{name: "gzip", encoding: "gzip", body: compress(`{"username":"john","email":"john@example.com"}`), wantStatus: http.StatusCreated},
+		{name: "invalid payload", encoding: "gzip", body: compress(`{"username":`), wantStatus: http.StatusBadRequest},
+		{name: "too large", encoding: "gzip", body: compress(`{"username":"` + strings.Repeat("a", 200) + `"}`), wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "unsupported encoding", encoding: "br", body: []byte(`{}`), wantStatus: http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/users", bytes.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Content-Encoding", tt.encoding)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d. Body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if _, ok := doc.Paths["/users"].Post.Extensions["x-request-encodings"]; !ok {
+		t.Error("Expected x-request-encodings extension")
+	}
+}
@@ -0,0 +1,52 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestConditions tests mutually exclusive and required_without parameter groups
+func TestConditions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	apiDef := api.NewAPIDefinition("GET", "/lookup", "Look up a contact").
+		WithQueryParam("email", "Email", false).
+		WithHeaderParam("X-Phone", "Phone", false).
+		WithRequiredWithout("email", "X-Phone").
+		WithMutuallyExclusive("email", "X-Phone").
+		WithNativeHandler(func(c *gin.Context) { c.Status(http.StatusOK) })
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		phone      string
+		wantStatus int
+	}{
+		{name: "email", target: "/api/lookup?email=a@b.c", wantStatus: http.StatusOK},
+		{name: "phone", target: "/api/lookup", phone: "123", wantStatus: http.StatusOK},
+		{name: "neither", target: "/api/lookup", wantStatus: http.StatusBadRequest},
+		{name: "both", target: "/api/lookup?email=a@b.c", phone: "123", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.target, nil)
+			if tt.phone != "" {
+				req.Header.Set("X-Phone", tt.phone)
+			}
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
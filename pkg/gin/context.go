@@ -0,0 +1,13 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// SetPrincipal stores the authenticated principal in the request context for request rules and
+// api.PrincipalFromContext; call it from authentication middlewares registered before the routes
+func SetPrincipal(c *gin.Context, principal interface{}) {
+	c.Request = c.Request.WithContext(api.ContextWithPrincipal(c.Request.Context(), principal))
+}
@@ -0,0 +1,131 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestOperationFromContext tests exposing the matched definition to middlewares and handlers
+func TestOperationFromContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	var seen []string
+	record := func(c *gin.Context) {
+		def, ok := api.OperationFromContext(c.Request.Context())
+		if !ok {
+			seen = append(seen, "missing")
+			return
+		}
+		seen = append(seen, def.OperationID)
+	}
+	explicit := api.NewAPIDefinition("GET", "/users", "List users").
+		WithOperationID("listUsers").
+		WithMiddleware(record).
+		WithNativeHandler(record)
+	generated := api.NewAPIDefinition("DELETE", "/users/{id}", "Delete user").
+		WithTags("users").
+		WithDeprecated(true).
+		WithNativeHandler(func(c *gin.Context) {
+			def, _ := api.OperationFromContext(c.Request.Context())
+			if !def.Deprecated {
+				t.Error("Expected the deprecated flag to be carried")
+			}
+			record(c)
+		})
+	for _, def := range []*api.APIDefinition{explicit, generated} {
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		wantSeen []string
+	}{
+		{name: "explicit operationId", method: "GET", target: "/api/users", wantSeen: []string{"listUsers", "listUsers"}},
+		{name: "generated operationId", method: "DELETE", target: "/api/users/1", wantSeen: []string{"users_users"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen = nil
+			engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.target, nil))
+			if len(seen) != len(tt.wantSeen) {
+				t.Fatalf("Expected %v, got %v", tt.wantSeen, seen)
+			}
+			for i := range seen {
+				if seen[i] != tt.wantSeen[i] {
+					t.Errorf("Expected %v, got %v", tt.wantSeen, seen)
+				}
+			}
+		})
+	}
+
+	if generated.OperationID != "" {
+		t.Errorf("Expected the registered definition to be left unchanged, got %q", generated.OperationID)
+	}
+}
+
+// TestRequestRules tests enforcing request rules with the principal set by an authentication middleware
+func TestRequestRules(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		if role := c.GetHeader("X-Role"); role != "" {
+			SetPrincipal(c, role)
+		}
+	})
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	def := api.NewAPIDefinition("GET", "/reports", "List reports").
+		WithQueryParam("from", "Start date", false).
+		WithQueryParam("to", "End date", false).
+		WithQueryParam("owner", "Owner", false).
+		WithAfter("to", "from").
+		WithRestrictedField("owner", "admins", func(principal interface{}) bool { return principal == "admin" }).
+		WithNativeHandler(func(c *gin.Context) {})
+	if err := router.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		role       string
+		wantStatus int
+	}{
+		{name: "ordered", target: "/api/reports?from=2024-01-01&to=2024-02-01", wantStatus: http.StatusOK},
+		{name: "reversed", target: "/api/reports?from=2024-02-01&to=2024-01-01", wantStatus: http.StatusBadRequest},
+		{name: "owner by admin", target: "/api/reports?owner=bob", role: "admin", wantStatus: http.StatusOK},
+		{name: "owner by user", target: "/api/reports?owner=bob", role: "user", wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.Header.Set("X-Role", tt.role)
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	rules, _ := doc.Paths["/reports"].Get.Extensions["x-request-rules"].([]string)
+	if len(rules) != 2 || rules[1] != "only admins may set owner" {
+		t.Errorf("Unexpected x-request-rules %v", rules)
+	}
+}
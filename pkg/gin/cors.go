@@ -0,0 +1,12 @@
+package gin
+
+import (
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// SetCORS sets the default cross-origin policy for all APIs
+// Call it before registering APIs so preflight handlers are installed for their paths; policies allowing
+// credentials from any origin are rejected
+func (r *APIRouter) SetCORS(policy api.CORSPolicy) error {
+	return r.core.SetCORS(policy)
+}
@@ -0,0 +1,78 @@
+package gin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestCORSPreflight tests automatic preflight handlers and x-cors documentation
+func TestCORSPreflight(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.SetCORS(api.CORSPolicy{
+		AllowOrigins:  []string{"https://app.example.com"},
+		AllowHeaders:  []string{"Content-Type", "Authorization"},
+		ExposeHeaders: []string{"X-Total-Count"},
+		MaxAge:        10 * time.Minute,
+	})
+
+	handler := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	for _, method := range []string{"GET", "POST"} {
+		if err := router.Register(api.NewAPIDefinition(method, "/users", "Users").WithHandler(handler)); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("OPTIONS", "/api/users", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Methods"); got != "GET, POST, OPTIONS" {
+		t.Errorf("Unexpected Access-Control-Allow-Methods %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Errorf("Unexpected Access-Control-Allow-Origin %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Max-Age"); got != "600" {
+		t.Errorf("Unexpected Access-Control-Max-Age %q", got)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("OPTIONS", "/api/users", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 for disallowed origin, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/users", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	engine.ServeHTTP(w, req)
+	if got := w.Header().Get("Access-Control-Expose-Headers"); got != "X-Total-Count" {
+		t.Errorf("Unexpected Access-Control-Expose-Headers %q", got)
+	}
+
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(router.swaggerDoc, &doc); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	op := doc["paths"].(map[string]interface{})["/users"].(map[string]interface{})["get"].(map[string]interface{})
+	if _, ok := op["x-cors"]; !ok {
+		t.Error("Expected x-cors extension on operation")
+	}
+}
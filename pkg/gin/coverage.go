@@ -0,0 +1,80 @@
+package gin
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DocumentationCoverage counts the documented parts of the generated document
+type DocumentationCoverage struct {
+	DescribedOperations   int `json:"describedOperations"`   // Operations with a description
+	DescribedParameters   int `json:"describedParameters"`   // Parameters with a description or a shared definition
+	SuccessResponses      int `json:"successResponses"`      // 2xx responses other than 204
+	TypedSuccessResponses int `json:"typedSuccessResponses"` // 2xx responses whose content has a typed schema
+}
+
+// CoverageLevel is how a coverage policy requirement is enforced
+type CoverageLevel int
+
+const (
+	// CoverageIgnore does not check the requirement
+	CoverageIgnore CoverageLevel = iota
+	// CoverageWarn adds a coverage warning to the generation report
+	CoverageWarn
+	// CoverageFail fails GenerateSwagger
+	CoverageFail
+)
+
+// CoveragePolicy is the documentation coverage GenerateSwagger requires
+type CoveragePolicy struct {
+	WarnDescribedBelow  float64       // Warn when a smaller percentage of operations has a description, 0 to disable
+	FailDescribedBelow  float64       // Fail when a smaller percentage of operations has a description, 0 to disable
+	TypedSuccess        CoverageLevel // Whether every 2xx response must have a typed schema
+	DescribedParameters CoverageLevel // Whether every parameter must have a description
+}
+
+// SetCoveragePolicy enforces a documentation coverage policy each time GenerateSwagger runs
+func (r *APIRouter) SetCoveragePolicy(policy CoveragePolicy) {
+	r.coveragePolicy = &policy
+}
+
+// DescribedPercent returns the percentage of operations with a description, 100 when there are none
+func (report GenerationReport) DescribedPercent() float64 {
+	if report.Operations == 0 {
+		return 100
+	}
+	return 100 * float64(report.Coverage.DescribedOperations) / float64(report.Operations)
+}
+
+// enforceCoverage checks report against the policy, adding warnings and returning the failed requirements
+func (p CoveragePolicy) enforceCoverage(report *GenerationReport) error {
+	var failures []error
+	check := func(level CoverageLevel, missed bool, format string, args ...interface{}) {
+		if !missed {
+			return
+		}
+		switch level {
+		case CoverageWarn:
+			report.Warnings = append(report.Warnings, GenerationWarning{Kind: WarningCoverage, Message: fmt.Sprintf(format, args...)})
+		case CoverageFail:
+			failures = append(failures, fmt.Errorf(format, args...))
+		}
+	}
+
+	described := report.DescribedPercent()
+	if described < p.FailDescribedBelow {
+		check(CoverageFail, true, "%.1f%% of operations have a description, below the required %.1f%%", described, p.FailDescribedBelow)
+	} else {
+		check(CoverageWarn, described < p.WarnDescribedBelow, "%.1f%% of operations have a description, below the expected %.1f%%", described, p.WarnDescribedBelow)
+	}
+	coverage := report.Coverage
+	check(p.TypedSuccess, coverage.TypedSuccessResponses < coverage.SuccessResponses,
+		"%d of %d success responses have no typed schema", coverage.SuccessResponses-coverage.TypedSuccessResponses, coverage.SuccessResponses)
+	check(p.DescribedParameters, coverage.DescribedParameters < report.Parameters,
+		"%d of %d parameters have no description", report.Parameters-coverage.DescribedParameters, report.Parameters)
+
+	if len(failures) > 0 {
+		return fmt.Errorf("documentation coverage policy failed: %w", errors.Join(failures...))
+	}
+	return nil
+}
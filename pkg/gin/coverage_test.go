@@ -0,0 +1,75 @@
+package gin
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestCoveragePolicy tests enforcing documentation coverage at generation
+func TestCoveragePolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       CoveragePolicy
+		wantErr      bool
+		wantWarnings int
+	}{
+		{"no requirements", CoveragePolicy{}, false, 0},
+		{"described operations met", CoveragePolicy{FailDescribedBelow: 50}, false, 0},
+		{"described operations warn", CoveragePolicy{WarnDescribedBelow: 80, FailDescribedBelow: 50}, false, 1},
+		{"described operations fail", CoveragePolicy{WarnDescribedBelow: 90, FailDescribedBelow: 80}, true, 0},
+		{"typed success warn", CoveragePolicy{TypedSuccess: CoverageWarn}, false, 1},
+		{"typed success fail", CoveragePolicy{TypedSuccess: CoverageFail}, true, 0},
+		{"described parameters warn", CoveragePolicy{DescribedParameters: CoverageWarn}, false, 1},
+		{"described parameters fail", CoveragePolicy{DescribedParameters: CoverageFail}, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+			router.SetCoveragePolicy(tt.policy)
+			handler := func(c *gin.Context) {}
+			definitions := []*api.APIDefinition{
+				api.NewAPIDefinition("GET", "/users", "List users").
+					WithDescription("Lists users").
+					WithQueryParam("q", "", false).
+					WithResponse([]UserResponse{}),
+				api.NewAPIDefinition("POST", "/users", "Create user").
+					WithDescription("Creates a user").
+					WithStatusResponse(201, "Created", nil),
+				api.NewAPIDefinition("DELETE", "/users/{id}", "Delete user").
+					WithPathParam("id", "User ID", true).
+					WithStatusResponse(204, "Deleted", nil),
+			}
+			for _, def := range definitions {
+				if err := router.Register(def.WithNativeHandler(handler)); err != nil {
+					t.Fatalf("Register failed: %v", err)
+				}
+			}
+
+			_, err := router.GenerateSwagger()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected generation error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+			report := router.GenerateReport()
+			if got := report.Coverage; got != (DocumentationCoverage{DescribedOperations: 2, DescribedParameters: 1, SuccessResponses: 2, TypedSuccessResponses: 1}) {
+				t.Errorf("Expected 2 described operations, 1 described parameter and 1 of 2 typed success responses, got %+v", got)
+			}
+			var warnings int
+			for _, warning := range report.Warnings {
+				if warning.Kind == WarningCoverage {
+					warnings++
+				}
+			}
+			if warnings != tt.wantWarnings {
+				t.Errorf("Expected %d coverage warnings, got %d: %+v", tt.wantWarnings, warnings, report.Warnings)
+			}
+		})
+	}
+}
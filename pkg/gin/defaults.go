@@ -0,0 +1,7 @@
+package gin
+
+// EnableDefaults fills absent optional query and header parameters and body fields with their documented
+// defaults before validation, so handlers see what the documentation promises
+func (r *APIRouter) EnableDefaults() {
+	r.core.EnableDefaults()
+}
@@ -0,0 +1,51 @@
+package gin
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type pageRequest struct {
+	Cursor string `json:"cursor,omitempty"`
+	Size   int    `json:"size" default:"50"`
+}
+
+// TestEnableDefaults tests applying documented defaults before the handler runs
+func TestEnableDefaults(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.EnableDefaults()
+
+	apiDef := api.NewAPIDefinition("POST", "/pages", "List pages").
+		WithQueryParam("tags", "Tags", false).
+		WithParamDefault("tags", []interface{}{"a", "b"}).
+		WithRequest(pageRequest{}).
+		WithNativeHandler(func(c *gin.Context) {
+			var req pageRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.Status(http.StatusInternalServerError)
+				return
+			}
+			tags, _ := QueryParam(c, "tags")
+			c.String(http.StatusOK, fmt.Sprintf("tags=%v size=%d", tags, req.Size))
+		})
+	apiDef.Params[0].Schema = map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "default": []interface{}{"a", "b"}}
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/pages", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	engine.ServeHTTP(w, req)
+	if want := "tags=[a b] size=50"; w.Body.String() != want {
+		t.Errorf("Expected %q, got %q (status %d)", want, w.Body.String(), w.Code)
+	}
+}
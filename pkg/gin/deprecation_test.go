@@ -0,0 +1,56 @@
+package gin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestDeprecationHeaders tests Deprecation/Sunset headers and x-sunset metadata
+func TestDeprecationHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	sunset := time.Date(2030, 6, 30, 0, 0, 0, 0, time.UTC)
+	apiDef := api.NewAPIDefinition("GET", "/v1/users", "List users").
+		WithDeprecation(sunset, "https://example.com/api/v2/users").
+		WithHandler(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/users", nil))
+	if w.Header().Get("Deprecation") != "true" {
+		t.Errorf("Expected Deprecation header, got %q", w.Header().Get("Deprecation"))
+	}
+	if w.Header().Get("Sunset") != "Sun, 30 Jun 2030 00:00:00 GMT" {
+		t.Errorf("Unexpected Sunset header %q", w.Header().Get("Sunset"))
+	}
+	if w.Header().Get("Link") != `<https://example.com/api/v2/users>; rel="successor-version"` {
+		t.Errorf("Unexpected Link header %q", w.Header().Get("Link"))
+	}
+
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(router.swaggerDoc, &doc); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	op := doc["paths"].(map[string]interface{})["/v1/users"].(map[string]interface{})["get"].(map[string]interface{})
+	if op["deprecated"] != true {
+		t.Error("Expected operation to be marked deprecated")
+	}
+	if op["x-sunset"] != "2030-06-30" {
+		t.Errorf("Expected x-sunset 2030-06-30, got %v", op["x-sunset"])
+	}
+}
@@ -0,0 +1,94 @@
+package gin
+
+import (
+	"crypto/subtle"
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DocsAuthFunc decides whether a request may read the documentation endpoints
+type DocsAuthFunc func(c *gin.Context) bool
+
+// ProtectDocs requires every given check to pass before documentation is served
+func (r *APIRouter) ProtectDocs(checks ...DocsAuthFunc) {
+	r.docsAuth = append(r.docsAuth, checks...)
+}
+
+// DisableDocs turns the documentation endpoints off entirely (they respond 404), e.g. in production
+func (r *APIRouter) DisableDocs(disabled bool) {
+	r.docsDisabled = disabled
+}
+
+// authorizeDocs applies the docs access policy, writing the rejection response when access is denied
+func (r *APIRouter) authorizeDocs(c *gin.Context) bool {
+	if r.docsDisabled {
+		c.AbortWithStatus(http.StatusNotFound)
+		return false
+	}
+	for _, check := range r.docsAuth {
+		if !check(c) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "Access to documentation denied",
+			})
+			return false
+		}
+	}
+	return true
+}
+
+// DocsBasicAuth allows requests carrying the given HTTP Basic credentials
+func DocsBasicAuth(username, password string) DocsAuthFunc {
+	return func(c *gin.Context) bool {
+		user, pass, ok := c.Request.BasicAuth()
+		if ok && secureEqual(user, username) && secureEqual(pass, password) {
+			return true
+		}
+		c.Header("WWW-Authenticate", `Basic realm="API documentation"`)
+		return false
+	}
+}
+
+// DocsTokenAuth allows requests whose header carries the given token
+func DocsTokenAuth(header, token string) DocsAuthFunc {
+	return func(c *gin.Context) bool {
+		return secureEqual(c.GetHeader(header), token)
+	}
+}
+
+// DocsIPAllowlist allows requests from the given IPs or CIDR ranges
+// Invalid entries are ignored
+func DocsIPAllowlist(entries ...string) DocsAuthFunc {
+	networks := make([]*net.IPNet, 0, len(entries))
+	for _, entry := range entries {
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			networks = append(networks, network)
+			continue
+		}
+		if ip := net.ParseIP(entry); ip != nil {
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+		}
+	}
+
+	return func(c *gin.Context) bool {
+		ip := net.ParseIP(c.ClientIP())
+		if ip == nil {
+			return false
+		}
+		for _, network := range networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,95 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+func newDocsTestRouter(t *testing.T) (*gin.Engine, *APIRouter) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	apiDef := api.NewAPIDefinition("GET", "/test", "Test").
+		WithHandler(func(w http.ResponseWriter, r *http.Request) {})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	engine.GET("/swagger.json", router.SwaggerHandler)
+	return engine, router
+}
+
+// TestProtectDocs tests access checks on the swagger endpoint
+func TestProtectDocs(t *testing.T) {
+	tests := []struct {
+		name       string
+		check      DocsAuthFunc
+		prepare    func(req *http.Request)
+		wantStatus int
+	}{
+		{
+			name:       "basic auth success",
+			check:      DocsBasicAuth("admin", "secret"),
+			prepare:    func(req *http.Request) { req.SetBasicAuth("admin", "secret") },
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "basic auth failure",
+			check:      DocsBasicAuth("admin", "secret"),
+			prepare:    func(req *http.Request) { req.SetBasicAuth("admin", "wrong") },
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "token success",
+			check:      DocsTokenAuth("X-Docs-Token", "t0ken"),
+			prepare:    func(req *http.Request) { req.Header.Set("X-Docs-Token", "t0ken") },
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "ip allowlist success",
+			check:      DocsIPAllowlist("192.0.2.0/24"),
+			prepare:    func(req *http.Request) { req.RemoteAddr = "192.0.2.10:1234" },
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "ip allowlist failure",
+			check:      DocsIPAllowlist("10.0.0.1"),
+			prepare:    func(req *http.Request) { req.RemoteAddr = "192.0.2.10:1234" },
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, router := newDocsTestRouter(t)
+			router.ProtectDocs(tt.check)
+
+			req := httptest.NewRequest("GET", "/swagger.json", nil)
+			tt.prepare(req)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+// TestDisableDocs tests turning documentation off
+func TestDisableDocs(t *testing.T) {
+	engine, router := newDocsTestRouter(t)
+	router.DisableDocs(true)
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/swagger.json", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
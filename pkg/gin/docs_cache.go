@@ -0,0 +1,56 @@
+package gin
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DocsCache is the HTTP caching policy of the served documents
+type DocsCache struct {
+	MaxAge    time.Duration // How long clients and proxies may reuse a document without revalidating
+	NoStore   bool          // Forbid caching entirely, e.g. in development where the document changes often
+	Private   bool          // Let only the client cache documents, not shared proxies
+	Immutable bool          // Documents never change at their URL, e.g. a versioned spec URL
+}
+
+var (
+	// DefaultDocsCache lets clients and proxies reuse documents for an hour
+	DefaultDocsCache = DocsCache{MaxAge: time.Hour}
+	// NoStoreDocsCache makes clients fetch documents on every request
+	NoStoreDocsCache = DocsCache{NoStore: true}
+	// ImmutableDocsCache caches documents for a year without revalidation, for versioned spec URLs
+	ImmutableDocsCache = DocsCache{MaxAge: 365 * 24 * time.Hour, Immutable: true}
+)
+
+// cacheControl formats the policy as a Cache-Control header value
+func (p DocsCache) cacheControl() string {
+	if p.NoStore {
+		return "no-store"
+	}
+	directives := []string{"public"}
+	if p.Private {
+		directives[0] = "private"
+	}
+	directives = append(directives, "max-age="+strconv.Itoa(int(p.MaxAge/time.Second)))
+	if p.MaxAge <= 0 {
+		directives = append(directives, "must-revalidate")
+	}
+	if p.Immutable {
+		directives = append(directives, "immutable")
+	}
+	return strings.Join(directives, ", ")
+}
+
+// SetDocsCache sets the caching policy of SwaggerHandler and the per-tag documents, DefaultDocsCache by default
+func (r *APIRouter) SetDocsCache(policy DocsCache) {
+	r.docsCache = &policy
+}
+
+// docsCacheControl returns the Cache-Control header value of the served documents
+func (r *APIRouter) docsCacheControl() string {
+	if r.docsCache == nil {
+		return DefaultDocsCache.cacheControl()
+	}
+	return r.docsCache.cacheControl()
+}
@@ -0,0 +1,54 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestDocsCache tests the Cache-Control header of the served documents
+func TestDocsCache(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *DocsCache
+		want   string
+	}{
+		{"default", nil, "public, max-age=3600"},
+		{"no-store", &NoStoreDocsCache, "no-store"},
+		{"immutable", &ImmutableDocsCache, "public, max-age=31536000, immutable"},
+		{"private", &DocsCache{MaxAge: 5 * time.Minute, Private: true}, "private, max-age=300"},
+		{"always revalidate", &DocsCache{}, "public, max-age=0, must-revalidate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			engine := gin.New()
+			router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+			if tt.policy != nil {
+				router.SetDocsCache(*tt.policy)
+			}
+			engine.GET("/swagger.json", router.SwaggerHandler)
+			router.ServeSpecPerTag("/swagger")
+			if err := router.Register(api.NewAPIDefinition("GET", "/users", "List users").WithNativeHandler(func(c *gin.Context) {})); err != nil {
+				t.Fatalf("Register() error = %v", err)
+			}
+			if _, err := router.GenerateSwagger(); err != nil {
+				t.Fatalf("GenerateSwagger() error = %v", err)
+			}
+
+			for _, path := range []string{"/swagger.json", "/swagger/tags.json"} {
+				w := httptest.NewRecorder()
+				engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+				if got := w.Header().Get("Cache-Control"); got != tt.want {
+					t.Errorf("%s Cache-Control = %q, want %q", path, got, tt.want)
+				}
+			}
+		})
+	}
+}
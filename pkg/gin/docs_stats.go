@@ -0,0 +1,149 @@
+package gin
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxTrackedClients bounds the memory used to count unique documentation clients
+const maxTrackedClients = 10000
+
+// unmatchedRoute keys the hits of requests that matched no route, e.g. with Middleware on NoRoute
+const unmatchedRoute = "(unmatched)"
+
+// DocsStats summarizes how the documentation is used
+type DocsStats struct {
+	Hits          map[string]int64 `json:"hits" doc:"Requests per documentation route"`
+	UniqueClients int              `json:"uniqueClients" doc:"Distinct clients (IP and user agent) seen, approximate past 10000"`
+	TopOperations []OperationViews `json:"topOperations" doc:"Most viewed operations, most viewed first"`
+}
+
+// OperationViews counts the views of an operation in the documentation UI
+type OperationViews struct {
+	OperationID string `json:"operationId"`
+	Views       int64  `json:"views"`
+}
+
+// DocsAnalytics counts documentation hits, unique clients and operation views
+type DocsAnalytics struct {
+	router  *APIRouter
+	mu      sync.Mutex
+	hits    map[string]int64
+	clients map[[sha256.Size]byte]struct{}
+	rotated int // Clients counted in the sets discarded on rotation
+	views   map[string]int64
+}
+
+// EnableDocsAnalytics counts requests to SwaggerHandler and SpecHistoryHandler
+// UI routes are counted by mounting Middleware on them, and operation views are reported to ViewHandler
+func (r *APIRouter) EnableDocsAnalytics() *DocsAnalytics {
+	r.docsAnalytics = &DocsAnalytics{
+		router:  r,
+		hits:    make(map[string]int64),
+		clients: make(map[[sha256.Size]byte]struct{}),
+		views:   make(map[string]int64),
+	}
+	return r.docsAnalytics
+}
+
+// Middleware counts requests to documentation UI routes
+func (a *DocsAnalytics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		a.hit(c)
+		c.Next()
+	}
+}
+
+// hit counts a documentation request under its route and its client
+// Clients are identified by a hash of their IP and user agent; the set is rotated once it holds maxTrackedClients
+// clients, so clients seen before and after a rotation are counted twice
+func (a *DocsAnalytics) hit(c *gin.Context) {
+	client := sha256.Sum256([]byte(c.ClientIP() + "|" + c.Request.UserAgent()))
+	route := c.FullPath()
+	if route == "" {
+		route = unmatchedRoute
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.hits[route]++
+	if _, seen := a.clients[client]; seen {
+		return
+	}
+	if len(a.clients) >= maxTrackedClients {
+		a.rotated += len(a.clients)
+		a.clients = make(map[[sha256.Size]byte]struct{})
+	}
+	a.clients[client] = struct{}{}
+}
+
+// ViewHandler records a view of the operation named by the operationId query parameter, e.g. reported by the
+// UI when its deep link (#/tag/operationId) changes; operations missing from the generated document are rejected
+func (a *DocsAnalytics) ViewHandler(c *gin.Context) {
+	operationID := c.Query("operationId")
+	if !a.router.hasOperationID(operationID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown operation"})
+		return
+	}
+	a.mu.Lock()
+	a.views[operationID]++
+	a.mu.Unlock()
+	c.Status(http.StatusNoContent)
+}
+
+// StatsHandler serves the statistics, listing the ?top=N (default 10) most viewed operations
+func (a *DocsAnalytics) StatsHandler(c *gin.Context) {
+	top, err := strconv.Atoi(c.DefaultQuery("top", "10"))
+	if err != nil || top < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid top parameter"})
+		return
+	}
+	c.JSON(http.StatusOK, a.Stats(top))
+}
+
+// Stats returns a snapshot of the statistics with the top most viewed operations
+func (a *DocsAnalytics) Stats(top int) DocsStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stats := DocsStats{
+		Hits:          make(map[string]int64, len(a.hits)),
+		UniqueClients: a.rotated + len(a.clients),
+		TopOperations: make([]OperationViews, 0, len(a.views)),
+	}
+	for path, hits := range a.hits {
+		stats.Hits[path] = hits
+	}
+	for id, views := range a.views {
+		stats.TopOperations = append(stats.TopOperations, OperationViews{OperationID: id, Views: views})
+	}
+	sort.Slice(stats.TopOperations, func(i, j int) bool {
+		if stats.TopOperations[i].Views != stats.TopOperations[j].Views {
+			return stats.TopOperations[i].Views > stats.TopOperations[j].Views
+		}
+		return stats.TopOperations[i].OperationID < stats.TopOperations[j].OperationID
+	})
+	if len(stats.TopOperations) > top {
+		stats.TopOperations = stats.TopOperations[:top]
+	}
+	return stats
+}
+
+// Reset clears the statistics
+func (a *DocsAnalytics) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.hits = make(map[string]int64)
+	a.clients = make(map[[sha256.Size]byte]struct{})
+	a.rotated = 0
+	a.views = make(map[string]int64)
+}
+
+// hasOperationID reports whether the generated document names an operation with the ID
+func (r *APIRouter) hasOperationID(id string) bool {
+	return r.core.HasOperationID(id)
+}
@@ -0,0 +1,110 @@
+package gin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestDocsAnalytics tests counting documentation hits, clients and operation views
+func TestDocsAnalytics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	analytics := router.EnableDocsAnalytics()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	for _, def := range []*api.APIDefinition{
+		api.NewAPIDefinition("GET", "/users", "List users").WithOperationID("listUsers").WithHandler(handler),
+		api.NewAPIDefinition("GET", "/users/{id}", "Get user").WithOperationID("getUser").WithHandler(handler),
+	} {
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	engine.GET("/swagger.json", router.SwaggerHandler)
+	engine.GET("/docs", analytics.Middleware(), func(c *gin.Context) { c.String(http.StatusOK, "ui") })
+	engine.GET("/docs/pages/:page", analytics.Middleware(), func(c *gin.Context) { c.String(http.StatusOK, "page") })
+	engine.POST("/docs/views", analytics.ViewHandler)
+	engine.GET("/docs/stats", analytics.StatsHandler)
+
+	requests := []struct {
+		method     string
+		target     string
+		userAgent  string
+		wantStatus int
+	}{
+		{method: "GET", target: "/docs", userAgent: "alice", wantStatus: http.StatusOK},
+		{method: "GET", target: "/docs/pages/intro", userAgent: "alice", wantStatus: http.StatusOK},
+		{method: "GET", target: "/docs/pages/auth", userAgent: "alice", wantStatus: http.StatusOK},
+		{method: "GET", target: "/swagger.json", userAgent: "alice", wantStatus: http.StatusOK},
+		{method: "GET", target: "/swagger.json", userAgent: "bob", wantStatus: http.StatusOK},
+		{method: "POST", target: "/docs/views?operationId=getUser", wantStatus: http.StatusNoContent},
+		{method: "POST", target: "/docs/views?operationId=getUser", wantStatus: http.StatusNoContent},
+		{method: "POST", target: "/docs/views?operationId=listUsers", wantStatus: http.StatusNoContent},
+		{method: "POST", target: "/docs/views?operationId=deleteEverything", wantStatus: http.StatusBadRequest},
+		{method: "GET", target: "/docs/stats?top=abc", wantStatus: http.StatusBadRequest},
+	}
+	for _, req := range requests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(req.method, req.target, nil)
+		r.Header.Set("User-Agent", req.userAgent)
+		engine.ServeHTTP(w, r)
+		if w.Code != req.wantStatus {
+			t.Errorf("%s %s: expected status %d, got %d", req.method, req.target, req.wantStatus, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/docs/stats?top=1", nil))
+	var stats DocsStats
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("Failed to decode stats: %v", err)
+	}
+	want := DocsStats{
+		Hits:          map[string]int64{"/docs": 1, "/docs/pages/:page": 2, "/swagger.json": 2},
+		UniqueClients: 2,
+		TopOperations: []OperationViews{{OperationID: "getUser", Views: 2}},
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("Expected %+v, got %+v", want, stats)
+	}
+
+	analytics.Reset()
+	if stats := analytics.Stats(10); len(stats.Hits) != 0 || stats.UniqueClients != 0 || len(stats.TopOperations) != 0 {
+		t.Errorf("Expected empty stats after Reset, got %+v", stats)
+	}
+}
+
+// TestDocsAnalyticsClientRotation tests that the unique clients set stays bounded
+func TestDocsAnalyticsClientRotation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+	analytics := router.EnableDocsAnalytics()
+
+	for i := 0; i < maxTrackedClients+5; i++ {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/docs", nil)
+		c.Request.Header.Set("User-Agent", fmt.Sprintf("client-%d", i))
+		analytics.hit(c)
+	}
+	if len(analytics.clients) > maxTrackedClients {
+		t.Errorf("Expected at most %d tracked clients, got %d", maxTrackedClients, len(analytics.clients))
+	}
+	stats := analytics.Stats(0)
+	if stats.UniqueClients != maxTrackedClients+5 {
+		t.Errorf("Expected %d unique clients, got %d", maxTrackedClients+5, stats.UniqueClients)
+	}
+	if stats.Hits[unmatchedRoute] != maxTrackedClients+5 {
+		t.Errorf("Expected unrouted hits under %s, got %v", unmatchedRoute, stats.Hits)
+	}
+}
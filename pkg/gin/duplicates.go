@@ -0,0 +1,30 @@
+package gin
+
+import (
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// DuplicatePolicy decides what Register does when a method and path are registered twice
+type DuplicatePolicy = router.DuplicatePolicy
+
+const (
+	// DuplicateError rejects the second registration with an error (default)
+	DuplicateError = router.DuplicateError
+	// DuplicateWarn keeps the first registration and reports the duplicate to the warning logger
+	DuplicateWarn = router.DuplicateWarn
+	// DuplicateLastWins replaces the earlier handler and definition
+	DuplicateLastWins = router.DuplicateLastWins
+)
+
+// WarningLogger receives configuration warnings such as ignored duplicate registrations
+type WarningLogger = router.WarningLogger
+
+// SetDuplicatePolicy sets how duplicate method and path registrations are handled
+func (r *APIRouter) SetDuplicatePolicy(policy DuplicatePolicy) {
+	r.core.SetDuplicatePolicy(policy)
+}
+
+// SetWarningLogger sets the hook receiving configuration warnings, log.Printf by default
+func (r *APIRouter) SetWarningLogger(logger WarningLogger) {
+	r.core.SetWarningLogger(logger)
+}
@@ -0,0 +1,88 @@
+package gin
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// duplicateRouter creates a router with the given duplicate policy
+func duplicateRouter(policy DuplicatePolicy) (*gin.Engine, *APIRouter) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.SetDuplicatePolicy(policy)
+	return engine, router
+}
+
+// textHandler answers with a fixed body
+func textHandler(body string) gin.HandlerFunc {
+	return func(c *gin.Context) { c.String(http.StatusOK, body) }
+}
+
+// TestDuplicateRegistrationError tests rejecting a second registration of a method and path
+func TestDuplicateRegistrationError(t *testing.T) {
+	_, router := duplicateRouter(DuplicateError)
+
+	if err := router.Register(api.GET("/users/{id}", "Get user").WithNativeHandler(textHandler("first"))); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	err := router.Register(api.GET("/users/:userId", "Get user again").WithNativeHandler(textHandler("second")))
+	if err == nil || !strings.Contains(err.Error(), "duplicate registration") {
+		t.Fatalf("Expected duplicate registration error, got %v", err)
+	}
+	if len(router.GetDefinitions()) != 1 {
+		t.Errorf("Expected 1 definition, got %d", len(router.GetDefinitions()))
+	}
+}
+
+// TestDuplicateRegistrationWarn tests keeping the first registration and logging a warning
+func TestDuplicateRegistrationWarn(t *testing.T) {
+	engine, router := duplicateRouter(DuplicateWarn)
+	var warnings []string
+	router.SetWarningLogger(func(format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	})
+
+	for _, body := range []string{"first", "second"} {
+		def := api.GET("/users", "List users "+body).WithNativeHandler(textHandler(body))
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "GET /api/users") {
+		t.Errorf("Expected one duplicate warning, got %v", warnings)
+	}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users", nil))
+	if w.Body.String() != "first" {
+		t.Errorf("Expected first registration to be kept, got %q", w.Body.String())
+	}
+}
+
+// TestDuplicateRegistrationLastWins tests replacing the first registration
+func TestDuplicateRegistrationLastWins(t *testing.T) {
+	engine, router := duplicateRouter(DuplicateLastWins)
+
+	for _, body := range []string{"first", "second"} {
+		def := api.GET("/users", "List users "+body).WithNativeHandler(textHandler(body))
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users", nil))
+	if w.Body.String() != "second" {
+		t.Errorf("Expected last registration to win, got %q", w.Body.String())
+	}
+	if len(router.GetDefinitions()) != 1 || router.GetDefinitions()[0].Summary != "List users second" {
+		t.Errorf("Expected the definition to be replaced, got %+v", router.GetDefinitions())
+	}
+}
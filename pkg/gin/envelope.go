@@ -0,0 +1,33 @@
+package gin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// SetResponseEnvelope wraps JSON 2xx responses in an envelope such as {code, message, data}
+// The template is a struct whose `envelope:"data"` field (or field serialized as "data") receives the payload;
+// response schemas are documented inside it and Respond wraps handler values with a copy of the template
+func (r *APIRouter) SetResponseEnvelope(template interface{}) {
+	r.core.SetResponseEnvelope(template)
+}
+
+// Respond writes data as a JSON response; 2xx responses are wrapped in the router's response envelope when
+// one is set
+func Respond(c *gin.Context, status int, data interface{}) {
+	template := router.EnvelopeFromContext(c.Request.Context())
+	if template == nil || !router.EnvelopeStatus(status) {
+		c.JSON(status, data)
+		return
+	}
+	wrapped, err := api.WrapEnvelope(template, data)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(status, wrapped)
+}
@@ -0,0 +1,113 @@
+package gin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type responseEnvelope struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data"`
+}
+
+type envelopedUser struct {
+	Name string `json:"name"`
+}
+
+// TestResponseEnvelope tests documenting and writing enveloped success responses
+func TestResponseEnvelope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.SetResponseEnvelope(responseEnvelope{Message: "ok"})
+
+	apiDef := api.NewAPIDefinition("GET", "/users/{id}", "Get user").
+		WithResponse(envelopedUser{}).
+		WithNativeHandler(func(c *gin.Context) { Respond(c, http.StatusOK, envelopedUser{Name: "alice"}) })
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/api/users/1", nil))
+	var body responseEnvelope
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Invalid response %q: %v", w.Body.String(), err)
+	}
+	if data, _ := body.Data.(map[string]interface{}); body.Message != "ok" || data["name"] != "alice" {
+		t.Errorf("Expected an enveloped response, got %+v", body)
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	props := doc.Paths["/users/{id}"].Get.Responses["200"].Content["application/json"].Schema["properties"].(map[string]interface{})
+	data, _ := props["data"].(map[string]interface{})
+	if _, ok := props["message"]; !ok || data["properties"].(map[string]interface{})["name"] == nil {
+		t.Errorf("Expected the user schema inside the envelope, got %v", props)
+	}
+}
+
+type envelopeError struct {
+	Error string `json:"error"`
+}
+
+// TestEnvelopeStatuses tests that only 2xx responses are documented and written inside the envelope
+func TestEnvelopeStatuses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.SetResponseEnvelope(responseEnvelope{Message: "ok"})
+
+	apiDef := api.NewAPIDefinition("POST", "/users/{id}", "Update user").
+		WithResponse(envelopedUser{}).
+		WithStatusResponse(http.StatusCreated, "Created", envelopedUser{}).
+		WithStatusResponse(http.StatusNotFound, "Not found", envelopeError{}).
+		WithNativeHandler(func(c *gin.Context) {
+			if c.Param("id") == "missing" {
+				Respond(c, http.StatusNotFound, envelopeError{Error: "no user"})
+				return
+			}
+			Respond(c, http.StatusCreated, envelopedUser{Name: "alice"})
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		target   string
+		wantCode int
+		wantBody string
+	}{
+		{target: "/api/users/1", wantCode: http.StatusCreated, wantBody: `{"code":0,"message":"ok","data":{"name":"alice"}}`},
+		{target: "/api/users/missing", wantCode: http.StatusNotFound, wantBody: `{"error":"no user"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest("POST", tt.target, nil))
+			if w.Code != tt.wantCode || w.Body.String() != tt.wantBody {
+				t.Errorf("Expected %d %s, got %d %s", tt.wantCode, tt.wantBody, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	responses := doc.Paths["/users/{id}"].Post.Responses
+	for status, wantEnveloped := range map[string]bool{"200": true, "201": true, "404": false} {
+		props, _ := responses[status].Content["application/json"].Schema["properties"].(map[string]interface{})
+		if _, enveloped := props["data"]; enveloped != wantEnveloped {
+			t.Errorf("Expected enveloped=%v for %s, got %v", wantEnveloped, status, responses[status].Content["application/json"].Schema)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package gin
+
+import (
+	"fmt"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// EnvironmentProfile controls how the documentation looks in a given deployment environment
+type EnvironmentProfile struct {
+	Servers           []api.OpenAPIServer // Servers listed in the spec (replaces the default server)
+	HideInternal      bool                // Leave out APIs marked WithInternal
+	HiddenTags        []string            // Leave out APIs carrying any of these tags
+	StripExamples     bool                // Remove example values from parameters and schemas
+	StripDescriptions bool                // Remove operation descriptions, keeping summaries
+	DisableDocs       bool                // Turn the documentation endpoints off
+}
+
+// AddEnvironment registers a named environment profile
+func (r *APIRouter) AddEnvironment(name string, profile EnvironmentProfile) {
+	if r.environments == nil {
+		r.environments = make(map[string]EnvironmentProfile)
+	}
+	r.environments[name] = profile
+}
+
+// SetEnvironment selects the active environment profile used by BuildOpenAPI and the docs endpoints
+func (r *APIRouter) SetEnvironment(name string) error {
+	profile, ok := r.environments[name]
+	if !ok {
+		return fmt.Errorf("unknown environment: %s", name)
+	}
+	r.environment = name
+	r.DisableDocs(profile.DisableDocs)
+	return nil
+}
+
+// activeProfile returns the selected environment profile, if any
+func (r *APIRouter) activeProfile() (EnvironmentProfile, bool) {
+	if r.environment == "" {
+		return EnvironmentProfile{}, false
+	}
+	profile, ok := r.environments[r.environment]
+	return profile, ok
+}
+
+// visibleInProfile reports whether a definition is documented under the active environment profile
+func (r *APIRouter) visibleInProfile(def *api.APIDefinition) bool {
+	profile, ok := r.activeProfile()
+	if !ok {
+		return true
+	}
+	if profile.HideInternal && def.Internal {
+		return false
+	}
+	for _, hidden := range profile.HiddenTags {
+		for _, tag := range def.Tags {
+			if tag == hidden {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// applyProfile rewrites the document according to the active environment profile
+func (r *APIRouter) applyProfile(doc *api.OpenAPIDoc) {
+	profile, ok := r.activeProfile()
+	if !ok {
+		return
+	}
+
+	if len(profile.Servers) > 0 {
+		doc.Servers = profile.Servers
+	}
+	if !profile.StripExamples && !profile.StripDescriptions {
+		return
+	}
+
+	for _, item := range doc.Paths {
+		for _, op := range item.Operations() {
+			if profile.StripDescriptions {
+				op.Description = ""
+			}
+			if !profile.StripExamples {
+				continue
+			}
+			// Parameters are shared with the registered definitions, so strip copies
+			params := make([]api.Parameter, len(op.Parameters))
+			for i, param := range op.Parameters {
+				param.Example = nil
+				param.Examples = nil
+				param.Schema = cloneSchema(param.Schema)
+				stripExamples(param.Schema)
+				params[i] = param
+			}
+			op.Parameters = params
+			if op.RequestBody != nil {
+				for _, content := range op.RequestBody.Content {
+					stripExamples(content.Schema)
+				}
+			}
+			for _, resp := range op.Responses {
+				for _, content := range resp.Content {
+					stripExamples(content.Schema)
+				}
+			}
+		}
+	}
+}
+
+// stripExamples removes example keywords from a schema tree
+func stripExamples(schema map[string]interface{}) {
+	if schema == nil {
+		return
+	}
+	delete(schema, "example")
+	delete(schema, "examples")
+	for _, value := range schema {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			stripExamples(v)
+		case []interface{}:
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					stripExamples(m)
+				}
+			}
+		}
+	}
+}
+
+// cloneSchema returns a deep copy of a schema tree
+func cloneSchema(schema map[string]interface{}) map[string]interface{} {
+	if schema == nil {
+		return nil
+	}
+	clone := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		clone[key] = cloneSchemaValue(value)
+	}
+	return clone
+}
+
+func cloneSchemaValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return cloneSchema(v)
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = cloneSchemaValue(item)
+		}
+		return items
+	default:
+		return v
+	}
+}
@@ -0,0 +1,78 @@
+package gin
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type exampleModel struct {
+	Name string `json:"name" example:"john"`
+}
+
+// TestEnvironmentProfiles tests per-environment servers, visibility and stripping
+func TestEnvironmentProfiles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+
+	public := api.NewAPIDefinition("GET", "/users", "List users").
+		WithDescription("Internal notes about the users table").
+		WithParamSchema("q", "query", "Search", false, map[string]interface{}{"type": "string", "example": "jo"}).
+		WithResponse(exampleModel{}).
+		WithHandler(handler)
+	internal := api.NewAPIDefinition("POST", "/admin/reindex", "Reindex").
+		WithInternal().
+		WithHandler(handler)
+	debug := api.NewAPIDefinition("GET", "/debug/vars", "Debug vars").
+		WithTags("debug").
+		WithHandler(handler)
+	for _, def := range []*api.APIDefinition{public, internal, debug} {
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	router.AddEnvironment("prod", EnvironmentProfile{
+		Servers:           []api.OpenAPIServer{{URL: "https://api.example.com", Description: "Production"}},
+		HideInternal:      true,
+		HiddenTags:        []string{"debug"},
+		StripExamples:     true,
+		StripDescriptions: true,
+	})
+	if err := router.SetEnvironment("staging"); err == nil {
+		t.Error("Expected error for unknown environment")
+	}
+	if err := router.SetEnvironment("prod"); err != nil {
+		t.Fatalf("SetEnvironment failed: %v", err)
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if len(doc.Paths) != 1 {
+		t.Errorf("Expected only the public path, got %d paths", len(doc.Paths))
+	}
+	if doc.Servers[0].URL != "https://api.example.com" {
+		t.Errorf("Expected production server, got %s", doc.Servers[0].URL)
+	}
+
+	op := doc.Paths["/users"].Get
+	if op.Description != "" {
+		t.Error("Expected description to be stripped")
+	}
+	if _, ok := op.Parameters[0].Schema["example"]; ok {
+		t.Error("Expected parameter example to be stripped")
+	}
+	if _, ok := public.Params[0].Schema["example"]; !ok {
+		t.Error("Expected registered definition to keep its example")
+	}
+	props := op.Responses["200"].Content["application/json"].Schema["properties"].(map[string]interface{})
+	if _, ok := props["name"].(map[string]interface{})["example"]; ok {
+		t.Error("Expected schema example to be stripped")
+	}
+}
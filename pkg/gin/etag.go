@@ -0,0 +1,40 @@
+package gin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// weakETag returns the weak ETag of a generated document; it changes only when the content does
+func weakETag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf(`W/"%x"`, sum[:16])
+}
+
+// notModified reports whether a conditional request already holds the current document
+// If-Modified-Since is only considered without If-None-Match, as RFC 9110 requires
+func notModified(req *http.Request, etag string, modified time.Time) bool {
+	if header := req.Header.Get("If-None-Match"); header != "" {
+		return router.ETagMatches(header, etag)
+	}
+	since, err := http.ParseTime(req.Header.Get("If-Modified-Since"))
+	return err == nil && !modified.After(since)
+}
+
+// sameDocs reports whether two sets of generated documents are identical
+func sameDocs(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, data := range a {
+		if !bytes.Equal(data, b[key]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,133 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestConditionalGet tests ETag computation and 304 responses
+func TestConditionalGet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	apiDef := api.NewAPIDefinition("GET", "/users/{id}", "Get user").
+		WithConditionalGet().
+		WithNativeHandler(func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/api/users/1", nil))
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag == "" {
+		t.Fatalf("Expected 200 with ETag, got %d %q", w.Code, etag)
+	}
+	if w.Body.String() != `{"id":"1"}` {
+		t.Errorf("Unexpected body %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/users/1", nil)
+	req.Header.Set("If-None-Match", etag)
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected 304, got %d", w.Code)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected empty body, got %s", w.Body.String())
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if _, ok := doc.Paths["/users/{id}"].Get.Responses["304"]; !ok {
+		t.Error("Expected 304 response in spec")
+	}
+}
+
+// TestSwaggerHandlerConditional tests 304 responses of the documentation endpoint
+func TestSwaggerHandlerConditional(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	engine.GET("/swagger.json", router.SwaggerHandler)
+	if err := router.Register(api.NewAPIDefinition("GET", "/users", "List users").WithNativeHandler(func(c *gin.Context) {})); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	get := func(header, value string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/swagger.json", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	first := get("", "")
+	etag, lastModified := first.Header().Get("ETag"), first.Header().Get("Last-Modified")
+	if first.Code != http.StatusOK || !strings.HasPrefix(etag, `W/"`) || lastModified == "" {
+		t.Fatalf("first response = %d, ETag %q, Last-Modified %q", first.Code, etag, lastModified)
+	}
+	modified, _ := http.ParseTime(lastModified)
+
+	// Regenerating an unchanged document keeps its validators
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		header     string
+		value      string
+		wantStatus int
+	}{
+		{"matching etag", "If-None-Match", etag, http.StatusNotModified},
+		{"strong form of the etag", "If-None-Match", strings.TrimPrefix(etag, "W/"), http.StatusNotModified},
+		{"other etag", "If-None-Match", `W/"other"`, http.StatusOK},
+		{"not modified since", "If-Modified-Since", lastModified, http.StatusNotModified},
+		{"modified since", "If-Modified-Since", modified.Add(-time.Second).Format(http.TimeFormat), http.StatusOK},
+		{"invalid date", "If-Modified-Since", "yesterday", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := get(tt.header, tt.value)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if w.Header().Get("ETag") != etag || w.Header().Get("Last-Modified") != lastModified {
+				t.Errorf("validators = %q, %q, want %q, %q", w.Header().Get("ETag"), w.Header().Get("Last-Modified"), etag, lastModified)
+			}
+			if tt.wantStatus == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("304 body = %s, want none", w.Body.String())
+			}
+		})
+	}
+
+	// A changed document gets a new ETag
+	if err := router.Register(api.NewAPIDefinition("GET", "/orders", "List orders").WithNativeHandler(func(c *gin.Context) {})); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if w := get("If-None-Match", etag); w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+		t.Errorf("changed document = %d with ETag %q, want 200 with a new ETag", w.Code, w.Header().Get("ETag"))
+	}
+}
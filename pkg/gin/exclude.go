@@ -0,0 +1,57 @@
+package gin
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ExcludePaths keeps the definitions whose paths match the globs out of the published document while still
+// serving them, e.g. ExcludePaths("/metrics", "/debug/pprof/**", "/health*"). Globs match definition paths
+// (without the base path) segment by segment, "**" spanning any number of segments; a glob starting with "!"
+// documents the paths it matches again, and the last matching glob wins
+func (r *APIRouter) ExcludePaths(globs ...string) error {
+	for _, glob := range globs {
+		for _, segment := range strings.Split(strings.TrimPrefix(glob, "!"), "/") {
+			if _, err := path.Match(segment, ""); err != nil {
+				return fmt.Errorf("invalid path glob %q: %w", glob, err)
+			}
+		}
+	}
+	r.excludedPaths = append(r.excludedPaths, globs...)
+	return nil
+}
+
+// excludedPath reports whether a definition path is kept out of the document by the ExcludePaths globs
+func (r *APIRouter) excludedPath(defPath string) bool {
+	excluded := false
+	for _, glob := range r.excludedPaths {
+		pattern, allow := strings.CutPrefix(glob, "!")
+		if matchPathGlob(strings.Split(pattern, "/"), strings.Split(defPath, "/")) {
+			excluded = !allow
+		}
+	}
+	return excluded
+}
+
+// matchPathGlob matches path segments against glob segments, "**" matching zero or more segments
+func matchPathGlob(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchPathGlob(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+		return false
+	}
+	return matchPathGlob(pattern[1:], segments[1:])
+}
@@ -0,0 +1,62 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestExcludePaths tests keeping infrastructure endpoints out of the document while serving them
+func TestExcludePaths(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	if err := router.ExcludePaths("/metrics", "/debug/**", "!/debug/vars", "/health*"); err != nil {
+		t.Fatalf("ExcludePaths failed: %v", err)
+	}
+	handler := func(c *gin.Context) { c.Status(http.StatusOK) }
+
+	tests := []struct {
+		path       string
+		documented bool
+	}{
+		{"/users/{id}", true},
+		{"/metrics", false},
+		{"/metrics/extra", true},
+		{"/debug/pprof/heap", false},
+		{"/debug", false},
+		{"/debug/vars", true},
+		{"/healthz", false},
+	}
+	for _, tt := range tests {
+		if err := router.Register(api.NewAPIDefinition("GET", tt.path, "Endpoint").WithNativeHandler(handler)); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if _, ok := doc.Paths[tt.path]; ok != tt.documented {
+				t.Errorf("documented = %v, want %v", ok, tt.documented)
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/api/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected excluded endpoints to be served, got status %d", w.Code)
+	}
+
+	if err := router.ExcludePaths("/[a-"); err == nil {
+		t.Error("Expected an invalid glob to be rejected")
+	}
+}
@@ -1,17 +1,21 @@
 package gin
 
 import (
+	"bytes"
 	"context"
-	"crypto/md5"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/smartcat999/go-swagger/pkg/api"
+	"github.com/smartcat999/go-swagger/pkg/publish"
+	"github.com/smartcat999/go-swagger/pkg/router"
 )
 
 // GenericAuthorizer defines an interface for checking route permissions
@@ -29,266 +33,168 @@ func WithGinHandler(apiDef *api.APIDefinition, handler gin.HandlerFunc) *api.API
 }
 
 // APIRouter enhanced route registrar
+// Routes are registered by the framework-neutral core router through the gin adapter, so requests run the
+// same steps as on any other framework; APIRouter adds the documentation endpoints and publishing on top
 type APIRouter struct {
-	engine           *gin.Engine
-	definitions      []api.APIDefinition
-	basePath         string
-	title            string
-	version          string
-	description      string
-	swaggerDoc       []byte // Cached swagger document
-	generated        bool   // Whether swagger has been generated
-	securitySchemes  map[string]api.SecurityScheme
-	globalSecurity   []map[string][]string
-	globalAuthorizer GenericAuthorizer // Global authorizer for all routes
+	engine          *gin.Engine
+	core            *router.Router
+	swaggerDoc      []byte           // Cached swagger document
+	docETag         string           // Weak ETag of swaggerDoc
+	docModified     time.Time        // Last generation that changed the served documents
+	generated       bool             // Whether swagger has been generated
+	docMu           sync.RWMutex     // Guards swaggerDoc against regeneration while serving
+	searchIndex     *api.SearchIndex // Index of the generated document served by SpecSearchHandler
+	tagDocs         *tagDocs         // Per-tag documents served by ServeSpecPerTag, nil when disabled
+	specPages       *specPages       // Generated document split for SpecPathsHandler and SpecComponentHandler
+	signer          SpecSigner       // Signs the generated document, nil when signing is disabled
+	specSignature   []byte           // Base64 detached signature of swaggerDoc
+	snapshots       map[string]*specSnapshot
+	lenient         bool
+	excludedPaths   []string
+	coveragePolicy  *CoveragePolicy
+	baseline        []byte
+	enforceBaseline bool
+	report          GenerationReport
+	translations    map[string]api.Catalog
+	localizedDocs   map[string][]byte // Cached translated documents by locale
+	docsAuth        []DocsAuthFunc    // Access checks for the documentation endpoints
+	docsDisabled    bool              // Whether the documentation endpoints are turned off
+	docsAnalytics   *DocsAnalytics    // Usage counters of the documentation endpoints
+	docsCache       *DocsCache        // Caching policy of the served documents, DefaultDocsCache when nil
+	environments    map[string]EnvironmentProfile
+	environment     string           // Active environment profile
+	refResolver     *api.RefResolver // Resolves external $refs of generated documents
+	history         SpecHistoryStore
+	publishMu       sync.Mutex
+	publishTimeout  time.Duration
+	publishedDigest string
+	publishers      []publish.Publisher // Receive every generated document
 }
 
 // NewAPIRouter creates a new API route registrar
 func NewAPIRouter(engine *gin.Engine, basePath, title, version, description string) *APIRouter {
-	return &APIRouter{
-		engine:          engine,
-		definitions:     make([]api.APIDefinition, 0),
-		basePath:        basePath,
-		title:           title,
-		version:         version,
-		description:     description,
-		swaggerDoc:      nil,
-		generated:       false,
-		securitySchemes: make(map[string]api.SecurityScheme),
-		globalSecurity:  make([]map[string][]string, 0),
+	r := &APIRouter{
+		engine: engine,
+		core:   router.New(&engineAdapter{engine: engine}, basePath, title, version, description),
 	}
+	r.SetRateLimitKey(ClientKey)
+	return r
 }
 
 // SetInfo sets basic API information
 func (r *APIRouter) SetInfo(title, version, description string) {
-	r.title = title
-	r.version = version
-	r.description = description
+	r.core.SetInfo(title, version, description)
 }
 
 // SetBasePath sets API base path
 func (r *APIRouter) SetBasePath(basePath string) {
-	r.basePath = basePath
+	r.core.SetBasePath(basePath)
 }
 
 // AddBasicAuth adds Basic Authentication security scheme
 func (r *APIRouter) AddBasicAuth(name, description string) {
-	r.securitySchemes[name] = api.SecurityScheme{
+	r.core.AddSecurityScheme(name, api.SecurityScheme{
 		Type:        "http",
 		Scheme:      "basic",
 		Description: description,
-	}
+	})
 }
 
 // AddBearerAuth adds Bearer token security scheme
 func (r *APIRouter) AddBearerAuth(name, description, format string) {
-	r.securitySchemes[name] = api.SecurityScheme{
+	r.core.AddSecurityScheme(name, api.SecurityScheme{
 		Type:         "http",
 		Scheme:       "bearer",
 		BearerFormat: format,
 		Description:  description,
-	}
+	})
 }
 
 // AddAPIKey adds API key security scheme
 func (r *APIRouter) AddAPIKey(name, description, in string) {
-	r.securitySchemes[name] = api.SecurityScheme{
+	r.core.AddSecurityScheme(name, api.SecurityScheme{
 		Type:        "apiKey",
 		Name:        name,
 		In:          in,
 		Description: description,
-	}
+	})
 }
 
 // AddOAuth2 adds OAuth2 security scheme
 func (r *APIRouter) AddOAuth2(name, description string, flows *api.OAuthFlows) {
-	r.securitySchemes[name] = api.SecurityScheme{
+	r.core.AddSecurityScheme(name, api.SecurityScheme{
 		Type:        "oauth2",
 		Description: description,
 		Flows:       flows,
-	}
+	})
 }
 
 // AddOpenIDConnect adds OpenID Connect security scheme
 func (r *APIRouter) AddOpenIDConnect(name, description, url string) {
-	r.securitySchemes[name] = api.SecurityScheme{
+	r.core.AddSecurityScheme(name, api.SecurityScheme{
 		Type:             "openIdConnect",
 		Description:      description,
 		OpenIDConnectURL: url,
-	}
+	})
 }
 
 // SetGlobalSecurity sets global security requirements
 func (r *APIRouter) SetGlobalSecurity(requirements []map[string][]string) {
-	r.globalSecurity = requirements
+	r.core.SetGlobalSecurity(requirements)
 }
 
 // SetGlobalAuthorizer sets a global authorizer for all routes
 // This authorizer will be called for every route and receives the gin.Context and route metadata
 func (r *APIRouter) SetGlobalAuthorizer(authorizer GenericAuthorizer) {
-	r.globalAuthorizer = authorizer
+	r.core.SetAuthorizer(engineAuthorizer{authorizer})
 }
 
-// Register registers an API route
-func (r *APIRouter) Register(api *api.APIDefinition) error {
-	if api == nil {
-		return fmt.Errorf("api definition cannot be nil")
-	}
-
-	// Check if we have either a native handler or standard handler
-	if api.NativeHandler == nil && api.Handler == nil {
-		return fmt.Errorf("handler cannot be nil for path: %s", api.Path)
-	}
-
-	// Validate path
-	if api.Path == "" {
-		return fmt.Errorf("path cannot be empty")
-	}
-
-	// Validate method
-	method := strings.ToUpper(api.Method)
-	switch method {
-	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch:
-		// Valid method
-	default:
-		return fmt.Errorf("unsupported HTTP method: %s", api.Method)
-	}
-
-	// Create middleware chain for parameter validation and permission checking
-	handler := func(c *gin.Context) {
-		// Validate path parameters
-		for _, param := range api.Params {
-			if param.In == "path" {
-				value := c.Param(param.Name)
-				if param.Required && value == "" {
-					c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-						"error": fmt.Sprintf("missing required path parameter: %s", param.Name),
-					})
-					return
-				}
-				if value != "" {
-					if err := param.Validate(value); err != nil {
-						c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-							"error": fmt.Sprintf("invalid path parameter %s: %v", param.Name, err),
-						})
-						return
-					}
-				}
-			}
-		}
-
-		// Validate query parameters
-		for _, param := range api.Params {
-			if param.In == "query" {
-				value := c.Query(param.Name)
-				if param.Required && value == "" {
-					c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-						"error": fmt.Sprintf("missing required query parameter: %s", param.Name),
-					})
-					return
-				}
-				if value != "" {
-					if err := param.Validate(value); err != nil {
-						c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-							"error": fmt.Sprintf("invalid query parameter %s: %v", param.Name, err),
-						})
-						return
-					}
-				}
-			}
-		}
-
-		// Validate header parameters
-		for _, param := range api.Params {
-			if param.In == "header" {
-				value := c.GetHeader(param.Name)
-				if param.Required && value == "" {
-					c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-						"error": fmt.Sprintf("missing required header: %s", param.Name),
-					})
-					return
-				}
-				if value != "" {
-					if err := param.Validate(value); err != nil {
-						c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-							"error": fmt.Sprintf("invalid header %s: %v", param.Name, err),
-						})
-						return
-					}
-				}
-			}
-		}
-
-		// Validate cookie parameters
-		for _, param := range api.Params {
-			if param.In == "cookie" {
-				value, err := c.Cookie(param.Name)
-				if err != nil {
-					if param.Required {
-						c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-							"error": fmt.Sprintf("missing required cookie: %s", param.Name),
-						})
-						return
-					}
-					continue
-				}
-				if value != "" {
-					if err := param.Validate(value); err != nil {
-						c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-							"error": fmt.Sprintf("invalid cookie %s: %v", param.Name, err),
-						})
-						return
-					}
-				}
-			}
-		}
+// engineAuthorizer hands the gin context of requests to a GenericAuthorizer
+type engineAuthorizer struct {
+	GenericAuthorizer
+}
 
-		// Check permissions using global authorizer
-		if r.globalAuthorizer != nil {
-			// Pass gin.Context and route metadata to the authorizer
-			if !r.globalAuthorizer.Authorize(c, api.Metadata) {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-					"error": "Access denied",
-				})
-				return
-			}
-		}
+// Authorize implements router.Authorizer
+func (a engineAuthorizer) Authorize(ctx context.Context, metadata map[string]interface{}) bool {
+	c, _ := ctx.Value(engineContextKey{}).(*gin.Context)
+	if c == nil {
+		return a.GenericAuthorizer.Authorize(ctx, metadata)
+	}
+	c.Request = c.Request.WithContext(ctx)
+	return a.GenericAuthorizer.Authorize(c, metadata)
+}
 
-		// Call the actual handler
-		// Prefer NativeHandler (gin.HandlerFunc) over standard http.HandlerFunc
-		if api.NativeHandler != nil {
-			switch h := api.NativeHandler.(type) {
-			case gin.HandlerFunc:
-				h(c)
-				return
-			case func(*gin.Context):
-				gin.HandlerFunc(h)(c)
-				return
-			case http.HandlerFunc:
-				h(c.Writer, c.Request)
-				return
-			case func(http.ResponseWriter, *http.Request):
-				h(c.Writer, c.Request)
-				return
-			}
-		}
+// Register registers an API route
+// The core router validates the definition and binds its request steps, operation middlewares and handler
+// as the engine route's handler chain
+func (r *APIRouter) Register(api *api.APIDefinition) error {
+	return r.core.Register(api)
+}
 
-		// Fallback to standard HTTP handler
-		if api.Handler != nil {
-			api.Handler(c.Writer, c.Request)
+// callHandler calls the handler of a definition
+// Prefer NativeHandler (gin.HandlerFunc) over standard http.HandlerFunc
+func callHandler(c *gin.Context, def *api.APIDefinition) {
+	if def.NativeHandler != nil {
+		switch h := def.NativeHandler.(type) {
+		case gin.HandlerFunc:
+			h(c)
+			return
+		case func(*gin.Context):
+			gin.HandlerFunc(h)(c)
+			return
+		case http.HandlerFunc:
+			h(c.Writer, c.Request)
+			return
+		case func(http.ResponseWriter, *http.Request):
+			h(c.Writer, c.Request)
 			return
 		}
 	}
 
-	// Register to gin engine
-	// Convert OpenAPI path format ({param}) to Gin format (:param)
-	ginPath := convertOpenAPIPathToGin(api.Path)
-	fullPath := fmt.Sprintf("%s%s", r.basePath, ginPath)
-	r.engine.Handle(method, fullPath, handler)
-
-	// Save API definition information
-	r.definitions = append(r.definitions, *api)
-	return nil
+	// Fallback to standard HTTP handler
+	if def.Handler != nil {
+		def.Handler(c.Writer, c.Request)
+	}
 }
 
 // RegisterGroup registers a group of related APIs
@@ -313,17 +219,94 @@ func (r *APIRouter) RegisterGroup(tag string, apis []api.APIDefinition) error {
 	return nil
 }
 
+// RegisterRegistry registers every definition collected in a registry, in the order they were added
+// Pass api.DefaultRegistry for definitions added with api.Add
+func (r *APIRouter) RegisterRegistry(registry *api.Registry) error {
+	for _, def := range registry.Definitions() {
+		if err := r.Register(def); err != nil {
+			return fmt.Errorf("failed to register %s %s: %w", def.Method, def.Path, err)
+		}
+	}
+	return nil
+}
+
 // GenerateSwagger generates and caches the swagger document, returns the generated document
 func (r *APIRouter) GenerateSwagger() (*api.OpenAPIDoc, error) {
-	if r.title == "" {
-		return nil, fmt.Errorf("API title is required")
+	report := GenerationReport{GeneratedAt: time.Now().UTC()}
+	doc, err := r.buildDocument(&report)
+	if err != nil {
+		return nil, err
+	}
+	report.inspect(doc)
+	if r.coveragePolicy != nil {
+		if err := r.coveragePolicy.enforceCoverage(&report); err != nil {
+			return nil, err
+		}
+	}
+
+	// Marshal document
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal OpenAPI document: %w", err)
+	}
+	if err := r.checkBaseline(data, &report); err != nil {
+		return nil, err
+	}
+
+	if r.history != nil {
+		if err := r.recordSnapshot(data); err != nil {
+			return nil, fmt.Errorf("failed to record spec snapshot: %w", err)
+		}
+	}
+
+	localized, err := r.generateLocalized()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.buildTagDocs(doc); err != nil {
+		return nil, err
+	}
+	if err := r.buildSpecPages(data); err != nil {
+		return nil, err
+	}
+
+	signature, err := r.signSpec(data)
+	if err != nil {
+		return nil, err
+	}
+
+	r.core.RecordOperationIDs(doc)
+	r.buildSearchIndex(doc)
+
+	report.Duration = time.Since(report.GeneratedAt)
+	r.docMu.Lock()
+	if r.docModified.IsZero() || !bytes.Equal(r.swaggerDoc, data) || !sameDocs(r.localizedDocs, localized) {
+		r.docModified = time.Now().UTC().Truncate(time.Second)
 	}
-	if r.version == "" {
+	r.swaggerDoc = data
+	r.docETag = weakETag(data)
+	r.specSignature = signature
+	r.localizedDocs = localized
+	r.report = report
+	r.generated = true
+	r.docMu.Unlock()
+
+	r.publishSpec(data, signature)
+	return doc, nil
+}
+
+// buildDocument builds the complete document: default server and responses, operationIds and plugin changes
+// Operation errors of lenient generation are added to report when it is not nil
+func (r *APIRouter) buildDocument(report *GenerationReport) (*api.OpenAPIDoc, error) {
+	if info := r.core.Info(); info.Title == "" {
+		return nil, fmt.Errorf("API title is required")
+	} else if info.Version == "" {
 		return nil, fmt.Errorf("API version is required")
 	}
 
 	// Build OpenAPI document
-	doc, err := r.BuildOpenAPI()
+	doc, err := r.buildOpenAPI(report)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build OpenAPI document: %w", err)
 	}
@@ -337,7 +320,7 @@ func (r *APIRouter) GenerateSwagger() (*api.OpenAPIDoc, error) {
 	if len(doc.Servers) == 0 {
 		doc.Servers = []api.OpenAPIServer{
 			{
-				URL:         r.basePath,
+				URL:         r.core.BasePath(),
 				Description: "Default server",
 			},
 		}
@@ -356,64 +339,50 @@ func (r *APIRouter) GenerateSwagger() (*api.OpenAPIDoc, error) {
 		}
 	}
 
-	// Add default responses for all operations
-	for path := range doc.Paths {
-		pathItem := doc.Paths[path]
-		operations := []*api.Operation{
-			pathItem.Get,
-			pathItem.Post,
-			pathItem.Put,
-			pathItem.Delete,
-			pathItem.Patch,
-		}
+	// Add default responses, closed request bodies, the X-Request-ID parameter and header, and operationIds
+	r.core.CompleteDocument(doc)
 
-		for _, op := range operations {
-			if op != nil {
-				// Add default error responses if not present
-				if op.Responses == nil {
-					op.Responses = make(map[string]api.Response)
-				}
-				if _, ok := op.Responses["400"]; !ok {
-					op.Responses["400"] = api.Response{
-						Description: "Bad Request - Invalid input parameters",
-					}
-				}
-				if _, ok := op.Responses["401"]; !ok && len(op.Security) > 0 {
-					op.Responses["401"] = api.Response{
-						Description: "Unauthorized - Authentication required",
-					}
-				}
-				if _, ok := op.Responses["403"]; !ok && len(op.Security) > 0 {
-					op.Responses["403"] = api.Response{
-						Description: "Forbidden - Insufficient permissions",
-					}
-				}
-				if _, ok := op.Responses["500"]; !ok {
-					op.Responses["500"] = api.Response{
-						Description: "Internal Server Error",
-					}
-				}
-
-				// Generate operationId if not set
-				if op.OperationID == "" {
-					op.OperationID = generateOperationID(path, op)
-				}
-			}
-		}
-		doc.Paths[path] = pathItem
+	// Check or bundle external schema references
+	if err := r.resolveRefs(doc); err != nil {
+		return nil, err
 	}
 
-	// Marshal document
-	data, err := json.MarshalIndent(doc, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal OpenAPI document: %w", err)
+	// Let plugins post-process the document
+	if err := r.core.RunGenerateHooks(doc); err != nil {
+		return nil, err
 	}
-
-	r.swaggerDoc = data
-	r.generated = true
 	return doc, nil
 }
 
+// QueryParam returns a query parameter decoded according to its documented style:
+// a string for scalars, []string for arrays and map[string]string for deepObject parameters
+func QueryParam(c *gin.Context, name string) (interface{}, bool) {
+	value, ok := router.QueryParams(c.Request.Context())[name]
+	return value, ok
+}
+
+// QueryModel returns the query parameters bound into a new instance of the definition's query model (a pointer)
+func QueryModel(c *gin.Context) interface{} {
+	return router.QueryModel(c.Request.Context())
+}
+
+// RequestBody returns the validated request body, a pointer to a new instance of the request model (or a slice
+// of it for batch requests, or the patch document); the raw body stays readable unless the router hands off
+// decoded bodies only, see SetBodyHandling
+func RequestBody(c *gin.Context) interface{} {
+	return router.RequestBody(c.Request.Context())
+}
+
+// PathModel returns the path parameters bound into a new instance of the definition's path model (a pointer)
+func PathModel(c *gin.Context) interface{} {
+	return router.PathModel(c.Request.Context())
+}
+
+// operationKey identifies an operation by method and documented path
+func operationKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
 // convertOpenAPIPathToGin converts OpenAPI path format to Gin router format
 // Example: /user/{name} -> /user/:name
 // Example: /users/{id}/posts/{postId} -> /users/:id/posts/:postId
@@ -423,30 +392,27 @@ func convertOpenAPIPathToGin(openAPIPath string) string {
 	return ginPathRegex.ReplaceAllString(openAPIPath, ":$1")
 }
 
-// generateOperationID generates a unique operation ID based on the path and operation
-func generateOperationID(path string, op *api.Operation) string {
-	// Remove path parameters
-	path = regexp.MustCompile(`\{[^}]+\}`).ReplaceAllString(path, "")
-	// Remove special characters
-	path = regexp.MustCompile(`[^a-zA-Z0-9]`).ReplaceAllString(path, "_")
-	// Remove consecutive underscores
-	path = regexp.MustCompile(`_+`).ReplaceAllString(path, "_")
-	// Remove leading and trailing underscores
-	path = strings.Trim(path, "_")
-
-	// Get the first tag if available
-	prefix := "operation"
-	if len(op.Tags) > 0 {
-		prefix = strings.ToLower(op.Tags[0])
+// SwaggerHandler provides swagger.json endpoint
+func (r *APIRouter) SwaggerHandler(c *gin.Context) {
+	if !r.authorizeDocs(c) {
+		return
+	}
+	if r.docsAnalytics != nil {
+		r.docsAnalytics.hit(c)
 	}
 
-	// Generate operation ID
-	return fmt.Sprintf("%s_%s", prefix, path)
-}
+	r.docMu.RLock()
+	swaggerDoc, signature, etag, modified := r.swaggerDoc, r.specSignature, r.docETag, r.docModified
+	r.docMu.RUnlock()
 
-// SwaggerHandler provides swagger.json endpoint
-func (r *APIRouter) SwaggerHandler(c *gin.Context) {
-	if !r.generated || r.swaggerDoc == nil {
+	// Serve a translated document when one is registered for ?lang=; only the original document is signed
+	if lang := c.Query("lang"); lang != "" {
+		if localized := r.localizedDoc(lang); localized != nil {
+			swaggerDoc, signature, etag = localized, nil, weakETag(localized)
+		}
+	}
+
+	if swaggerDoc == nil {
 		// This should not happen if GenerateSwagger was called at startup
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Swagger documentation not available",
@@ -455,119 +421,82 @@ func (r *APIRouter) SwaggerHandler(c *gin.Context) {
 		return
 	}
 
-	// Set cache headers for better performance
-	c.Header("Cache-Control", "public, max-age=3600") // Cache for 1 hour
-	c.Header("ETag", fmt.Sprintf(`"%x"`, md5.Sum(r.swaggerDoc)))
+	c.Header("Cache-Control", r.docsCacheControl())
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", modified.Format(http.TimeFormat))
+	c.Header("X-Spec-Digest", specDigest(swaggerDoc))
+	if signature != nil {
+		c.Header("X-Spec-Signature", string(signature))
+	}
+	if notModified(c.Request, etag, modified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", swaggerDoc)
+}
 
-	c.Data(http.StatusOK, "application/json; charset=utf-8", r.swaggerDoc)
+// Changelog renders a markdown changelog of the registered APIs from their WithChangelog entries
+func (r *APIRouter) Changelog() string {
+	return api.GenerateChangelog(r.core.Info().Title, r.core.GetDefinitions())
 }
 
 // GetDefinitions returns all registered API definitions
 func (r *APIRouter) GetDefinitions() []api.APIDefinition {
-	return r.definitions
+	return r.core.GetDefinitions()
 }
 
 // BuildOpenAPI builds OpenAPI specification document
 func (r *APIRouter) BuildOpenAPI() (*api.OpenAPIDoc, error) {
+	return r.buildOpenAPI(nil)
+}
+
+// buildOpenAPI builds the document, adding the operation errors of lenient generation to report when it is not nil
+func (r *APIRouter) buildOpenAPI(report *GenerationReport) (*api.OpenAPIDoc, error) {
 	doc := &api.OpenAPIDoc{
 		OpenAPI: "3.0.0",
-		Info: api.OpenAPIInfo{
-			Title:       r.title,
-			Version:     r.version,
-			Description: r.description,
-		},
+		Info:    r.core.Info(),
 		Servers: []api.OpenAPIServer{
 			{
-				URL:         r.basePath,
+				URL:         r.core.BasePath(),
 				Description: "API Server",
 			},
 		},
 		Paths: make(map[string]api.PathItem),
 		Components: &api.Components{
-			SecuritySchemes: r.securitySchemes,
+			SecuritySchemes: r.core.SecuritySchemes(),
 		},
 	}
 
 	// Add global security requirements if any
-	if len(r.globalSecurity) > 0 {
-		doc.Security = r.globalSecurity
+	if security := r.core.GlobalSecurity(); len(security) > 0 {
+		doc.Security = security
 	}
 
 	// Generate OpenAPI paths for each API definition
-	for _, apiDef := range r.definitions {
-		pathItem := doc.Paths[apiDef.Path]
-
-		operation := &api.Operation{
-			Summary:     apiDef.Summary,
-			Description: apiDef.Description,
-			Tags:        apiDef.Tags,
-			Responses:   make(map[string]api.Response),
-		}
-
-		// Generate parameter definitions
-		if len(apiDef.Params) > 0 {
-			operation.Parameters = apiDef.Params
+	for _, apiDef := range r.core.GetDefinitions() {
+		if !r.visibleInProfile(&apiDef) || r.excludedPath(apiDef.Path) {
+			continue
 		}
+		pathItem := doc.Paths[apiDef.Path]
 
-		// Generate request body schema
-		if apiDef.Request != nil {
-			schema, err := api.SafeSchemaFromStruct(apiDef.Request)
-			if err != nil {
-				return nil, fmt.Errorf("failed to generate request schema: %w", err)
-			}
-			if schema != nil {
-				operation.RequestBody = &api.RequestBody{
-					Content: map[string]api.Content{
-						"application/json": {
-							Schema: schema,
-						},
-					},
-				}
-			}
+		operation, err := r.buildOperation(&apiDef, report)
+		if err != nil {
+			return nil, err
 		}
 
-		// Generate response schema
-		if apiDef.Response != nil {
-			schema, err := api.SafeSchemaFromStruct(apiDef.Response)
-			if err != nil {
-				return nil, fmt.Errorf("failed to generate response schema: %w", err)
-			}
-			if schema != nil {
-				operation.Responses["200"] = api.Response{
-					Description: "Success",
-					Content: map[string]api.Content{
-						"application/json": {
-							Schema: schema,
-						},
-					},
-				}
-			}
-		}
-
-		// Add default error responses
-		operation.Responses["400"] = api.Response{
-			Description: "Bad Request",
-		}
-		operation.Responses["500"] = api.Response{
-			Description: "Internal Server Error",
+		// Document what the router's configuration adds to the operation
+		if err := r.core.DocumentOperation(doc, &apiDef, operation); err != nil {
+			return nil, err
 		}
 
 		// Set operation based on HTTP method
-		switch apiDef.Method {
-		case http.MethodGet:
-			pathItem.Get = operation
-		case http.MethodPost:
-			pathItem.Post = operation
-		case http.MethodPut:
-			pathItem.Put = operation
-		case http.MethodDelete:
-			pathItem.Delete = operation
-		case http.MethodPatch:
-			pathItem.Patch = operation
-		}
+		pathItem.SetOperation(apiDef.Method, operation)
 
 		doc.Paths[apiDef.Path] = pathItem
 	}
 
+	r.applyProfile(doc)
+
 	return doc, nil
 }
@@ -4,11 +4,16 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	ugorji "github.com/ugorji/go/codec"
+
 	"github.com/smartcat999/go-swagger/pkg/api"
+	"github.com/smartcat999/go-swagger/pkg/codec"
 )
 
 // Test structs
@@ -34,14 +39,14 @@ func TestNewAPIRouter(t *testing.T) {
 	if router == nil {
 		t.Fatal("Expected router to be created")
 	}
-	if router.basePath != "/api/v1" {
-		t.Errorf("Expected basePath '/api/v1', got %s", router.basePath)
+	if router.core.BasePath() != "/api/v1" {
+		t.Errorf("Expected basePath '/api/v1', got %s", router.core.BasePath())
 	}
-	if router.title != "Test API" {
-		t.Errorf("Expected title 'Test API', got %s", router.title)
+	if router.core.Info().Title != "Test API" {
+		t.Errorf("Expected title 'Test API', got %s", router.core.Info().Title)
 	}
-	if router.version != "1.0.0" {
-		t.Errorf("Expected version '1.0.0', got %s", router.version)
+	if router.core.Info().Version != "1.0.0" {
+		t.Errorf("Expected version '1.0.0', got %s", router.core.Info().Version)
 	}
 }
 
@@ -53,14 +58,14 @@ func TestSetInfo(t *testing.T) {
 
 	router.SetInfo("New Title", "2.0.0", "New Description")
 
-	if router.title != "New Title" {
-		t.Errorf("Expected title 'New Title', got %s", router.title)
+	if router.core.Info().Title != "New Title" {
+		t.Errorf("Expected title 'New Title', got %s", router.core.Info().Title)
 	}
-	if router.version != "2.0.0" {
-		t.Errorf("Expected version '2.0.0', got %s", router.version)
+	if router.core.Info().Version != "2.0.0" {
+		t.Errorf("Expected version '2.0.0', got %s", router.core.Info().Version)
 	}
-	if router.description != "New Description" {
-		t.Errorf("Expected description 'New Description', got %s", router.description)
+	if router.core.Info().Description != "New Description" {
+		t.Errorf("Expected description 'New Description', got %s", router.core.Info().Description)
 	}
 }
 
@@ -83,8 +88,8 @@ func TestRegisterAPI(t *testing.T) {
 		t.Fatalf("Register failed: %v", err)
 	}
 
-	if len(router.definitions) != 1 {
-		t.Errorf("Expected 1 definition, got %d", len(router.definitions))
+	if len(router.GetDefinitions()) != 1 {
+		t.Errorf("Expected 1 definition, got %d", len(router.GetDefinitions()))
 	}
 }
 
@@ -151,30 +156,30 @@ func TestSecuritySchemes(t *testing.T) {
 
 	// Test Basic Auth
 	router.AddBasicAuth("basicAuth", "Basic authentication")
-	if len(router.securitySchemes) != 1 {
-		t.Errorf("Expected 1 security scheme, got %d", len(router.securitySchemes))
+	if len(router.core.SecuritySchemes()) != 1 {
+		t.Errorf("Expected 1 security scheme, got %d", len(router.core.SecuritySchemes()))
 	}
 
 	// Test Bearer Auth
 	router.AddBearerAuth("bearerAuth", "JWT Bearer token", "JWT")
-	if len(router.securitySchemes) != 2 {
-		t.Errorf("Expected 2 security schemes, got %d", len(router.securitySchemes))
+	if len(router.core.SecuritySchemes()) != 2 {
+		t.Errorf("Expected 2 security schemes, got %d", len(router.core.SecuritySchemes()))
 	}
 
 	// Test API Key
 	router.AddAPIKey("apiKey", "API Key authentication", "header")
-	if len(router.securitySchemes) != 3 {
-		t.Errorf("Expected 3 security schemes, got %d", len(router.securitySchemes))
+	if len(router.core.SecuritySchemes()) != 3 {
+		t.Errorf("Expected 3 security schemes, got %d", len(router.core.SecuritySchemes()))
 	}
 
 	// Verify scheme types
-	if router.securitySchemes["basicAuth"].Type != "http" {
+	if router.core.SecuritySchemes()["basicAuth"].Type != "http" {
 		t.Error("Expected basicAuth type to be 'http'")
 	}
-	if router.securitySchemes["bearerAuth"].Scheme != "bearer" {
+	if router.core.SecuritySchemes()["bearerAuth"].Scheme != "bearer" {
 		t.Error("Expected bearerAuth scheme to be 'bearer'")
 	}
-	if router.securitySchemes["apiKey"].Type != "apiKey" {
+	if router.core.SecuritySchemes()["apiKey"].Type != "apiKey" {
 		t.Error("Expected apiKey type to be 'apiKey'")
 	}
 }
@@ -404,6 +409,47 @@ func TestParameterValidationMiddleware(t *testing.T) {
 	}
 }
 
+// TestQueryParamStyles tests that query parameters are decoded according to their documented style
+func TestQueryParamStyles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	var ids, filter interface{}
+	apiDef := api.NewAPIDefinition("GET", "/users", "Get users").
+		WithParamSchema("ids", "query", "User IDs", true, map[string]interface{}{"type": "array"},
+			api.ValidationRule{Type: "max", Value: 100.0, Message: "id too large"}).
+		WithParam("filter", "query", "Filter", false).
+		WithParamStyle("ids", api.StylePipeDelimited, false).
+		WithParamStyle("filter", api.StyleDeepObject, true)
+	WithGinHandler(apiDef, func(c *gin.Context) {
+		ids, _ = QueryParam(c, "ids")
+		filter, _ = QueryParam(c, "filter")
+		c.Status(http.StatusOK)
+	})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/api/users?ids=1|2|3&filter[name]=x", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !reflect.DeepEqual(ids, []string{"1", "2", "3"}) {
+		t.Errorf("Unexpected ids %#v", ids)
+	}
+	if !reflect.DeepEqual(filter, map[string]string{"name": "x"}) {
+		t.Errorf("Unexpected filter %#v", filter)
+	}
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/api/users?ids=1|200", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid item, got %d", w.Code)
+	}
+}
+
 // TestRequestBodyValidation tests request body validation
 func TestRequestBodyValidation(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -464,6 +510,62 @@ func TestRequestBodyValidation(t *testing.T) {
 	}
 }
 
+// TestRequestCodec tests validating request bodies decoded by a registered codec
+func TestRequestCodec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	api.RegisterCodec(codec.MsgPack)
+
+	apiDef := api.NewAPIDefinition("POST", "/users", "Create user").
+		WithRequest(CreateUserRequest{}).
+		WithRequestTypes(api.MediaTypeMsgPack).
+		WithNativeHandler(func(c *gin.Context) {
+			c.String(http.StatusCreated, RequestBody(c).(*CreateUserRequest).Username)
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	var body []byte
+	if err := ugorji.NewEncoderBytes(&body, &ugorji.MsgpackHandle{}).Encode(map[string]interface{}{"username": "john", "email": "john@example.com"}); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		wantStatus  int
+	}{
+		{name: "msgpack", body: string(body), contentType: api.MediaTypeMsgPack, wantStatus: http.StatusCreated},
+		{name: "json", body: `{"username":"john","email":"john@example.com"}`, contentType: "application/json", wantStatus: http.StatusCreated},
+		{name: "malformed msgpack", body: "\xc1", contentType: api.MediaTypeMsgPack, wantStatus: http.StatusBadRequest},
+		{name: "not accepted", body: string(body), contentType: api.MediaTypeCBOR, wantStatus: http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/users", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if w.Code == http.StatusCreated && w.Body.String() != "john" {
+				t.Errorf("Expected the decoded username, got %q", w.Body.String())
+			}
+		})
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if _, ok := doc.Paths["/users"].Post.RequestBody.Content[api.MediaTypeMsgPack]; !ok {
+		t.Error("Expected an application/msgpack request body")
+	}
+}
+
 // TestRegisterGroup tests API group registration
 func TestRegisterGroup(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -485,12 +587,12 @@ func TestRegisterGroup(t *testing.T) {
 		t.Fatalf("RegisterGroup failed: %v", err)
 	}
 
-	if len(router.definitions) != 3 {
-		t.Errorf("Expected 3 definitions, got %d", len(router.definitions))
+	if len(router.GetDefinitions()) != 3 {
+		t.Errorf("Expected 3 definitions, got %d", len(router.GetDefinitions()))
 	}
 
 	// Verify all APIs have the tag
-	for _, def := range router.definitions {
+	for _, def := range router.GetDefinitions() {
 		if len(def.Tags) == 0 || def.Tags[0] != "users" {
 			t.Error("Expected all APIs to have 'users' tag")
 		}
@@ -631,6 +733,110 @@ func TestConvertOpenAPIPathToGin(t *testing.T) {
 	}
 }
 
+// TestBinaryResponse tests documentation of file download responses
+func TestBinaryResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	apiDef := api.NewAPIDefinition("GET", "/reports/{id}/pdf", "Download report").
+		WithPathParam("id", "Report ID", true).
+		WithBinaryResponse("application/pdf").
+		WithHandler(func(w http.ResponseWriter, r *http.Request) {})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	op := doc.Paths["/reports/{id}/pdf"].Get
+	if op == nil {
+		t.Fatal("Expected GET operation to be generated")
+	}
+	content, ok := op.Responses["200"].Content["application/pdf"]
+	if !ok {
+		t.Fatalf("Expected application/pdf response content, got %+v", op.Responses["200"].Content)
+	}
+	if content.Schema["type"] != "string" || content.Schema["format"] != "binary" {
+		t.Errorf("Expected binary string schema, got %v", content.Schema)
+	}
+}
+
+// TestPaginationDocumentation tests paging headers in the generated spec
+func TestPaginationDocumentation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	apiDef := api.NewAPIDefinition("GET", "/users", "List users").
+		WithPagination(api.PaginationPage).
+		WithResponse(api.PagedResponse[UserResponse]{}).
+		WithHandler(func(w http.ResponseWriter, r *http.Request) {})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	success := doc.Paths["/users"].Get.Responses["200"]
+	for _, name := range []string{"Link", "X-Total-Count"} {
+		if _, ok := success.Headers[name]; !ok {
+			t.Errorf("Expected %s header on 200 response", name)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/api/users?per_page=500", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for oversized page, got %d", w.Code)
+	}
+}
+
+// TestIdempotencyKeyParameter tests shared header components and runtime presence checks
+func TestIdempotencyKeyParameter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	apiDef := api.NewAPIDefinition("POST", "/payments", "Create payment").
+		WithIdempotencyKey(true).
+		WithHandler(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	shared, ok := doc.Components.Parameters["IdempotencyKeyRequired"]
+	if !ok || shared.Name != "Idempotency-Key" {
+		t.Fatalf("Expected shared Idempotency-Key component, got %+v", doc.Components.Parameters)
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("POST", "/api/payments", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 without Idempotency-Key, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/payments", nil)
+	req.Header.Set("Idempotency-Key", "abc-123")
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected status 201 with Idempotency-Key, got %d", w.Code)
+	}
+}
+
 // BenchmarkRegisterAPI benchmarks API registration
 func BenchmarkRegisterAPI(b *testing.B) {
 	gin.SetMode(gin.TestMode)
@@ -667,3 +873,139 @@ func BenchmarkGenerateSwagger(b *testing.B) {
 		_, _ = router.GenerateSwagger()
 	}
 }
+
+// TestMethodNormalization tests that lower-case and HEAD/OPTIONS methods are registered and documented
+func TestMethodNormalization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	noop := func(w http.ResponseWriter, r *http.Request) {}
+	for _, def := range []*api.APIDefinition{
+		api.NewAPIDefinition("get", "/items", "List items").WithHandler(noop),
+		api.HEAD("/items", "Check items").WithHandler(noop),
+		api.OPTIONS("/items", "Item options").WithHandler(noop),
+	} {
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register %s failed: %v", def.Method, err)
+		}
+	}
+	if err := router.Register(api.NewAPIDefinition("TRACE", "/items", "Trace").WithHandler(noop)); err == nil {
+		t.Error("Expected TRACE to be rejected")
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	item := doc.Paths["/items"]
+	if item.Get == nil || item.Head == nil || item.Options == nil {
+		t.Errorf("Expected get, head and options operations, got %+v", item)
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/items", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected lower-case registration to serve GET, got %d", w.Code)
+	}
+}
+
+// TestGeneratedDocIsValid tests the generated document against the official OpenAPI meta-schema
+func TestGeneratedDocIsValid(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+	router.AddBearerAuth("bearerAuth", "JWT token", "JWT")
+	router.SetCORS(api.CORSPolicy{AllowOrigins: []string{"*"}})
+
+	noop := func(c *gin.Context) {}
+	defs := []*api.APIDefinition{
+		api.GET("/users", "List users").
+			WithTags("users").
+			WithPagination(api.PaginationPage).
+			WithRequestID().
+			WithConditionalGet().
+			WithRateLimit(api.RateLimitPolicy{Requests: 10, Window: time.Minute}).
+			WithResponse(UserResponse{}),
+		api.POST("/users", "Create user").
+			WithTags("users").
+			WithRequest(CreateUserRequest{}).
+			WithResponse(UserResponse{}).
+			WithIdempotencyKey(true).
+			WithSecurity("bearerAuth", []string{}).
+			WithTimeout(time.Second).
+			WithMaxBodySize(1024),
+		api.GET("/users/{id}", "Get user").
+			WithPathParam("id", "User ID", true).
+			WithResponse(UserResponse{}).
+			WithDeprecation(time.Now().Add(24*time.Hour), "/v2/users/{id}").
+			WithChangelog("1.1", "Added email"),
+		api.PUT("/reports/{id}", "Replace report").
+			WithPathParam("id", "Report ID", true).
+			WithBinaryResponse("application/pdf").
+			WithConditionalUpdate(true),
+	}
+	for _, def := range defs {
+		if err := router.Register(def.WithNativeHandler(noop)); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	if err := router.RegisterHealthEndpoints(HealthOptions{}); err != nil {
+		t.Fatalf("RegisterHealthEndpoints failed: %v", err)
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if err := api.ValidateDoc(doc); err != nil {
+		t.Errorf("Generated document is invalid: %v", err)
+	}
+}
+
+// TestOperationMetadata tests that definition metadata reaches the serialized operations
+func TestOperationMetadata(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+	apiDef := api.NewAPIDefinition("PUT", "/users/{id}", "Update user").
+		WithOperationID("updateUser").
+		WithPathParam("id", "User ID", true).
+		WithDeprecated(true).
+		WithSecurity("oauth2", []string{"users:write"}).
+		WithExternalDocs("User guide", "https://docs.example.com/users").
+		WithServer("https://users.example.com", "Users service").
+		WithNativeHandler(func(c *gin.Context) {})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	data, err := json.Marshal(doc.Paths["/users/{id}"].Put)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	var operation map[string]interface{}
+	if err := json.Unmarshal(data, &operation); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		want interface{}
+	}{
+		{"operationId", "updateUser"},
+		{"deprecated", true},
+		{"security", []interface{}{map[string]interface{}{"oauth2": []interface{}{"users:write"}}}},
+		{"externalDocs", map[string]interface{}{"description": "User guide", "url": "https://docs.example.com/users"}},
+		{"servers", []interface{}{map[string]interface{}{"url": "https://users.example.com", "description": "Users service"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := operation[tt.key]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
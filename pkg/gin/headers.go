@@ -0,0 +1,7 @@
+package gin
+
+// EnableNegotiatedHeaders documents the Accept and Content-Type headers of every operation as optional
+// parameters listing the media types it produces and consumes
+func (r *APIRouter) EnableNegotiatedHeaders() {
+	r.core.EnableNegotiatedHeaders()
+}
@@ -0,0 +1,52 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestNegotiatedHeaders tests case-insensitive header validation and documenting negotiated headers
+func TestNegotiatedHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.EnableNegotiatedHeaders()
+
+	apiDef := api.NewAPIDefinition("POST", "/items", "Create item").
+		WithHeaderParam("x-tenant-id", "Tenant", true).
+		WithRequest(struct {
+			Name string `json:"name"`
+		}{}).
+		WithResponse(struct {
+			ID string `json:"id"`
+		}{}).
+		WithNativeHandler(func(c *gin.Context) { c.Status(http.StatusOK) })
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/api/items", strings.NewReader(`{"name":"a"}`))
+	req.Header["x-tenant-id"] = []string{"acme"}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 for a raw header key, got %d: %s", w.Code, w.Body.String())
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	names := make(map[string]bool)
+	for _, param := range doc.Paths["/items"].Post.Parameters {
+		names[param.Name] = !param.Required
+	}
+	if !names["Accept"] || !names["Content-Type"] {
+		t.Errorf("Expected optional Accept and Content-Type parameters, got %+v", doc.Paths["/items"].Post.Parameters)
+	}
+}
@@ -0,0 +1,100 @@
+package gin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// HealthCheck reports whether a dependency is ready
+type HealthCheck func(ctx context.Context) error
+
+// HealthOptions configures the built-in health, readiness and version endpoints
+type HealthOptions struct {
+	HealthPath  string                 // Liveness path (default /healthz)
+	ReadyPath   string                 // Readiness path (default /readyz)
+	VersionPath string                 // Version path (default /version)
+	Checks      map[string]HealthCheck // Readiness checks keyed by dependency name
+	Version     VersionInfo            // Build information served by the version endpoint
+	Tag         string                 // Tag for the endpoints (default "infrastructure")
+	Internal    bool                   // Hide the endpoints from public environment profiles
+}
+
+// HealthStatus is the payload of the health and readiness endpoints
+type HealthStatus struct {
+	Status string            `json:"status" doc:"ok or unavailable"`
+	Checks map[string]string `json:"checks,omitempty" doc:"Result of each readiness check"`
+}
+
+// VersionInfo is the payload of the version endpoint
+type VersionInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"buildTime,omitempty"`
+}
+
+// RegisterHealthEndpoints registers documented liveness, readiness and version endpoints
+func (r *APIRouter) RegisterHealthEndpoints(opts HealthOptions) error {
+	if opts.HealthPath == "" {
+		opts.HealthPath = "/healthz"
+	}
+	if opts.ReadyPath == "" {
+		opts.ReadyPath = "/readyz"
+	}
+	if opts.VersionPath == "" {
+		opts.VersionPath = "/version"
+	}
+	if opts.Tag == "" {
+		opts.Tag = "infrastructure"
+	}
+	if opts.Version.Version == "" {
+		opts.Version.Version = r.core.Info().Version
+	}
+
+	definitions := []*api.APIDefinition{
+		api.NewAPIDefinition(http.MethodGet, opts.HealthPath, "Liveness probe").
+			WithDescription("Reports whether the process is running").
+			WithResponse(HealthStatus{}).
+			WithNativeHandler(func(c *gin.Context) {
+				c.JSON(http.StatusOK, HealthStatus{Status: "ok"})
+			}),
+		api.NewAPIDefinition(http.MethodGet, opts.ReadyPath, "Readiness probe").
+			WithDescription("Reports whether the service and its dependencies can handle traffic").
+			WithResponse(HealthStatus{}).
+			WithStatusResponse(http.StatusServiceUnavailable, "Service Unavailable - A readiness check failed", HealthStatus{}).
+			WithNativeHandler(func(c *gin.Context) {
+				status := HealthStatus{Status: "ok", Checks: make(map[string]string)}
+				code := http.StatusOK
+				for name, check := range opts.Checks {
+					if err := check(c.Request.Context()); err != nil {
+						status.Checks[name] = err.Error()
+						status.Status = "unavailable"
+						code = http.StatusServiceUnavailable
+						continue
+					}
+					status.Checks[name] = "ok"
+				}
+				c.JSON(code, status)
+			}),
+		api.NewAPIDefinition(http.MethodGet, opts.VersionPath, "Version information").
+			WithDescription("Reports the deployed build").
+			WithResponse(VersionInfo{}).
+			WithNativeHandler(func(c *gin.Context) {
+				c.JSON(http.StatusOK, opts.Version)
+			}),
+	}
+
+	for _, def := range definitions {
+		def.WithTags(opts.Tag)
+		if opts.Internal {
+			def.WithInternal()
+		}
+		if err := r.Register(def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
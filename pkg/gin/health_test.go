@@ -0,0 +1,72 @@
+package gin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestHealthEndpoints tests the built-in infrastructure endpoints and their documentation
+func TestHealthEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "", "Test API", "1.0.0", "Test")
+
+	dbErr := errors.New("connection refused")
+	err := router.RegisterHealthEndpoints(HealthOptions{
+		Checks: map[string]HealthCheck{
+			"cache":    func(ctx context.Context) error { return nil },
+			"database": func(ctx context.Context) error { return dbErr },
+		},
+		Version:  VersionInfo{Commit: "abc123"},
+		Internal: true,
+	})
+	if err != nil {
+		t.Fatalf("RegisterHealthEndpoints failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected healthz status 200, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+	var ready HealthStatus
+	_ = json.Unmarshal(w.Body.Bytes(), &ready)
+	if w.Code != http.StatusServiceUnavailable || ready.Checks["database"] != "connection refused" || ready.Checks["cache"] != "ok" {
+		t.Errorf("Unexpected readyz response %d %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/version", nil))
+	var version VersionInfo
+	_ = json.Unmarshal(w.Body.Bytes(), &version)
+	if version.Version != "1.0.0" || version.Commit != "abc123" {
+		t.Errorf("Unexpected version response %s", w.Body.String())
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	ready503 := doc.Paths["/readyz"].Get.Responses["503"]
+	if ready503.Content == nil {
+		t.Error("Expected documented 503 readiness payload")
+	}
+	if tags := doc.Paths["/healthz"].Get.Tags; len(tags) != 1 || tags[0] != "infrastructure" {
+		t.Errorf("Expected infrastructure tag, got %v", tags)
+	}
+
+	router.AddEnvironment("public", EnvironmentProfile{HideInternal: true})
+	_ = router.SetEnvironment("public")
+	if _, err := router.GenerateSwagger(); err == nil {
+		t.Error("Expected internal health endpoints to be hidden from the public profile")
+	}
+}
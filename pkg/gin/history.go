@@ -0,0 +1,117 @@
+package gin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// SpecSnapshot is a stored version of the generated document
+type SpecSnapshot struct {
+	Hash        string    `json:"hash"`        // SHA-256 of the document
+	Version     string    `json:"version"`     // API version at generation time
+	GeneratedAt time.Time `json:"generatedAt"` // When the document was generated
+	Document    []byte    `json:"-"`           // Serialized document
+}
+
+// SpecHistoryStore persists spec snapshots, oldest first
+type SpecHistoryStore interface {
+	Save(snapshot SpecSnapshot) error
+	List() ([]SpecSnapshot, error)
+}
+
+// MemoryHistoryStore keeps snapshots in process memory
+type MemoryHistoryStore struct {
+	mu        sync.RWMutex
+	snapshots []SpecSnapshot
+}
+
+// NewMemoryHistoryStore creates an in-memory snapshot store
+func NewMemoryHistoryStore() *MemoryHistoryStore {
+	return &MemoryHistoryStore{}
+}
+
+// Save implements SpecHistoryStore
+func (s *MemoryHistoryStore) Save(snapshot SpecSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshots = append(s.snapshots, snapshot)
+	return nil
+}
+
+// List implements SpecHistoryStore
+func (s *MemoryHistoryStore) List() ([]SpecSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]SpecSnapshot(nil), s.snapshots...), nil
+}
+
+// SetHistoryStore records a snapshot every time GenerateSwagger produces a changed document
+func (r *APIRouter) SetHistoryStore(store SpecHistoryStore) {
+	r.history = store
+}
+
+// recordSnapshot saves the document unless it matches the latest snapshot
+func (r *APIRouter) recordSnapshot(data []byte) error {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	snapshots, err := r.history.List()
+	if err != nil {
+		return err
+	}
+	if len(snapshots) > 0 && snapshots[len(snapshots)-1].Hash == hash {
+		return nil
+	}
+	return r.history.Save(SpecSnapshot{
+		Hash:        hash,
+		Version:     r.core.Info().Version,
+		GeneratedAt: time.Now().UTC(),
+		Document:    data,
+	})
+}
+
+// specHistoryEntry is a snapshot listed with its changes relative to the previous one
+type specHistoryEntry struct {
+	SpecSnapshot
+	Changes *api.SpecDiff `json:"changes"`
+}
+
+// SpecHistoryHandler lists stored spec versions with operation diffs (e.g., mount at /swagger/history)
+func (r *APIRouter) SpecHistoryHandler(c *gin.Context) {
+	if !r.authorizeDocs(c) {
+		return
+	}
+	if r.docsAnalytics != nil {
+		r.docsAnalytics.hit(c)
+	}
+	if r.history == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Spec history is not enabled"})
+		return
+	}
+
+	snapshots, err := r.history.List()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries := make([]specHistoryEntry, 0, len(snapshots))
+	var previous []byte
+	for _, snapshot := range snapshots {
+		diff, err := api.DiffSpecs(previous, snapshot.Document)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		entries = append(entries, specHistoryEntry{SpecSnapshot: snapshot, Changes: diff})
+		previous = snapshot.Document
+	}
+	c.JSON(http.StatusOK, gin.H{"versions": entries})
+}
@@ -0,0 +1,66 @@
+package gin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestSpecHistory tests snapshot recording and the history endpoint
+func TestSpecHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	store := NewMemoryHistoryStore()
+	router.SetHistoryStore(store)
+	engine.GET("/swagger/history", router.SpecHistoryHandler)
+
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	if err := router.Register(api.NewAPIDefinition("GET", "/users", "List users").WithHandler(handler)); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	if err := router.Register(api.NewAPIDefinition("POST", "/users", "Create user").WithHandler(handler)); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	snapshots, _ := store.List()
+	if len(snapshots) != 2 {
+		t.Fatalf("Expected 2 snapshots (unchanged document skipped), got %d", len(snapshots))
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/swagger/history", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var body struct {
+		Versions []struct {
+			Hash    string       `json:"hash"`
+			Changes api.SpecDiff `json:"changes"`
+		} `json:"versions"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if len(body.Versions) != 2 || body.Versions[0].Hash == "" {
+		t.Fatalf("Unexpected history %s", w.Body.String())
+	}
+	if added := body.Versions[1].Changes.Added; len(added) != 1 || added[0] != "POST /users" {
+		t.Errorf("Expected POST /users to be added, got %v", added)
+	}
+}
@@ -0,0 +1,8 @@
+package gin
+
+// EnableResponseFiltering removes fields tagged `swaggerignore:"true"` from JSON responses, so data kept
+// for peers (e.g. replication bookkeeping) doesn't leak past the documented contract
+// Only operations whose response model has ignored fields are buffered
+func (r *APIRouter) EnableResponseFiltering() {
+	r.core.EnableResponseFiltering()
+}
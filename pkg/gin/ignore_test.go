@@ -0,0 +1,111 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type replicatedUser struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Version int    `json:"version" swaggerignore:"true"`
+}
+
+// TestResponseFiltering tests removing swaggerignore fields from JSON responses
+func TestResponseFiltering(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  bool
+		response interface{}
+		wantBody string
+	}{
+		{name: "filtered", enabled: true, response: replicatedUser{}, wantBody: `{"id":"1","name":"Ada"}`},
+		{name: "disabled", enabled: false, response: replicatedUser{}, wantBody: `{"id":"1","name":"Ada","version":7}`},
+		{name: "no ignored fields", enabled: true, response: nil, wantBody: `{"id":"1","name":"Ada","version":7}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			engine := gin.New()
+			router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+			if tt.enabled {
+				router.EnableResponseFiltering()
+			}
+			apiDef := api.NewAPIDefinition("GET", "/users/{id}", "Get user").
+				WithNativeHandler(func(c *gin.Context) {
+					c.JSON(http.StatusCreated, replicatedUser{ID: c.Param("id"), Name: "Ada", Version: 7})
+				})
+			apiDef.Response = tt.response
+			if err := router.Register(apiDef); err != nil {
+				t.Fatalf("Register failed: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest("GET", "/api/users/1", nil))
+			if w.Code != http.StatusCreated {
+				t.Errorf("Expected status %d, got %d", http.StatusCreated, w.Code)
+			}
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("Expected body %s, got %s", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+type replicatedTeam struct {
+	Version int              `json:"version"`
+	Members []replicatedUser `json:"members"`
+	Lead    replicatedUser   `json:"lead"`
+}
+
+type replicatedError struct {
+	Error   string `json:"error"`
+	TraceID string `json:"trace_id" swaggerignore:"true"`
+}
+
+// TestResponseFilteringStructure tests removing only the ignored fields the response model places at each level
+func TestResponseFilteringStructure(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.EnableResponseFiltering()
+	apiDef := api.NewAPIDefinition("GET", "/teams/{id}", "Get team").
+		WithResponse(replicatedTeam{}).
+		WithStatusResponse(http.StatusNotFound, "Not found", replicatedError{}).
+		WithNativeHandler(func(c *gin.Context) {
+			if c.Param("id") == "missing" {
+				c.JSON(http.StatusNotFound, gin.H{"error": "no team", "trace_id": "t1", "version": 1})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"version": 3,
+				"Version": 4,
+				"members": []gin.H{{"id": "1", "name": "Ada", "version": 7}},
+				"lead":    gin.H{"id": "1", "name": "Ada", "version": 7},
+			})
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		target   string
+		wantBody string
+	}{
+		{target: "/api/teams/1", wantBody: `{"Version":4,"lead":{"id":"1","name":"Ada"},"members":[{"id":"1","name":"Ada"}],"version":3}`},
+		{target: "/api/teams/missing", wantBody: `{"error":"no team","version":1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest("GET", tt.target, nil))
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("Expected body %s, got %s", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package gin
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// ginParamNamePattern captures the names of the :name and *name segments of a gin path
+var ginParamNamePattern = regexp.MustCompile(`[:*]([^/]+)`)
+
+// routerHandlerPrefixes start the names of the handlers the APIRouter and its adapter register, for
+// operations, CORS preflights and the documentation endpoints
+var routerHandlerPrefixes = []string{
+	reflect.TypeOf(APIRouter{}).PkgPath() + ".(*APIRouter).",
+	reflect.TypeOf(APIRouter{}).PkgPath() + ".(*engineAdapter).",
+}
+
+// ImportExistingRoutes documents the routes registered directly on the engine under the base path as skeleton
+// definitions (method, path, path parameters and handler name) flagged with x-undocumented, so they appear in
+// the document and as undocumented-route warnings of GenerateReport instead of silently escaping it.
+// Routes of registered definitions and of the router itself are skipped; the imported definitions are returned
+func (r *APIRouter) ImportExistingRoutes() []api.APIDefinition {
+	basePath := r.core.BasePath()
+	definitions := r.core.GetDefinitions()
+	known := make(map[string]bool, len(definitions))
+	for i := range definitions {
+		def := &definitions[i]
+		known[routeKey(def.Method, basePath+convertOpenAPIPathToGin(def.Path))] = true
+	}
+
+	var imported []api.APIDefinition
+	for _, route := range r.engine.Routes() {
+		if known[routeKey(route.Method, route.Path)] || routerHandler(route.Handler) {
+			continue
+		}
+		path, ok := strings.CutPrefix(route.Path, basePath)
+		if !ok || (path != "" && !strings.HasPrefix(path, "/")) {
+			continue
+		}
+		if path == "" {
+			path = "/"
+		}
+
+		def := api.NewAPIDefinition(route.Method, ginParamNamePattern.ReplaceAllString(path, "{$1}"), handlerName(route.Handler))
+		for _, match := range ginParamNamePattern.FindAllStringSubmatch(path, -1) {
+			def.WithPathParam(match[1], "", true)
+		}
+		def.WithExtension("x-undocumented", true).WithExtension("x-handler", route.Handler)
+		known[routeKey(route.Method, route.Path)] = true
+		r.core.Document(*def)
+		imported = append(imported, *def)
+	}
+	return imported
+}
+
+// routerHandler reports whether a handler name belongs to the APIRouter or its adapter
+func routerHandler(name string) bool {
+	for _, prefix := range routerHandlerPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// closureSuffix matches the suffixes of the names of anonymous functions, e.g. ".func1.2"
+var closureSuffix = regexp.MustCompile(`(\.func\d+)(\.\d+)*$`)
+
+// handlerName shortens a handler's qualified function name, e.g. "main.(*Server).health-fm" to "health";
+// anonymous functions are named after the function declaring them
+func handlerName(qualified string) string {
+	name := qualified[strings.LastIndex(qualified, "/")+1:]
+	name = closureSuffix.ReplaceAllString(strings.TrimSuffix(name, "-fm"), "")
+	return name[strings.LastIndex(name, ".")+1:]
+}
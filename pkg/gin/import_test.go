@@ -0,0 +1,98 @@
+package gin
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// healthCheck is a handler registered directly on the engine
+func healthCheck(c *gin.Context) {
+	c.Status(http.StatusOK)
+}
+
+// TestImportExistingRoutes tests documenting routes registered outside the router as skeleton definitions
+func TestImportExistingRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	if err := router.Register(api.NewAPIDefinition("GET", "/users", "List users").WithNativeHandler(func(c *gin.Context) {})); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	engine.GET("/api/health", healthCheck)
+	engine.DELETE("/api/users/:id", func(c *gin.Context) {})
+	engine.GET("/metrics", func(c *gin.Context) {})
+
+	imported := router.ImportExistingRoutes()
+	tests := []struct {
+		method  string
+		path    string
+		summary string
+		params  int
+	}{
+		{"GET", "/health", "healthCheck", 0},
+		{"DELETE", "/users/{id}", "TestImportExistingRoutes", 1},
+	}
+	if len(imported) != len(tests) {
+		t.Fatalf("ImportExistingRoutes() imported %d definitions, want %d: %+v", len(imported), len(tests), imported)
+	}
+	for i, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			def := imported[i]
+			if def.Method != tt.method || def.Path != tt.path || def.Summary != tt.summary || len(def.Params) != tt.params {
+				t.Errorf("Unexpected definition %s %s %q with %d parameters", def.Method, def.Path, def.Summary, len(def.Params))
+			}
+			if def.Extensions["x-undocumented"] != true {
+				t.Errorf("Expected x-undocumented, got %v", def.Extensions)
+			}
+		})
+	}
+
+	if again := router.ImportExistingRoutes(); len(again) != 0 {
+		t.Errorf("Expected a second import to add nothing, got %+v", again)
+	}
+	if err := router.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if op := doc.Paths["/health"].Get; op == nil || op.Extensions["x-undocumented"] != true {
+		t.Errorf("Expected the imported route in the document, got %+v", op)
+	}
+	var warnings int
+	for _, warning := range router.GenerateReport().Warnings {
+		if warning.Kind == WarningUndocumentedRoute {
+			warnings++
+		}
+	}
+	if warnings != 2 {
+		t.Errorf("undocumented-route warnings = %d, want 2", warnings)
+	}
+}
+
+// TestHandlerName tests shortening qualified handler names
+func TestHandlerName(t *testing.T) {
+	tests := []struct {
+		qualified string
+		want      string
+	}{
+		{"main.health", "health"},
+		{"github.com/acme/app/server.(*Server).health-fm", "health"},
+		{"github.com/acme/app/server.routes.func1", "routes"},
+		{"github.com/acme/app/server.routes.func2.1", "routes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.qualified, func(t *testing.T) {
+			if got := handlerName(tt.qualified); got != tt.want {
+				t.Errorf("handlerName(%q) = %q, want %q", tt.qualified, got, tt.want)
+			}
+		})
+	}
+}
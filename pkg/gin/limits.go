@@ -0,0 +1,11 @@
+package gin
+
+import (
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// SetPayloadLimits bounds the size and shape of the request bodies of definitions declaring no limits
+// Bodies are checked before they are decoded; the 413 and 400 responses are documented
+func (r *APIRouter) SetPayloadLimits(limits api.PayloadLimits) {
+	r.core.SetPayloadLimits(limits)
+}
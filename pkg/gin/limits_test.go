@@ -0,0 +1,96 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestMaxBodySize tests request body size enforcement
+func TestMaxBodySize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	apiDef := api.NewAPIDefinition("POST", "/users", "Create user").
+		WithRequest(CreateUserRequest{}).
+		WithMaxBodySize(64).
+		WithHandler(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		chunked    bool
+		wantStatus int
+	}{
+		{name: "within limit", body: `{"username":"john"}`, wantStatus: http.StatusCreated},
+		{name: "content length too large", body: `{"username":"` + strings.Repeat("a", 100) + `"}`, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "streamed body too large", body: `{"username":"` + strings.Repeat("a", 100) + `"}`, chunked: true, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/users", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d. Body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if _, ok := doc.Paths["/users"].Post.Responses["413"]; !ok {
+		t.Error("Expected 413 response in spec")
+	}
+}
+
+// TestTimeout tests request deadline propagation and 408 responses
+func TestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	apiDef := api.NewAPIDefinition("GET", "/slow", "Slow operation").
+		WithTimeout(10 * time.Millisecond).
+		WithNativeHandler(func(c *gin.Context) {
+			if _, ok := c.Request.Context().Deadline(); !ok {
+				t.Error("Expected request context to carry a deadline")
+			}
+			<-c.Request.Context().Done()
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/api/slow", nil))
+	if w.Code != http.StatusRequestTimeout {
+		t.Errorf("Expected status 408, got %d", w.Code)
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if _, ok := doc.Paths["/slow"].Get.Responses["408"]; !ok {
+		t.Error("Expected 408 response in spec")
+	}
+}
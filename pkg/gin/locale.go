@@ -0,0 +1,85 @@
+package gin
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// AddTranslations registers the catalog of a locale, served by SwaggerHandler as /swagger.json?lang=<locale>
+// Catalog keys are <key>.summary and <key>.description for definitions using WithDescriptionKey,
+// plus info.title and info.description for the document itself
+func (r *APIRouter) AddTranslations(locale string, catalog api.Catalog) {
+	if r.translations == nil {
+		r.translations = make(map[string]api.Catalog)
+	}
+	r.translations[locale] = catalog
+}
+
+// GenerateSwaggerForLocale returns the document with the summaries and descriptions translated into a locale
+// Texts missing from the locale's catalog keep their default language
+func (r *APIRouter) GenerateSwaggerForLocale(locale string) (*api.OpenAPIDoc, error) {
+	catalog, ok := r.translations[locale]
+	if !ok {
+		return nil, fmt.Errorf("unknown locale: %s", locale)
+	}
+	doc, err := r.buildDocument(nil)
+	if err != nil {
+		return nil, err
+	}
+	r.localize(doc, catalog)
+	return doc, nil
+}
+
+// localize translates the texts of a document with a catalog
+func (r *APIRouter) localize(doc *api.OpenAPIDoc, catalog api.Catalog) {
+	doc.Info.Title = catalog.Translate("info.title", doc.Info.Title)
+	doc.Info.Description = catalog.Translate("info.description", doc.Info.Description)
+
+	for _, def := range r.core.GetDefinitions() {
+		if def.DescKey == "" {
+			continue
+		}
+		op := doc.Paths[def.Path].Operation(def.Method)
+		if op == nil {
+			continue
+		}
+		op.Summary = catalog.Translate(def.DescKey+".summary", op.Summary)
+		op.Description = catalog.Translate(def.DescKey+".description", op.Description)
+	}
+}
+
+// generateLocalized builds and marshals the document of every registered locale
+func (r *APIRouter) generateLocalized() (map[string][]byte, error) {
+	if len(r.translations) == 0 {
+		return nil, nil
+	}
+	localized := make(map[string][]byte, len(r.translations))
+	for locale := range r.translations {
+		doc, err := r.GenerateSwaggerForLocale(locale)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s document: %w", locale, err)
+		}
+		localized[locale] = data
+	}
+	return localized, nil
+}
+
+// localizedDoc returns the cached document of a locale, falling back from a regional locale (pt-BR) to its language (pt)
+func (r *APIRouter) localizedDoc(lang string) []byte {
+	r.docMu.RLock()
+	defer r.docMu.RUnlock()
+	if data, ok := r.localizedDocs[lang]; ok {
+		return data
+	}
+	if language, _, ok := strings.Cut(lang, "-"); ok {
+		return r.localizedDocs[language]
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package gin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestLocalization tests translated documents and serving them by ?lang=
+func TestLocalization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.AddTranslations("ja", api.Catalog{
+		"info.title":            "テスト API",
+		"users.get.summary":     "ユーザーを取得",
+		"users.get.description": "ID でユーザーを取得します",
+	})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	defs := []*api.APIDefinition{
+		api.NewAPIDefinition("GET", "/users/{id}", "Get user").
+			WithDescription("Gets a user by ID").
+			WithDescriptionKey("users.get").
+			WithHandler(handler),
+		api.NewAPIDefinition("DELETE", "/users/{id}", "Delete user").
+			WithDescriptionKey("users.delete").
+			WithHandler(handler),
+	}
+	for _, def := range defs {
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	if _, err := router.GenerateSwaggerForLocale("fr"); err == nil {
+		t.Error("Expected an unknown locale to be rejected")
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	engine.GET("/swagger.json", router.SwaggerHandler)
+
+	tests := []struct {
+		name            string
+		target          string
+		wantTitle       string
+		wantSummary     string
+		wantDescription string
+	}{
+		{name: "default", target: "/swagger.json", wantTitle: "Test API", wantSummary: "Get user", wantDescription: "Gets a user by ID"},
+		{name: "japanese", target: "/swagger.json?lang=ja", wantTitle: "テスト API", wantSummary: "ユーザーを取得", wantDescription: "ID でユーザーを取得します"},
+		{name: "regional fallback", target: "/swagger.json?lang=ja-JP", wantTitle: "テスト API", wantSummary: "ユーザーを取得", wantDescription: "ID でユーザーを取得します"},
+		{name: "unknown locale", target: "/swagger.json?lang=fr", wantTitle: "Test API", wantSummary: "Get user", wantDescription: "Gets a user by ID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest("GET", tt.target, nil))
+			var doc api.OpenAPIDoc
+			if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+				t.Fatalf("Failed to decode document: %v", err)
+			}
+			if doc.Info.Title != tt.wantTitle {
+				t.Errorf("Expected title %q, got %q", tt.wantTitle, doc.Info.Title)
+			}
+			item := doc.Paths["/users/{id}"]
+			if item.Get.Summary != tt.wantSummary || item.Get.Description != tt.wantDescription {
+				t.Errorf("Expected %q / %q, got %q / %q", tt.wantSummary, tt.wantDescription, item.Get.Summary, item.Get.Description)
+			}
+			if item.Delete.Summary != "Delete user" {
+				t.Errorf("Expected untranslated operations to keep their summary, got %q", item.Delete.Summary)
+			}
+		})
+	}
+}
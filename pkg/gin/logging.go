@@ -0,0 +1,26 @@
+package gin
+
+import (
+	"log"
+
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// RequestLogEntry describes a handled request in terms of its documented operation
+type RequestLogEntry = router.RequestLogEntry
+
+// RequestLogger receives one entry per handled request
+type RequestLogger = router.RequestLogger
+
+// RequestLoggerFunc adapts a function to RequestLogger
+type RequestLoggerFunc = router.RequestLoggerFunc
+
+// NewStdRequestLogger writes entries to a standard library logger
+func NewStdRequestLogger(logger *log.Logger) RequestLogger {
+	return router.NewStdRequestLogger(logger)
+}
+
+// SetRequestLogger logs every request handled by a registered operation
+func (r *APIRouter) SetRequestLogger(logger RequestLogger) {
+	r.core.SetRequestLogger(logger)
+}
@@ -0,0 +1,66 @@
+package gin
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestRequestLogger tests structured request logging with sensitive parameter masking
+func TestRequestLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	var entries []RequestLogEntry
+	router.SetRequestLogger(RequestLoggerFunc(func(ctx context.Context, entry RequestLogEntry) {
+		entries = append(entries, entry)
+	}))
+
+	apiDef := api.NewAPIDefinition("GET", "/users/{id}", "Get user").
+		WithOperationID("getUser").
+		WithPathParam("id", "User ID", true).
+		WithParams([]api.Parameter{{Name: "X-Api-Token", In: "header", Sensitive: true}}).
+		WithQueryParam("fields", "Fields", false, api.NewValidationRule("pattern", "^[a-z,]+$", "invalid fields")).
+		WithHandler(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/api/users/42?fields=name", nil)
+	req.Header.Set("X-Api-Token", "s3cret")
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/users/42?fields=NAME", nil))
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 log entries, got %d", len(entries))
+	}
+	first := entries[0]
+	if first.OperationID != "getUser" || first.Route != "/api/users/{id}" || first.Status != http.StatusOK {
+		t.Errorf("Unexpected entry %+v", first)
+	}
+	if first.Params["id"] != "42" || first.Params["X-Api-Token"] != "***" {
+		t.Errorf("Expected id and masked token, got %v", first.Params)
+	}
+	if !entries[1].ValidationFailed || entries[1].Status != http.StatusBadRequest {
+		t.Errorf("Expected validation failure, got %+v", entries[1])
+	}
+}
+
+// TestStdRequestLogger tests the standard library logger adapter
+func TestStdRequestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStdRequestLogger(log.New(&buf, "", 0))
+	logger.LogRequest(context.Background(), RequestLogEntry{OperationID: "getUser", Status: 200})
+
+	if !strings.Contains(buf.String(), "operation_id getUser") {
+		t.Errorf("Unexpected log output %q", buf.String())
+	}
+}
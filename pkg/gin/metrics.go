@@ -0,0 +1,17 @@
+package gin
+
+import (
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// MetricLabels identifies the operation a measurement belongs to
+type MetricLabels = router.MetricLabels
+
+// Metrics receives per-operation measurements
+// The prommetrics subpackage implements it with Prometheus collectors registered on a prometheus.Registerer
+type Metrics = router.Metrics
+
+// SetMetrics records request count, latency and validation failures for every registered operation
+func (r *APIRouter) SetMetrics(metrics Metrics) {
+	r.core.SetMetrics(metrics)
+}
@@ -0,0 +1,56 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type fakeMetrics struct {
+	requests []MetricLabels
+	failures []MetricLabels
+}
+
+func (m *fakeMetrics) ObserveRequest(labels MetricLabels, duration time.Duration) {
+	m.requests = append(m.requests, labels)
+}
+
+func (m *fakeMetrics) IncValidationFailure(labels MetricLabels) {
+	m.failures = append(m.failures, labels)
+}
+
+// TestMetrics tests per-operation request and validation failure measurements
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	metrics := &fakeMetrics{}
+	router.SetMetrics(metrics)
+
+	apiDef := api.NewAPIDefinition("GET", "/users", "List users").
+		WithOperationID("listUsers").
+		WithTags("users").
+		WithQueryParam("limit", "Limit", true).
+		WithHandler(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/users?limit=5", nil))
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/users", nil))
+
+	if len(metrics.requests) != 2 {
+		t.Fatalf("Expected 2 observations, got %d", len(metrics.requests))
+	}
+	want := MetricLabels{OperationID: "listUsers", Tag: "users", Method: "GET", Route: "/api/users", Status: http.StatusOK}
+	if metrics.requests[0] != want {
+		t.Errorf("Expected labels %+v, got %+v", want, metrics.requests[0])
+	}
+	if len(metrics.failures) != 1 || metrics.failures[0].Status != http.StatusBadRequest {
+		t.Errorf("Expected one validation failure with status 400, got %+v", metrics.failures)
+	}
+}
@@ -0,0 +1,52 @@
+package gin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// checkMiddleware rejects operation middlewares the adapter can't run
+func checkMiddleware(middleware []interface{}) error {
+	for _, m := range middleware {
+		switch m.(type) {
+		case gin.HandlerFunc, func(*gin.Context), func(http.Handler) http.Handler:
+		default:
+			return fmt.Errorf("unsupported middleware type %T", m)
+		}
+	}
+	return nil
+}
+
+// middlewareChain converts operation middlewares into gin handlers, registered between request validation and
+// the handler so c.Next advances to the rest of the chain; net/http middlewares call it through their next handler
+func middlewareChain(middleware []interface{}) gin.HandlersChain {
+	chain := make(gin.HandlersChain, 0, len(middleware))
+	for _, m := range middleware {
+		switch m := m.(type) {
+		case gin.HandlerFunc:
+			chain = append(chain, m)
+		case func(*gin.Context):
+			chain = append(chain, m)
+		case func(http.Handler) http.Handler:
+			chain = append(chain, httpMiddleware(m))
+		}
+	}
+	return chain
+}
+
+// httpMiddleware runs a net/http middleware in the gin chain, aborting the chain when it does not call next
+func httpMiddleware(m func(http.Handler) http.Handler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		called := false
+		m(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			called = true
+			c.Request = req
+			c.Next()
+		})).ServeHTTP(c.Writer, c.Request)
+		if !called {
+			c.Abort()
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package gin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// flagKey keys the feature flag set by the net/http middleware in the test
+type flagKey struct{}
+
+// TestMiddleware tests running operation middlewares in order before the handler
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	var calls []string
+	auth := gin.HandlerFunc(func(c *gin.Context) {
+		calls = append(calls, "auth")
+		if c.GetHeader("Authorization") == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		}
+	})
+	quota := func(c *gin.Context) {
+		calls = append(calls, "quota")
+	}
+	flags := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "flags")
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), flagKey{}, "beta")))
+		})
+	}
+
+	def := api.NewAPIDefinition("GET", "/reports", "List reports").
+		WithMiddleware(auth, flags, quota).
+		WithNativeHandler(func(c *gin.Context) {
+			calls = append(calls, "handler")
+			c.String(http.StatusOK, "%v", c.Request.Context().Value(flagKey{}))
+		})
+	if err := router.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		auth       string
+		wantStatus int
+		wantCalls  []string
+	}{
+		{name: "all middlewares pass", auth: "Bearer token", wantStatus: http.StatusOK, wantCalls: []string{"auth", "flags", "quota", "handler"}},
+		{name: "aborted by middleware", wantStatus: http.StatusUnauthorized, wantCalls: []string{"auth"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/reports", nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("Expected calls %v, got %v", tt.wantCalls, calls)
+			}
+			if tt.wantStatus == http.StatusOK && w.Body.String() != "beta" {
+				t.Errorf("Expected the handler to see the middleware's context, got %q", w.Body.String())
+			}
+		})
+	}
+
+	unsupported := api.NewAPIDefinition("GET", "/other", "Other").
+		WithMiddleware("not a middleware").
+		WithHandler(func(w http.ResponseWriter, r *http.Request) {})
+	if err := router.Register(unsupported); err == nil {
+		t.Error("Expected Register to reject an unsupported middleware")
+	}
+}
+
+// TestMiddlewareNext tests that c.Next and net/http next handlers run the operation handler
+func TestMiddlewareNext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	var calls []string
+	timing := gin.HandlerFunc(func(c *gin.Context) {
+		calls = append(calls, "before")
+		c.Next()
+		calls = append(calls, "after")
+	})
+	wrap := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "wrap before")
+			next.ServeHTTP(w, r)
+			calls = append(calls, "wrap after")
+		})
+	}
+	def := api.NewAPIDefinition("GET", "/reports", "List reports").
+		WithMiddleware(timing, wrap).
+		WithNativeHandler(func(c *gin.Context) {
+			calls = append(calls, "handler")
+			c.Status(http.StatusOK)
+		})
+	if err := router.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/api/reports", nil))
+	want := []string{"before", "wrap before", "handler", "wrap after", "after"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Expected calls %v, got %v", want, calls)
+	}
+}
@@ -0,0 +1,14 @@
+package gin
+
+import (
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// OperationIDStrategy derives the operationId of an operation that does not set one explicitly
+type OperationIDStrategy = router.OperationIDStrategy
+
+// SetOperationIDStrategy replaces the default tag_path operationId generation
+// Generated IDs colliding with another operation get a deterministic numeric suffix (_2, _3, ...)
+func (r *APIRouter) SetOperationIDStrategy(strategy OperationIDStrategy) {
+	r.core.SetOperationIDStrategy(strategy)
+}
@@ -0,0 +1,61 @@
+package gin
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestSetOperationIDStrategy tests custom operationId generation with collision suffixes
+func TestSetOperationIDStrategy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+	router.SetOperationIDStrategy(func(method, path string, def api.APIDefinition) string {
+		return strings.ToLower(method) + "Users"
+	})
+	noop := func(c *gin.Context) {}
+	router.Register(api.GET("/users", "List users").WithNativeHandler(noop))
+	router.Register(api.GET("/users/{id}", "Get user").WithNativeHandler(noop))
+	router.Register(api.GET("/v2/users", "List users").WithNativeHandler(noop))
+	router.Register(api.POST("/users", "Create user").WithOperationID("getUsers_2").WithNativeHandler(noop))
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	want := map[string]string{
+		"/users":      "getUsers",
+		"/users/{id}": "getUsers_3",
+		"/v2/users":   "getUsers_4",
+	}
+	for path, id := range want {
+		if got := doc.Paths[path].Get.OperationID; got != id {
+			t.Errorf("Expected operationId %s for %s, got %s", id, path, got)
+		}
+	}
+	if got := doc.Paths["/users"].Post.OperationID; got != "getUsers_2" {
+		t.Errorf("Expected explicit operationId to be kept, got %s", got)
+	}
+}
+
+// TestDefaultOperationIDStrategy tests the tag_path fallback and the suffixes of colliding IDs
+func TestDefaultOperationIDStrategy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+	router.Register(api.GET("/users", "List users").WithTags("Users").WithNativeHandler(func(c *gin.Context) {}))
+	router.Register(api.GET("/users/{id}", "Get user").WithTags("Users").WithNativeHandler(func(c *gin.Context) {}))
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if got := doc.Paths["/users"].Get.OperationID; got != "users_users" {
+		t.Errorf("Expected operationId users_users, got %s", got)
+	}
+	if got := doc.Paths["/users/{id}"].Get.OperationID; got != "users_users_2" {
+		t.Errorf("Expected operationId users_users_2, got %s", got)
+	}
+}
@@ -0,0 +1,7 @@
+package gin
+
+// EnablePatchValidation checks JSON Patch and JSON Merge Patch documents sent to definitions accepting them:
+// merge patches against the optional variant of the request schema, JSON Patch operations and their paths
+func (r *APIRouter) EnablePatchValidation() {
+	r.core.EnablePatchValidation()
+}
@@ -0,0 +1,74 @@
+package gin
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type patchProfile struct {
+	Name string `json:"name" binding:"required"`
+	Bio  string `json:"bio"`
+}
+
+// TestPatchValidation tests validating patch documents and keeping them readable by the handler
+func TestPatchValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.EnablePatchValidation()
+
+	apiDef := api.NewAPIDefinition("PATCH", "/profile", "Patch profile").
+		WithJSONMergePatch(patchProfile{}).
+		WithJSONPatch().
+		WithNativeHandler(func(c *gin.Context) {
+			body, _ := io.ReadAll(c.Request.Body)
+			c.String(http.StatusOK, string(body))
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{name: "merge patch without required fields", contentType: api.MediaTypeMergePatch, body: `{"bio":"hi"}`, wantStatus: http.StatusOK},
+		{name: "json patch", contentType: api.MediaTypeJSONPatch, body: `[{"op":"replace","path":"/bio","value":"hi"}]`, wantStatus: http.StatusOK},
+		{name: "invalid merge patch", contentType: api.MediaTypeMergePatch, body: `{"bio":false}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid json patch", contentType: api.MediaTypeJSONPatch, body: `[{"op":"replace","path":"/bio"}]`, wantStatus: http.StatusBadRequest},
+		{name: "unsupported media type", contentType: "application/json", body: `{"name":"alice"}`, wantStatus: http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("PATCH", "/api/profile", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && w.Body.String() != tt.body {
+				t.Errorf("Expected the handler to read %s, got %s", tt.body, w.Body.String())
+			}
+		})
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if content := doc.Paths["/profile"].Patch.RequestBody.Content; len(content) != 2 {
+		t.Errorf("Expected the patch media types to be documented, got %v", content)
+	}
+	if err := api.ValidateDoc(doc); err != nil {
+		t.Errorf("Expected a valid document: %v", err)
+	}
+}
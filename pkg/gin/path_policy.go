@@ -0,0 +1,21 @@
+package gin
+
+import (
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// SetPathPolicy normalizes the trailing slash and casing of the paths registered afterwards, both as gin routes
+// and as Paths keys; the engine redirects requests differing in case or trailing slash to the canonical route
+func (r *APIRouter) SetPathPolicy(policy router.PathPolicy) {
+	r.core.SetPathPolicy(policy)
+}
+
+// SetPathPolicy makes the engine redirect requests differing in case or trailing slash to the canonical route
+func (a *engineAdapter) SetPathPolicy(policy router.PathPolicy) {
+	if policy.TrailingSlash != router.KeepTrailingSlash {
+		a.engine.RedirectTrailingSlash = true
+	}
+	if policy.CaseInsensitive {
+		a.engine.RedirectFixedPath = true
+	}
+}
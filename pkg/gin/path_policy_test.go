@@ -0,0 +1,64 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// TestPathPolicy tests that gin routes and documented paths follow the policy
+func TestPathPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	apiRouter := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	apiRouter.SetPathPolicy(router.PathPolicy{TrailingSlash: router.StripTrailingSlash, CaseInsensitive: true})
+
+	apiDef := api.NewAPIDefinition("GET", "/Users/", "List users").
+		WithNativeHandler(func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+	if err := apiRouter.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	duplicate := api.NewAPIDefinition("GET", "/users", "List users again").WithNativeHandler(func(c *gin.Context) {})
+	if err := apiRouter.Register(duplicate); err == nil {
+		t.Error("Expected a path normalized to a registered route to be rejected")
+	}
+
+	tests := []struct {
+		name         string
+		target       string
+		wantStatus   int
+		wantLocation string
+	}{
+		{name: "canonical", target: "/api/users", wantStatus: http.StatusOK},
+		{name: "trailing slash", target: "/api/users/", wantStatus: http.StatusMovedPermanently, wantLocation: "/api/users"},
+		{name: "mixed case", target: "/api/USERS", wantStatus: http.StatusMovedPermanently, wantLocation: "/api/users"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest("GET", tt.target, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if location := w.Header().Get("Location"); location != tt.wantLocation {
+				t.Errorf("Expected redirect to %q, got %q", tt.wantLocation, location)
+			}
+		})
+	}
+
+	doc, err := apiRouter.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if _, ok := doc.Paths["/users"]; !ok || len(doc.Paths) != 1 {
+		t.Errorf("Expected the normalized path, got %v", doc.Paths)
+	}
+}
@@ -0,0 +1,146 @@
+package gin
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type orderPath struct {
+	OrderID int64 `path:"id" binding:"min=1"`
+}
+
+// TestPathModel tests converting path parameters into a path model
+func TestPathModel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	apiDef := api.NewAPIDefinition("GET", "/orders/{id}", "Get order").
+		WithPathModel(orderPath{}).
+		WithNativeHandler(func(c *gin.Context) {
+			c.String(http.StatusOK, "%d", PathModel(c).(*orderPath).OrderID)
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "bound", target: "/api/orders/42", wantStatus: http.StatusOK, wantBody: "42"},
+		{name: "invalid integer", target: "/api/orders/x", wantStatus: http.StatusBadRequest},
+		{name: "invalid value", target: "/api/orders/0", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest("GET", tt.target, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("Expected %q, got %q", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	operation := doc.Paths["/orders/{id}"].Get
+	if len(operation.Parameters) != 1 || operation.Parameters[0].Schema["type"] != "integer" {
+		t.Errorf("Unexpected path parameters %+v", operation.Parameters)
+	}
+	if _, ok := operation.Responses["400"]; !ok {
+		t.Error("Expected a documented 400 response")
+	}
+}
+
+// orderID is a 16-byte identifier decoded from text, like uuid.UUID
+type orderID [16]byte
+
+// UnmarshalText parses the hyphenated hex form
+func (id *orderID) UnmarshalText(text []byte) error {
+	decoded, err := hex.DecodeString(strings.ReplaceAll(string(text), "-", ""))
+	if err != nil || len(decoded) != len(id) {
+		return fmt.Errorf("invalid order ID")
+	}
+	copy(id[:], decoded)
+	return nil
+}
+
+// MarshalText returns the hyphenated hex form
+func (id orderID) MarshalText() ([]byte, error) {
+	s := hex.EncodeToString(id[:])
+	return []byte(s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]), nil
+}
+
+type updateOrder struct {
+	ID   orderID `path:"id" json:"-"`
+	Note string  `json:"note" binding:"required"`
+}
+
+// TestRequestPathFields tests filling path tagged fields of the request model
+func TestRequestPathFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	apiDef := api.NewAPIDefinition("PUT", "/orders/{id}", "Update order").
+		WithRequest(updateOrder{}).
+		WithNativeHandler(func(c *gin.Context) {
+			req := RequestBody(c).(*updateOrder)
+			text, _ := req.ID.MarshalText()
+			c.String(http.StatusOK, "%s %s", text, req.Note)
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	const id = "6f1c2b9e-8a7d-4c3b-9e2f-1a0b3c4d5e6f"
+	tests := []struct {
+		name       string
+		target     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "bound", target: "/api/orders/" + id, body: `{"note":"gift"}`, wantStatus: http.StatusOK, wantBody: id + " gift"},
+		{name: "invalid id", target: "/api/orders/42", body: `{"note":"gift"}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid body", target: "/api/orders/" + id, body: `{}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("Expected %q, got %q", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	operation := doc.Paths["/orders/{id}"].Put
+	if len(operation.Parameters) != 1 || operation.Parameters[0].Schema["type"] != "string" || operation.Parameters[0].Schema["format"] != "uuid" {
+		t.Errorf("Expected a uuid path parameter, got %+v", operation.Parameters)
+	}
+}
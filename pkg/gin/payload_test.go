@@ -0,0 +1,64 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type importBatch struct {
+	Items []map[string]interface{} `json:"items"`
+}
+
+// TestPayloadLimits tests rejecting hostile bodies before decoding
+func TestPayloadLimits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.SetPayloadLimits(api.PayloadLimits{MaxBodySize: 256, MaxDepth: 4, MaxArrayLength: 2})
+
+	apiDef := api.NewAPIDefinition("POST", "/imports", "Import").
+		WithRequest(importBatch{}).
+		WithNativeHandler(func(c *gin.Context) { c.Status(http.StatusOK) })
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "within limits", body: `{"items":[{"a":1},{"b":2}]}`, wantStatus: http.StatusOK},
+		{name: "too many elements", body: `{"items":[{},{},{}]}`, wantStatus: http.StatusBadRequest},
+		{name: "too deep", body: `{"items":[{"a":{"b":{}}}]}`, wantStatus: http.StatusBadRequest},
+		{name: "too large", body: `{"items":[{"a":"` + strings.Repeat("x", 300) + `"}]}`, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/imports", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	responses := doc.Paths["/imports"].Post.Responses
+	if _, ok := responses["413"]; !ok {
+		t.Error("Expected 413 response")
+	}
+	if !strings.Contains(responses["400"].Description, "nesting depth of 4") {
+		t.Errorf("Unexpected 400 response %q", responses["400"].Description)
+	}
+}
@@ -0,0 +1,34 @@
+package gin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// PlanResolver returns the plan of the tenant making a request
+type PlanResolver func(c *gin.Context) string
+
+// SetPlanResolver enables rejecting requests to operations outside the caller's plan with 403
+func (r *APIRouter) SetPlanResolver(resolver PlanResolver) {
+	r.core.SetPlanResolver(func(req *http.Request) string {
+		c := contextOf(req)
+		c.Request = req
+		return resolver(c)
+	})
+}
+
+// GenerateSwaggerForTenant returns the document filtered to the operations available on a plan
+// Unlike GenerateSwagger, the document is neither cached nor recorded in the spec history
+func (r *APIRouter) GenerateSwaggerForTenant(plan string) (*api.OpenAPIDoc, error) {
+	doc, err := r.buildDocument(nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := api.FilterPlan(doc, plan); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
@@ -0,0 +1,72 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestPlans tests per-tenant documents and runtime plan checks
+func TestPlans(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.SetPlanResolver(func(c *gin.Context) string {
+		return c.GetHeader("X-Plan")
+	})
+
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	defs := []*api.APIDefinition{
+		api.NewAPIDefinition("GET", "/status", "Status").WithHandler(ok),
+		api.NewAPIDefinition("GET", "/reports", "Reports").WithPlans("free", "pro").WithHandler(ok),
+		api.NewAPIDefinition("GET", "/exports", "Exports").WithPlans("pro").WithHandler(ok),
+	}
+	for _, def := range defs {
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	free, err := router.GenerateSwaggerForTenant("free")
+	if err != nil {
+		t.Fatalf("GenerateSwaggerForTenant failed: %v", err)
+	}
+	if _, ok := free.Paths["/exports"]; ok || len(free.Paths) != 2 {
+		t.Errorf("Expected the free document to leave out /exports, got %v", free.Paths)
+	}
+	full, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if len(full.Paths) != 3 {
+		t.Errorf("Expected the full document to keep every path, got %v", full.Paths)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		plan       string
+		wantStatus int
+	}{
+		{name: "unrestricted", path: "/api/status", wantStatus: http.StatusOK},
+		{name: "in plan", path: "/api/exports", plan: "pro", wantStatus: http.StatusOK},
+		{name: "outside plan", path: "/api/exports", plan: "free", wantStatus: http.StatusForbidden},
+		{name: "unknown plan", path: "/api/reports", wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.plan != "" {
+				req.Header.Set("X-Plan", tt.plan)
+			}
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
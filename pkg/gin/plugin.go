@@ -0,0 +1,71 @@
+package gin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// Plugin extends the router without dedicated options; it implements any of RegisterHook,
+// GenerateHook and RequestHook to take part in the corresponding lifecycle stage
+type Plugin = router.Plugin
+
+// RegisterHook is called for every definition before its route is registered
+// The definition may be adjusted; an error aborts the registration
+type RegisterHook = router.RegisterHook
+
+// GenerateHook is called with every generated document before it is cached and served
+// The document may be adjusted; an error aborts the generation
+type GenerateHook = router.GenerateHook
+
+// RequestHook is called for every request to a registered operation before validation
+// Aborting the gin context (e.g. with c.AbortWithStatusJSON) stops the request
+type RequestHook interface {
+	OnRequest(def *api.APIDefinition, c *gin.Context)
+}
+
+// Use adds plugins to the router; hooks run in the order plugins were added
+// Plugins must be added before the operations they should see are registered
+func (r *APIRouter) Use(plugins ...Plugin) {
+	for _, plugin := range plugins {
+		r.core.Use(enginePlugin{plugin})
+	}
+}
+
+// enginePlugin hands the gin context of requests to the RequestHook of a plugin
+type enginePlugin struct {
+	Plugin
+}
+
+// OnRegister implements router.RegisterHook
+func (p enginePlugin) OnRegister(def *api.APIDefinition) error {
+	if hook, ok := p.Plugin.(RegisterHook); ok {
+		return hook.OnRegister(def)
+	}
+	return nil
+}
+
+// OnGenerate implements router.GenerateHook
+func (p enginePlugin) OnGenerate(doc *api.OpenAPIDoc) error {
+	if hook, ok := p.Plugin.(GenerateHook); ok {
+		return hook.OnGenerate(doc)
+	}
+	return nil
+}
+
+// OnRequest implements router.RequestHook, stopping the request when the hook aborts the gin context
+func (p enginePlugin) OnRequest(def *api.APIDefinition, w http.ResponseWriter, req *http.Request) bool {
+	hook, ok := p.Plugin.(RequestHook)
+	if !ok {
+		return true
+	}
+	c := contextOf(req)
+	c.Request = req
+	withEngineWriter(c, w, func() {
+		hook.OnRequest(def, c)
+	})
+	return !c.IsAborted()
+}
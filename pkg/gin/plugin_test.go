@@ -0,0 +1,104 @@
+package gin
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// auditPlugin records the lifecycle events it sees and blocks requests without a tenant header
+type auditPlugin struct {
+	events []string
+}
+
+func (p *auditPlugin) Name() string { return "audit" }
+
+func (p *auditPlugin) OnRegister(def *api.APIDefinition) error {
+	if def.Path == "/forbidden" {
+		return errors.New("path not allowed")
+	}
+	p.events = append(p.events, "register "+def.Method+" "+def.Path)
+	def.WithTags("audited")
+	return nil
+}
+
+func (p *auditPlugin) OnGenerate(doc *api.OpenAPIDoc) error {
+	p.events = append(p.events, "generate")
+	doc.Info.Description = "Audited API"
+	return nil
+}
+
+func (p *auditPlugin) OnRequest(def *api.APIDefinition, c *gin.Context) {
+	p.events = append(p.events, "request "+def.Path)
+	if c.GetHeader("X-Tenant") == "" {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "missing tenant"})
+	}
+}
+
+// namedPlugin implements no hooks
+type namedPlugin struct{}
+
+func (namedPlugin) Name() string { return "named" }
+
+// TestPlugins tests the registration, generation and request hooks
+func TestPlugins(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	plugin := &auditPlugin{}
+	router.Use(namedPlugin{}, plugin)
+
+	users := api.NewAPIDefinition("get", "/users", "List users").
+		WithHandler(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	if err := router.Register(users); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	forbidden := api.NewAPIDefinition("GET", "/forbidden", "Forbidden").
+		WithHandler(func(w http.ResponseWriter, r *http.Request) {})
+	if err := router.Register(forbidden); err == nil {
+		t.Error("Expected plugin to reject registration")
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if doc.Info.Description != "Audited API" {
+		t.Errorf("Expected generate hook to adjust the document, got %q", doc.Info.Description)
+	}
+	if tags := doc.Paths["/users"].Get.Tags; !reflect.DeepEqual(tags, []string{"audited"}) {
+		t.Errorf("Expected register hook to tag the operation, got %v", tags)
+	}
+
+	tests := []struct {
+		name       string
+		tenant     string
+		wantStatus int
+	}{
+		{name: "allowed", tenant: "acme", wantStatus: http.StatusOK},
+		{name: "aborted by hook", wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/users", nil)
+			if tt.tenant != "" {
+				req.Header.Set("X-Tenant", tt.tenant)
+			}
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+
+	want := []string{"register GET /users", "generate", "request /users", "request /users"}
+	if !reflect.DeepEqual(plugin.events, want) {
+		t.Errorf("Expected events %v, got %v", want, plugin.events)
+	}
+}
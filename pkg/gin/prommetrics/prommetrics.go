@@ -0,0 +1,76 @@
+// Package prommetrics records the per-operation measurements of the gin router in Prometheus collectors
+package prommetrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	ginSwagger "github.com/smartcat999/go-swagger/pkg/gin"
+)
+
+// labelNames are the labels of every collector, in MetricLabels order
+var labelNames = []string{"operation_id", "tag", "method", "route", "status"}
+
+// Metrics implements ginSwagger.Metrics with a request counter, a latency histogram and a validation
+// failure counter
+type Metrics struct {
+	requests           *prometheus.CounterVec
+	duration           *prometheus.HistogramVec
+	validationFailures *prometheus.CounterVec
+}
+
+var _ ginSwagger.Metrics = (*Metrics)(nil)
+
+// New registers the collectors on reg (prometheus.DefaultRegisterer when nil), prefixed with namespace
+// (e.g. "api"); buckets default to prometheus.DefBuckets
+func New(reg prometheus.Registerer, namespace string, buckets ...float64) (*Metrics, error) {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+	if len(buckets) == 0 {
+		buckets = prometheus.DefBuckets
+	}
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Requests handled per operation and status",
+		}, labelNames),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Request latency per operation and status",
+			Buckets:   buckets,
+		}, labelNames),
+		validationFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_validation_failures_total",
+			Help:      "Requests rejected by parameter or body validation per operation",
+		}, labelNames),
+	}
+	for _, collector := range []prometheus.Collector{m.requests, m.duration, m.validationFailures} {
+		if err := reg.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// ObserveRequest implements ginSwagger.Metrics
+func (m *Metrics) ObserveRequest(labels ginSwagger.MetricLabels, duration time.Duration) {
+	values := labelValues(labels)
+	m.requests.WithLabelValues(values...).Inc()
+	m.duration.WithLabelValues(values...).Observe(duration.Seconds())
+}
+
+// IncValidationFailure implements ginSwagger.Metrics
+func (m *Metrics) IncValidationFailure(labels ginSwagger.MetricLabels) {
+	m.validationFailures.WithLabelValues(labelValues(labels)...).Inc()
+}
+
+// labelValues returns the label values of a measurement in labelNames order
+func labelValues(labels ginSwagger.MetricLabels) []string {
+	return []string{labels.OperationID, labels.Tag, labels.Method, labels.Route, strconv.Itoa(labels.Status)}
+}
@@ -0,0 +1,59 @@
+package prommetrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+	ginSwagger "github.com/smartcat999/go-swagger/pkg/gin"
+)
+
+// TestMetrics tests recording router measurements in Prometheus collectors
+func TestMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metrics, err := New(reg, "api")
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := ginSwagger.NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.SetMetrics(metrics)
+	apiDef := api.NewAPIDefinition("GET", "/users", "List users").
+		WithOperationID("listUsers").
+		WithTags("users").
+		WithQueryParam("limit", "Limit", true).
+		WithHandler(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/users?limit=5", nil))
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/users?limit=5", nil))
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/users", nil))
+
+	ok := []string{"listUsers", "users", "GET", "/api/users", "200"}
+	rejected := []string{"listUsers", "users", "GET", "/api/users", "400"}
+	if got := testutil.ToFloat64(metrics.requests.WithLabelValues(ok...)); got != 2 {
+		t.Errorf("Expected 2 successful requests, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.requests.WithLabelValues(rejected...)); got != 1 {
+		t.Errorf("Expected 1 rejected request, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.validationFailures.WithLabelValues(rejected...)); got != 1 {
+		t.Errorf("Expected 1 validation failure, got %v", got)
+	}
+	if got := testutil.CollectAndCount(metrics.duration); got != 2 {
+		t.Errorf("Expected 2 latency series, got %d", got)
+	}
+
+	if _, err := New(reg, "api"); err == nil {
+		t.Error("Expected registering the collectors twice to fail")
+	}
+}
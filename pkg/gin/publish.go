@@ -0,0 +1,53 @@
+package gin
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/publish"
+)
+
+// AddPublishers publishes the document every time GenerateSwagger produces a new one, e.g. so each deploy
+// uploads its contract; publishing runs once the document is served, and failures are reported to the
+// warning logger without failing generation
+func (r *APIRouter) AddPublishers(publishers ...publish.Publisher) {
+	r.publishers = append(r.publishers, publishers...)
+}
+
+// SetPublishTimeout bounds each round of publishing, publish.DefaultTimeout by default
+func (r *APIRouter) SetPublishTimeout(timeout time.Duration) {
+	r.publishTimeout = timeout
+}
+
+// publishSpec hands the generated document and its signature to the publishers, skipping documents
+// whose digest was already published
+func (r *APIRouter) publishSpec(data, signature []byte) {
+	if len(r.publishers) == 0 {
+		return
+	}
+	digest := specDigest(data)
+	r.publishMu.Lock()
+	defer r.publishMu.Unlock()
+	if digest == r.publishedDigest {
+		return
+	}
+
+	timeout := r.publishTimeout
+	if timeout <= 0 {
+		timeout = publish.DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	spec := publish.Spec{
+		Title:     r.core.Info().Title,
+		Version:   r.core.Info().Version,
+		Digest:    digest,
+		Signature: signature,
+		Document:  data,
+	}
+	if err := publish.PublishAll(ctx, spec, r.publishers...); err != nil {
+		r.core.Warnf("go-swagger: failed to publish OpenAPI document: %v", err)
+		return
+	}
+	r.publishedDigest = digest
+}
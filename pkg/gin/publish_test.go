@@ -0,0 +1,113 @@
+package gin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+	"github.com/smartcat999/go-swagger/pkg/publish"
+)
+
+// TestAddPublishers tests that generated documents are handed to the publishers
+func TestAddPublishers(t *testing.T) {
+	tests := []struct {
+		name     string
+		fail     bool
+		wantWarn bool
+	}{
+		{"published", false, false},
+		{"publisher fails", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+			if err := router.Register(api.NewAPIDefinition("GET", "/users", "List users").WithNativeHandler(func(c *gin.Context) {})); err != nil {
+				t.Fatalf("Register failed: %v", err)
+			}
+			var warnings []string
+			router.SetWarningLogger(func(format string, args ...interface{}) {
+				warnings = append(warnings, fmt.Sprintf(format, args...))
+			})
+
+			var published []publish.Spec
+			router.AddPublishers(publish.PublisherFunc(func(ctx context.Context, spec publish.Spec) error {
+				if _, ok := ctx.Deadline(); !ok {
+					t.Error("Expected the publish context to have a deadline")
+				}
+				router.docMu.Lock()
+				served := router.swaggerDoc
+				router.docMu.Unlock()
+				if string(served) != string(spec.Document) {
+					t.Error("Expected the document to be served before being published")
+				}
+				published = append(published, spec)
+				if tt.fail {
+					return errors.New("registry unavailable")
+				}
+				return nil
+			}))
+
+			if _, err := router.GenerateSwagger(); err != nil {
+				t.Fatalf("GenerateSwagger failed: %v", err)
+			}
+			if tt.wantWarn != (len(warnings) == 1 && strings.Contains(warnings[0], "registry unavailable")) {
+				t.Errorf("Expected publisher failure warning %v, got warnings %v", tt.wantWarn, warnings)
+			}
+			if len(published) != 1 {
+				t.Fatalf("Expected 1 published document, got %d", len(published))
+			}
+			spec := published[0]
+			if spec.Title != "Test API" || spec.Version != "1.0.0" || spec.Digest != specDigest(spec.Document) {
+				t.Errorf("Expected the spec of Test API 1.0.0 with its digest, got %+v", spec)
+			}
+			if err := api.ValidateDocJSON(spec.Document); err != nil {
+				t.Errorf("Expected a valid published document, got %v", err)
+			}
+
+			// Unchanged documents are only published again after a failure
+			if _, err := router.GenerateSwagger(); err != nil {
+				t.Fatalf("GenerateSwagger failed: %v", err)
+			}
+			want := 1
+			if tt.fail {
+				want = 2
+			}
+			if len(published) != want {
+				t.Errorf("Expected %d published documents after regenerating, got %d", want, len(published))
+			}
+		})
+	}
+}
+
+// TestSetPublishTimeout tests that slow publishers are cancelled
+func TestSetPublishTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+	if err := router.Register(api.NewAPIDefinition("GET", "/users", "List users").WithNativeHandler(func(c *gin.Context) {})); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	var warnings []string
+	router.SetWarningLogger(func(format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	})
+	router.SetPublishTimeout(10 * time.Millisecond)
+	router.AddPublishers(publish.PublisherFunc(func(ctx context.Context, _ publish.Spec) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "deadline exceeded") {
+		t.Errorf("Expected a timeout warning, got %v", warnings)
+	}
+}
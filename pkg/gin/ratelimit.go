@@ -0,0 +1,51 @@
+package gin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// RateLimitDecision is the outcome of a Limiter check
+type RateLimitDecision = router.RateLimitDecision
+
+// Limiter decides whether a request may proceed under an operation's rate limit policy
+// The key identifies the bucket: the policy scope, or the operation's method and path, followed by the client
+type Limiter = router.Limiter
+
+// MemoryLimiter is an in-process fixed window Limiter, suitable for single instance deployments and tests
+type MemoryLimiter = router.MemoryLimiter
+
+// RateLimitKeyFunc identifies the client a request is counted against
+type RateLimitKeyFunc func(c *gin.Context) string
+
+// NewMemoryLimiter creates a new in-memory fixed window limiter
+func NewMemoryLimiter() *MemoryLimiter {
+	return router.NewMemoryLimiter()
+}
+
+// SetLimiter sets the limiter consulted for operations declaring WithRateLimit
+// Limits apply per client, as identified by the rate limit key function (ClientKey unless set)
+func (r *APIRouter) SetLimiter(limiter Limiter) {
+	r.core.SetLimiter(limiter)
+}
+
+// SetRateLimitKey sets how requests are attributed to clients for rate limiting, e.g. by API key or tenant
+func (r *APIRouter) SetRateLimitKey(key RateLimitKeyFunc) {
+	r.core.SetRateLimitKey(func(req *http.Request) string {
+		c := contextOf(req)
+		c.Request = req
+		return key(c)
+	})
+}
+
+// ClientKey identifies the client of a request by its authenticated principal (see SetPrincipal), or else by
+// c.ClientIP(), which only honors forwarded headers from the engine's trusted proxies
+func ClientKey(c *gin.Context) string {
+	if key, ok := router.PrincipalKey(c.Request); ok {
+		return key
+	}
+	return "ip:" + c.ClientIP()
+}
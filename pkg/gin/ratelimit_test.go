@@ -0,0 +1,104 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestRateLimit tests limiter enforcement and documentation
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.SetLimiter(NewMemoryLimiter())
+
+	apiDef := api.NewAPIDefinition("GET", "/search", "Search").
+		WithRateLimit(api.RateLimitPolicy{Requests: 2, Window: time.Minute}).
+		WithHandler(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	wantStatus := []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}
+	for i, want := range wantStatus {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest("GET", "/api/search", nil))
+		if w.Code != want {
+			t.Errorf("Request %d: expected status %d, got %d", i, want, w.Code)
+		}
+		if w.Header().Get("X-RateLimit-Limit") != "2" {
+			t.Errorf("Request %d: expected X-RateLimit-Limit 2, got %q", i, w.Header().Get("X-RateLimit-Limit"))
+		}
+		if want == http.StatusTooManyRequests && w.Header().Get("Retry-After") == "" {
+			t.Error("Expected Retry-After header on 429")
+		}
+	}
+
+	// Other clients have buckets of their own
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/search", nil)
+	req.RemoteAddr = "198.51.100.7:4321"
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected another client to be allowed, got status %d", w.Code)
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	op := doc.Paths["/search"].Get
+	if _, ok := op.Responses["429"].Headers["Retry-After"]; !ok {
+		t.Error("Expected 429 response with Retry-After header")
+	}
+	if _, ok := op.Responses["200"].Headers["X-RateLimit-Remaining"]; !ok {
+		t.Error("Expected X-RateLimit-Remaining header on 200 response")
+	}
+}
+
+// TestRateLimitKey tests attributing requests to clients with a custom key function
+func TestRateLimitKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.SetLimiter(NewMemoryLimiter())
+	router.SetRateLimitKey(func(c *gin.Context) string {
+		return c.GetHeader("X-API-Key")
+	})
+	apiDef := api.NewAPIDefinition("GET", "/search", "Search").
+		WithRateLimit(api.RateLimitPolicy{Requests: 1, Window: time.Minute}).
+		WithNativeHandler(func(c *gin.Context) {})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		apiKey     string
+		remoteAddr string
+		wantStatus int
+	}{
+		{name: "first request", apiKey: "a", remoteAddr: "192.0.2.1:1234", wantStatus: http.StatusOK},
+		{name: "same key from another address", apiKey: "a", remoteAddr: "198.51.100.7:4321", wantStatus: http.StatusTooManyRequests},
+		{name: "other key", apiKey: "b", remoteAddr: "192.0.2.1:1234", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/search", nil)
+			req.Header.Set("X-API-Key", tt.apiKey)
+			req.RemoteAddr = tt.remoteAddr
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
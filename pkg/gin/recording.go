@@ -0,0 +1,40 @@
+package gin
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// DefaultRedactedFields lists JSON properties masked in recorded examples
+var DefaultRedactedFields = router.DefaultRedactedFields
+
+// RecordingOptions configures sampling of live traffic into spec examples
+type RecordingOptions = router.RecordingOptions
+
+// EnableExampleRecording samples live JSON traffic and attaches it to operations as named examples
+// Samples appear in the spec the next time GenerateSwagger runs (see StartExampleRegeneration)
+func (r *APIRouter) EnableExampleRecording(opts RecordingOptions) {
+	if opts.RedactFields == nil {
+		opts.RedactFields = DefaultRedactedFields
+	}
+	r.core.EnableExampleRecording(opts)
+}
+
+// StartExampleRegeneration regenerates the swagger document periodically until ctx is done,
+// so recorded examples reach the served document
+func (r *APIRouter) StartExampleRegeneration(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_, _ = r.GenerateSwagger()
+			}
+		}
+	}()
+}
@@ -0,0 +1,65 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	OTP      string `json:"otp" sensitive:"true"`
+}
+
+// TestExampleRecording tests sampling of traffic into redacted spec examples
+func TestExampleRecording(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.EnableExampleRecording(RecordingOptions{SampleRate: 1, MaxPerOperation: 2})
+
+	apiDef := api.NewAPIDefinition("POST", "/login", "Log in").
+		WithRequest(loginRequest{}).
+		WithResponse(UserResponse{}).
+		WithNativeHandler(func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"id": 1, "username": "john", "token": "abc"})
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest("POST", "/api/login", strings.NewReader(`{"username":"john","password":"hunter2","otp":"123456"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	op := doc.Paths["/login"].Post
+
+	requests := op.RequestBody.Content["application/json"].Examples
+	if len(requests) != 2 {
+		t.Fatalf("Expected 2 request examples, got %d", len(requests))
+	}
+	value := requests["recorded-1"].Value.(map[string]interface{})
+	if value["password"] != "***" || value["otp"] != "***" || value["username"] != "john" {
+		t.Errorf("Expected password and otp to be redacted, got %v", value)
+	}
+
+	responses := op.Responses["200"].Content["application/json"].Examples
+	if responses["recorded-2"].Value.(map[string]interface{})["token"] != "***" {
+		t.Errorf("Expected token to be redacted, got %v", responses["recorded-2"].Value)
+	}
+}
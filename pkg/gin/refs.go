@@ -0,0 +1,23 @@
+package gin
+
+import (
+	"fmt"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// SetRefResolver checks the external $refs of generated documents and, in api.RefBundle mode, inlines them
+func (r *APIRouter) SetRefResolver(resolver *api.RefResolver) {
+	r.refResolver = resolver
+}
+
+// resolveRefs applies the ref resolver, if any, to a generated document
+func (r *APIRouter) resolveRefs(doc *api.OpenAPIDoc) error {
+	if r.refResolver == nil {
+		return nil
+	}
+	if err := r.refResolver.Resolve(doc); err != nil {
+		return fmt.Errorf("failed to resolve external references: %w", err)
+	}
+	return nil
+}
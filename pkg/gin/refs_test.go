@@ -0,0 +1,74 @@
+package gin
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// sharedOrder references a company-wide schema
+type sharedOrder struct {
+	ID      string      `json:"id"`
+	Address interface{} `json:"address" ref:"common.json#/Address"`
+}
+
+// TestRefResolver tests keeping and bundling external references in generated documents
+func TestRefResolver(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dir := t.TempDir()
+	common := `{"Address": {"type": "object", "properties": {"street": {"type": "string"}}}}`
+	if err := os.WriteFile(filepath.Join(dir, "common.json"), []byte(common), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		mode api.RefMode
+		want map[string]interface{}
+	}{
+		{name: "keep", mode: api.RefKeep, want: map[string]interface{}{"$ref": "common.json#/Address"}},
+		{name: "bundle", mode: api.RefBundle, want: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"street": map[string]interface{}{"type": "string"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+			router.SetRefResolver(api.NewRefResolver(tt.mode, dir))
+			def := api.NewAPIDefinition("GET", "/orders/{id}", "Get order").
+				WithResponse(sharedOrder{}).
+				WithHandler(func(w http.ResponseWriter, r *http.Request) {})
+			if err := router.Register(def); err != nil {
+				t.Fatalf("Register failed: %v", err)
+			}
+
+			doc, err := router.GenerateSwagger()
+			if err != nil {
+				t.Fatalf("GenerateSwagger failed: %v", err)
+			}
+			schema := doc.Paths["/orders/{id}"].Get.Responses["200"].Content["application/json"].Schema
+			address := schema["properties"].(map[string]interface{})["address"]
+			if !reflect.DeepEqual(address, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, address)
+			}
+		})
+	}
+
+	router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+	router.SetRefResolver(api.NewRefResolver(api.RefKeep, t.TempDir()))
+	def := api.NewAPIDefinition("GET", "/orders", "List orders").
+		WithResponse(sharedOrder{}).
+		WithHandler(func(w http.ResponseWriter, r *http.Request) {})
+	if err := router.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, err := router.GenerateSwagger(); err == nil {
+		t.Error("Expected generation to fail on an unresolvable reference")
+	}
+}
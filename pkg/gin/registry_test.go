@@ -0,0 +1,45 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestRegisterRegistry tests registering definitions collected from several packages
+func TestRegisterRegistry(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	handler := func(c *gin.Context) { c.Status(http.StatusOK) }
+	registry := api.NewRegistry()
+	registry.AddGroup("users", api.NewAPIDefinition("GET", "/users", "List users").WithNativeHandler(handler))
+	registry.AddGroup("orders", api.NewAPIDefinition("GET", "/orders", "List orders").WithNativeHandler(handler))
+	if err := router.RegisterRegistry(registry); err != nil {
+		t.Fatalf("RegisterRegistry failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/api/orders", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if tags := doc.Paths["/users"].Get.Tags; len(tags) != 1 || tags[0] != "users" {
+		t.Errorf("Expected the users tag, got %v", tags)
+	}
+
+	invalid := api.NewRegistry()
+	invalid.Add(api.NewAPIDefinition("GET", "/broken", "Broken"))
+	if err := router.RegisterRegistry(invalid); err == nil || !strings.Contains(err.Error(), "GET /broken") {
+		t.Errorf("Expected an error naming the failing definition, got %v", err)
+	}
+}
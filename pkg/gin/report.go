@@ -0,0 +1,196 @@
+package gin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// OperationError is a schema generation failure of an operation documented with a placeholder
+type OperationError struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Error  string `json:"error"`
+}
+
+// Warning kinds of GenerationReport
+const (
+	WarningMissingDescription = "missing-description" // Operation or parameter without a description
+	WarningUntypedParameter   = "untyped-parameter"   // Parameter declared without a schema
+	WarningInlineSchema       = "inline-schema"       // Object schema defined in place instead of referenced from components
+	WarningUntypedResponse    = "untyped-response"    // Success response without a typed schema
+	WarningCoverage           = "coverage"            // Coverage policy requirement missed at the warn level
+	WarningBreakingChange     = "breaking-change"     // Operation of the baseline document removed or narrowed
+	WarningUndocumentedRoute  = "undocumented-route"  // Engine route imported as a skeleton definition
+)
+
+// GenerationWarning is a documentation gap of an operation
+type GenerationWarning struct {
+	Kind    string `json:"kind"`
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// GenerationReport describes the last document generated by GenerateSwagger
+type GenerationReport struct {
+	GeneratedAt time.Time             `json:"generatedAt"`
+	Duration    time.Duration         `json:"duration"`   // Time spent generating, in nanoseconds when encoded
+	Operations  int                   `json:"operations"` // Documented operations
+	Schemas     int                   `json:"schemas"`    // Request, response and component schemas
+	Parameters  int                   `json:"parameters"` // Operation parameters
+	Coverage    DocumentationCoverage `json:"coverage"`
+	Warnings    []GenerationWarning   `json:"warnings,omitempty"`
+	Errors      []OperationError      `json:"errors,omitempty"` // Failures documented with placeholders by lenient generation
+}
+
+// SetLenientGeneration documents schemas that fail to generate with x-generation-error placeholders
+// instead of failing the whole document; the failures are listed by GenerateReport
+func (r *APIRouter) SetLenientGeneration(lenient bool) {
+	r.lenient = lenient
+}
+
+// GenerateReport returns the statistics, warnings and errors of the last GenerateSwagger, e.g. for coverage dashboards
+func (r *APIRouter) GenerateReport() GenerationReport {
+	r.docMu.RLock()
+	defer r.docMu.RUnlock()
+	return r.report
+}
+
+// buildOperation documents a definition, leniently when enabled, adding its failures and untyped parameters
+// to report when it is not nil
+func (r *APIRouter) buildOperation(def *api.APIDefinition, report *GenerationReport) (*api.Operation, error) {
+	if report != nil {
+		for _, param := range def.Params {
+			if param.Schema == nil && len(param.Content) == 0 && param.Ref == "" {
+				report.Warnings = append(report.Warnings, GenerationWarning{
+					Kind:    WarningUntypedParameter,
+					Method:  def.Method,
+					Path:    def.Path,
+					Message: fmt.Sprintf("%s parameter %s has no schema and is documented as a string", param.In, param.Name),
+				})
+			}
+		}
+	}
+	if !r.lenient {
+		return router.BuildOperation(def)
+	}
+	operation, errs := router.BuildOperationLenient(def)
+	if report != nil {
+		for _, err := range errs {
+			report.Errors = append(report.Errors, OperationError{Method: def.Method, Path: def.Path, Error: err.Error()})
+		}
+	}
+	return operation, nil
+}
+
+// inspect counts the operations, schemas and parameters of doc and warns about its missing descriptions and inline schemas
+func (report *GenerationReport) inspect(doc *api.OpenAPIDoc) {
+	if doc.Components != nil {
+		report.Schemas += len(doc.Components.Schemas)
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := doc.Paths[path]
+		for _, method := range api.SupportedMethods {
+			operation := item.Operation(method)
+			if operation == nil {
+				continue
+			}
+			warn := func(kind, format string, args ...interface{}) {
+				report.Warnings = append(report.Warnings, GenerationWarning{Kind: kind, Method: method, Path: path, Message: fmt.Sprintf(format, args...)})
+			}
+
+			report.Operations++
+			if undocumented, _ := operation.Extensions["x-undocumented"].(bool); undocumented {
+				warn(WarningUndocumentedRoute, "route was registered on the engine without a definition")
+			}
+			if operation.Description == "" {
+				warn(WarningMissingDescription, "operation has no description")
+			} else {
+				report.Coverage.DescribedOperations++
+			}
+
+			for _, param := range operation.Parameters {
+				report.Parameters++
+				if param.Ref != "" || param.Description != "" {
+					report.Coverage.DescribedParameters++
+					continue
+				}
+				warn(WarningMissingDescription, "%s parameter %s has no description", param.In, param.Name)
+			}
+
+			if operation.RequestBody != nil {
+				for _, content := range operation.RequestBody.Content {
+					report.Schemas++
+					if inlineSchema(content.Schema) {
+						warn(WarningInlineSchema, "request body schema is defined inline")
+					}
+				}
+			}
+
+			statuses := make([]string, 0, len(operation.Responses))
+			for status := range operation.Responses {
+				statuses = append(statuses, status)
+			}
+			sort.Strings(statuses)
+			for _, status := range statuses {
+				response := operation.Responses[status]
+				if successStatus(status) {
+					report.Coverage.SuccessResponses++
+					if typedResponse(response) {
+						report.Coverage.TypedSuccessResponses++
+					} else {
+						warn(WarningUntypedResponse, "%s response has no typed schema", status)
+					}
+				}
+				for _, content := range response.Content {
+					report.Schemas++
+					if inlineSchema(content.Schema) {
+						warn(WarningInlineSchema, "%s response schema is defined inline", status)
+					}
+				}
+			}
+		}
+	}
+}
+
+// inlineSchema reports whether a schema declares object properties in place rather than referencing a component
+func inlineSchema(schema map[string]interface{}) bool {
+	_, hasProperties := schema["properties"]
+	return hasProperties && schema["$ref"] == nil
+}
+
+// successStatus reports whether a response status is a 2xx one expected to carry a body, i.e. not 204
+func successStatus(status string) bool {
+	return strings.HasPrefix(status, "2") && status != "204"
+}
+
+// typedResponse reports whether every content of a response has a schema with a type, reference or composition
+func typedResponse(response api.Response) bool {
+	if len(response.Content) == 0 {
+		return false
+	}
+	for _, content := range response.Content {
+		typed := false
+		for _, key := range []string{"type", "$ref", "allOf", "oneOf", "anyOf"} {
+			if content.Schema[key] != nil {
+				typed = true
+			}
+		}
+		if !typed {
+			return false
+		}
+	}
+	return true
+}
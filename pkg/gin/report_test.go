@@ -0,0 +1,130 @@
+package gin
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// unsupportedModel declares a schema that cannot be generated
+type unsupportedModel struct{}
+
+// OpenAPISchema fails the schema generation
+func (unsupportedModel) OpenAPISchema() map[string]interface{} {
+	panic("unsupported model")
+}
+
+// brokenResponse is a response model whose schema fails to generate
+type brokenResponse struct {
+	Value unsupportedModel `json:"value"`
+}
+
+// TestLenientGeneration tests documenting placeholders for schemas that fail to generate
+func TestLenientGeneration(t *testing.T) {
+	tests := []struct {
+		name       string
+		lenient    bool
+		wantErr    bool
+		wantErrors int
+	}{
+		{"strict", false, true, 0},
+		{"lenient", true, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+			router.SetLenientGeneration(tt.lenient)
+			handler := func(c *gin.Context) {}
+			if err := router.Register(api.NewAPIDefinition("GET", "/users", "List users").WithResponse(UserResponse{}).WithNativeHandler(handler)); err != nil {
+				t.Fatalf("Register failed: %v", err)
+			}
+			if err := router.Register(api.NewAPIDefinition("GET", "/broken", "Broken").WithResponse(brokenResponse{}).WithNativeHandler(handler)); err != nil {
+				t.Fatalf("Register failed: %v", err)
+			}
+
+			doc, err := router.GenerateSwagger()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected generation error %v, got %v", tt.wantErr, err)
+			}
+			report := router.GenerateReport()
+			if len(report.Errors) != tt.wantErrors {
+				t.Fatalf("Expected %d report errors, got %v", tt.wantErrors, report.Errors)
+			}
+			if !tt.lenient {
+				return
+			}
+
+			if got := report.Errors[0]; got.Method != "GET" || got.Path != "/broken" || !strings.Contains(got.Error, "unsupported model") {
+				t.Errorf("Expected an unsupported model error for GET /broken, got %+v", got)
+			}
+			schema := doc.Paths["/broken"].Get.Responses["200"].Content["application/json"].Schema
+			if _, ok := schema["x-generation-error"]; !ok {
+				t.Errorf("Expected a placeholder schema, got %v", schema)
+			}
+			if schema := doc.Paths["/users"].Get.Responses["200"].Content["application/json"].Schema; schema["x-generation-error"] != nil {
+				t.Errorf("Expected the generated users schema, got %v", schema)
+			}
+			data, err := json.Marshal(doc)
+			if err != nil {
+				t.Fatalf("json.Marshal failed: %v", err)
+			}
+			if err := api.ValidateDocJSON(data); err != nil {
+				t.Errorf("Expected a valid document, got %v", err)
+			}
+		})
+	}
+}
+
+// TestGenerateReport tests the statistics and warnings of the generated document
+func TestGenerateReport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+	handler := func(c *gin.Context) {}
+	definitions := []*api.APIDefinition{
+		api.NewAPIDefinition("GET", "/users/{id}", "Get user").
+			WithDescription("Returns a user by ID").
+			WithParamSchema("id", "path", "User ID", true, map[string]interface{}{"type": "integer"}).
+			WithResponse(UserResponse{}),
+		api.NewAPIDefinition("GET", "/users", "List users").
+			WithParams([]api.Parameter{{Name: "q", In: "query"}}),
+	}
+	for _, def := range definitions {
+		if err := router.Register(def.WithNativeHandler(handler)); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	if got := router.GenerateReport(); got.Operations != 0 || !got.GeneratedAt.IsZero() {
+		t.Errorf("Expected an empty report before generation, got %+v", got)
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	report := router.GenerateReport()
+	if report.Operations != 2 || report.Parameters != 2 || report.Schemas != 1 {
+		t.Errorf("Expected 2 operations, 2 parameters and 1 schema, got %d, %d and %d", report.Operations, report.Parameters, report.Schemas)
+	}
+	if report.GeneratedAt.IsZero() || report.Duration <= 0 {
+		t.Errorf("Expected a generation time and duration, got %v at %v", report.Duration, report.GeneratedAt)
+	}
+
+	kinds := make(map[string]int)
+	for _, warning := range report.Warnings {
+		kinds[warning.Kind+" "+warning.Method+" "+warning.Path]++
+	}
+	want := map[string]int{
+		WarningMissingDescription + " GET /users": 2, // Operation and q parameter
+		WarningUntypedParameter + " GET /users":   1,
+		WarningInlineSchema + " GET /users/{id}":  1,
+	}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Errorf("Expected warnings %v, got %+v", want, report.Warnings)
+	}
+}
@@ -0,0 +1,22 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// RequestIDHeader is the header carrying the correlation ID of a request
+const RequestIDHeader = router.RequestIDHeader
+
+// EnableRequestID makes every operation accept and echo an X-Request-ID header
+// Requests without one get an ID from generate (random hex when nil); the ID is echoed in the response,
+// attached to spans and log entries, and documented as a parameter and response header of every operation
+func (r *APIRouter) EnableRequestID(generate func() string) {
+	r.core.EnableRequestID(generate)
+}
+
+// RequestID returns the correlation ID of the request, or "" when request IDs are not enabled
+func RequestID(c *gin.Context) string {
+	return router.RequestID(c.Request)
+}
@@ -0,0 +1,75 @@
+package gin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestRequestID tests propagating, generating and documenting X-Request-ID
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.EnableRequestID(func() string { return "generated" })
+	tracer := &fakeTracer{}
+	router.SetTracer(tracer)
+	var logged []RequestLogEntry
+	router.SetRequestLogger(RequestLoggerFunc(func(_ context.Context, entry RequestLogEntry) {
+		logged = append(logged, entry)
+	}))
+
+	apiDef := api.NewAPIDefinition("GET", "/users", "List users").
+		WithResponse([]string{}).
+		WithNativeHandler(func(c *gin.Context) { c.String(http.StatusOK, RequestID(c)) })
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "propagated", header: "client-id", want: "client-id"},
+		{name: "generated", want: "generated"},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/users", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			if w.Body.String() != tt.want || w.Header().Get(RequestIDHeader) != tt.want {
+				t.Errorf("Expected request ID %q, got body %q and header %q", tt.want, w.Body.String(), w.Header().Get(RequestIDHeader))
+			}
+			if tracer.spans[i].attributes["http.request_id"] != tt.want {
+				t.Errorf("Expected span attribute %q, got %v", tt.want, tracer.spans[i].attributes)
+			}
+			if logged[i].RequestID != tt.want {
+				t.Errorf("Expected log entry request ID %q, got %q", tt.want, logged[i].RequestID)
+			}
+		})
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	op := doc.Paths["/users"].Get
+	if len(op.Parameters) != 1 || op.Parameters[0].Ref != api.ParameterRef(api.RequestIDParam) {
+		t.Errorf("Expected a X-Request-ID parameter reference, got %+v", op.Parameters)
+	}
+	if _, ok := doc.Components.Parameters[api.RequestIDParam]; !ok {
+		t.Error("Expected the X-Request-ID parameter under components")
+	}
+	if _, ok := op.Responses["200"].Headers[RequestIDHeader]; !ok {
+		t.Errorf("Expected the X-Request-ID response header, got %+v", op.Responses["200"])
+	}
+}
@@ -0,0 +1,17 @@
+package gin
+
+import (
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// DefaultResponse is a canned response added to operations that do not document the status themselves
+type DefaultResponse = router.DefaultResponse
+
+// DefaultResponses are the canned responses added when no policy has been set
+var DefaultResponses = router.DefaultResponses
+
+// SetDefaultResponsePolicy replaces the canned responses added to every operation
+// Calling it without responses disables them entirely
+func (r *APIRouter) SetDefaultResponsePolicy(responses ...DefaultResponse) {
+	r.core.SetDefaultResponsePolicy(responses...)
+}
@@ -0,0 +1,81 @@
+package gin
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+func newResponsesTestRouter(t *testing.T, defs ...*api.APIDefinition) *APIRouter {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+	for _, def := range defs {
+		def.WithHandler(func(w http.ResponseWriter, r *http.Request) {})
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	return router
+}
+
+// TestDefaultResponses tests the canned responses added without a policy
+func TestDefaultResponses(t *testing.T) {
+	router := newResponsesTestRouter(t,
+		api.NewAPIDefinition("GET", "/public", "Public"),
+		api.NewAPIDefinition("GET", "/private", "Private").WithSecurity("bearerAuth", []string{}),
+	)
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	public := doc.Paths["/public"].Get.Responses
+	for _, status := range []string{"400", "500"} {
+		if _, ok := public[status]; !ok {
+			t.Errorf("Expected default %s response", status)
+		}
+	}
+	if _, ok := public["401"]; ok {
+		t.Error("Expected no 401 response on an unsecured operation")
+	}
+	if _, ok := doc.Paths["/private"].Get.Responses["401"]; !ok {
+		t.Error("Expected 401 response on a secured operation")
+	}
+}
+
+// TestSetDefaultResponsePolicy tests replacing and disabling the canned responses
+func TestSetDefaultResponsePolicy(t *testing.T) {
+	router := newResponsesTestRouter(t,
+		api.NewAPIDefinition("GET", "/users", "List users"),
+		api.NewAPIDefinition("GET", "/health", "Health").WithNoDefaultResponses(),
+	)
+	router.SetDefaultResponsePolicy(DefaultResponse{Status: "default", Description: "Unexpected error"})
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	users := doc.Paths["/users"].Get.Responses
+	if _, ok := users["default"]; !ok {
+		t.Error("Expected the policy's default response")
+	}
+	if _, ok := users["400"]; ok {
+		t.Error("Expected the built-in 400 response to be replaced")
+	}
+	health := doc.Paths["/health"].Get.Responses
+	if _, ok := health["default"]; ok || len(health) != 1 {
+		t.Errorf("Expected only a success response on an operation that opted out, got %v", health)
+	}
+
+	router.SetDefaultResponsePolicy()
+	doc, err = router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if _, ok := doc.Paths["/users"].Get.Responses["default"]; ok {
+		t.Error("Expected canned responses to be disabled")
+	}
+}
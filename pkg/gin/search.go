@@ -0,0 +1,55 @@
+package gin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// maxSearchResults bounds the ?limit= of SpecSearchHandler
+const maxSearchResults = 100
+
+// buildSearchIndex indexes a generated document for SpecSearchHandler
+func (r *APIRouter) buildSearchIndex(doc *api.OpenAPIDoc) {
+	index := api.NewSearchIndex(doc, r.core.GetDefinitions())
+	r.docMu.Lock()
+	r.searchIndex = index
+	r.docMu.Unlock()
+}
+
+// SpecSearchHandler searches the generated document for ?q= (e.g., mount at /swagger/search), matching
+// summaries, paths, tags, operationIds and schema names, and returns up to ?limit= (default 20) deep-linked results
+func (r *APIRouter) SpecSearchHandler(c *gin.Context) {
+	if !r.authorizeDocs(c) {
+		return
+	}
+	if r.docsAnalytics != nil {
+		r.docsAnalytics.hit(c)
+	}
+
+	query := c.Query("q")
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing q parameter"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > maxSearchResults {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit parameter"})
+		return
+	}
+
+	r.docMu.RLock()
+	index := r.searchIndex
+	r.docMu.RUnlock()
+	if index == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Swagger documentation not available",
+			"message": "Documentation was not generated at startup",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"query": query, "results": index.Search(query, limit)})
+}
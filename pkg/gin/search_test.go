@@ -0,0 +1,98 @@
+package gin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestSpecSearchHandler tests searching the generated document
+func TestSpecSearchHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	engine.GET("/swagger/search", router.SpecSearchHandler)
+
+	// Searching before the document is generated fails like SwaggerHandler
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/search?q=user", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500 before generation, got %d", w.Code)
+	}
+
+	for _, def := range []*api.APIDefinition{
+		api.NewAPIDefinition("GET", "/users", "List users").WithTags("users").WithOperationID("listUsers"),
+		api.NewAPIDefinition("GET", "/orders/{id}", "Get order").WithTags("orders").WithOperationID("getOrder").
+			WithPathParam("id", "Order ID", true),
+	} {
+		if err := router.Register(def.WithNativeHandler(func(c *gin.Context) {})); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantIDs    []string
+	}{
+		{"match", "?q=order", http.StatusOK, []string{"getOrder"}},
+		{"limit", "?q=get&limit=1", http.StatusOK, []string{"getOrder"}},
+		{"missing query", "", http.StatusBadRequest, nil},
+		{"invalid limit", "?q=user&limit=0", http.StatusBadRequest, nil},
+		{"limit too large", "?q=user&limit=1000", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/search"+tt.query, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var body struct {
+				Results []api.SearchResult `json:"results"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Failed to decode the response: %v", err)
+			}
+			if len(body.Results) != len(tt.wantIDs) {
+				t.Fatalf("Expected results %v, got %+v", tt.wantIDs, body.Results)
+			}
+			for i, id := range tt.wantIDs {
+				if body.Results[i].OperationID != id {
+					t.Errorf("Expected result %d to be %s, got %s", i, id, body.Results[i].OperationID)
+				}
+			}
+			if body.Results[0].Link != "#/orders/getOrder" {
+				t.Errorf("Expected link #/orders/getOrder, got %q", body.Results[0].Link)
+			}
+		})
+	}
+}
+
+// TestSpecSearchHandlerAccess tests that the search endpoint honors documentation access checks
+func TestSpecSearchHandlerAccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.ProtectDocs(DocsTokenAuth("X-Docs-Token", "secret"))
+	engine.GET("/swagger/search", router.SpecSearchHandler)
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/search?q=user", nil))
+	if w.Code != http.StatusUnauthorized && w.Code != http.StatusForbidden {
+		t.Errorf("Expected the request without token to be rejected, got status %d", w.Code)
+	}
+}
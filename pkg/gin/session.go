@@ -0,0 +1,38 @@
+package gin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// SessionValidator checks the value of a session cookie, returning false to reject the request
+type SessionValidator func(c *gin.Context, session string) bool
+
+// AddCookieAuth adds an API key security scheme carried in a session cookie
+func (r *APIRouter) AddCookieAuth(name, cookieName, description string) {
+	r.core.AddSecurityScheme(name, api.SecurityScheme{
+		Type:        "apiKey",
+		Name:        cookieName,
+		In:          "cookie",
+		Description: description,
+	})
+}
+
+// SetSessionValidator enforces a cookie security scheme at runtime
+// Operations requiring the scheme reject requests whose cookie is missing or fails the validator with 401
+func (r *APIRouter) SetSessionValidator(scheme string, validator SessionValidator) {
+	r.core.SetSessionValidator(scheme, func(req *http.Request, session string) bool {
+		c := contextOf(req)
+		c.Request = req
+		return validator(c, session)
+	})
+}
+
+// SessionCookie returns the session cookie value accepted for the current request
+func SessionCookie(c *gin.Context) (string, bool) {
+	return router.SessionCookie(c.Request.Context())
+}
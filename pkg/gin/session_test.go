@@ -0,0 +1,78 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestAddCookieAuth tests the documented apiKey-in-cookie scheme
+func TestAddCookieAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+	router.AddCookieAuth("session", "SESSIONID", "Dashboard session")
+
+	scheme := router.core.SecuritySchemes()["session"]
+	if scheme.Type != "apiKey" || scheme.In != "cookie" || scheme.Name != "SESSIONID" {
+		t.Errorf("Unexpected scheme %+v", scheme)
+	}
+}
+
+// TestSessionValidator tests enforcing the session cookie at runtime
+func TestSessionValidator(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.AddCookieAuth("session", "SESSIONID", "Dashboard session")
+	router.SetSessionValidator("session", func(c *gin.Context, session string) bool {
+		return session == "valid"
+	})
+
+	var accepted string
+	dashboard := api.NewAPIDefinition("GET", "/dashboard", "Dashboard").
+		WithSecurity("session", []string{})
+	WithGinHandler(dashboard, func(c *gin.Context) {
+		accepted, _ = SessionCookie(c)
+		c.Status(http.StatusOK)
+	})
+	public := api.NewAPIDefinition("GET", "/public", "Public").
+		WithHandler(func(w http.ResponseWriter, r *http.Request) {})
+	for _, def := range []*api.APIDefinition{dashboard, public} {
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		url        string
+		cookie     string
+		wantStatus int
+	}{
+		{name: "valid session", url: "/api/dashboard", cookie: "valid", wantStatus: http.StatusOK},
+		{name: "invalid session", url: "/api/dashboard", cookie: "forged", wantStatus: http.StatusUnauthorized},
+		{name: "missing session", url: "/api/dashboard", wantStatus: http.StatusUnauthorized},
+		{name: "unsecured operation", url: "/api/public", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.url, nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "SESSIONID", Value: tt.cookie})
+			}
+			engine.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+	if accepted != "valid" {
+		t.Errorf("Expected accepted session to be exposed, got %q", accepted)
+	}
+}
@@ -0,0 +1,89 @@
+package gin
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestSpecSigning tests the digest and detached signature of the served document
+func TestSpecSigning(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		signer        SpecSigner
+		wantSigStatus int
+	}{
+		{"unsigned", nil, http.StatusNotFound},
+		{"ed25519", NewEd25519Signer(private), http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+			if tt.signer != nil {
+				router.SetSpecSigner(tt.signer)
+			}
+			engine.GET("/swagger.json", router.SwaggerHandler)
+			engine.GET("/swagger.json.sig", router.SpecSignatureHandler)
+			if err := router.Register(api.NewAPIDefinition("GET", "/users", "List users").WithNativeHandler(func(c *gin.Context) {})); err != nil {
+				t.Fatalf("Register failed: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger.json.sig", nil))
+			if w.Code != http.StatusInternalServerError {
+				t.Errorf("Expected signature status 500 before generation, got %d", w.Code)
+			}
+			if _, err := router.GenerateSwagger(); err != nil {
+				t.Fatalf("GenerateSwagger failed: %v", err)
+			}
+
+			w = httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger.json", nil))
+			doc := w.Body.Bytes()
+			sum := sha256.Sum256(doc)
+			if got, want := w.Header().Get("X-Spec-Digest"), "sha-256="+base64.StdEncoding.EncodeToString(sum[:]); got != want {
+				t.Errorf("Expected X-Spec-Digest %q, got %q", want, got)
+			}
+			header := w.Header().Get("X-Spec-Signature")
+
+			w = httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger.json.sig", nil))
+			if w.Code != tt.wantSigStatus {
+				t.Fatalf("Expected signature status %d, got %d", tt.wantSigStatus, w.Code)
+			}
+			if tt.signer == nil {
+				if header != "" {
+					t.Errorf("Expected no X-Spec-Signature, got %q", header)
+				}
+				return
+			}
+			if header != w.Body.String() {
+				t.Errorf("Expected X-Spec-Signature to match the detached signature %q, got %q", w.Body.String(), header)
+			}
+			if err := VerifySpecSignature(public, doc, w.Body.Bytes()); err != nil {
+				t.Errorf("Expected the signature to verify, got %v", err)
+			}
+			tampered := append([]byte(nil), doc...)
+			tampered[len(tampered)-2] = ' '
+			if err := VerifySpecSignature(public, tampered, w.Body.Bytes()); err == nil {
+				t.Error("Expected VerifySpecSignature to reject a tampered document")
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package gin
+
+import (
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// SLOResult compares the measured performance of an operation with its objectives
+type SLOResult = router.SLOResult
+
+// SLOMonitor measures operations declaring an SLO against their objectives
+type SLOMonitor = router.SLOMonitor
+
+// EnableSLOMonitor measures the latency and throughput of operations declaring an SLO, keeping the given
+// number of recent latencies per operation (default 1000) for percentile computation
+func (r *APIRouter) EnableSLOMonitor(sampleSize int) *SLOMonitor {
+	return r.core.EnableSLOMonitor(sampleSize)
+}
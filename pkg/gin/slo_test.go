@@ -0,0 +1,103 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestSLOExtension tests documenting objectives as the x-slo extension
+func TestSLOExtension(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+	apiDef := api.NewAPIDefinition("GET", "/users", "List users").
+		WithSLO(250*time.Millisecond, 100).
+		WithHandler(func(w http.ResponseWriter, r *http.Request) {})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	doc, err := router.BuildOpenAPI()
+	if err != nil {
+		t.Fatalf("BuildOpenAPI failed: %v", err)
+	}
+	want := map[string]interface{}{"latencyP99Ms": 250.0, "rps": 100.0}
+	if got := doc.Paths["/users"].Get.Extensions["x-slo"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected x-slo %v, got %v", want, got)
+	}
+}
+
+// TestSLOMonitor tests measuring conformance of operations declaring an SLO
+func TestSLOMonitor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	monitor := router.EnableSLOMonitor(0)
+
+	defs := []*api.APIDefinition{
+		api.NewAPIDefinition("GET", "/fast", "Fast").
+			WithOperationID("fast").
+			WithSLO(time.Second, 0).
+			WithHandler(func(w http.ResponseWriter, r *http.Request) {}),
+		api.NewAPIDefinition("GET", "/slow", "Slow").
+			WithOperationID("slow").
+			WithSLO(time.Millisecond, 0).
+			WithHandler(func(w http.ResponseWriter, r *http.Request) { time.Sleep(5 * time.Millisecond) }),
+		api.NewAPIDefinition("GET", "/busy", "Busy").
+			WithOperationID("busy").
+			WithSLO(0, 1e9).
+			WithHandler(func(w http.ResponseWriter, r *http.Request) { time.Sleep(time.Millisecond) }),
+		api.NewAPIDefinition("GET", "/untracked", "Untracked").
+			WithHandler(func(w http.ResponseWriter, r *http.Request) {}),
+	}
+	for _, def := range defs {
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	for _, path := range []string{"/fast", "/slow", "/busy", "/untracked"} {
+		for i := 0; i < 3; i++ {
+			engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api"+path, nil))
+		}
+	}
+
+	report := monitor.Report()
+	if len(report) != 3 {
+		t.Fatalf("Expected 3 monitored operations, got %+v", report)
+	}
+	tests := []struct {
+		name           string
+		result         SLOResult
+		wantConforming bool
+	}{
+		{name: "busy", result: report[0], wantConforming: false},
+		{name: "fast", result: report[1], wantConforming: true},
+		{name: "slow", result: report[2], wantConforming: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result.OperationID != tt.name || tt.result.Requests != 3 {
+				t.Errorf("Expected 3 requests for %s, got %+v", tt.name, tt.result)
+			}
+			if tt.result.Conforming() != tt.wantConforming {
+				t.Errorf("Expected conforming %v, got %+v", tt.wantConforming, tt.result)
+			}
+		})
+	}
+
+	err := monitor.Verify()
+	if err == nil || !strings.Contains(err.Error(), "slow: p99") || !strings.Contains(err.Error(), "busy:") {
+		t.Errorf("Expected slow and busy violations, got %v", err)
+	}
+
+	monitor.Reset()
+	if err := monitor.Verify(); err != nil {
+		t.Errorf("Expected no violations after reset, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package gin
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// snapshotPathPrefix is where SnapshotSpec serves frozen documents
+const snapshotPathPrefix = "/swagger/"
+
+// specSnapshot is a document frozen by SnapshotSpec
+type specSnapshot struct {
+	data      []byte
+	etag      string
+	signature []byte
+	created   time.Time
+}
+
+// SnapshotSpec freezes the current document under /swagger/{version}.json, served with ImmutableDocsCache,
+// while SwaggerHandler keeps serving the latest one; GenerateSwagger must have run
+// Snapshotting a version again is a no-op when the document is unchanged, and an error otherwise
+func (r *APIRouter) SnapshotSpec(version string) error {
+	if version == "" || strings.ContainsAny(version, "/?#") {
+		return fmt.Errorf("invalid snapshot version %q", version)
+	}
+
+	r.docMu.Lock()
+	defer r.docMu.Unlock()
+	if r.swaggerDoc == nil {
+		return fmt.Errorf("cannot snapshot version %s: documentation was not generated", version)
+	}
+	if existing, ok := r.snapshots[version]; ok {
+		if bytes.Equal(existing.data, r.swaggerDoc) {
+			return nil
+		}
+		return fmt.Errorf("version %s is already snapshotted with a different document", version)
+	}
+	path := snapshotPathPrefix + version + ".json"
+	for _, route := range r.engine.Routes() {
+		if route.Method == http.MethodGet && route.Path == path {
+			return fmt.Errorf("cannot snapshot version %s: GET %s is already routed", version, path)
+		}
+	}
+	if r.snapshots == nil {
+		r.snapshots = make(map[string]*specSnapshot)
+	}
+	r.snapshots[version] = &specSnapshot{
+		data:      r.swaggerDoc,
+		etag:      r.docETag,
+		signature: r.specSignature,
+		created:   time.Now().UTC().Truncate(time.Second),
+	}
+
+	r.engine.GET(path, func(c *gin.Context) {
+		r.serveSnapshot(c, version)
+	})
+	return nil
+}
+
+// SnapshotVersions returns the versions frozen by SnapshotSpec, in order
+func (r *APIRouter) SnapshotVersions() []string {
+	r.docMu.RLock()
+	defer r.docMu.RUnlock()
+	versions := make([]string, 0, len(r.snapshots))
+	for version := range r.snapshots {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// serveSnapshot serves a frozen document after the documentation access checks
+func (r *APIRouter) serveSnapshot(c *gin.Context, version string) {
+	if !r.authorizeDocs(c) {
+		return
+	}
+	if r.docsAnalytics != nil {
+		r.docsAnalytics.hit(c)
+	}
+
+	r.docMu.RLock()
+	snapshot := r.snapshots[version]
+	r.docMu.RUnlock()
+
+	c.Header("Cache-Control", ImmutableDocsCache.cacheControl())
+	c.Header("ETag", snapshot.etag)
+	c.Header("Last-Modified", snapshot.created.Format(http.TimeFormat))
+	c.Header("X-Spec-Digest", specDigest(snapshot.data))
+	if snapshot.signature != nil {
+		c.Header("X-Spec-Signature", string(snapshot.signature))
+	}
+	if notModified(c.Request, snapshot.etag, snapshot.created) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", snapshot.data)
+}
@@ -0,0 +1,97 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestSnapshotSpec tests freezing documents under versioned URLs
+func TestSnapshotSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	engine.GET("/swagger.json", router.SwaggerHandler)
+	router.ServeSpecPerTag("/swagger")
+	handler := func(c *gin.Context) {}
+
+	if err := router.SnapshotSpec("1.0.0"); err == nil {
+		t.Error("Expected SnapshotSpec to fail before generation")
+	}
+	if err := router.Register(api.NewAPIDefinition("GET", "/users", "List users").WithNativeHandler(handler)); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if err := router.SnapshotSpec("1.0.0"); err != nil {
+		t.Fatalf("SnapshotSpec failed: %v", err)
+	}
+
+	// Head moves on while the snapshot stays frozen
+	if err := router.Register(api.NewAPIDefinition("GET", "/orders", "List orders").WithNativeHandler(handler)); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	head, frozen := get("/swagger.json"), get("/swagger/1.0.0.json")
+	if frozen.Code != http.StatusOK || strings.Contains(frozen.Body.String(), "/orders") {
+		t.Errorf("Expected the snapshot without /orders, got %d %s", frozen.Code, frozen.Body.String())
+	}
+	if !strings.Contains(head.Body.String(), "/orders") {
+		t.Error("Expected the head document to include /orders")
+	}
+	if got := frozen.Header().Get("Cache-Control"); got != ImmutableDocsCache.cacheControl() {
+		t.Errorf("Expected snapshot Cache-Control %q, got %q", ImmutableDocsCache.cacheControl(), got)
+	}
+	if frozen.Header().Get("ETag") == head.Header().Get("ETag") || frozen.Header().Get("X-Spec-Digest") != specDigest(frozen.Body.Bytes()) {
+		t.Errorf("Expected the snapshot's own ETag and digest, got %v", frozen.Header())
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/swagger/1.0.0.json", nil)
+	req.Header.Set("If-None-Match", frozen.Header().Get("ETag"))
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected status 304 for a conditional snapshot request, got %d", w.Code)
+	}
+
+	tests := []struct {
+		version string
+		wantErr bool
+	}{
+		{"1.0.0", true},  // Frozen with a different document
+		{"1.1.0", false}, // New version of the current document
+		{"1.1.0", false}, // Same document again
+		{"", true},
+		{"1.0/beta", true},
+		{"tags", true}, // Routed by ServeSpecPerTag
+	}
+	for _, tt := range tests {
+		if err := router.SnapshotSpec(tt.version); (err != nil) != tt.wantErr {
+			t.Errorf("Expected SnapshotSpec(%q) error %v, got %v", tt.version, tt.wantErr, err)
+		}
+	}
+	if got, want := router.SnapshotVersions(), []string{"1.0.0", "1.1.0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected snapshot versions %v, got %v", want, got)
+	}
+	if w := get("/swagger/1.1.0.json"); w.Body.String() != head.Body.String() {
+		t.Error("Expected snapshot 1.1.0 to match the head document")
+	}
+	if w := get("/swagger/2.0.0.json"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for an unknown snapshot, got %d", w.Code)
+	}
+}
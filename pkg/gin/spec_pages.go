@@ -0,0 +1,116 @@
+package gin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Bounds of the ?limit= of SpecPathsHandler
+const (
+	defaultSpecPathsLimit = 100
+	maxSpecPathsLimit     = 1000
+)
+
+// specPages holds the generated document split for SpecPathsHandler and SpecComponentHandler
+type specPages struct {
+	paths      []string                              // Paths in order
+	items      map[string]json.RawMessage            // Path items by path
+	components map[string]map[string]json.RawMessage // Components by kind and name
+}
+
+// SpecPathsPage is a slice of the document's paths served by SpecPathsHandler
+type SpecPathsPage struct {
+	Offset int                        `json:"offset"`
+	Limit  int                        `json:"limit"`
+	Total  int                        `json:"total" doc:"Number of paths in the document"`
+	Paths  map[string]json.RawMessage `json:"paths" doc:"Path items of the slice, keyed by path"`
+}
+
+// buildSpecPages splits the marshaled generated document into paths and components
+func (r *APIRouter) buildSpecPages(data []byte) error {
+	var doc struct {
+		Paths      map[string]json.RawMessage            `json:"paths"`
+		Components map[string]map[string]json.RawMessage `json:"components"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to index document paths: %w", err)
+	}
+	pages := &specPages{items: doc.Paths, components: doc.Components}
+	for path := range doc.Paths {
+		pages.paths = append(pages.paths, path)
+	}
+	sort.Strings(pages.paths)
+
+	r.docMu.Lock()
+	r.specPages = pages
+	r.docMu.Unlock()
+	return nil
+}
+
+// generatedPages returns the split document, answering the request when documentation is unavailable
+func (r *APIRouter) generatedPages(c *gin.Context) (*specPages, bool) {
+	if !r.authorizeDocs(c) {
+		return nil, false
+	}
+	if r.docsAnalytics != nil {
+		r.docsAnalytics.hit(c)
+	}
+	r.docMu.RLock()
+	pages := r.specPages
+	r.docMu.RUnlock()
+	if pages == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Swagger documentation not available",
+			"message": "Documentation was not generated at startup",
+		})
+		return nil, false
+	}
+	return pages, true
+}
+
+// SpecPathsHandler serves the paths of the generated document in slices of ?limit= (default 100) starting at
+// ?offset=, in path order (e.g., mount at /swagger/paths), for tooling that cannot load the whole document
+// References are left as they are and resolved through SpecComponentHandler
+func (r *APIRouter) SpecPathsHandler(c *gin.Context) {
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset parameter"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultSpecPathsLimit)))
+	if err != nil || limit < 1 || limit > maxSpecPathsLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit parameter"})
+		return
+	}
+	pages, ok := r.generatedPages(c)
+	if !ok {
+		return
+	}
+
+	page := SpecPathsPage{Offset: offset, Limit: limit, Total: len(pages.paths), Paths: make(map[string]json.RawMessage)}
+	for i := offset; i < len(pages.paths) && i < offset+limit; i++ {
+		path := pages.paths[i]
+		page.Paths[path] = pages.items[path]
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// SpecComponentHandler serves one component of the generated document by its :kind and :name route
+// parameters (e.g., mount at /swagger/components/:kind/:name to fetch /swagger/components/schemas/User)
+func (r *APIRouter) SpecComponentHandler(c *gin.Context) {
+	pages, ok := r.generatedPages(c)
+	if !ok {
+		return
+	}
+	component, ok := pages.components[c.Param("kind")][c.Param("name")]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "component not found"})
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", component)
+}
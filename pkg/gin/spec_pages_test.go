@@ -0,0 +1,141 @@
+package gin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// schemaPlugin adds a shared component schema to generated documents
+type schemaPlugin struct{}
+
+func (schemaPlugin) Name() string { return "schemas" }
+
+func (schemaPlugin) OnGenerate(doc *api.OpenAPIDoc) error {
+	if doc.Components.Schemas == nil {
+		doc.Components.Schemas = make(map[string]interface{})
+	}
+	doc.Components.Schemas["User"] = map[string]interface{}{"type": "object"}
+	return nil
+}
+
+// TestSpecPathsHandler tests fetching the paths of the document in slices
+func TestSpecPathsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	engine.GET("/swagger/paths", router.SpecPathsHandler)
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/paths", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500 before generation, got %d", w.Code)
+	}
+
+	for i := 1; i <= 5; i++ {
+		def := api.NewAPIDefinition("GET", fmt.Sprintf("/items%d", i), "Get item").WithNativeHandler(func(c *gin.Context) {})
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantPaths  []string
+	}{
+		{"first page", "?limit=2", http.StatusOK, []string{"/items1", "/items2"}},
+		{"next page", "?offset=2&limit=2", http.StatusOK, []string{"/items3", "/items4"}},
+		{"last page", "?offset=4&limit=2", http.StatusOK, []string{"/items5"}},
+		{"past the end", "?offset=10", http.StatusOK, []string{}},
+		{"default limit", "", http.StatusOK, []string{"/items1", "/items2", "/items3", "/items4", "/items5"}},
+		{"negative offset", "?offset=-1", http.StatusBadRequest, nil},
+		{"zero limit", "?limit=0", http.StatusBadRequest, nil},
+		{"limit too large", "?limit=100000", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/paths"+tt.query, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var page struct {
+				Total int                     `json:"total"`
+				Paths map[string]api.PathItem `json:"paths"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+				t.Fatalf("Failed to decode the page: %v", err)
+			}
+			if page.Total != 5 {
+				t.Errorf("Expected total 5, got %d", page.Total)
+			}
+			if len(page.Paths) != len(tt.wantPaths) {
+				t.Fatalf("Expected paths %v, got %v", tt.wantPaths, page.Paths)
+			}
+			for _, path := range tt.wantPaths {
+				if item, ok := page.Paths[path]; !ok || item.Get == nil {
+					t.Errorf("Expected path %s on the page", path)
+				}
+			}
+		})
+	}
+}
+
+// TestSpecComponentHandler tests fetching single components of the document
+func TestSpecComponentHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.Use(schemaPlugin{})
+	engine.GET("/swagger/components/:kind/:name", router.SpecComponentHandler)
+	if err := router.Register(api.NewAPIDefinition("GET", "/users", "List users").WithNativeHandler(func(c *gin.Context) {})); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"/swagger/components/schemas/User", http.StatusOK, `{"type":"object"}`},
+		{"/swagger/components/schemas/Order", http.StatusNotFound, ""},
+		{"/swagger/components/widgets/User", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantBody == "" {
+				return
+			}
+			var got, want interface{}
+			_ = json.Unmarshal(w.Body.Bytes(), &got)
+			_ = json.Unmarshal([]byte(tt.wantBody), &want)
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("Expected body %s, got %s", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
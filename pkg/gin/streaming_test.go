@@ -0,0 +1,82 @@
+package gin
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestStreamingRequest tests handing unread bodies to streaming handlers while bounding their size
+func TestStreamingRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.SetPayloadLimits(api.PayloadLimits{MaxBodySize: 1024, MaxDepth: 2})
+	router.SetStrictRequests(true)
+
+	var received int
+	apiDef := api.NewAPIDefinition("PUT", "/files/{name}", "Upload file").
+		WithRequest(CreateUserRequest{}).
+		WithStreamingRequest(api.MediaTypeOctetStream, "multipart/form-data").
+		WithNativeHandler(func(c *gin.Context) {
+			n, err := io.Copy(io.Discard, c.Request.Body)
+			if err != nil {
+				c.Status(http.StatusRequestEntityTooLarge)
+				return
+			}
+			received = int(n)
+			c.Status(http.StatusCreated)
+		})
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "binary body", body: strings.Repeat("\x00\x01", 100), wantStatus: http.StatusCreated},
+		{name: "json-like body left unvalidated", body: `{"a":{"b":{"c":{}}},"unknown":true}`, wantStatus: http.StatusCreated},
+		{name: "too large", body: strings.Repeat("x", 2048), wantStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = 0
+			req := httptest.NewRequest("PUT", "/api/files/report.bin", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", api.MediaTypeOctetStream)
+			req.ContentLength = -1
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusCreated && received != len(tt.body) {
+				t.Errorf("Handler read %d bytes, want %d", received, len(tt.body))
+			}
+		})
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	operation := doc.Paths["/files/{name}"].Put
+	content := operation.RequestBody.Content
+	if len(content) != 2 || content[api.MediaTypeOctetStream].Schema["format"] != "binary" {
+		t.Errorf("Unexpected streamed request body %v", content)
+	}
+	if _, ok := content["multipart/form-data"].Schema["properties"]; !ok {
+		t.Errorf("Expected the multipart body to document the request model, got %v", content["multipart/form-data"].Schema)
+	}
+	if _, ok := operation.Responses["413"]; !ok {
+		t.Error("Expected 413 response for the body size limit")
+	}
+}
@@ -0,0 +1,14 @@
+package gin
+
+// SetStrictRequests makes request bodies reject undocumented fields by default: request schemas are
+// documented with additionalProperties: false unless their model opts out through api.StrictModel
+// Models declaring strictness themselves are enforced whatever the default
+func (r *APIRouter) SetStrictRequests(strict bool) {
+	r.core.SetStrictRequests(strict)
+}
+
+// SetDisallowUnknownFields makes request validation reject bodies with undocumented properties, answering
+// 400 with the offending fields; unlike SetStrictRequests, the documented schemas are left open
+func (r *APIRouter) SetDisallowUnknownFields(disallow bool) {
+	r.core.SetDisallowUnknownFields(disallow)
+}
@@ -0,0 +1,121 @@
+package gin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type strictSignup struct {
+	Email string `json:"email"`
+}
+
+func (strictSignup) Strict() bool { return true }
+
+type openSignup struct {
+	Email string `json:"email"`
+}
+
+func (openSignup) Strict() bool { return false }
+
+type plainSignup struct {
+	Email string `json:"email"`
+}
+
+// TestStrictRequests tests rejecting undocumented request fields per model and by router default
+func TestStrictRequests(t *testing.T) {
+	tests := []struct {
+		name       string
+		strict     bool
+		request    interface{}
+		body       string
+		wantStatus int
+		wantClosed bool
+	}{
+		{name: "strict model", request: strictSignup{}, body: `{"email":"a@b.c","admin":true}`, wantStatus: http.StatusBadRequest, wantClosed: true},
+		{name: "strict model known fields", request: strictSignup{}, body: `{"email":"a@b.c"}`, wantStatus: http.StatusOK, wantClosed: true},
+		{name: "plain model", request: plainSignup{}, body: `{"email":"a@b.c","admin":true}`, wantStatus: http.StatusOK},
+		{name: "router default", strict: true, request: plainSignup{}, body: `{"email":"a@b.c","admin":true}`, wantStatus: http.StatusBadRequest, wantClosed: true},
+		{name: "opted out", strict: true, request: openSignup{}, body: `{"email":"a@b.c","admin":true}`, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			engine := gin.New()
+			router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+			router.SetStrictRequests(tt.strict)
+			apiDef := api.NewAPIDefinition("POST", "/signups", "Sign up").
+				WithRequest(tt.request).
+				WithNativeHandler(func(c *gin.Context) { c.Status(http.StatusOK) })
+			if err := router.Register(apiDef); err != nil {
+				t.Fatalf("Register failed: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/signups", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusBadRequest && !strings.Contains(w.Body.String(), "unknown fields: admin") {
+				t.Errorf("Expected unknown field error, got %s", w.Body.String())
+			}
+
+			doc, err := router.GenerateSwagger()
+			if err != nil {
+				t.Fatalf("GenerateSwagger failed: %v", err)
+			}
+			schema := doc.Paths["/signups"].Post.RequestBody.Content["application/json"].Schema
+			if closed := schema["additionalProperties"] == false; closed != tt.wantClosed {
+				t.Errorf("Unexpected documented additionalProperties %v", schema["additionalProperties"])
+			}
+		})
+	}
+}
+
+// TestDisallowUnknownFields tests rejecting undocumented fields without closing the documented schema
+func TestDisallowUnknownFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.SetDisallowUnknownFields(true)
+	apiDef := api.NewAPIDefinition("POST", "/signups", "Sign up").
+		WithRequest(plainSignup{}).
+		WithNativeHandler(func(c *gin.Context) { c.Status(http.StatusOK) })
+	if err := router.Register(apiDef); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/signups", strings.NewReader(`{"email":"a@b.c","admin":true,"plan":"pro"}`))
+	req.Header.Set("Content-Type", "application/json")
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", w.Code)
+	}
+	var body struct {
+		Fields []string `json:"fields"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(body.Fields, []string{"admin", "plan"}) {
+		t.Errorf("Expected offending fields, got %v", body.Fields)
+	}
+
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	schema := doc.Paths["/signups"].Post.RequestBody.Content["application/json"].Schema
+	if _, ok := schema["additionalProperties"]; ok {
+		t.Errorf("Expected the documented schema to stay open, got %v", schema)
+	}
+}
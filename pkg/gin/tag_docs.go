@@ -0,0 +1,137 @@
+package gin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TagDocument describes a per-tag document in the index served by ServeSpecPerTag
+type TagDocument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Operations  int    `json:"operations" doc:"Number of operations in the document"`
+	URL         string `json:"url" doc:"Path of the tag's document"`
+}
+
+// tagDocs holds the per-tag documents built by GenerateSwagger
+type tagDocs struct {
+	basePath string
+	index    []byte
+	docs     map[string][]byte
+}
+
+// ServeSpecPerTag serves one document per tag at basePath/tags/{tag}.json, each holding only that tag's paths
+// and the components they reach, and an index of them at basePath/tags.json (e.g., basePath /swagger)
+// Untagged operations are served as the api.DefaultTag document; documents are built by GenerateSwagger
+func (r *APIRouter) ServeSpecPerTag(basePath string) {
+	basePath = strings.TrimSuffix(basePath, "/")
+	r.docMu.Lock()
+	r.tagDocs = &tagDocs{basePath: basePath}
+	r.docMu.Unlock()
+
+	r.engine.GET(basePath+"/tags.json", func(c *gin.Context) {
+		r.serveTagDoc(c, func(docs *tagDocs) []byte { return docs.index })
+	})
+	r.engine.GET(basePath+"/tags/:file", func(c *gin.Context) {
+		tag, ok := strings.CutSuffix(c.Param("file"), ".json")
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": "tag document not found"})
+			return
+		}
+		r.serveTagDoc(c, func(docs *tagDocs) []byte { return docs.docs[tag] })
+	})
+}
+
+// serveTagDoc serves a per-tag document or the index after the documentation access checks
+func (r *APIRouter) serveTagDoc(c *gin.Context, pick func(*tagDocs) []byte) {
+	if !r.authorizeDocs(c) {
+		return
+	}
+	if r.docsAnalytics != nil {
+		r.docsAnalytics.hit(c)
+	}
+
+	r.docMu.RLock()
+	docs := r.tagDocs
+	var data []byte
+	if docs != nil && docs.index != nil {
+		data = pick(docs)
+	}
+	generated := docs != nil && docs.index != nil
+	r.docMu.RUnlock()
+
+	if !generated {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Swagger documentation not available",
+			"message": "Documentation was not generated at startup",
+		})
+		return
+	}
+	if data == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "tag document not found"})
+		return
+	}
+	c.Header("Cache-Control", r.docsCacheControl())
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
+
+// buildTagDocs splits a generated document by tag when ServeSpecPerTag is enabled
+func (r *APIRouter) buildTagDocs(doc *api.OpenAPIDoc) error {
+	r.docMu.RLock()
+	enabled := r.tagDocs != nil
+	r.docMu.RUnlock()
+	if !enabled {
+		return nil
+	}
+
+	descriptions := make(map[string]string, len(doc.Tags))
+	for _, tag := range doc.Tags {
+		descriptions[tag.Name] = tag.Description
+	}
+
+	r.docMu.RLock()
+	basePath := r.tagDocs.basePath
+	r.docMu.RUnlock()
+
+	built := &tagDocs{basePath: basePath, docs: make(map[string][]byte)}
+	index := make([]TagDocument, 0)
+	for _, tag := range api.DocumentTags(doc) {
+		filtered, err := api.FilterTag(doc, tag)
+		if err != nil {
+			return fmt.Errorf("failed to build %s tag document: %w", tag, err)
+		}
+		data, err := json.MarshalIndent(filtered, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s tag document: %w", tag, err)
+		}
+		built.docs[tag] = data
+
+		operations := 0
+		for _, item := range filtered.Paths {
+			operations += len(item.Operations())
+		}
+		index = append(index, TagDocument{
+			Name:        tag,
+			Description: descriptions[tag],
+			Operations:  operations,
+			URL:         basePath + "/tags/" + url.PathEscape(tag) + ".json",
+		})
+	}
+	data, err := json.MarshalIndent(gin.H{"info": doc.Info, "tags": index}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tag index: %w", err)
+	}
+	built.index = data
+
+	r.docMu.Lock()
+	r.tagDocs = built
+	r.docMu.Unlock()
+	return nil
+}
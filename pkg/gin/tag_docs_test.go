@@ -0,0 +1,108 @@
+package gin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestServeSpecPerTag tests serving one document per tag and their index
+func TestServeSpecPerTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	router.ServeSpecPerTag("/swagger/")
+
+	// Documents are not available before generation
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/tags.json", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected index status 500 before generation, got %d", w.Code)
+	}
+
+	handler := func(c *gin.Context) {}
+	for _, def := range []*api.APIDefinition{
+		api.NewAPIDefinition("GET", "/users", "List users").WithTags("users").WithResponse(UserResponse{}),
+		api.NewAPIDefinition("POST", "/users", "Create user").WithTags("users", "admin"),
+		api.NewAPIDefinition("GET", "/orders", "List orders").WithTags("orders"),
+		api.NewAPIDefinition("GET", "/health", "Health check"),
+	} {
+		if err := router.Register(def.WithNativeHandler(handler)); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/tags.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected index status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var index struct {
+		Info api.OpenAPIInfo `json:"info"`
+		Tags []TagDocument   `json:"tags"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &index); err != nil {
+		t.Fatalf("Failed to decode the index: %v", err)
+	}
+	want := []TagDocument{
+		{Name: "admin", Operations: 1, URL: "/swagger/tags/admin.json"},
+		{Name: "default", Operations: 1, URL: "/swagger/tags/default.json"},
+		{Name: "orders", Operations: 1, URL: "/swagger/tags/orders.json"},
+		{Name: "users", Operations: 2, URL: "/swagger/tags/users.json"},
+	}
+	if index.Info.Title != "Test API" || len(index.Tags) != len(want) {
+		t.Fatalf("Expected index %+v, got %+v", want, index)
+	}
+	for i := range want {
+		if index.Tags[i] != want[i] {
+			t.Errorf("Expected index entry %d to be %+v, got %+v", i, want[i], index.Tags[i])
+		}
+	}
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantPaths  []string
+	}{
+		{"/swagger/tags/users.json", http.StatusOK, []string{"/users"}},
+		{"/swagger/tags/default.json", http.StatusOK, []string{"/health"}},
+		{"/swagger/tags/billing.json", http.StatusNotFound, nil},
+		{"/swagger/tags/users", http.StatusNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if err := api.ValidateDocJSON(w.Body.Bytes()); err != nil {
+				t.Errorf("Expected a valid tag document, got %v", err)
+			}
+			var doc api.OpenAPIDoc
+			if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+				t.Fatalf("Failed to decode the document: %v", err)
+			}
+			if len(doc.Paths) != len(tt.wantPaths) {
+				t.Fatalf("Expected paths %v, got %v", tt.wantPaths, doc.Paths)
+			}
+			for _, path := range tt.wantPaths {
+				if _, ok := doc.Paths[path]; !ok {
+					t.Errorf("Expected path %s, got %v", path, doc.Paths)
+				}
+			}
+		})
+	}
+}
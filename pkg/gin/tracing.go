@@ -0,0 +1,22 @@
+package gin
+
+import (
+	"github.com/smartcat999/go-swagger/pkg/router"
+)
+
+// Tracer starts spans around registered handlers
+// It mirrors the shape of OpenTelemetry's trace.Tracer so an adapter is a few lines:
+//
+//	func (t otelTracer) Start(ctx context.Context, name string, attrs map[string]interface{}) (context.Context, ginSwagger.Span) {
+//		ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(toKeyValues(attrs)...))
+//		return ctx, otelSpan{span}
+//	}
+type Tracer = router.Tracer
+
+// Span is a started span
+type Span = router.Span
+
+// SetTracer wraps every registered handler in a span named by the operation ID
+func (r *APIRouter) SetTracer(tracer Tracer) {
+	r.core.SetTracer(tracer)
+}
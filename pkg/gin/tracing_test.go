@@ -0,0 +1,71 @@
+package gin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type fakeSpan struct {
+	name       string
+	attributes map[string]interface{}
+	status     int
+	ended      bool
+}
+
+func (s *fakeSpan) SetStatus(status int) { s.status = status }
+func (s *fakeSpan) End()                 { s.ended = true }
+
+type fakeTracer struct {
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string, attributes map[string]interface{}) (context.Context, Span) {
+	span := &fakeSpan{name: name, attributes: attributes}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+// TestTracer tests span naming and attributes derived from definitions
+func TestTracer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+	tracer := &fakeTracer{}
+	router.SetTracer(tracer)
+
+	handler := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusAccepted) }
+	named := api.NewAPIDefinition("GET", "/users/{id}", "Get user").
+		WithOperationID("getUser").
+		WithTags("users").
+		WithHandler(handler)
+	unnamed := api.NewAPIDefinition("DELETE", "/users/{id}", "Delete user").
+		WithDeprecated(true).
+		WithHandler(handler)
+	for _, def := range []*api.APIDefinition{named, unnamed} {
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/users/1", nil))
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("DELETE", "/api/users/1", nil))
+
+	if len(tracer.spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(tracer.spans))
+	}
+	first, second := tracer.spans[0], tracer.spans[1]
+	if first.name != "getUser" || first.attributes["http.route"] != "/api/users/{id}" {
+		t.Errorf("Unexpected span %s %v", first.name, first.attributes)
+	}
+	if first.status != http.StatusAccepted || !first.ended {
+		t.Errorf("Expected ended span with status 202, got %d ended=%v", first.status, first.ended)
+	}
+	if second.name != "DELETE /api/users/{id}" || second.attributes["api.deprecated"] != true {
+		t.Errorf("Unexpected span %s %v", second.name, second.attributes)
+	}
+}
@@ -0,0 +1,120 @@
+package gin
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// Validate checks the registered configuration before the server starts:
+// conflicting routes and operation IDs, definitions with no engine route, path parameters
+// that don't match the path's placeholders, security requirements naming undeclared schemes
+// and empty summaries. All problems are reported together; nil means the router is consistent
+func (r *APIRouter) Validate() error {
+	var errs []error
+
+	securitySchemes := r.core.SecuritySchemes()
+	for _, requirement := range r.core.GlobalSecurity() {
+		for scheme := range requirement {
+			if _, ok := securitySchemes[scheme]; !ok {
+				errs = append(errs, fmt.Errorf("global security: scheme %q is not declared", scheme))
+			}
+		}
+	}
+
+	engineRoutes := make(map[string]bool)
+	for _, route := range r.engine.Routes() {
+		engineRoutes[routeKey(route.Method, route.Path)] = true
+	}
+
+	routes := make(map[string]string)
+	operationIDs := make(map[string]string)
+	definitions := r.core.GetDefinitions()
+	for i := range definitions {
+		def := &definitions[i]
+		label := fmt.Sprintf("%s %s", def.Method, def.Path)
+		fail := func(format string, args ...interface{}) {
+			errs = append(errs, fmt.Errorf("%s: %s", label, fmt.Sprintf(format, args...)))
+		}
+
+		key := routeKey(def.Method, r.core.BasePath()+convertOpenAPIPathToGin(def.Path))
+		if first, ok := routes[key]; ok {
+			fail("conflicts with %s", first)
+		} else {
+			routes[key] = label
+		}
+		if !engineRoutes[key] {
+			fail("not reachable, no route is registered on the engine")
+		}
+
+		if def.OperationID != "" {
+			if first, ok := operationIDs[def.OperationID]; ok {
+				fail("operationId %q is already used by %s", def.OperationID, first)
+			} else {
+				operationIDs[def.OperationID] = label
+			}
+		}
+
+		if strings.TrimSpace(def.Summary) == "" {
+			fail("summary is empty")
+		}
+
+		for _, problem := range pathParamProblems(def) {
+			fail("%s", problem)
+		}
+
+		for _, requirement := range def.Security {
+			for scheme := range requirement {
+				if _, ok := securitySchemes[scheme]; !ok {
+					fail("security scheme %q is not declared", scheme)
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+var pathPlaceholderPattern = regexp.MustCompile(`\{([^}]+)\}|[:*]([^/]+)`)
+
+// pathPlaceholders returns the parameter names appearing in a path, in either {name} or :name form
+func pathPlaceholders(path string) []string {
+	var names []string
+	for _, match := range pathPlaceholderPattern.FindAllStringSubmatch(path, -1) {
+		if match[1] != "" {
+			names = append(names, match[1])
+		} else {
+			names = append(names, match[2])
+		}
+	}
+	return names
+}
+
+// pathParamProblems compares a definition's path parameters with its path placeholders
+func pathParamProblems(def *api.APIDefinition) []string {
+	placeholders := make(map[string]bool)
+	for _, name := range pathPlaceholders(def.Path) {
+		placeholders[name] = true
+	}
+
+	var problems []string
+	declared := make(map[string]bool)
+	for _, param := range def.Params {
+		if param.In != "path" {
+			continue
+		}
+		declared[param.Name] = true
+		if !placeholders[param.Name] {
+			problems = append(problems, fmt.Sprintf("path parameter %q has no placeholder in the path", param.Name))
+		}
+	}
+	for _, name := range pathPlaceholders(def.Path) {
+		if !declared[name] {
+			problems = append(problems, fmt.Sprintf("placeholder %q has no declared path parameter", name))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,74 @@
+package gin
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestValidateDuplicateOperationIDs tests reporting operationIds used twice
+func TestValidateDuplicateOperationIDs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+
+	noop := func(c *gin.Context) {}
+	router.Register(api.GET("/users", "List users").WithOperationID("listUsers").WithNativeHandler(noop))
+	if err := router.Validate(); err != nil {
+		t.Fatalf("Expected valid router, got %v", err)
+	}
+
+	router.Register(api.GET("/accounts", "List accounts").WithOperationID("listUsers").WithNativeHandler(noop))
+	err := router.Validate()
+	if err == nil || !strings.Contains(err.Error(), `operationId "listUsers" is already used by GET /users`) {
+		t.Errorf("Expected duplicate operationId error, got %v", err)
+	}
+}
+
+// TestValidateReportsAllProblems tests reporting every configuration problem at once
+func TestValidateReportsAllProblems(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+	router.AddBearerAuth("bearerAuth", "JWT", "JWT")
+	router.SetGlobalSecurity([]map[string][]string{{"sessionCookie": {}}})
+
+	noop := func(c *gin.Context) {}
+	defs := []*api.APIDefinition{
+		api.GET("/users/{id}", "Get user").
+			WithPathParam("id", "User ID", true).
+			WithSecurity("bearerAuth", []string{}).
+			WithNativeHandler(noop),
+		api.GET("/orders/{orderId}", "").
+			WithPathParam("id", "Order ID", true).
+			WithSecurity("apiKey", []string{}).
+			WithNativeHandler(noop),
+	}
+	for _, def := range defs {
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	// A definition that bypassed Register has no engine route
+	router.core.Document(*api.GET("/ghost", "Ghost"))
+
+	err := router.Validate()
+	if err == nil {
+		t.Fatal("Expected validation errors")
+	}
+	for _, want := range []string{
+		`global security: scheme "sessionCookie" is not declared`,
+		`GET /orders/{orderId}: summary is empty`,
+		`GET /orders/{orderId}: path parameter "id" has no placeholder in the path`,
+		`GET /orders/{orderId}: placeholder "orderId" has no declared path parameter`,
+		`GET /orders/{orderId}: security scheme "apiKey" is not declared`,
+		`GET /ghost: not reachable`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "GET /users/{id}") {
+		t.Errorf("Expected the valid definition not to be reported, got:\n%v", err)
+	}
+}
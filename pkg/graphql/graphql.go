@@ -0,0 +1,321 @@
+// Package graphql exports the request and response models of API definitions as GraphQL type definitions
+// (SDL), to bootstrap a GraphQL schema for services exposing both REST and GraphQL
+//
+// The exporter is experimental: the generated SDL is a starting point to review, not a finished schema
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// Options configures the SDL export
+type Options struct {
+	// Scalars maps a schema format (e.g. "date-time", "int64") or type (e.g. "integer") to a GraphQL scalar
+	// Formats take precedence over types; custom scalars are declared in the output
+	Scalars map[string]string
+}
+
+// DefaultScalars maps schema types and formats to GraphQL scalars when Options.Scalars has no entry
+var DefaultScalars = map[string]string{
+	"string":    "String",
+	"integer":   "Int",
+	"number":    "Float",
+	"boolean":   "Boolean",
+	"date-time": "DateTime",
+	"int64":     "String", // GraphQL Int is 32-bit
+	"object":    "JSON",   // Free-form objects without properties
+}
+
+// builtinScalars are the scalars every GraphQL schema provides
+var builtinScalars = map[string]bool{"String": true, "Int": true, "Float": true, "Boolean": true, "ID": true}
+
+// ExportSDL generates GraphQL type definitions for the request (input types) and response (object types)
+// models of the given definitions
+func ExportSDL(defs []api.APIDefinition, opts Options) (string, error) {
+	e := &exporter{
+		opts:    opts,
+		types:   make(map[string]string),
+		scalars: make(map[string]bool),
+	}
+
+	for _, def := range defs {
+		if def.Request != nil {
+			if err := e.addModel(def.Request, true); err != nil {
+				return "", fmt.Errorf("failed to export request of %s %s: %w", def.Method, def.Path, err)
+			}
+		}
+		if def.Response != nil {
+			if err := e.addModel(def.Response, false); err != nil {
+				return "", fmt.Errorf("failed to export response of %s %s: %w", def.Method, def.Path, err)
+			}
+		}
+		statuses := make([]int, 0, len(def.Responses))
+		for status := range def.Responses {
+			statuses = append(statuses, status)
+		}
+		sort.Ints(statuses)
+		for _, status := range statuses {
+			if model := def.Responses[status].Model; model != nil {
+				if err := e.addModel(model, false); err != nil {
+					return "", fmt.Errorf("failed to export %d response of %s %s: %w", status, def.Method, def.Path, err)
+				}
+			}
+		}
+	}
+
+	return e.render(), nil
+}
+
+// exporter accumulates the type definitions of the exported models
+type exporter struct {
+	opts    Options
+	types   map[string]string // Rendered definitions keyed by type name
+	scalars map[string]bool   // Custom scalars to declare
+}
+
+var invalidNameChars = regexp.MustCompile(`[^_0-9A-Za-z]+`)
+
+// typeName converts a name into a valid GraphQL name in PascalCase
+func typeName(name string) string {
+	parts := invalidNameChars.Split(name, -1)
+	var b strings.Builder
+	for _, part := range parts {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	if b.Len() == 0 || (b.String()[0] >= '0' && b.String()[0] <= '9') {
+		return "T" + b.String()
+	}
+	return b.String()
+}
+
+// fieldName converts a JSON property name into a valid GraphQL field name
+func fieldName(name string) string {
+	name = invalidNameChars.ReplaceAllString(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return "_" + name
+	}
+	return name
+}
+
+// addModel exports a Go model under its type name
+func (e *exporter) addModel(model interface{}, input bool) error {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	schema, err := api.SafeSchemaFromStruct(reflect.New(t).Elem().Interface())
+	if err != nil {
+		return err
+	}
+	if _, ok := schema["properties"]; !ok {
+		// Scalars and free-form values have no type definition of their own
+		return nil
+	}
+	name := typeName(t.Name())
+	if input {
+		name += "Input"
+	}
+	e.objectType(name, schema, input)
+	return nil
+}
+
+// objectType renders an object schema as a type (or input) definition, returning its name
+func (e *exporter) objectType(name string, schema map[string]interface{}, input bool) string {
+	if _, ok := e.types[name]; ok {
+		return name
+	}
+	e.types[name] = "" // Reserve the name while rendering nested types
+
+	props, _ := schema["properties"].(map[string]interface{})
+	required := make(map[string]bool)
+	switch r := schema["required"].(type) {
+	case []string:
+		for _, field := range r {
+			required[field] = true
+		}
+	case []interface{}:
+		for _, field := range r {
+			if s, ok := field.(string); ok {
+				required[s] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(props))
+	for prop := range props {
+		names = append(names, prop)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	writeDescription(&b, schema, "")
+	keyword := "type"
+	if input {
+		keyword = "input"
+	}
+	fmt.Fprintf(&b, "%s %s {\n", keyword, name)
+	for _, prop := range names {
+		propSchema, _ := props[prop].(map[string]interface{})
+		fieldType := e.fieldType(name+typeName(prop), propSchema, input)
+		if required[prop] {
+			fieldType += "!"
+		}
+		writeDescription(&b, propSchema, "  ")
+		fmt.Fprintf(&b, "  %s: %s\n", fieldName(prop), fieldType)
+	}
+	if len(names) == 0 {
+		// GraphQL types need at least one field
+		b.WriteString("  _empty: Boolean\n")
+	}
+	b.WriteString("}\n")
+
+	e.types[name] = b.String()
+	return name
+}
+
+// fieldType returns the GraphQL type of a property, defining nested types as needed
+func (e *exporter) fieldType(name string, schema map[string]interface{}, input bool) string {
+	if schema == nil {
+		return e.scalar("object", "")
+	}
+
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		return e.unionType(name, oneOf, input)
+	}
+
+	schemaType, _ := schema["type"].(string)
+	format, _ := schema["format"].(string)
+	switch schemaType {
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		return "[" + e.fieldType(name+"Item", items, input) + "!]"
+	case "object":
+		if _, ok := schema["properties"]; ok {
+			return e.objectType(name, schema, input)
+		}
+	case "string":
+		if enum, ok := schema["enum"].([]interface{}); ok && format == "" {
+			if enumName, ok := e.enumType(name, schema, enum); ok {
+				return enumName
+			}
+		}
+	}
+	return e.scalar(schemaType, format)
+}
+
+// unionType renders a oneOf as a union of object types; inputs cannot be unions and become JSON
+func (e *exporter) unionType(name string, oneOf []interface{}, input bool) string {
+	if input {
+		return e.scalar("object", "")
+	}
+	if _, ok := e.types[name]; ok {
+		return name
+	}
+	members := make([]string, 0, len(oneOf))
+	for i, branch := range oneOf {
+		schema, _ := branch.(map[string]interface{})
+		if _, ok := schema["properties"]; !ok {
+			return e.scalar("object", "")
+		}
+		memberName := fmt.Sprintf("%s%d", name, i+1)
+		if title, ok := schema["title"].(string); ok && title != "" {
+			memberName = typeName(title)
+		}
+		members = append(members, e.objectType(memberName, schema, false))
+	}
+	e.types[name] = fmt.Sprintf("union %s = %s\n", name, strings.Join(members, " | "))
+	return name
+}
+
+// enumType renders a string enum as a GraphQL enum when every value is a valid enum value name
+func (e *exporter) enumType(name string, schema map[string]interface{}, enum []interface{}) (string, bool) {
+	values := make([]string, 0, len(enum))
+	for _, value := range enum {
+		s, ok := value.(string)
+		if !ok || s == "" || fieldName(s) != s || s == "true" || s == "false" || s == "null" {
+			return "", false
+		}
+		values = append(values, s)
+	}
+	if _, ok := e.types[name]; !ok {
+		var b strings.Builder
+		writeDescription(&b, schema, "")
+		fmt.Fprintf(&b, "enum %s {\n", name)
+		for _, value := range values {
+			fmt.Fprintf(&b, "  %s\n", value)
+		}
+		b.WriteString("}\n")
+		e.types[name] = b.String()
+	}
+	return name, true
+}
+
+// scalar resolves the GraphQL scalar of a schema type and format
+func (e *exporter) scalar(schemaType, format string) string {
+	scalar := ""
+	for _, key := range []string{format, schemaType} {
+		if key == "" {
+			continue
+		}
+		if s, ok := e.opts.Scalars[key]; ok {
+			scalar = s
+			break
+		}
+		if s, ok := DefaultScalars[key]; ok {
+			scalar = s
+			break
+		}
+	}
+	if scalar == "" {
+		scalar = "String"
+	}
+	if !builtinScalars[scalar] {
+		e.scalars[scalar] = true
+	}
+	return scalar
+}
+
+// render writes the custom scalars followed by the type definitions, sorted by name
+func (e *exporter) render() string {
+	var b strings.Builder
+
+	scalars := make([]string, 0, len(e.scalars))
+	for scalar := range e.scalars {
+		scalars = append(scalars, scalar)
+	}
+	sort.Strings(scalars)
+	for _, scalar := range scalars {
+		fmt.Fprintf(&b, "scalar %s\n", scalar)
+	}
+
+	names := make([]string, 0, len(e.types))
+	for name := range e.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(e.types[name])
+	}
+	return b.String()
+}
+
+// writeDescription writes a schema description as a GraphQL block string
+func writeDescription(b *strings.Builder, schema map[string]interface{}, indent string) {
+	description, _ := schema["description"].(string)
+	if description == "" {
+		return
+	}
+	description = strings.ReplaceAll(description, `"""`, `\"""`)
+	fmt.Fprintf(b, "%s\"\"\"%s\"\"\"\n", indent, description)
+}
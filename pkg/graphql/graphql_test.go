@@ -0,0 +1,80 @@
+package graphql
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type address struct {
+	City string `json:"city"`
+}
+
+type user struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Nickname  string    `json:"nickname,omitempty"`
+	Roles     []string  `json:"roles"`
+	Status    string    `json:"status" validate:"oneof=active disabled"`
+	Address   address   `json:"address"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type createUser struct {
+	Name string `json:"name"`
+}
+
+// TestExportSDL tests exporting request and response models as GraphQL types
+func TestExportSDL(t *testing.T) {
+	defs := []api.APIDefinition{
+		*api.NewAPIDefinition("POST", "/users", "Create user").
+			WithRequest(createUser{}).
+			WithResponse(user{}),
+		*api.NewAPIDefinition("GET", "/users", "List users").
+			WithResponse([]user{}),
+	}
+
+	sdl, err := ExportSDL(defs, Options{})
+	if err != nil {
+		t.Fatalf("ExportSDL failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"scalar DateTime\n",
+		"input CreateUserInput {\n  name: String!\n}\n",
+		"type User {\n",
+		"  id: String!\n",
+		"  nickname: String\n",
+		"  roles: [String!]!\n",
+		"  address: UserAddress!\n",
+		"  created_at: DateTime!\n",
+		"  status: UserStatus!\n",
+		"enum UserStatus {\n  active\n  disabled\n}\n",
+		"type UserAddress {\n  city: String!\n}\n",
+	} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("Expected SDL to contain %q, got:\n%s", want, sdl)
+		}
+	}
+	if strings.Count(sdl, "type User {") != 1 {
+		t.Errorf("Expected the shared model to be defined once, got:\n%s", sdl)
+	}
+}
+
+// TestExportSDLScalars tests overriding the scalar mapping
+func TestExportSDLScalars(t *testing.T) {
+	defs := []api.APIDefinition{*api.NewAPIDefinition("GET", "/users/{id}", "Get user").WithResponse(user{})}
+
+	sdl, err := ExportSDL(defs, Options{Scalars: map[string]string{"int64": "ID", "date-time": "Time"}})
+	if err != nil {
+		t.Fatalf("ExportSDL failed: %v", err)
+	}
+	if !strings.Contains(sdl, "  id: ID!\n") || !strings.Contains(sdl, "scalar Time\n") {
+		t.Errorf("Expected scalar overrides, got:\n%s", sdl)
+	}
+	if strings.Contains(sdl, "scalar ID") {
+		t.Errorf("Expected built-in scalars not to be declared, got:\n%s", sdl)
+	}
+}
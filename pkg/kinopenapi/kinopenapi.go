@@ -0,0 +1,54 @@
+// Package kinopenapi converts documents between this module and github.com/getkin/kin-openapi,
+// so generated specs can be used with that ecosystem's loaders, validators and request routers
+package kinopenapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// ToOpenAPI3 converts a generated document into a kin-openapi document
+// References are resolved, so the result is ready for openapi3filter and routers
+func ToOpenAPI3(doc *api.OpenAPIDoc) (*openapi3.T, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("document cannot be nil")
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal document: %w", err)
+	}
+	t, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load document into kin-openapi: %w", err)
+	}
+	return t, nil
+}
+
+// FromOpenAPI3 converts a kin-openapi document into this module's document type
+func FromOpenAPI3(t *openapi3.T) (*api.OpenAPIDoc, error) {
+	if t == nil {
+		return nil, fmt.Errorf("document cannot be nil")
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal kin-openapi document: %w", err)
+	}
+	doc := &api.OpenAPIDoc{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal document: %w", err)
+	}
+	return doc, nil
+}
+
+// Validate converts a generated document and runs kin-openapi's semantic validation on it
+func Validate(ctx context.Context, doc *api.OpenAPIDoc, opts ...openapi3.ValidationOption) error {
+	t, err := ToOpenAPI3(doc)
+	if err != nil {
+		return err
+	}
+	return t.Validate(ctx, opts...)
+}
@@ -0,0 +1,124 @@
+package kinopenapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+	ginSwagger "github.com/smartcat999/go-swagger/pkg/gin"
+)
+
+type createUserRequest struct {
+	Username string `json:"username" validate:"required,min=3"`
+	Password string `json:"password" sensitive:"true"`
+}
+
+type userResponse struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+// generatedDoc generates the document of a router with a secured, extended operation and a path parameter
+func generatedDoc(t *testing.T) *api.OpenAPIDoc {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := ginSwagger.NewAPIRouter(gin.New(), "/api", "Test API", "1.0.0", "Test")
+	router.AddBearerAuth("bearerAuth", "JWT token", "JWT")
+
+	noop := func(c *gin.Context) {}
+	defs := []*api.APIDefinition{
+		api.POST("/users", "Create user").
+			WithOperationID("createUser").
+			WithRequest(createUserRequest{}).
+			WithResponse(userResponse{}).
+			WithIdempotencyKey(true).
+			WithSecurity("bearerAuth", []string{}).
+			WithExtension("owner", "identity-team"),
+		api.GET("/users/{id}", "Get user").
+			WithPathParam("id", "User ID", true).
+			WithResponse(userResponse{}),
+	}
+	for _, def := range defs {
+		if err := router.Register(def.WithNativeHandler(noop)); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	return doc
+}
+
+// TestToOpenAPI3 tests converting a generated document into a valid kin-openapi document
+func TestToOpenAPI3(t *testing.T) {
+	doc := generatedDoc(t)
+
+	kinDoc, err := ToOpenAPI3(doc)
+	if err != nil {
+		t.Fatalf("ToOpenAPI3 failed: %v", err)
+	}
+	if err := kinDoc.Validate(context.Background()); err != nil {
+		t.Errorf("Expected a valid kin-openapi document, got %v", err)
+	}
+
+	op := kinDoc.Paths.Find("/users").Post
+	if op == nil {
+		t.Fatal("Expected POST /users in kin-openapi document")
+	}
+	if op.Extensions["x-owner"] != "identity-team" {
+		t.Errorf("Expected x-owner extension, got %v", op.Extensions)
+	}
+	// Shared parameters are resolved through components
+	var idempotency bool
+	for _, param := range op.Parameters {
+		if param.Value != nil && param.Value.Name == "Idempotency-Key" {
+			idempotency = true
+		}
+	}
+	if !idempotency {
+		t.Error("Expected resolved Idempotency-Key parameter")
+	}
+}
+
+// TestRoundTrip tests converting a document to kin-openapi and back without losing operations
+func TestRoundTrip(t *testing.T) {
+	doc := generatedDoc(t)
+
+	kinDoc, err := ToOpenAPI3(doc)
+	if err != nil {
+		t.Fatalf("ToOpenAPI3 failed: %v", err)
+	}
+	back, err := FromOpenAPI3(kinDoc)
+	if err != nil {
+		t.Fatalf("FromOpenAPI3 failed: %v", err)
+	}
+
+	if back.Info.Title != doc.Info.Title || len(back.Paths) != len(doc.Paths) {
+		t.Errorf("Round trip lost document info: %+v", back.Info)
+	}
+	post := back.Paths["/users"].Post
+	if post == nil || post.OperationID != doc.Paths["/users"].Post.OperationID {
+		t.Fatalf("Round trip lost POST /users: %+v", post)
+	}
+	if post.Extensions["x-owner"] != "identity-team" {
+		t.Errorf("Round trip lost extensions: %v", post.Extensions)
+	}
+	if len(post.Parameters) == 0 || post.Parameters[0].Ref == "" {
+		t.Errorf("Round trip lost parameter reference: %+v", post.Parameters)
+	}
+	if err := api.ValidateDoc(back); err != nil {
+		t.Errorf("Round-tripped document is invalid: %v", err)
+	}
+}
+
+// TestValidate tests validating a generated document with kin-openapi
+func TestValidate(t *testing.T) {
+	if err := Validate(context.Background(), generatedDoc(t)); err != nil {
+		t.Errorf("Validate failed: %v", err)
+	}
+	if _, err := ToOpenAPI3(nil); err == nil {
+		t.Error("Expected error for nil document")
+	}
+}
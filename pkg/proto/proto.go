@@ -0,0 +1,294 @@
+// Package proto generates OpenAPI schemas from protobuf message descriptors, so services whose DTOs are
+// defined in .proto files can document them without hand-maintained mirror structs
+//
+// Schemas describe the protojson encoding: fields use their JSON names, 64-bit integers are strings,
+// enums are their value names and well-known types use their canonical JSON form
+package proto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SchemaFromMessage generates the schema of a protobuf message
+func SchemaFromMessage(m proto.Message) (map[string]interface{}, error) {
+	if m == nil {
+		return nil, fmt.Errorf("message cannot be nil")
+	}
+	return SchemaFromDescriptor(m.ProtoReflect().Descriptor())
+}
+
+// SchemaFromDescriptor generates the schema of a protobuf message descriptor
+func SchemaFromDescriptor(md protoreflect.MessageDescriptor) (map[string]interface{}, error) {
+	if md == nil {
+		return nil, fmt.Errorf("message descriptor cannot be nil")
+	}
+	return messageSchema(md, make(map[protoreflect.FullName]bool))
+}
+
+// messageSchema builds the schema of a message, tracking the messages being expanded to break cycles
+func messageSchema(md protoreflect.MessageDescriptor, visiting map[protoreflect.FullName]bool) (map[string]interface{}, error) {
+	if schema, ok := wellKnownSchema(md.FullName()); ok {
+		return schema, nil
+	}
+	if visiting[md.FullName()] {
+		// Recursive messages are left open rather than expanded forever
+		return map[string]interface{}{
+			"type":        "object",
+			"description": fmt.Sprintf("Recursive reference to %s", md.FullName()),
+		}, nil
+	}
+	visiting[md.FullName()] = true
+	defer delete(visiting, md.FullName())
+
+	props := make(map[string]interface{})
+	required := make([]string, 0)
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		schema, err := fieldSchema(fd, visiting)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create schema for field %s: %w", fd.FullName(), err)
+		}
+		if comment := leadingComment(fd); comment != "" {
+			schema["description"] = comment
+		}
+		props[fd.JSONName()] = schema
+		if fd.Cardinality() == protoreflect.Required {
+			required = append(required, fd.JSONName())
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": props,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	if comment := leadingComment(md); comment != "" {
+		schema["description"] = comment
+	}
+
+	// At most one member of a oneof is set: each member becomes an alternative requiring it
+	oneofs := md.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		od := oneofs.Get(i)
+		if od.IsSynthetic() {
+			continue
+		}
+		members := od.Fields()
+		alternatives := make([]interface{}, 0, members.Len())
+		for j := 0; j < members.Len(); j++ {
+			alternatives = append(alternatives, map[string]interface{}{
+				"required": []string{members.Get(j).JSONName()},
+			})
+		}
+		if _, ok := schema["oneOf"]; !ok {
+			schema["oneOf"] = alternatives
+			continue
+		}
+		// Several oneofs in one message must each hold
+		allOf, _ := schema["allOf"].([]interface{})
+		schema["allOf"] = append(allOf, map[string]interface{}{"oneOf": alternatives})
+	}
+
+	return schema, nil
+}
+
+// fieldSchema builds the schema of a field, wrapping repeated fields and maps
+func fieldSchema(fd protoreflect.FieldDescriptor, visiting map[protoreflect.FullName]bool) (map[string]interface{}, error) {
+	if fd.IsMap() {
+		value, err := singularSchema(fd.MapValue(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": value,
+		}, nil
+	}
+
+	schema, err := singularSchema(fd, visiting)
+	if err != nil {
+		return nil, err
+	}
+	if fd.IsList() {
+		return map[string]interface{}{
+			"type":  "array",
+			"items": schema,
+		}, nil
+	}
+	return schema, nil
+}
+
+// singularSchema builds the schema of a single field value
+func singularSchema(fd protoreflect.FieldDescriptor, visiting map[protoreflect.FullName]bool) (map[string]interface{}, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]interface{}{"type": "boolean"}, nil
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]interface{}{
+			"type":   "integer",
+			"format": "int32",
+		}, nil
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]interface{}{
+			"type":    "integer",
+			"format":  "int32",
+			"minimum": 0,
+		}, nil
+
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// protojson encodes 64-bit integers as strings
+		return map[string]interface{}{
+			"type":   "string",
+			"format": "int64",
+		}, nil
+
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]interface{}{
+			"type":   "string",
+			"format": "uint64",
+		}, nil
+
+	case protoreflect.FloatKind:
+		return map[string]interface{}{
+			"type":   "number",
+			"format": "float",
+		}, nil
+
+	case protoreflect.DoubleKind:
+		return map[string]interface{}{
+			"type":   "number",
+			"format": "double",
+		}, nil
+
+	case protoreflect.StringKind:
+		return map[string]interface{}{"type": "string"}, nil
+
+	case protoreflect.BytesKind:
+		return map[string]interface{}{
+			"type":   "string",
+			"format": "byte",
+		}, nil
+
+	case protoreflect.EnumKind:
+		return enumSchema(fd.Enum()), nil
+
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageSchema(fd.Message(), visiting)
+	}
+	return nil, fmt.Errorf("unsupported field kind %s", fd.Kind())
+}
+
+// enumSchema documents an enum by its value names
+func enumSchema(ed protoreflect.EnumDescriptor) map[string]interface{} {
+	if ed.FullName() == "google.protobuf.NullValue" {
+		return map[string]interface{}{"nullable": true}
+	}
+	values := ed.Values()
+	names := make([]interface{}, 0, values.Len())
+	for i := 0; i < values.Len(); i++ {
+		names = append(names, string(values.Get(i).Name()))
+	}
+	schema := map[string]interface{}{
+		"type": "string",
+		"enum": names,
+	}
+	if comment := leadingComment(ed); comment != "" {
+		schema["description"] = comment
+	}
+	return schema
+}
+
+// wellKnownSchema returns the canonical JSON form of the google.protobuf well-known types
+func wellKnownSchema(name protoreflect.FullName) (map[string]interface{}, bool) {
+	switch name {
+	case "google.protobuf.Timestamp":
+		return map[string]interface{}{
+			"type":   "string",
+			"format": "date-time",
+		}, true
+	case "google.protobuf.Duration":
+		return map[string]interface{}{
+			"type":    "string",
+			"pattern": `^-?[0-9]+(\.[0-9]{1,9})?s$`,
+			"example": "1.5s",
+		}, true
+	case "google.protobuf.FieldMask":
+		return map[string]interface{}{"type": "string"}, true
+	case "google.protobuf.Struct":
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": true,
+		}, true
+	case "google.protobuf.Value":
+		return map[string]interface{}{}, true
+	case "google.protobuf.ListValue":
+		return map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{},
+		}, true
+	case "google.protobuf.Empty":
+		return map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		}, true
+	case "google.protobuf.Any":
+		return map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"@type": map[string]interface{}{"type": "string"},
+			},
+			"required":             []string{"@type"},
+			"additionalProperties": true,
+		}, true
+	}
+
+	// Wrapper types encode as their nullable wrapped value
+	wrappers := map[protoreflect.FullName]map[string]interface{}{
+		"google.protobuf.BoolValue":   {"type": "boolean"},
+		"google.protobuf.Int32Value":  {"type": "integer", "format": "int32"},
+		"google.protobuf.UInt32Value": {"type": "integer", "format": "int32", "minimum": 0},
+		"google.protobuf.Int64Value":  {"type": "string", "format": "int64"},
+		"google.protobuf.UInt64Value": {"type": "string", "format": "uint64"},
+		"google.protobuf.FloatValue":  {"type": "number", "format": "float"},
+		"google.protobuf.DoubleValue": {"type": "number", "format": "double"},
+		"google.protobuf.StringValue": {"type": "string"},
+		"google.protobuf.BytesValue":  {"type": "string", "format": "byte"},
+	}
+	if schema, ok := wrappers[name]; ok {
+		schema["nullable"] = true
+		return schema, true
+	}
+	return nil, false
+}
+
+// leadingComment returns the .proto comment attached to a declaration, when source info is available
+func leadingComment(d protoreflect.Descriptor) string {
+	file := d.ParentFile()
+	if file == nil {
+		return ""
+	}
+	return strings.TrimSpace(file.SourceLocations().ByDescriptor(d).LeadingComments)
+}
+
+// Register declares the schemas of protobuf messages for their generated Go types,
+// so the messages can be passed to WithRequest, WithResponse and nested in other models
+func Register(messages ...proto.Message) error {
+	for _, m := range messages {
+		schema, err := SchemaFromMessage(m)
+		if err != nil {
+			return err
+		}
+		api.RegisterTypeSchema(m, schema)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package proto
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testMessage builds the descriptor of:
+//
+//	enum Status { STATUS_UNKNOWN = 0; STATUS_ACTIVE = 1; }
+//	message User {
+//	  int64 id = 1;
+//	  string display_name = 2;
+//	  repeated string roles = 3;
+//	  map<string, int32> quotas = 4;
+//	  Status status = 5;
+//	  google.protobuf.Timestamp created_at = 6;
+//	  oneof contact { string email = 7; string phone = 8; }
+//	  User manager = 9;
+//	}
+func testMessage(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	label := func(l descriptorpb.FieldDescriptorProto_Label) *descriptorpb.FieldDescriptorProto_Label { return &l }
+	kind := func(k descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto_Type { return &k }
+	optional := label(descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL)
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{Name: proto.String(name), Number: proto.Int32(number), Label: optional, Type: kind(typ)}
+	}
+
+	roles := field("roles", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	roles.Label = label(descriptorpb.FieldDescriptorProto_LABEL_REPEATED)
+	quotas := field("quotas", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)
+	quotas.Label = label(descriptorpb.FieldDescriptorProto_LABEL_REPEATED)
+	quotas.TypeName = proto.String(".test.User.QuotasEntry")
+	status := field("status", 5, descriptorpb.FieldDescriptorProto_TYPE_ENUM)
+	status.TypeName = proto.String(".test.Status")
+	createdAt := field("created_at", 6, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)
+	createdAt.TypeName = proto.String(".google.protobuf.Timestamp")
+	email := field("email", 7, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	email.OneofIndex = proto.Int32(0)
+	phone := field("phone", 8, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	phone.OneofIndex = proto.Int32(0)
+	manager := field("manager", 9, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)
+	manager.TypeName = proto.String(".test.User")
+
+	file := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("test/user.proto"),
+		Package:    proto.String("test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{timestamppb.File_google_protobuf_timestamp_proto.Path()},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Status"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("STATUS_UNKNOWN"), Number: proto.Int32(0)},
+				{Name: proto.String("STATUS_ACTIVE"), Number: proto.Int32(1)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("User"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+				field("display_name", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+				roles, quotas, status, createdAt, email, phone, manager,
+			},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("QuotasEntry"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+				},
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			}},
+			OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("contact")}},
+		}},
+	}
+
+	fd, err := protodesc.NewFile(file, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("NewFile failed: %v", err)
+	}
+	return fd.Messages().ByName("User")
+}
+
+// TestSchemaFromDescriptor tests the schema of a message with scalars, collections, enums and oneofs
+func TestSchemaFromDescriptor(t *testing.T) {
+	schema, err := SchemaFromDescriptor(testMessage(t))
+	if err != nil {
+		t.Fatalf("SchemaFromDescriptor failed: %v", err)
+	}
+	props := schema["properties"].(map[string]interface{})
+
+	tests := []struct {
+		field string
+		want  map[string]interface{}
+	}{
+		{"id", map[string]interface{}{"type": "string", "format": "int64"}},
+		{"displayName", map[string]interface{}{"type": "string"}},
+		{"roles", map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}},
+		{"quotas", map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": map[string]interface{}{"type": "integer", "format": "int32"},
+		}},
+		{"status", map[string]interface{}{"type": "string", "enum": []interface{}{"STATUS_UNKNOWN", "STATUS_ACTIVE"}}},
+		{"createdAt", map[string]interface{}{"type": "string", "format": "date-time"}},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(props[tt.field], tt.want) {
+			t.Errorf("Field %s: expected %v, got %v", tt.field, tt.want, props[tt.field])
+		}
+	}
+
+	want := []interface{}{
+		map[string]interface{}{"required": []string{"email"}},
+		map[string]interface{}{"required": []string{"phone"}},
+	}
+	if !reflect.DeepEqual(schema["oneOf"], want) {
+		t.Errorf("Unexpected oneOf %v", schema["oneOf"])
+	}
+
+	manager := props["manager"].(map[string]interface{})
+	if manager["type"] != "object" || manager["properties"] != nil {
+		t.Errorf("Expected recursive reference to stay open, got %v", manager)
+	}
+}
+
+// TestSchemaFromMessage tests well-known types passed as messages
+func TestSchemaFromMessage(t *testing.T) {
+	schema, err := SchemaFromMessage(&timestamppb.Timestamp{})
+	if err != nil {
+		t.Fatalf("SchemaFromMessage failed: %v", err)
+	}
+	if schema["format"] != "date-time" {
+		t.Errorf("Expected date-time, got %v", schema)
+	}
+	if _, err := SchemaFromMessage(nil); err == nil {
+		t.Error("Expected error for nil message")
+	}
+}
+
+// TestRegister tests that registered messages are documented by their protojson form
+func TestRegister(t *testing.T) {
+	if err := Register(&timestamppb.Timestamp{}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	schema, err := api.SafeSchemaFromStruct(&timestamppb.Timestamp{})
+	if err != nil {
+		t.Fatalf("SafeSchemaFromStruct failed: %v", err)
+	}
+	if schema["format"] != "date-time" {
+		t.Errorf("Expected registered schema, got %v", schema)
+	}
+}
@@ -0,0 +1,147 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Annotations set on Backstage API entities by the Backstage publisher
+const (
+	AnnotationSpecVersion = "go-swagger/spec-version"
+	AnnotationSpecDigest  = "go-swagger/spec-digest"
+	AnnotationSpecURL     = "go-swagger/spec-url"
+)
+
+// backstagePublisher records published documents in a Backstage catalog-info.yaml
+type backstagePublisher struct {
+	path    string
+	entity  string
+	specURL string
+}
+
+// NewBackstagePublisher updates the annotations of the API entity named entity (any API entity when empty) in
+// the catalog-info.yaml at path with the document's version, digest and, when specURL is set, its URL
+// Other content and comments of the file are kept
+func NewBackstagePublisher(path, entity, specURL string) Publisher {
+	return backstagePublisher{path: path, entity: entity, specURL: specURL}
+}
+
+// Publish implements Publisher
+func (p backstagePublisher) Publish(_ context.Context, spec Spec) error {
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return fmt.Errorf("failed to read catalog: %w", err)
+	}
+	annotations := []string{AnnotationSpecVersion, spec.Version, AnnotationSpecDigest, spec.Digest}
+	if p.specURL != "" {
+		annotations = append(annotations, AnnotationSpecURL, Expand(p.specURL, spec))
+	}
+	updated, err := annotateCatalog(data, p.entity, annotations)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", p.path, err)
+	}
+	return os.WriteFile(p.path, updated, 0o644)
+}
+
+// annotateCatalog sets the key/value annotations on the matching API entities of a catalog file
+func annotateCatalog(data []byte, entity string, annotations []string) ([]byte, error) {
+	var docs []*yaml.Node
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		docs = append(docs, &doc)
+	}
+
+	matched := false
+	for _, doc := range docs {
+		if len(doc.Content) == 0 {
+			continue
+		}
+		root := doc.Content[0]
+		metadata := mappingValue(root, "metadata")
+		if scalarValue(root, "kind") != "API" || metadata == nil {
+			continue
+		}
+		if entity != "" && scalarValue(metadata, "name") != entity {
+			continue
+		}
+		matched = true
+		values := mappingValue(metadata, "annotations")
+		if values == nil {
+			values = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			metadata.Content = append(metadata.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "annotations"}, values)
+		}
+		for i := 0; i+1 < len(annotations); i += 2 {
+			setScalar(values, annotations[i], annotations[i+1])
+		}
+	}
+	if !matched {
+		if entity != "" {
+			return nil, fmt.Errorf("API entity %q not found", entity)
+		}
+		return nil, errors.New("no API entity found")
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	for _, doc := range docs {
+		if err := encoder.Encode(doc); err != nil {
+			return nil, err
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mappingValue returns the value of key in a mapping node, nil when absent or not a mapping
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key && node.Content[i+1].Kind == yaml.MappingNode {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// scalarValue returns the scalar value of key in a mapping node, "" when absent
+func scalarValue(node *yaml.Node, key string) string {
+	if node.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key && node.Content[i+1].Kind == yaml.ScalarNode {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// setScalar sets key to a string value in a mapping node, replacing any existing value
+func setScalar(node *yaml.Node, key, value string) {
+	scalar := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Style: yaml.DoubleQuotedStyle}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = scalar
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, scalar)
+}
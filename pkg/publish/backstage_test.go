@@ -0,0 +1,111 @@
+package publish
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const catalogInfo = `# Service catalog
+apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: orders
+spec:
+  type: service
+---
+apiVersion: backstage.io/v1alpha1
+kind: API
+metadata:
+  name: orders-api
+  annotations:
+    github.com/project-slug: acme/orders
+    go-swagger/spec-version: "1.0.0"
+spec:
+  type: openapi # keep this comment
+---
+apiVersion: backstage.io/v1alpha1
+kind: API
+metadata:
+  name: billing-api
+spec:
+  type: openapi
+`
+
+// TestBackstagePublisher tests annotating API entities of a catalog file
+func TestBackstagePublisher(t *testing.T) {
+	tests := []struct {
+		name      string
+		entity    string
+		wantErr   bool
+		annotated []string // API entities expected to carry the annotations
+	}{
+		{"named entity", "orders-api", false, []string{"orders-api"}},
+		{"every API entity", "", false, []string{"orders-api", "billing-api"}},
+		{"unknown entity", "payments-api", true, nil},
+		{"component is not an API", "orders", true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "catalog-info.yaml")
+			if err := os.WriteFile(path, []byte(catalogInfo), 0o644); err != nil {
+				t.Fatalf("Failed to write the catalog: %v", err)
+			}
+			err := NewBackstagePublisher(path, tt.entity, "https://specs.example.com/{version}.json").Publish(context.Background(), testSpec())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			data, _ := os.ReadFile(path)
+			if tt.wantErr {
+				if string(data) != catalogInfo {
+					t.Error("Expected the catalog to be unchanged on failure")
+				}
+				return
+			}
+			if !strings.Contains(string(data), "# keep this comment") || !strings.Contains(string(data), "# Service catalog") {
+				t.Errorf("Expected the comments to be kept, got:\n%s", data)
+			}
+
+			annotations := catalogAnnotations(t, data)
+			for _, name := range tt.annotated {
+				got := annotations[name]
+				if got[AnnotationSpecVersion] != "1.2.0" || got[AnnotationSpecDigest] != "sha-256=abc" ||
+					got[AnnotationSpecURL] != "https://specs.example.com/1.2.0.json" {
+					t.Errorf("Expected %s to carry the spec annotations, got %v", name, got)
+				}
+			}
+			if annotations["orders-api"]["github.com/project-slug"] != "acme/orders" {
+				t.Errorf("Expected the existing annotations to be kept, got %v", annotations["orders-api"])
+			}
+			if len(tt.annotated) == 1 && len(annotations["billing-api"]) != 0 {
+				t.Errorf("Expected no billing-api annotations, got %v", annotations["billing-api"])
+			}
+		})
+	}
+}
+
+// catalogAnnotations returns the annotations of each entity of a catalog file by name
+func catalogAnnotations(t *testing.T, data []byte) map[string]map[string]string {
+	t.Helper()
+	type entity struct {
+		Metadata struct {
+			Name        string            `yaml:"name"`
+			Annotations map[string]string `yaml:"annotations"`
+		} `yaml:"metadata"`
+	}
+	annotations := make(map[string]map[string]string)
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	for {
+		var e entity
+		if err := decoder.Decode(&e); err != nil {
+			break
+		}
+		annotations[e.Metadata.Name] = e.Metadata.Annotations
+	}
+	return annotations
+}
@@ -0,0 +1,186 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Store writes objects to Amazon S3 or an S3-compatible service with SigV4-signed PUT requests
+type S3Store struct {
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+	endpoint        string
+	client          *http.Client
+	now             func() time.Time
+}
+
+// NewS3Store creates a store for buckets in region, authenticated with a static access key
+func NewS3Store(region, accessKeyID, secretAccessKey string) *S3Store {
+	return &S3Store{
+		region:          region,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		endpoint:        fmt.Sprintf("https://s3.%s.amazonaws.com", region),
+		client:          newClient(),
+		now:             time.Now,
+	}
+}
+
+// WithSessionToken sets the session token of temporary credentials
+// Chain call: publish.NewS3Store(region, key, secret).WithSessionToken(token)
+func (s *S3Store) WithSessionToken(token string) *S3Store {
+	s.sessionToken = token
+	return s
+}
+
+// WithEndpoint sets the service endpoint, e.g. for MinIO; buckets are addressed path-style
+// Chain call: publish.NewS3Store(region, key, secret).WithEndpoint("https://minio.internal:9000")
+func (s *S3Store) WithEndpoint(endpoint string) *S3Store {
+	s.endpoint = strings.TrimSuffix(endpoint, "/")
+	return s
+}
+
+// WithClient sets the HTTP client, one with a DefaultTimeout timeout by default
+// Chain call: publish.NewS3Store(region, key, secret).WithClient(client)
+func (s *S3Store) WithClient(client *http.Client) *S3Store {
+	s.client = client
+	return s
+}
+
+// PutObject implements ObjectStore
+func (s *S3Store) PutObject(ctx context.Context, bucket, key string, data []byte, contentType string) error {
+	target := s.endpoint + "/" + s3Escape(bucket) + "/" + s3Escape(key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if s.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+	s.sign(req, data, s.now().UTC())
+	return do(s.client, req)
+}
+
+// sign adds the SigV4 headers and Authorization header of the S3 service, covering the host and every
+// header set on the request
+func (s *S3Store) sign(req *http.Request, payload []byte, now time.Time) {
+	payloadHash := sha256.Sum256(payload)
+	amzDate := now.Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := amzDate[:8] + "/" + s.region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + s.secretAccessKey)
+	for _, part := range []string{amzDate[:8], s.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Escape encodes an object key for the request path, keeping only unreserved characters and slashes
+// as the SigV4 canonical URI requires
+func s3Escape(key string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if c == '/' || c == '-' || c == '_' || c == '.' || c == '~' ||
+			('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') {
+			escaped.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&escaped, "%%%02X", c)
+	}
+	return escaped.String()
+}
+
+// TokenSource returns an OAuth2 access token, e.g. from golang.org/x/oauth2/google credentials
+type TokenSource func(ctx context.Context) (string, error)
+
+// GCSStore writes objects to Google Cloud Storage through the JSON API media upload
+type GCSStore struct {
+	token    TokenSource
+	endpoint string
+	client   *http.Client
+}
+
+// NewGCSStore creates a store authenticated with the access tokens of token
+func NewGCSStore(token TokenSource) *GCSStore {
+	return &GCSStore{token: token, endpoint: "https://storage.googleapis.com", client: newClient()}
+}
+
+// WithEndpoint sets the service endpoint, e.g. for a storage emulator
+// Chain call: publish.NewGCSStore(token).WithEndpoint("http://localhost:4443")
+func (s *GCSStore) WithEndpoint(endpoint string) *GCSStore {
+	s.endpoint = strings.TrimSuffix(endpoint, "/")
+	return s
+}
+
+// WithClient sets the HTTP client, one with a DefaultTimeout timeout by default
+// Chain call: publish.NewGCSStore(token).WithClient(client)
+func (s *GCSStore) WithClient(client *http.Client) *GCSStore {
+	s.client = client
+	return s
+}
+
+// PutObject implements ObjectStore
+func (s *GCSStore) PutObject(ctx context.Context, bucket, key string, data []byte, contentType string) error {
+	token, err := s.token(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get access token: %w", err)
+	}
+	query := url.Values{"uploadType": {"media"}, "name": {key}}
+	target := s.endpoint + "/upload/storage/v1/b/" + url.PathEscape(bucket) + "/o?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return do(s.client, req)
+}
@@ -0,0 +1,196 @@
+// Package publish ships generated OpenAPI documents to where their consumers read them: files, object
+// storage, HTTP endpoints and Backstage catalogs
+package publish
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds the uploads of publishers and stores created without an explicit client
+const DefaultTimeout = 30 * time.Second
+
+// Spec is a generated document handed to publishers
+type Spec struct {
+	Title     string
+	Version   string
+	Digest    string // SHA-256 digest of Document, formatted as sha-256=<base64>
+	Signature []byte // Base64 detached signature of Document, nil when signing is disabled
+	Document  []byte // Serialized document
+}
+
+// Publisher publishes a generated document
+type Publisher interface {
+	Publish(ctx context.Context, spec Spec) error
+}
+
+// PublisherFunc adapts a function to Publisher
+type PublisherFunc func(ctx context.Context, spec Spec) error
+
+// Publish implements Publisher
+func (f PublisherFunc) Publish(ctx context.Context, spec Spec) error {
+	return f(ctx, spec)
+}
+
+// Expand replaces the {title} and {version} placeholders of a path, key or URL pattern
+func Expand(pattern string, spec Spec) string {
+	title := strings.ToLower(strings.Join(strings.Fields(spec.Title), "-"))
+	return strings.NewReplacer("{title}", title, "{version}", spec.Version).Replace(pattern)
+}
+
+// filePublisher writes documents to the filesystem
+type filePublisher struct {
+	path string
+}
+
+// NewFilePublisher writes the document to path (e.g., "specs/{version}/openapi.json"), creating missing
+// directories; the file is replaced atomically so readers never see a partial document
+func NewFilePublisher(path string) Publisher {
+	return filePublisher{path: path}
+}
+
+// Publish implements Publisher
+func (p filePublisher) Publish(_ context.Context, spec Spec) error {
+	path := Expand(p.path, spec)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(spec.Document); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// HTTPPublisher uploads documents with an HTTP PUT, e.g. to an artifact registry or a presigned URL
+type HTTPPublisher struct {
+	url    string
+	header http.Header
+	client *http.Client
+}
+
+// NewHTTPPublisher creates a publisher PUTting the document to url (placeholders are expanded)
+func NewHTTPPublisher(url string) *HTTPPublisher {
+	return &HTTPPublisher{url: url, header: make(http.Header), client: newClient()}
+}
+
+// WithHeader sets a request header, e.g. Authorization
+// Chain call: publish.NewHTTPPublisher(url).WithHeader("Authorization", "Bearer "+token)
+func (p *HTTPPublisher) WithHeader(key, value string) *HTTPPublisher {
+	p.header.Set(key, value)
+	return p
+}
+
+// WithClient sets the HTTP client, one with a DefaultTimeout timeout by default
+// Chain call: publish.NewHTTPPublisher(url).WithClient(&http.Client{Timeout: 10 * time.Second})
+func (p *HTTPPublisher) WithClient(client *http.Client) *HTTPPublisher {
+	p.client = client
+	return p
+}
+
+// Publish implements Publisher
+func (p *HTTPPublisher) Publish(ctx context.Context, spec Spec) error {
+	url := Expand(p.url, spec)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(spec.Document))
+	if err != nil {
+		return fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+	for key, values := range p.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Spec-Digest", spec.Digest)
+	if spec.Signature != nil {
+		req.Header.Set("X-Spec-Signature", string(spec.Signature))
+	}
+
+	if err := do(p.client, req); err != nil {
+		return fmt.Errorf("failed to upload to %s: %w", url, err)
+	}
+	return nil
+}
+
+// newClient returns the HTTP client used when none is configured
+func newClient() *http.Client {
+	return &http.Client{Timeout: DefaultTimeout}
+}
+
+// do sends an upload request and turns non-2xx responses into errors carrying the start of the body
+func do(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// ObjectStore writes objects to a bucket; S3Store and GCSStore implement it for Amazon S3 and Google Cloud
+// Storage, and other clients plug in with a PutObject method
+type ObjectStore interface {
+	PutObject(ctx context.Context, bucket, key string, data []byte, contentType string) error
+}
+
+// objectPublisher writes documents to object storage
+type objectPublisher struct {
+	store  ObjectStore
+	bucket string
+	key    string
+}
+
+// NewObjectPublisher writes the document to bucket at key (e.g., "apis/{title}/{version}.json"), and its
+// signature next to it at key.sig when the document is signed
+func NewObjectPublisher(store ObjectStore, bucket, key string) Publisher {
+	return objectPublisher{store: store, bucket: bucket, key: key}
+}
+
+// Publish implements Publisher
+func (p objectPublisher) Publish(ctx context.Context, spec Spec) error {
+	key := Expand(p.key, spec)
+	if err := p.store.PutObject(ctx, p.bucket, key, spec.Document, "application/json"); err != nil {
+		return fmt.Errorf("failed to put %s/%s: %w", p.bucket, key, err)
+	}
+	if spec.Signature != nil {
+		if err := p.store.PutObject(ctx, p.bucket, key+".sig", spec.Signature, "text/plain"); err != nil {
+			return fmt.Errorf("failed to put %s/%s.sig: %w", p.bucket, key, err)
+		}
+	}
+	return nil
+}
+
+// PublishAll runs every publisher, continuing past failures, and returns their joined errors
+func PublishAll(ctx context.Context, spec Spec, publishers ...Publisher) error {
+	var errs []error
+	for _, publisher := range publishers {
+		if err := publisher.Publish(ctx, spec); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,156 @@
+package publish
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testSpec returns the spec published by the tests
+func testSpec() Spec {
+	return Spec{
+		Title:     "Order Service",
+		Version:   "1.2.0",
+		Digest:    "sha-256=abc",
+		Signature: []byte("c2ln"),
+		Document:  []byte(`{"openapi":"3.0.0"}`),
+	}
+}
+
+// TestExpand tests the placeholders of publish targets
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"specs/{version}/openapi.json", "specs/1.2.0/openapi.json"},
+		{"apis/{title}/{version}.json", "apis/order-service/1.2.0.json"},
+		{"openapi.json", "openapi.json"},
+	}
+
+	for _, tt := range tests {
+		if got := Expand(tt.pattern, testSpec()); got != tt.want {
+			t.Errorf("Expected %q to expand to %q, got %q", tt.pattern, tt.want, got)
+		}
+	}
+}
+
+// TestFilePublisher tests writing documents to versioned files
+func TestFilePublisher(t *testing.T) {
+	dir := t.TempDir()
+	publisher := NewFilePublisher(filepath.Join(dir, "{version}", "openapi.json"))
+	if err := publisher.Publish(context.Background(), testSpec()); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "1.2.0", "openapi.json"))
+	if err != nil {
+		t.Fatalf("Failed to read the published file: %v", err)
+	}
+	if string(data) != string(testSpec().Document) {
+		t.Errorf("Expected published file %s, got %s", testSpec().Document, data)
+	}
+	entries, _ := os.ReadDir(filepath.Join(dir, "1.2.0"))
+	if len(entries) != 1 {
+		t.Errorf("Expected only the document in the directory, got %d files", len(entries))
+	}
+}
+
+// TestHTTPPublisher tests uploading documents with PUT
+func TestHTTPPublisher(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{"created", http.StatusCreated, false},
+		{"rejected", http.StatusForbidden, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := NewHTTPPublisher(server.URL+"/specs/{version}").
+				WithHeader("Authorization", "Bearer token").
+				Publish(context.Background(), testSpec())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if got.Method != http.MethodPut || got.URL.Path != "/specs/1.2.0" {
+				t.Errorf("Expected PUT /specs/1.2.0, got %s %s", got.Method, got.URL.Path)
+			}
+			if got.Header.Get("Authorization") != "Bearer token" || got.Header.Get("X-Spec-Digest") != "sha-256=abc" {
+				t.Errorf("Expected the authorization and digest headers, got %v", got.Header)
+			}
+			if string(body) != string(testSpec().Document) {
+				t.Errorf("Expected the document as body, got %s", body)
+			}
+		})
+	}
+
+	if timeout := NewHTTPPublisher("https://registry.example.com").client.Timeout; timeout != DefaultTimeout {
+		t.Errorf("Expected default client timeout %v, got %v", DefaultTimeout, timeout)
+	}
+}
+
+// memoryStore is an ObjectStore keeping objects in a map
+type memoryStore map[string]string
+
+func (s memoryStore) PutObject(_ context.Context, bucket, key string, data []byte, _ string) error {
+	if bucket == "missing" {
+		return errors.New("no such bucket")
+	}
+	s[bucket+"/"+key] = string(data)
+	return nil
+}
+
+// TestObjectPublisher tests writing documents and signatures to object storage
+func TestObjectPublisher(t *testing.T) {
+	store := memoryStore{}
+	if err := NewObjectPublisher(store, "contracts", "apis/{title}/{version}.json").Publish(context.Background(), testSpec()); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if store["contracts/apis/order-service/1.2.0.json"] != string(testSpec().Document) {
+		t.Errorf("Expected the document to be stored, got %v", store)
+	}
+	if store["contracts/apis/order-service/1.2.0.json.sig"] != "c2ln" {
+		t.Errorf("Expected the signature to be stored, got %v", store)
+	}
+
+	err := NewObjectPublisher(store, "missing", "openapi.json").Publish(context.Background(), testSpec())
+	if err == nil || !strings.Contains(err.Error(), "missing/openapi.json") {
+		t.Errorf("Expected an error naming the object, got %v", err)
+	}
+}
+
+// TestPublishAll tests that every publisher runs and failures are joined
+func TestPublishAll(t *testing.T) {
+	calls := 0
+	ok := PublisherFunc(func(context.Context, Spec) error { calls++; return nil })
+	failing := PublisherFunc(func(context.Context, Spec) error { calls++; return errors.New("unavailable") })
+
+	if err := PublishAll(context.Background(), testSpec(), ok, ok); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	calls = 0
+	err := PublishAll(context.Background(), testSpec(), failing, ok, failing)
+	if err == nil || strings.Count(err.Error(), "unavailable") != 2 {
+		t.Errorf("Expected both failures, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 publishers to run, got %d", calls)
+	}
+}
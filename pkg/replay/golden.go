@@ -0,0 +1,205 @@
+// Package replay guards backwards compatibility with recorded traffic: a Recorder samples live requests per
+// operation into golden files, and Run replays them against the current build, checking that requests
+// accepted before are still accepted and that responses still match the schemas documented when recorded
+//
+//	rec := replay.NewRecorder(doc, replay.Options{})
+//	http.ListenAndServe(":8080", rec.Wrap(engine))
+//	...
+//	rec.WriteGolden("testdata/golden")
+//
+//	func TestBackwardsCompatibility(t *testing.T) {
+//		replay.Run(t, engine, "testdata/golden", replay.Options{})
+//	}
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// goldenSuffix is the file name suffix of golden files
+const goldenSuffix = ".golden.json"
+
+// Golden holds the traffic samples of one operation along with the response schemas documented when they
+// were recorded, so later versions are checked against the contract clients were built for
+type Golden struct {
+	OperationID string                            `json:"operationId"`
+	Method      string                            `json:"method"`
+	Path        string                            `json:"path"`
+	Responses   map[string]map[string]interface{} `json:"responses,omitempty"` // JSON response schemas keyed by status
+	Samples     []Sample                          `json:"samples"`
+}
+
+// Sample is a recorded request and the response it received
+type Sample struct {
+	Target   string            `json:"target"` // Request URI including the query
+	Header   map[string]string `json:"header,omitempty"`
+	Body     json.RawMessage   `json:"body,omitempty"`
+	Status   int               `json:"status"`
+	Response json.RawMessage   `json:"response,omitempty"`
+	Redacted bool              `json:"redacted,omitempty"` // Whether request values were masked, so acceptance isn't asserted
+}
+
+// Options configures recording and replay
+type Options struct {
+	SampleRate      float64             // Fraction of requests recorded, between 0 and 1 (default 1)
+	MaxPerOperation int                 // Number of samples kept per operation (default 5)
+	RedactFields    []string            // JSON properties masked in recorded bodies (default DefaultRedactedFields)
+	ExcludeHeaders  []string            // Request headers not recorded (default DefaultExcludedHeaders)
+	MaxBodyBytes    int                 // Exchanges with larger bodies are not recorded (default 64KB)
+	Prepare         func(*http.Request) // Decorates replayed requests, e.g. with credentials
+}
+
+// DefaultRedactedFields lists JSON properties masked in golden files
+var DefaultRedactedFields = []string{"password", "token", "secret", "authorization", "api_key", "apikey"}
+
+// DefaultExcludedHeaders lists request headers left out of golden files
+var DefaultExcludedHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "X-Api-Key"}
+
+func (o Options) withDefaults() Options {
+	if o.SampleRate <= 0 {
+		o.SampleRate = 1
+	}
+	if o.MaxPerOperation <= 0 {
+		o.MaxPerOperation = 5
+	}
+	if o.RedactFields == nil {
+		o.RedactFields = DefaultRedactedFields
+	}
+	if o.ExcludeHeaders == nil {
+		o.ExcludeHeaders = DefaultExcludedHeaders
+	}
+	if o.MaxBodyBytes <= 0 {
+		o.MaxBodyBytes = 64 << 10
+	}
+	return o
+}
+
+// ReadGolden loads every golden file of a directory, sorted by operationId
+func ReadGolden(dir string) ([]Golden, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+goldenSuffix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list golden files: %w", err)
+	}
+	sort.Strings(files)
+
+	goldens := make([]Golden, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read golden file: %w", err)
+		}
+		var golden Golden
+		if err := json.Unmarshal(data, &golden); err != nil {
+			return nil, fmt.Errorf("failed to decode golden file %s: %w", filepath.Base(file), err)
+		}
+		goldens = append(goldens, golden)
+	}
+	return goldens, nil
+}
+
+// WriteGolden writes one <operationId>.golden.json file per golden into dir
+func WriteGolden(dir string, goldens []Golden) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create golden directory: %w", err)
+	}
+	for _, golden := range goldens {
+		data, err := json.MarshalIndent(golden, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal golden file %s: %w", golden.OperationID, err)
+		}
+		name := invalidFileChars.ReplaceAllString(golden.OperationID, "_") + goldenSuffix
+		if err := os.WriteFile(filepath.Join(dir, name), append(data, '\n'), 0o644); err != nil {
+			return fmt.Errorf("failed to write golden file %s: %w", golden.OperationID, err)
+		}
+	}
+	return nil
+}
+
+var invalidFileChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// operation is a documented operation the recorder matches requests against
+type operation struct {
+	id        string
+	method    string
+	path      string
+	pattern   *regexp.Regexp
+	params    int
+	responses map[string]map[string]interface{}
+}
+
+var pathParamPattern = regexp.MustCompile(`\{[^}]+\}`)
+
+// documentOperations lists the operations of a document, most specific paths first
+func documentOperations(doc *api.OpenAPIDoc) []operation {
+	base := basePath(doc)
+	var ops []operation
+	for path, item := range doc.Paths {
+		literals := pathParamPattern.Split(base+path, -1)
+		for i, literal := range literals {
+			literals[i] = regexp.QuoteMeta(literal)
+		}
+		pattern := "^" + strings.Join(literals, "[^/]+") + "$"
+		for _, method := range api.SupportedMethods {
+			op := item.Operation(method)
+			if op == nil {
+				continue
+			}
+			id := op.OperationID
+			if id == "" {
+				id = strings.ToLower(method) + "_" + strings.Trim(invalidFileChars.ReplaceAllString(path, "_"), "_")
+			}
+			ops = append(ops, operation{
+				id:        id,
+				method:    method,
+				path:      path,
+				pattern:   regexp.MustCompile(pattern),
+				params:    strings.Count(path, "{"),
+				responses: responseSchemas(op),
+			})
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].params != ops[j].params {
+			return ops[i].params < ops[j].params
+		}
+		return ops[i].path+ops[i].method < ops[j].path+ops[j].method
+	})
+	return ops
+}
+
+// responseSchemas collects the JSON response schemas of an operation, translated to JSON Schema
+func responseSchemas(op *api.Operation) map[string]map[string]interface{} {
+	schemas := make(map[string]map[string]interface{})
+	for status, resp := range op.Responses {
+		if content, ok := resp.Content["application/json"]; ok && content.Schema != nil {
+			schemas[status] = api.ToJSONSchema(content.Schema)
+		}
+	}
+	return schemas
+}
+
+// basePath returns the path of the first server URL, e.g. /api
+func basePath(doc *api.OpenAPIDoc) string {
+	if len(doc.Servers) == 0 {
+		return ""
+	}
+	url := doc.Servers[0].URL
+	if i := strings.Index(url, "://"); i >= 0 {
+		url = url[i+3:]
+		if j := strings.Index(url, "/"); j >= 0 {
+			url = url[j:]
+		} else {
+			url = ""
+		}
+	}
+	return strings.TrimRight(url, "/")
+}
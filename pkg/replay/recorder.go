@@ -0,0 +1,205 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// Recorder samples successful JSON exchanges per documented operation for golden files
+type Recorder struct {
+	opts       Options
+	operations []operation
+	mu         sync.Mutex
+	samples    map[string][]Sample // Keyed by operationId
+}
+
+// NewRecorder creates a recorder matching requests against the operations of a generated document
+func NewRecorder(doc *api.OpenAPIDoc, opts Options) *Recorder {
+	return &Recorder{
+		opts:       opts.withDefaults(),
+		operations: documentOperations(doc),
+		samples:    make(map[string][]Sample),
+	}
+}
+
+// Wrap returns a handler recording the traffic served by next
+func (rec *Recorder) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op := rec.match(r)
+		if op == nil || rand.Float64() >= rec.opts.SampleRate {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var body []byte
+		if r.Body != nil {
+			data, err := io.ReadAll(io.LimitReader(r.Body, int64(rec.opts.MaxBodyBytes)+1))
+			// Restore what was read, followed by whatever remains unread
+			r.Body = readCloser{io.MultiReader(bytes.NewReader(data), r.Body), r.Body}
+			if err != nil || len(data) > rec.opts.MaxBodyBytes {
+				next.ServeHTTP(w, r)
+				return
+			}
+			body = data
+		}
+
+		tee := &teeWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(tee, r)
+
+		if tee.status < 200 || tee.status >= 300 || tee.body.Len() > rec.opts.MaxBodyBytes {
+			return
+		}
+		sample := Sample{
+			Target: r.URL.RequestURI(),
+			Header: rec.headers(r.Header),
+			Status: tee.status,
+		}
+		sample.Body, sample.Redacted = redactJSON(body, rec.opts.RedactFields)
+		sample.Response, _ = redactJSON(tee.body.Bytes(), rec.opts.RedactFields)
+		rec.add(op.id, sample)
+	})
+}
+
+// match finds the documented operation of a request
+func (rec *Recorder) match(r *http.Request) *operation {
+	for i := range rec.operations {
+		op := &rec.operations[i]
+		if op.method == r.Method && op.pattern.MatchString(r.URL.Path) {
+			return op
+		}
+	}
+	return nil
+}
+
+// headers keeps the request headers worth replaying
+func (rec *Recorder) headers(header http.Header) map[string]string {
+	kept := make(map[string]string)
+	for name := range header {
+		if !containsFold(rec.opts.ExcludeHeaders, name) {
+			kept[name] = header.Get(name)
+		}
+	}
+	return kept
+}
+
+func (rec *Recorder) add(id string, sample Sample) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	samples := append(rec.samples[id], sample)
+	if len(samples) > rec.opts.MaxPerOperation {
+		samples = samples[len(samples)-rec.opts.MaxPerOperation:]
+	}
+	rec.samples[id] = samples
+}
+
+// Golden returns the recorded samples of each operation with its documented response schemas
+func (rec *Recorder) Golden() []Golden {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	var goldens []Golden
+	for _, op := range rec.operations {
+		samples := rec.samples[op.id]
+		if len(samples) == 0 {
+			continue
+		}
+		goldens = append(goldens, Golden{
+			OperationID: op.id,
+			Method:      op.method,
+			Path:        op.path,
+			Responses:   op.responses,
+			Samples:     append([]Sample(nil), samples...),
+		})
+	}
+	sort.Slice(goldens, func(i, j int) bool { return goldens[i].OperationID < goldens[j].OperationID })
+	return goldens
+}
+
+// WriteGolden writes the recorded samples as golden files into dir
+func (rec *Recorder) WriteGolden(dir string) error {
+	return WriteGolden(dir, rec.Golden())
+}
+
+// teeWriter copies the response body and status while writing them to the client
+type teeWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *teeWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *teeWriter) Write(data []byte) (int, error) {
+	w.wroteHeader = true
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// redactJSON masks the given properties of a JSON body, reporting whether any was masked
+// Bodies that aren't JSON are dropped
+func redactJSON(body []byte, fields []string) (json.RawMessage, bool) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, false
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, false
+	}
+	redacted := redactValue(value, fields)
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+	return data, redacted
+}
+
+// redactValue masks object properties whose names match the given fields (case-insensitive)
+func redactValue(value interface{}, fields []string) bool {
+	redacted := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if containsFold(fields, key) {
+				v[key] = "***"
+				redacted = true
+				continue
+			}
+			redacted = redactValue(item, fields) || redacted
+		}
+	case []interface{}:
+		for _, item := range v {
+			redacted = redactValue(item, fields) || redacted
+		}
+	}
+	return redacted
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// readCloser combines a reader with the original body's Close
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
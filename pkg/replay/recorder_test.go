@@ -0,0 +1,143 @@
+package replay
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcat999/go-swagger/pkg/api"
+	ginSwagger "github.com/smartcat999/go-swagger/pkg/gin"
+)
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type login struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// newServer registers the user API; respond writes the user lookup response so versions can differ
+func newServer(t *testing.T, respond func(c *gin.Context)) (*gin.Engine, *api.OpenAPIDoc) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := ginSwagger.NewAPIRouter(engine, "/api", "Test API", "1.0.0", "Test")
+
+	getUser := api.NewAPIDefinition("GET", "/users/{id}", "Get user").
+		WithOperationID("getUser").
+		WithPathParam("id", "User ID", true).
+		WithResponse(user{})
+	ginSwagger.WithGinHandler(getUser, respond)
+	listUsers := api.NewAPIDefinition("GET", "/users/me", "Current user").
+		WithOperationID("currentUser").
+		WithResponse(user{})
+	ginSwagger.WithGinHandler(listUsers, func(c *gin.Context) {
+		c.JSON(http.StatusOK, user{ID: 7, Name: "me"})
+	})
+	postLogin := api.NewAPIDefinition("POST", "/login", "Log in").
+		WithOperationID("login").
+		WithRequest(login{})
+	ginSwagger.WithGinHandler(postLogin, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"token": "abc"})
+	})
+
+	for _, def := range []*api.APIDefinition{getUser, listUsers, postLogin} {
+		if err := router.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+	doc, err := router.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	return engine, doc
+}
+
+func respondV1(c *gin.Context) {
+	c.JSON(http.StatusOK, user{ID: 1, Name: "ada"})
+}
+
+// record sends representative traffic through a recorder
+func record(t *testing.T, engine *gin.Engine, doc *api.OpenAPIDoc) *Recorder {
+	rec := NewRecorder(doc, Options{})
+	handler := rec.Wrap(engine)
+
+	requests := []*http.Request{
+		httptest.NewRequest("GET", "/api/users/1", nil),
+		httptest.NewRequest("GET", "/api/users/me", nil),
+		httptest.NewRequest("GET", "/api/unknown", nil),
+		httptest.NewRequest("POST", "/api/login", strings.NewReader(`{"username":"ada","password":"hunter2"}`)),
+	}
+	requests[0].Header.Set("Authorization", "Bearer secret")
+	requests[0].Header.Set("Accept-Language", "en")
+	requests[3].Header.Set("Content-Type", "application/json")
+	for _, req := range requests {
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	return rec
+}
+
+// TestRecorder tests sampling traffic per operation with redaction
+func TestRecorder(t *testing.T) {
+	engine, doc := newServer(t, respondV1)
+	goldens := record(t, engine, doc).Golden()
+
+	byID := make(map[string]Golden)
+	for _, golden := range goldens {
+		byID[golden.OperationID] = golden
+	}
+	if len(goldens) != 3 {
+		t.Fatalf("Expected 3 recorded operations, got %d", len(goldens))
+	}
+
+	getUser := byID["getUser"]
+	if len(getUser.Samples) != 1 || getUser.Samples[0].Target != "/api/users/1" {
+		t.Fatalf("Expected /api/users/1 recorded under getUser, got %+v", getUser.Samples)
+	}
+	if _, ok := getUser.Samples[0].Header["Authorization"]; ok {
+		t.Error("Expected Authorization header to be excluded")
+	}
+	if getUser.Samples[0].Header["Accept-Language"] != "en" {
+		t.Errorf("Expected Accept-Language header to be recorded, got %v", getUser.Samples[0].Header)
+	}
+	if _, ok := getUser.Responses["200"]; !ok {
+		t.Error("Expected the documented 200 schema to be recorded")
+	}
+
+	if samples := byID["currentUser"].Samples; len(samples) != 1 || samples[0].Target != "/api/users/me" {
+		t.Errorf("Expected literal path to win over the parameterized one, got %+v", samples)
+	}
+
+	loginSample := byID["login"].Samples[0]
+	if !loginSample.Redacted || strings.Contains(string(loginSample.Body), "hunter2") {
+		t.Errorf("Expected password to be redacted, got %s", loginSample.Body)
+	}
+	if strings.Contains(string(loginSample.Response), "abc") {
+		t.Errorf("Expected token to be redacted, got %s", loginSample.Response)
+	}
+}
+
+// TestWriteGolden tests writing and reading golden files
+func TestWriteGolden(t *testing.T) {
+	engine, doc := newServer(t, respondV1)
+	dir := t.TempDir()
+	if err := record(t, engine, doc).WriteGolden(dir); err != nil {
+		t.Fatalf("WriteGolden failed: %v", err)
+	}
+
+	goldens, err := ReadGolden(dir)
+	if err != nil {
+		t.Fatalf("ReadGolden failed: %v", err)
+	}
+	var ids []string
+	for _, golden := range goldens {
+		ids = append(ids, golden.OperationID)
+	}
+	if strings.Join(ids, ",") != "currentUser,getUser,login" {
+		t.Errorf("Unexpected golden files %v", ids)
+	}
+}
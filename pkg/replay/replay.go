@@ -0,0 +1,126 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// Failure is a replayed sample the current build no longer handles compatibly
+type Failure struct {
+	OperationID string
+	Sample      int // Index of the sample in its golden file
+	Reason      string
+}
+
+func (f Failure) String() string {
+	return fmt.Sprintf("%s sample %d: %s", f.OperationID, f.Sample+1, f.Reason)
+}
+
+// Run replays the golden files of dir against the handler, reporting each incompatibility as a test error
+func Run(t testing.TB, handler http.Handler, dir string, opts Options) {
+	t.Helper()
+	goldens, err := ReadGolden(dir)
+	if err != nil {
+		t.Fatalf("failed to load golden files: %v", err)
+	}
+	failures, err := Replay(handler, goldens, opts)
+	if err != nil {
+		t.Fatalf("failed to replay golden files: %v", err)
+	}
+	for _, f := range failures {
+		t.Errorf("%s", f)
+	}
+}
+
+// Replay sends every recorded sample to the handler and checks that it is still accepted with the recorded
+// status and that the response still matches the schema documented when the sample was recorded
+// Acceptance isn't asserted for samples whose request values were redacted
+func Replay(handler http.Handler, goldens []Golden, opts Options) ([]Failure, error) {
+	var failures []Failure
+	for _, golden := range goldens {
+		schemas := make(map[string]*jsonschema.Schema)
+		for i, sample := range golden.Samples {
+			reason, err := replaySample(handler, golden, sample, schemas, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to replay %s: %w", golden.OperationID, err)
+			}
+			if reason != "" {
+				failures = append(failures, Failure{OperationID: golden.OperationID, Sample: i, Reason: reason})
+			}
+		}
+	}
+	return failures, nil
+}
+
+// replaySample replays one sample, returning why it is incompatible or an empty string
+func replaySample(handler http.Handler, golden Golden, sample Sample, schemas map[string]*jsonschema.Schema, opts Options) (string, error) {
+	req := httptest.NewRequest(golden.Method, sample.Target, bytes.NewReader(sample.Body))
+	for name, value := range sample.Header {
+		req.Header.Set(name, value)
+	}
+	if opts.Prepare != nil {
+		opts.Prepare(req)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code >= 500 {
+		return fmt.Sprintf("got status %d", w.Code), nil
+	}
+	if sample.Redacted && w.Code != sample.Status {
+		return "", nil
+	}
+	if w.Code != sample.Status {
+		return fmt.Sprintf("expected status %d, got %d: %s", sample.Status, w.Code, truncate(w.Body.String(), 200)), nil
+	}
+
+	status := strconv.Itoa(w.Code)
+	document, ok := golden.Responses[status]
+	if !ok || w.Body.Len() == 0 {
+		return "", nil
+	}
+	schema, ok := schemas[status]
+	if !ok {
+		var err error
+		if schema, err = compile(document); err != nil {
+			return "", fmt.Errorf("failed to compile %s response schema: %w", status, err)
+		}
+		schemas[status] = schema
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		return "response is no longer JSON: " + err.Error(), nil
+	}
+	if err := schema.Validate(body); err != nil {
+		return "response no longer matches the recorded schema: " + err.Error(), nil
+	}
+	return "", nil
+}
+
+// compile compiles a recorded JSON Schema document
+func compile(document map[string]interface{}) (*jsonschema.Schema, error) {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("response.json", bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	return compiler.Compile("response.json")
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n] + "..."
+	}
+	return s
+}
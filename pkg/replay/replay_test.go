@@ -0,0 +1,76 @@
+package replay
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestReplay tests replaying golden files recorded against v1 on later versions
+func TestReplay(t *testing.T) {
+	engine, doc := newServer(t, respondV1)
+	dir := t.TempDir()
+	if err := record(t, engine, doc).WriteGolden(dir); err != nil {
+		t.Fatalf("WriteGolden failed: %v", err)
+	}
+	goldens, err := ReadGolden(dir)
+	if err != nil {
+		t.Fatalf("ReadGolden failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		respond    func(c *gin.Context)
+		wantReason string
+	}{
+		{name: "same version", respond: respondV1},
+		{
+			name: "added property",
+			respond: func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"id": 1, "name": "ada", "email": "ada@example.com"})
+			},
+		},
+		{
+			name: "changed property type",
+			respond: func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"id": "1", "name": "ada"})
+			},
+			wantReason: "no longer matches the recorded schema",
+		},
+		{
+			name: "no longer accepted",
+			respond: func(c *gin.Context) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			},
+			wantReason: "expected status 200, got 404",
+		},
+		{
+			name: "server error",
+			respond: func(c *gin.Context) {
+				c.Status(http.StatusInternalServerError)
+			},
+			wantReason: "got status 500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, _ := newServer(t, tt.respond)
+			failures, err := Replay(current, goldens, Options{})
+			if err != nil {
+				t.Fatalf("Replay failed: %v", err)
+			}
+			if tt.wantReason == "" {
+				if len(failures) > 0 {
+					t.Errorf("Expected no failures, got %v", failures)
+				}
+				return
+			}
+			if len(failures) != 1 || failures[0].OperationID != "getUser" || !strings.Contains(failures[0].Reason, tt.wantReason) {
+				t.Errorf("Expected one getUser failure %q, got %v", tt.wantReason, failures)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type roleAuthorizer struct{}
+
+func (roleAuthorizer) Authorize(ctx context.Context, metadata map[string]interface{}) bool {
+	return metadata["role"] != "admin"
+}
+
+// TestAccessChecks tests the session, plan and authorizer checks run before the handler
+func TestAccessChecks(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	r.AddSecurityScheme("session", api.SecurityScheme{Type: "apiKey", In: "cookie", Name: "sid"})
+	r.SetSessionValidator("session", func(req *http.Request, session string) bool {
+		return session == "valid"
+	})
+	r.SetPlanResolver(func(req *http.Request) string {
+		return req.Header.Get("X-Plan")
+	})
+	r.SetAuthorizer(roleAuthorizer{})
+
+	handler := func(w http.ResponseWriter, req *http.Request) {
+		if session, ok := SessionCookie(req.Context()); ok {
+			w.Header().Set("X-Session", session)
+		}
+	}
+	for _, def := range []*api.APIDefinition{
+		api.NewAPIDefinition("GET", "/profile", "Profile").WithSecurity("session", nil).WithHandler(handler),
+		api.NewAPIDefinition("GET", "/reports", "Reports").WithPlans("pro").WithHandler(handler),
+		api.NewAPIDefinition("GET", "/admin", "Admin").WithMetadata("role", "admin").WithHandler(handler),
+	} {
+		if err := r.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		cookie      string
+		plan        string
+		wantStatus  int
+		wantSession string
+	}{
+		{name: "valid session", path: "/api/profile", cookie: "valid", wantStatus: http.StatusOK, wantSession: "valid"},
+		{name: "invalid session", path: "/api/profile", cookie: "forged", wantStatus: http.StatusUnauthorized},
+		{name: "missing session", path: "/api/profile", wantStatus: http.StatusUnauthorized},
+		{name: "plan included", path: "/api/reports", plan: "pro", wantStatus: http.StatusOK},
+		{name: "plan excluded", path: "/api/reports", plan: "free", wantStatus: http.StatusForbidden},
+		{name: "authorizer denies", path: "/api/admin", wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "sid", Value: tt.cookie})
+			}
+			if tt.plan != "" {
+				req.Header.Set("X-Plan", tt.plan)
+			}
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("X-Session"); got != tt.wantSession {
+				t.Errorf("Expected session %q, got %q", tt.wantSession, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package router
+
+import (
+	"context"
+)
+
+// Authorizer checks route permissions after the request has been validated
+// Returns true if access is allowed; metadata is the definition's route metadata (e.g. required roles)
+type Authorizer interface {
+	Authorize(ctx context.Context, metadata map[string]interface{}) bool
+}
+
+// SetAuthorizer sets the authorizer consulted for every operation, rejecting denied requests with 403
+func (r *Router) SetAuthorizer(authorizer Authorizer) {
+	r.authorizer = authorizer
+}
@@ -0,0 +1,61 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestBatchRequest tests decoding, size checks and per-item validation of batch bodies
+func TestBatchRequest(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	def := api.NewAPIDefinition("POST", "/users/batch", "Create users").
+		WithBatchRequest(createUser{}, 2).
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {
+			users := RequestBody(req.Context()).(*[]createUser)
+			fmt.Fprint(w, len(*users))
+		})
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "valid", body: `[{"name":"alice","email":"alice@example.com"},{"name":"bob","email":"bob@example.com"}]`, wantStatus: http.StatusOK},
+		{name: "empty", body: `[]`, wantStatus: http.StatusBadRequest},
+		{name: "too many", body: `[{"name":"a","email":"a@example.com"},{"name":"b","email":"b@example.com"},{"name":"c","email":"c@example.com"}]`, wantStatus: http.StatusBadRequest},
+		{name: "invalid item", body: `[{"name":"alice","email":"alice@example.com"},{"email":"bob@example.com"}]`, wantStatus: http.StatusBadRequest},
+		{name: "not an array", body: `{"name":"alice","email":"alice@example.com"}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/users/batch", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	doc, err := r.BuildOpenAPI()
+	if err != nil {
+		t.Fatalf("BuildOpenAPI failed: %v", err)
+	}
+	op := doc.Paths["/users/batch"].Post
+	if schema := op.RequestBody.Content["application/json"].Schema; schema["type"] != "array" || schema["maxItems"] != 2 {
+		t.Errorf("Expected an array request body, got %v", schema)
+	}
+	if _, ok := op.Responses["207"]; !ok {
+		t.Errorf("Expected a 207 response, got %v", op.Responses)
+	}
+}
@@ -0,0 +1,21 @@
+package router
+
+import "net/http"
+
+// BodyHandling selects what handlers find in a request body the router has validated
+type BodyHandling int
+
+const (
+	// RestoreBody leaves the raw body readable by the handler, next to the decoded value in the context
+	RestoreBody BodyHandling = iota
+	// DecodedBodyOnly empties the raw body so the decoded value read with RequestBody is the only copy
+	DecodedBodyOnly
+)
+
+// HandOffBody prepares the body of a validated request for the handler according to the mode
+func HandOffBody(r *http.Request, mode BodyHandling) {
+	if mode == DecodedBodyOnly {
+		r.Body = http.NoBody
+		r.ContentLength = 0
+	}
+}
@@ -0,0 +1,64 @@
+package router
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestBodyHandling tests what handlers find in validated request bodies under each mode
+func TestBodyHandling(t *testing.T) {
+	body := `{"name":"Ada","email":"a@b.co"}`
+	tests := []struct {
+		name    string
+		mode    BodyHandling
+		batch   bool
+		wantRaw string
+	}{
+		{name: "restore", mode: RestoreBody, wantRaw: body},
+		{name: "restore batch", mode: RestoreBody, batch: true, wantRaw: "[" + body + "]"},
+		{name: "decoded only", mode: DecodedBodyOnly, wantRaw: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := NewMux()
+			r := New(mux, "/api", "Test API", "1.0.0", "Test")
+			r.SetBodyHandling(tt.mode)
+			var raw string
+			var decoded interface{}
+			def := api.NewAPIDefinition("POST", "/users", "Create user").WithRequest(createUser{}).
+				WithHandler(func(w http.ResponseWriter, req *http.Request) {
+					data, _ := io.ReadAll(req.Body)
+					raw, decoded = string(data), RequestBody(req.Context())
+					w.WriteHeader(http.StatusCreated)
+				})
+			sent := body
+			if tt.batch {
+				def.WithBatchRequest(createUser{}, 10)
+				sent = "[" + body + "]"
+			}
+			if err := r.Register(def); err != nil {
+				t.Fatalf("Register failed: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/users", strings.NewReader(sent))
+			req.Header.Set("Content-Type", "application/json")
+			mux.ServeHTTP(w, req)
+			if w.Code != http.StatusCreated {
+				t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+			}
+			if raw != tt.wantRaw {
+				t.Errorf("Handler read %q, want %q", raw, tt.wantRaw)
+			}
+			if decoded == nil {
+				t.Error("Expected the decoded body in the context")
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package router
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// RequestEncodings lists the Content-Encoding values DecompressBody accepts
+var RequestEncodings = []string{"gzip", "deflate"}
+
+// DecompressBody replaces a gzip or deflate encoded request body with its decompressed content so
+// validation and decoding see the payload, rejecting bodies inflating beyond maxSize bytes with 413
+// and unsupported encodings with 415; identity bodies are left untouched
+func DecompressBody(r *http.Request, maxSize int64) *ValidationError {
+	encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding")))
+	if encoding == "" || encoding == "identity" || r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+
+	var reader io.ReadCloser
+	var err error
+	switch encoding {
+	case "gzip", "x-gzip":
+		reader, err = gzip.NewReader(r.Body)
+	case "deflate":
+		reader, err = zlib.NewReader(r.Body)
+	default:
+		return &ValidationError{
+			Status:  http.StatusUnsupportedMediaType,
+			Message: fmt.Sprintf("unsupported content encoding %q, expected one of %s", encoding, strings.Join(RequestEncodings, ", ")),
+		}
+	}
+	if err != nil {
+		return decompressError(encoding, err)
+	}
+	defer reader.Close()
+
+	limited := io.Reader(reader)
+	if maxSize > 0 {
+		limited = io.LimitReader(reader, maxSize+1)
+	}
+	data, err := io.ReadAll(limited)
+	if err != nil {
+		return decompressError(encoding, err)
+	}
+	if maxSize > 0 && int64(len(data)) > maxSize {
+		return &ValidationError{
+			Status:  http.StatusRequestEntityTooLarge,
+			Message: fmt.Sprintf("decompressed request body exceeds %d bytes", maxSize),
+		}
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	r.ContentLength = int64(len(data))
+	r.Header.Del("Content-Encoding")
+	r.Header.Set("Content-Length", strconv.Itoa(len(data)))
+	return nil
+}
+
+// decompressError maps a failure reading an encoded body to 413 for the wire size limit and 400 otherwise
+func decompressError(encoding string, err error) *ValidationError {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return &ValidationError{Status: http.StatusRequestEntityTooLarge, Message: "request body too large"}
+	}
+	return badRequest("invalid %s request body: %v", encoding, err)
+}
+
+// DocumentRequestEncodings marks an operation with a request body as accepting compressed bodies with
+// the x-request-encodings extension and documents the 415 response of unsupported encodings
+func DocumentRequestEncodings(operation *api.Operation, maxSize int64) {
+	if operation.RequestBody == nil {
+		return
+	}
+	if operation.Extensions == nil {
+		operation.Extensions = make(map[string]interface{})
+	}
+	extension := map[string]interface{}{"encodings": RequestEncodings}
+	if maxSize > 0 {
+		extension["maxDecompressedSize"] = maxSize
+	}
+	operation.Extensions["x-request-encodings"] = extension
+	if operation.Responses == nil {
+		operation.Responses = make(map[string]api.Response)
+	}
+	if _, ok := operation.Responses["415"]; !ok {
+		operation.Responses["415"] = api.Response{Description: "Unsupported Media Type - The request body uses an unsupported content encoding"}
+	}
+}
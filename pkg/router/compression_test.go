@@ -0,0 +1,94 @@
+package router
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// encodeBody compresses a request body with the given Content-Encoding
+func encodeBody(t *testing.T, encoding, body string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	switch encoding {
+	case "gzip":
+		w := gzip.NewWriter(&buf)
+		w.Write([]byte(body))
+		w.Close()
+	case "deflate":
+		w := zlib.NewWriter(&buf)
+		w.Write([]byte(body))
+		w.Close()
+	default:
+		buf.WriteString(body)
+	}
+	return buf.Bytes()
+}
+
+// TestRequestDecompression tests decompressing encoded bodies before validation and documenting the encodings
+func TestRequestDecompression(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	r.EnableRequestDecompression(64)
+	var received string
+	handler := func(w http.ResponseWriter, req *http.Request) {
+		received = RequestBody(req.Context()).(*createUser).Name
+		w.WriteHeader(http.StatusCreated)
+	}
+	if err := r.Register(api.NewAPIDefinition("POST", "/users", "Create user").WithRequest(createUser{}).WithHandler(handler)); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	valid := `{"name":"Ada","email":"a@b.co"}`
+	tests := []struct {
+		name       string
+		encoding   string
+		body       []byte
+		wantStatus int
+	}{
+		{name: "identity", body: []byte(valid), wantStatus: http.StatusCreated},
+		{name: "gzip", encoding: "gzip", body: encodeBody(t, "gzip", valid), wantStatus: http.StatusCreated},
+		{name: "deflate", encoding: "deflate", body: encodeBody(t, "deflate", valid), wantStatus: http.StatusCreated},
+		{name: "invalid payload", encoding: "gzip", body: encodeBody(t, "gzip", `{"name":"Ada"}`), wantStatus: http.StatusBadRequest},
+		{name: "corrupt stream", encoding: "gzip", body: []byte(valid), wantStatus: http.StatusBadRequest},
+		{name: "decompression bomb", encoding: "gzip", body: encodeBody(t, "gzip", `{"name":"`+strings.Repeat("a", 1000)+`"}`), wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "unsupported encoding", encoding: "br", body: []byte(valid), wantStatus: http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = ""
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/users", bytes.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.encoding != "" {
+				req.Header.Set("Content-Encoding", tt.encoding)
+			}
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusCreated && received != "Ada" {
+				t.Errorf("Expected decoded name Ada, got %q", received)
+			}
+		})
+	}
+
+	doc, err := r.BuildOpenAPI()
+	if err != nil {
+		t.Fatalf("BuildOpenAPI failed: %v", err)
+	}
+	operation := doc.Paths["/users"].Post
+	extension, ok := operation.Extensions["x-request-encodings"].(map[string]interface{})
+	if !ok || extension["maxDecompressedSize"] != int64(64) {
+		t.Errorf("Unexpected x-request-encodings %v", operation.Extensions["x-request-encodings"])
+	}
+	if _, ok := operation.Responses["415"]; !ok {
+		t.Error("Expected 415 response for unsupported encodings")
+	}
+}
@@ -0,0 +1,99 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// ValidateConditions checks the cross-field conditions of a definition against the parameters and top-level
+// JSON body properties present in a request; the body is restored for later decoding
+func ValidateConditions(def *api.APIDefinition, r *http.Request, pathParam ParamExtractor) *ValidationError {
+	if len(def.Conditions) == 0 {
+		return nil
+	}
+	present, verr := presentValues(def, r, pathParam)
+	if verr != nil {
+		return verr
+	}
+	for _, condition := range def.Conditions {
+		if err := condition.Check(present); err != nil {
+			return badRequest("%v", err)
+		}
+	}
+	return nil
+}
+
+// ValidateRequestRules checks the request rules of a definition against the parameters and top-level JSON body
+// properties present in a request, its headers and the principal in its context; the body is restored
+func ValidateRequestRules(def *api.APIDefinition, r *http.Request, pathParam ParamExtractor) *ValidationError {
+	if len(def.RequestRules) == 0 {
+		return nil
+	}
+	present, verr := presentValues(def, r, pathParam)
+	if verr != nil {
+		return verr
+	}
+	principal, _ := api.PrincipalFromContext(r.Context())
+	req := api.RequestContext{Context: r.Context(), Values: present, Header: r.Header, Principal: principal}
+	for _, rule := range def.RequestRules {
+		if status, err := rule.Evaluate(req); err != nil {
+			return &ValidationError{Status: status, Message: err.Error()}
+		}
+	}
+	return nil
+}
+
+// presentValues returns the values of the parameters and top-level body properties sent with a request
+// Parameters win over body properties of the same name; null properties count as absent
+func presentValues(def *api.APIDefinition, r *http.Request, pathParam ParamExtractor) (map[string]string, *ValidationError) {
+	present := make(map[string]string)
+	if def.Request != nil && !def.StreamsRequest() && HasRequestBody(r.Method) {
+		data, verr := readBody(r)
+		if verr != nil {
+			return nil, verr
+		}
+		var body map[string]interface{}
+		if json.Unmarshal(data, &body) == nil {
+			for name, value := range body {
+				if value != nil {
+					present[name] = presentString(value)
+				}
+			}
+		}
+	}
+
+	query := r.URL.Query()
+	for i := range def.Params {
+		param := &def.Params[i]
+		switch param.In {
+		case "path":
+			if value := pathParam(r, param.Name); value != "" {
+				present[param.Name] = value
+			}
+		case "query":
+			if value, ok := param.DecodeQuery(query); ok {
+				present[param.Name] = presentString(value)
+			}
+		case "header":
+			if value, ok := param.DecodeHeader(r.Header); ok {
+				present[param.Name] = presentString(value)
+			}
+		case "cookie":
+			if cookie, err := r.Cookie(param.Name); err == nil {
+				present[param.Name] = cookie.Value
+			}
+		}
+	}
+	return present, nil
+}
+
+// presentString formats a present value for comparison with required_if values
+func presentString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
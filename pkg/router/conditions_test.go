@@ -0,0 +1,62 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestValidateConditions tests cross-field rules over query parameters and body properties
+func TestValidateConditions(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	def := api.NewAPIDefinition("POST", "/users", "Create user").
+		WithQueryParam("notify", "Notification channel", false).
+		WithRequest(createUser{}).
+		WithRequiredIf("phone", "notify", "sms").
+		WithMutuallyExclusive("notify", "silent").
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "email notification", target: "/api/users?notify=email", body: `{"name":"Ada","email":"ada@example.com"}`, wantStatus: http.StatusCreated},
+		{name: "sms with phone", target: "/api/users?notify=sms", body: `{"name":"Ada","email":"ada@example.com","phone":"123"}`, wantStatus: http.StatusCreated},
+		{name: "sms without phone", target: "/api/users?notify=sms", body: `{"name":"Ada","email":"ada@example.com"}`, wantStatus: http.StatusBadRequest, wantBody: "phone is required when notify is sms"},
+		{name: "exclusive across query and body", target: "/api/users?notify=email", body: `{"name":"Ada","email":"ada@example.com","silent":true}`, wantStatus: http.StatusBadRequest, wantBody: "notify, silent are mutually exclusive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("Expected body to contain %q, got %s", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+
+	operation, err := BuildOperation(def)
+	if err != nil {
+		t.Fatalf("BuildOperation failed: %v", err)
+	}
+	if conditions, ok := operation.Extensions["x-conditions"].([]api.Condition); !ok || len(conditions) != 2 {
+		t.Errorf("Expected x-conditions extension, got %v", operation.Extensions["x-conditions"])
+	}
+}
@@ -0,0 +1,112 @@
+package router
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// SetCORS sets the default cross-origin policy for all APIs
+// Call it before registering APIs so preflight handlers are installed for their paths; policies allowing
+// credentials from any origin are rejected
+func (r *Router) SetCORS(policy api.CORSPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	r.cors = &policy
+	return nil
+}
+
+// corsPolicy returns the effective cross-origin policy for a definition
+func (r *Router) corsPolicy(def *api.APIDefinition) *api.CORSPolicy {
+	if def.CORS != nil {
+		return def.CORS
+	}
+	return r.cors
+}
+
+// PreflightRoute answers the CORS preflight requests of a path with the methods registered on it
+// Each method keeps the policy it was registered with, so operations sharing a path may differ
+type PreflightRoute struct {
+	policies map[string]*api.CORSPolicy
+	methods  []string
+}
+
+// NewPreflightRoute creates the preflight route of a path first registered with method under policy
+func NewPreflightRoute(policy *api.CORSPolicy, method string) *PreflightRoute {
+	p := &PreflightRoute{policies: make(map[string]*api.CORSPolicy)}
+	p.AddMethod(method, policy)
+	return p
+}
+
+// AddMethod records another method registered on the path, replacing the policy of a method registered again
+func (p *PreflightRoute) AddMethod(method string, policy *api.CORSPolicy) {
+	if _, ok := p.policies[method]; !ok {
+		p.methods = append(p.methods, method)
+	}
+	p.policies[method] = policy
+}
+
+// ServeHTTP answers 204 with the allowed methods, headers and max age under the policy of the requested
+// method, or 403 for disallowed origins and methods not registered on the path
+func (p *PreflightRoute) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	origin := req.Header.Get("Origin")
+	policy := p.policies[req.Header.Get("Access-Control-Request-Method")]
+	if origin == "" || policy == nil || !policy.AllowsOrigin(origin) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	SetCORSHeaders(w.Header(), policy, origin)
+
+	methods := policy.AllowMethods
+	if len(methods) == 0 {
+		for _, method := range p.methods {
+			if p.policies[method].AllowsOrigin(origin) {
+				methods = append(methods, method)
+			}
+		}
+		methods = append(methods, http.MethodOptions)
+	}
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+	if len(policy.AllowHeaders) > 0 {
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(policy.AllowHeaders, ", "))
+	} else if requested := req.Header.Get("Access-Control-Request-Headers"); requested != "" {
+		w.Header().Set("Access-Control-Allow-Headers", requested)
+	}
+	if policy.MaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(policy.MaxAge/time.Second)))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetCORSHeaders writes the headers shared by preflight and actual cross-origin responses
+func SetCORSHeaders(h http.Header, policy *api.CORSPolicy, origin string) {
+	allowOrigin := origin
+	if len(policy.AllowOrigins) == 1 && policy.AllowOrigins[0] == "*" {
+		allowOrigin = "*"
+	}
+	h.Set("Access-Control-Allow-Origin", allowOrigin)
+	if allowOrigin != "*" {
+		h.Add("Vary", "Origin")
+	}
+	// Credentials are never allowed together with a wildcard origin
+	if policy.AllowCredentials && allowOrigin != "*" {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+	if len(policy.ExposeHeaders) > 0 {
+		h.Set("Access-Control-Expose-Headers", strings.Join(policy.ExposeHeaders, ", "))
+	}
+}
+
+// ApplyCORS sets the cross-origin headers of an actual request from an allowed origin
+func ApplyCORS(w http.ResponseWriter, req *http.Request, policy *api.CORSPolicy) {
+	if policy == nil {
+		return
+	}
+	if origin := req.Header.Get("Origin"); origin != "" && policy.AllowsOrigin(origin) {
+		SetCORSHeaders(w.Header(), policy, origin)
+	}
+}
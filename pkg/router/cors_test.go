@@ -0,0 +1,220 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestCORS tests cross-origin headers and preflight responses through the Mux adapter
+func TestCORS(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	r.SetCORS(api.CORSPolicy{AllowOrigins: []string{"https://app.example.com"}, MaxAge: time.Minute})
+	handler := func(w http.ResponseWriter, req *http.Request) {}
+	for _, def := range []*api.APIDefinition{
+		api.NewAPIDefinition("GET", "/users", "List users").WithHandler(handler),
+		api.NewAPIDefinition("POST", "/users", "Create user").WithHandler(handler),
+	} {
+		if err := r.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		origin      string
+		wantStatus  int
+		wantOrigin  string
+		wantMethods string
+	}{
+		{name: "actual request", method: "GET", origin: "https://app.example.com", wantStatus: http.StatusOK, wantOrigin: "https://app.example.com"},
+		{name: "disallowed actual request", method: "GET", origin: "https://evil.example.com", wantStatus: http.StatusOK},
+		{name: "preflight", method: "OPTIONS", origin: "https://app.example.com", wantStatus: http.StatusNoContent, wantOrigin: "https://app.example.com", wantMethods: "GET, POST, OPTIONS"},
+		{name: "disallowed preflight", method: "OPTIONS", origin: "https://evil.example.com", wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/api/users", nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.method == "OPTIONS" {
+				req.Header.Set("Access-Control-Request-Method", "POST")
+			}
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Expected allowed origin %q, got %q", tt.wantOrigin, got)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Methods"); got != tt.wantMethods {
+				t.Errorf("Expected allowed methods %q, got %q", tt.wantMethods, got)
+			}
+		})
+	}
+
+	options := api.NewAPIDefinition("OPTIONS", "/users", "Describe users").WithHandler(handler)
+	if err := r.Register(options); err == nil {
+		t.Error("Expected registering OPTIONS over a preflight route to fail")
+	}
+}
+
+// TestCORSPreflightPolicy tests answering preflight requests under the policy of the requested method
+func TestCORSPreflightPolicy(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	handler := func(w http.ResponseWriter, req *http.Request) {}
+	for _, def := range []*api.APIDefinition{
+		api.NewAPIDefinition("GET", "/users", "List users").
+			WithCORS(api.CORSPolicy{AllowOrigins: []string{"*"}}).
+			WithHandler(handler),
+		api.NewAPIDefinition("POST", "/users", "Create user").
+			WithCORS(api.CORSPolicy{AllowOrigins: []string{"https://app.example.com"}, AllowCredentials: true}).
+			WithHandler(handler),
+	} {
+		if err := r.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		origin          string
+		method          string
+		wantStatus      int
+		wantOrigin      string
+		wantMethods     string
+		wantCredentials string
+	}{
+		{name: "public method", origin: "https://evil.example.com", method: "GET", wantStatus: http.StatusNoContent, wantOrigin: "*", wantMethods: "GET, OPTIONS"},
+		{name: "credentialed method", origin: "https://app.example.com", method: "POST", wantStatus: http.StatusNoContent, wantOrigin: "https://app.example.com", wantMethods: "GET, POST, OPTIONS", wantCredentials: "true"},
+		{name: "credentialed method from another origin", origin: "https://evil.example.com", method: "POST", wantStatus: http.StatusForbidden},
+		{name: "unregistered method", origin: "https://app.example.com", method: "DELETE", wantStatus: http.StatusForbidden},
+		{name: "missing method", origin: "https://app.example.com", wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("OPTIONS", "/api/users", nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.method != "" {
+				req.Header.Set("Access-Control-Request-Method", tt.method)
+			}
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Expected allowed origin %q, got %q", tt.wantOrigin, got)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Methods"); got != tt.wantMethods {
+				t.Errorf("Expected allowed methods %q, got %q", tt.wantMethods, got)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Credentials"); got != tt.wantCredentials {
+				t.Errorf("Expected allowed credentials %q, got %q", tt.wantCredentials, got)
+			}
+		})
+	}
+}
+
+// TestCORSCredentials tests rejecting policies that allow credentials from any origin
+func TestCORSCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  api.CORSPolicy
+		wantErr bool
+	}{
+		{name: "credentials with explicit origins", policy: api.CORSPolicy{AllowOrigins: []string{"https://app.example.com"}, AllowCredentials: true}},
+		{name: "any origin without credentials", policy: api.CORSPolicy{AllowOrigins: []string{"*"}}},
+		{name: "credentials with any origin", policy: api.CORSPolicy{AllowOrigins: []string{"https://app.example.com", "*"}, AllowCredentials: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(NewMux(), "/api", "Test API", "1.0.0", "Test")
+			if err := r.SetCORS(tt.policy); (err != nil) != tt.wantErr {
+				t.Errorf("Expected SetCORS error %v, got %v", tt.wantErr, err)
+			}
+			def := api.NewAPIDefinition("GET", "/users", "List users").
+				WithCORS(tt.policy).
+				WithHandler(func(w http.ResponseWriter, req *http.Request) {})
+			if err := r.Register(def); (err != nil) != tt.wantErr {
+				t.Errorf("Expected Register error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestCORSExplicitOptions tests that an explicitly registered OPTIONS operation owns its route
+func TestCORSExplicitOptions(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	r.SetCORS(api.CORSPolicy{AllowOrigins: []string{"*"}})
+	for _, def := range []*api.APIDefinition{
+		api.NewAPIDefinition("OPTIONS", "/users", "Describe users").
+			WithHandler(func(w http.ResponseWriter, req *http.Request) { w.WriteHeader(http.StatusOK) }),
+		api.NewAPIDefinition("GET", "/users", "List users").
+			WithHandler(func(w http.ResponseWriter, req *http.Request) {}),
+	} {
+		if err := r.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("OPTIONS", "/api/users", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected the explicit OPTIONS handler, got status %d", w.Code)
+	}
+}
+
+// TestCORSDocumented tests documenting the router-wide CORS policy on operations without their own
+func TestCORSDocumented(t *testing.T) {
+	r := New(NewMux(), "/api", "Test API", "1.0.0", "Test")
+	r.SetCORS(api.CORSPolicy{AllowOrigins: []string{"https://app.example.com"}})
+	handler := func(w http.ResponseWriter, req *http.Request) {}
+	for _, def := range []*api.APIDefinition{
+		api.NewAPIDefinition("GET", "/users", "List users").WithHandler(handler),
+		api.NewAPIDefinition("GET", "/public", "Public").
+			WithCORS(api.CORSPolicy{AllowOrigins: []string{"*"}}).
+			WithHandler(handler),
+	} {
+		if err := r.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	doc, err := r.BuildOpenAPI()
+	if err != nil {
+		t.Fatalf("BuildOpenAPI failed: %v", err)
+	}
+	tests := []struct {
+		path        string
+		wantOrigins []string
+	}{
+		{path: "/users", wantOrigins: []string{"https://app.example.com"}},
+		{path: "/public", wantOrigins: []string{"*"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			operation := doc.Paths[tt.path].Get
+			if operation == nil {
+				t.Fatalf("Expected a GET operation at %s", tt.path)
+			}
+			ext, ok := operation.Extensions["x-cors"].(map[string]interface{})
+			if !ok {
+				t.Fatalf("Expected an x-cors extension, got %v", operation.Extensions["x-cors"])
+			}
+			if got := fmt.Sprint(ext["allowOrigins"]); got != fmt.Sprint(tt.wantOrigins) {
+				t.Errorf("Expected allowed origins %v, got %s", tt.wantOrigins, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// ApplyParamDefaults sets the documented defaults of absent optional query and header parameters on a request,
+// so they are validated and decoded like values sent by the client
+func ApplyParamDefaults(def *api.APIDefinition, r *http.Request) {
+	query := r.URL.Query()
+	queryChanged := false
+	for i := range def.Params {
+		param := &def.Params[i]
+		value, ok := param.Default()
+		if !ok || param.Required {
+			continue
+		}
+		switch param.In {
+		case "query":
+			if present, _ := param.DecodeQuery(query); present != nil {
+				continue
+			}
+			switch v := value.(type) {
+			case map[string]interface{}:
+				for key, item := range v {
+					query.Set(fmt.Sprintf("%s[%s]", param.Name, key), fmt.Sprint(item))
+				}
+			case []interface{}:
+				for _, item := range v {
+					query.Add(param.Name, fmt.Sprint(item))
+				}
+			case []string:
+				for _, item := range v {
+					query.Add(param.Name, item)
+				}
+			default:
+				query.Set(param.Name, fmt.Sprint(v))
+			}
+			queryChanged = true
+		case "header":
+			if len(api.HeaderValues(r.Header, param.Name)) == 0 {
+				r.Header.Set(param.Name, fmt.Sprint(value))
+			}
+		}
+	}
+	if queryChanged {
+		r.URL.RawQuery = query.Encode()
+	}
+}
+
+// DefaultsSchema returns the request schema of a definition when it documents defaults, or nil
+func DefaultsSchema(def *api.APIDefinition) map[string]interface{} {
+	schema, err := api.RequestSchema(def)
+	if err != nil || !hasDefaults(schema) {
+		return nil
+	}
+	return schema
+}
+
+func hasDefaults(schema map[string]interface{}) bool {
+	if schema == nil {
+		return false
+	}
+	if _, ok := schema["default"]; ok {
+		return true
+	}
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		for _, prop := range props {
+			if propSchema, ok := prop.(map[string]interface{}); ok && hasDefaults(propSchema) {
+				return true
+			}
+		}
+	}
+	items, _ := schema["items"].(map[string]interface{})
+	return hasDefaults(items)
+}
+
+// ApplyBodyDefaults sets the documented defaults of absent optional fields in a JSON request body
+// Malformed bodies are left to ValidateBody
+func ApplyBodyDefaults(schema map[string]interface{}, r *http.Request) *ValidationError {
+	data, verr := readBody(r)
+	if verr != nil {
+		return verr
+	}
+	// Numbers are kept as written so integers beyond float64 precision survive re-encoding
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil
+	}
+	if filled, err := json.Marshal(api.ApplyDefaults(schema, value)); err == nil {
+		r.Body = io.NopCloser(bytes.NewReader(filled))
+		r.ContentLength = int64(len(filled))
+	}
+	return nil
+}
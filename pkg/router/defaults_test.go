@@ -0,0 +1,95 @@
+package router
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type listOptions struct {
+	Name  string `json:"name"`
+	Limit int    `json:"limit" default:"20"`
+}
+
+// TestEnableDefaults tests applying documented defaults to parameters and body fields
+func TestEnableDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  bool
+		target   string
+		body     string
+		wantBody string
+	}{
+		{name: "defaults applied", enabled: true, target: "/api/items", body: `{"name":"a"}`, wantBody: "sort=name region=eu limit=20"},
+		{name: "client values kept", enabled: true, target: "/api/items?sort=date", body: `{"name":"a","limit":5}`, wantBody: "sort=date region=eu limit=5"},
+		{name: "disabled", target: "/api/items", body: `{"name":"a"}`, wantBody: "sort= region= limit=0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := NewMux()
+			r := New(mux, "/api", "Test API", "1.0.0", "Test")
+			if tt.enabled {
+				r.EnableDefaults()
+			}
+			def := api.NewAPIDefinition("POST", "/items", "List items").
+				WithQueryParam("sort", "Sort order", false).
+				WithParamDefault("sort", "name").
+				WithHeaderParam("X-Region", "Region", false).
+				WithParamDefault("X-Region", "eu").
+				WithRequest(listOptions{}).
+				WithHandler(func(w http.ResponseWriter, req *http.Request) {
+					opts := RequestBody(req.Context()).(*listOptions)
+					fmt.Fprintf(w, "sort=%v region=%s limit=%d", req.URL.Query().Get("sort"), req.Header.Get("X-Region"), opts.Limit)
+				})
+			if err := r.Register(def); err != nil {
+				t.Fatalf("Register failed: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			mux.ServeHTTP(w, req)
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("Expected %q, got %q", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestApplyBodyDefaultsNumbers tests that filling defaults keeps the client's numbers exactly as sent
+func TestApplyBodyDefaultsNumbers(t *testing.T) {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id":    map[string]interface{}{"type": "integer", "format": "int64"},
+			"ratio": map[string]interface{}{"type": "number"},
+			"limit": map[string]interface{}{"type": "integer", "default": 20},
+		},
+	}
+	tests := []struct {
+		name     string
+		body     string
+		wantBody string
+	}{
+		{name: "integer above 2^53", body: `{"id":9007199254740993}`, wantBody: `{"id":9007199254740993,"limit":20}`},
+		{name: "max int64", body: `{"id":9223372036854775807,"limit":5}`, wantBody: `{"id":9223372036854775807,"limit":5}`},
+		{name: "decimal", body: `{"ratio":0.1}`, wantBody: `{"limit":20,"ratio":0.1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/items", strings.NewReader(tt.body))
+			if verr := ApplyBodyDefaults(schema, req); verr != nil {
+				t.Fatalf("Expected no error, got %v", verr)
+			}
+			data, _ := io.ReadAll(req.Body)
+			if string(data) != tt.wantBody {
+				t.Errorf("Expected %s, got %s", tt.wantBody, data)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// SetDeprecationHeaders emits the Deprecation, Sunset (RFC 8594) and successor Link headers of a deprecated
+// definition
+func SetDeprecationHeaders(h http.Header, def *api.APIDefinition) {
+	if !def.Deprecated {
+		return
+	}
+	h.Set("Deprecation", "true")
+	if !def.Sunset.IsZero() {
+		h.Set("Sunset", def.Sunset.UTC().Format(http.TimeFormat))
+	}
+	if def.Replacement != "" {
+		h.Add("Link", "<"+def.Replacement+`>; rel="successor-version"`)
+	}
+}
@@ -0,0 +1,62 @@
+package router
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"regexp"
+)
+
+// ErrRouteExists is returned by adapters asked to bind a method and path that already have a route
+var ErrRouteExists = errors.New("route already registered")
+
+// DuplicatePolicy decides what Register does when a method and path are registered twice
+type DuplicatePolicy int
+
+const (
+	// DuplicateError rejects the second registration with an error (default)
+	DuplicateError DuplicatePolicy = iota
+	// DuplicateWarn keeps the first registration and reports the duplicate to the warning logger
+	DuplicateWarn
+	// DuplicateLastWins replaces the earlier handler and definition
+	DuplicateLastWins
+)
+
+// WarningLogger receives configuration warnings such as ignored duplicate registrations
+type WarningLogger func(format string, args ...interface{})
+
+// coreRoute is the route backing one method and path
+// Adapters can't replace routes, so plain ones are bound to an indirection whose handler is swapped in place
+// when a later registration wins
+type coreRoute struct {
+	handler http.Handler
+	index   int // Position of the definition in Router.definitions
+}
+
+// SetDuplicatePolicy sets how duplicate method and path registrations are handled
+func (r *Router) SetDuplicatePolicy(policy DuplicatePolicy) {
+	r.duplicatePolicy = policy
+}
+
+// SetWarningLogger sets the hook receiving configuration warnings, log.Printf by default
+func (r *Router) SetWarningLogger(logger WarningLogger) {
+	r.warn = logger
+}
+
+// Warnf reports a configuration warning to the warning logger
+func (r *Router) Warnf(format string, args ...interface{}) {
+	if r.warn != nil {
+		r.warn(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+// routeParamPattern matches the parameter segments of a path, in {name} or framework :name and *name form
+var routeParamPattern = regexp.MustCompile(`\{[^}/]+\}|[:*][^/]+`)
+
+// routeKey identifies a route; parameter names are ignored since routers treat /users/{id} and
+// /users/{userId} as the same route
+func routeKey(method, fullPath string) string {
+	return operationKey(method, routeParamPattern.ReplaceAllString(fullPath, "{}"))
+}
@@ -0,0 +1,67 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// envelopeKey keys the router's response envelope template in request contexts
+type envelopeKey struct{}
+
+// EnvelopeResponse documents the JSON 2xx responses of an operation wrapped in the envelope template
+// Error responses keep their own schemas, as Respond writes them unwrapped
+func EnvelopeResponse(operation *api.Operation, template interface{}) error {
+	for status, response := range operation.Responses {
+		if !strings.HasPrefix(status, "2") {
+			continue
+		}
+		content, ok := response.Content["application/json"]
+		if !ok || content.Schema == nil {
+			continue
+		}
+		schema, err := api.EnvelopeSchema(template, content.Schema)
+		if err != nil {
+			return err
+		}
+		content.Schema = schema
+		response.Content["application/json"] = content
+		operation.Responses[status] = response
+	}
+	return nil
+}
+
+// EnvelopeStatus reports whether a response status is wrapped in the response envelope, i.e. a 2xx one
+func EnvelopeStatus(status int) bool {
+	return status >= http.StatusOK && status < http.StatusMultipleChoices
+}
+
+// contextWithEnvelope returns a copy of ctx carrying the response envelope template
+func contextWithEnvelope(ctx context.Context, template interface{}) context.Context {
+	return context.WithValue(ctx, envelopeKey{}, template)
+}
+
+// Respond writes data as a JSON response; 2xx responses are wrapped in the router's response envelope when
+// one is set
+func Respond(w http.ResponseWriter, r *http.Request, status int, data interface{}) {
+	body := data
+	if template := r.Context().Value(envelopeKey{}); template != nil && EnvelopeStatus(status) {
+		wrapped, err := api.WrapEnvelope(template, data)
+		if err != nil {
+			writeError(w, &ValidationError{Status: http.StatusInternalServerError, Message: err.Error()})
+			return
+		}
+		body = wrapped
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// EnvelopeFromContext returns the response envelope template of the router serving the request of ctx, or nil
+func EnvelopeFromContext(ctx context.Context) interface{} {
+	return ctx.Value(envelopeKey{})
+}
@@ -0,0 +1,121 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type responseEnvelope struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data"`
+}
+
+// TestResponseEnvelope tests documenting and writing enveloped success responses
+func TestResponseEnvelope(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	r.SetResponseEnvelope(responseEnvelope{Message: "ok"})
+	def := api.NewAPIDefinition("GET", "/users/{id}", "Get user").
+		WithResponse(userResponse{}).
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {
+			Respond(w, req, http.StatusOK, userResponse{Name: "alice"})
+		})
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/users/1", nil))
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Invalid response %q: %v", w.Body.String(), err)
+	}
+	data, _ := body["data"].(map[string]interface{})
+	if body["message"] != "ok" || data["name"] != "alice" {
+		t.Errorf("Expected an enveloped response, got %v", body)
+	}
+
+	doc, err := r.BuildOpenAPI()
+	if err != nil {
+		t.Fatalf("BuildOpenAPI failed: %v", err)
+	}
+	schema := doc.Paths["/users/{id}"].Get.Responses["200"].Content["application/json"].Schema
+	props := schema["properties"].(map[string]interface{})
+	dataSchema, _ := props["data"].(map[string]interface{})
+	if _, ok := props["code"]; !ok || dataSchema["properties"].(map[string]interface{})["name"] == nil {
+		t.Errorf("Expected the user schema inside the envelope, got %v", schema)
+	}
+}
+
+// TestRespondWithoutEnvelope tests that Respond writes plain JSON when no envelope is set
+func TestRespondWithoutEnvelope(t *testing.T) {
+	w := httptest.NewRecorder()
+	Respond(w, httptest.NewRequest("GET", "/", nil), http.StatusCreated, map[string]string{"name": "alice"})
+	if w.Code != http.StatusCreated || w.Body.String() != "{\"name\":\"alice\"}\n" {
+		t.Errorf("Unexpected response %d %q", w.Code, w.Body.String())
+	}
+}
+
+type envelopeError struct {
+	Error string `json:"error"`
+}
+
+// TestEnvelopeStatuses tests that only 2xx responses are documented and written inside the envelope
+func TestEnvelopeStatuses(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	r.SetResponseEnvelope(responseEnvelope{Message: "ok"})
+	def := api.NewAPIDefinition("POST", "/users/{id}", "Update user").
+		WithResponse(userResponse{}).
+		WithStatusResponse(http.StatusCreated, "Created", userResponse{}).
+		WithStatusResponse(http.StatusNotFound, "Not found", envelopeError{}).
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {
+			if mux.ParamExtractor()(req, "id") == "missing" {
+				Respond(w, req, http.StatusNotFound, envelopeError{Error: "no user"})
+				return
+			}
+			Respond(w, req, http.StatusCreated, userResponse{Name: "alice"})
+		})
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		target   string
+		wantCode int
+		wantKey  string
+	}{
+		{target: "/api/users/1", wantCode: http.StatusCreated, wantKey: "data"},
+		{target: "/api/users/missing", wantCode: http.StatusNotFound, wantKey: "error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("POST", tt.target, nil))
+			var body map[string]interface{}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Invalid response %q: %v", w.Body.String(), err)
+			}
+			if w.Code != tt.wantCode || body[tt.wantKey] == nil {
+				t.Errorf("Expected %d with %q, got %d %v", tt.wantCode, tt.wantKey, w.Code, body)
+			}
+		})
+	}
+
+	doc, err := r.BuildOpenAPI()
+	if err != nil {
+		t.Fatalf("BuildOpenAPI failed: %v", err)
+	}
+	responses := doc.Paths["/users/{id}"].Post.Responses
+	for status, wantEnveloped := range map[string]bool{"200": true, "201": true, "404": false} {
+		props, _ := responses[status].Content["application/json"].Schema["properties"].(map[string]interface{})
+		if _, enveloped := props["data"]; enveloped != wantEnveloped {
+			t.Errorf("Expected enveloped=%v for %s, got %v", wantEnveloped, status, responses[status].Content["application/json"].Schema)
+		}
+	}
+}
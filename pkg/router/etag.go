@@ -0,0 +1,51 @@
+package router
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// etagWriter buffers the response of a conditional GET operation so an ETag can be computed before anything
+// is sent
+type etagWriter struct {
+	*bufferWriter
+}
+
+func newETagWriter(w http.ResponseWriter) *etagWriter {
+	return &etagWriter{bufferWriter: newBufferWriter(w)}
+}
+
+// flush sends the buffered response, replacing it with 304 Not Modified when If-None-Match matches
+func (w *etagWriter) flush(req *http.Request) {
+	if w.status == http.StatusOK {
+		etag := w.Header().Get("ETag")
+		if etag == "" {
+			etag = fmt.Sprintf(`"%x"`, sha1.Sum(w.body.Bytes()))
+			w.Header().Set("ETag", etag)
+		}
+		if ETagMatches(req.Header.Get("If-None-Match"), etag) {
+			w.send(http.StatusNotModified, nil)
+			return
+		}
+	}
+	w.send(w.status, w.body.Bytes())
+}
+
+// ETagMatches reports whether an If-None-Match header matches the given ETag (weak comparison)
+func ETagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestConditionalGet tests ETags and 304 responses of conditional GET operations
+func TestConditionalGet(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	def := api.NewAPIDefinition("GET", "/users", "List users").
+		WithConditionalGet().
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {
+			_, _ = w.Write([]byte(`["ada"]`))
+		})
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/users", nil))
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag == "" || w.Body.String() != `["ada"]` {
+		t.Fatalf("Expected 200 with an ETag, got %d %q %q", w.Code, etag, w.Body.String())
+	}
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantStatus  int
+		wantBody    string
+	}{
+		{name: "matching etag", ifNoneMatch: etag, wantStatus: http.StatusNotModified},
+		{name: "stale etag", ifNoneMatch: `"stale"`, wantStatus: http.StatusOK, wantBody: `["ada"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/users", nil)
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("Expected body %q, got %q", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestETagMatches tests If-None-Match parsing
+func TestETagMatches(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{header: `"abc"`, want: true},
+		{header: `W/"abc"`, want: true},
+		{header: `"xyz", "abc"`, want: true},
+		{header: `*`, want: true},
+		{header: `"xyz"`, want: false},
+		{header: ``, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := ETagMatches(tt.header, `"abc"`); got != tt.want {
+			t.Errorf("Expected ETagMatches(%q) to be %v, got %v", tt.header, tt.want, got)
+		}
+	}
+}
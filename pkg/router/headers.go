@@ -0,0 +1,68 @@
+package router
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// DocumentNegotiatedHeaders adds the Accept and Content-Type headers to an operation as optional parameters,
+// enumerating the media types of its responses and request body; headers it already declares are kept
+func DocumentNegotiatedHeaders(operation *api.Operation) {
+	var produces []map[string]api.Content
+	for _, response := range operation.Responses {
+		produces = append(produces, response.Content)
+	}
+	if accept := mediaTypes(produces...); len(accept) > 0 && !hasHeaderParam(operation, "Accept") {
+		operation.Parameters = append(operation.Parameters, negotiatedHeader("Accept",
+			"Media types the client accepts in the response", accept))
+	}
+	if operation.RequestBody == nil || hasHeaderParam(operation, "Content-Type") {
+		return
+	}
+	if consumes := mediaTypes(operation.RequestBody.Content); len(consumes) > 0 {
+		operation.Parameters = append(operation.Parameters, negotiatedHeader("Content-Type",
+			"Media type of the request body", consumes))
+	}
+}
+
+// negotiatedHeader builds an optional header parameter restricted to the given media types
+func negotiatedHeader(name, description string, types []string) api.Parameter {
+	enum := make([]interface{}, len(types))
+	for i, t := range types {
+		enum[i] = t
+	}
+	return api.Parameter{
+		Name:        name,
+		In:          "header",
+		Description: description,
+		Schema:      map[string]interface{}{"type": "string", "enum": enum},
+	}
+}
+
+// mediaTypes returns the sorted, distinct media types of content maps
+func mediaTypes(contents ...map[string]api.Content) []string {
+	seen := make(map[string]bool)
+	var types []string
+	for _, content := range contents {
+		for mediaType := range content {
+			if !seen[mediaType] {
+				seen[mediaType] = true
+				types = append(types, mediaType)
+			}
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// hasHeaderParam reports whether an operation declares a header, comparing names case-insensitively
+func hasHeaderParam(operation *api.Operation, name string) bool {
+	for _, param := range operation.Parameters {
+		if param.In == "header" && strings.EqualFold(param.Name, name) {
+			return true
+		}
+	}
+	return false
+}
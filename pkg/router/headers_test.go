@@ -0,0 +1,75 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestHeaderValidation tests case-insensitive matching and per-value validation of header parameters
+func TestHeaderValidation(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	def := api.NewAPIDefinition("GET", "/items", "List items").
+		WithHeaderParam("x-tenant-id", "Tenant", true, api.MinLength(3, "tenant too short")).
+		WithParamSchema("X-Tags", "header", "Tags", false, map[string]interface{}{"type": "array"}).
+		WithArrayConstraints("X-Tags", 0, 2, true).
+		WithHandler(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		header     http.Header
+		wantStatus int
+	}{
+		{name: "canonical", header: http.Header{"X-Tenant-Id": {"acme"}}, wantStatus: http.StatusOK},
+		{name: "raw key", header: http.Header{"x-tenant-id": {"acme"}}, wantStatus: http.StatusOK},
+		{name: "missing", header: http.Header{}, wantStatus: http.StatusBadRequest},
+		{name: "invalid", header: http.Header{"X-Tenant-Id": {"ab"}}, wantStatus: http.StatusBadRequest},
+		{name: "repeated values", header: http.Header{"X-Tenant-Id": {"acme"}, "X-Tags": {"a", "b"}}, wantStatus: http.StatusOK},
+		{name: "too many values", header: http.Header{"X-Tenant-Id": {"acme"}, "X-Tags": {"a", "b,c"}}, wantStatus: http.StatusBadRequest},
+		{name: "duplicate values", header: http.Header{"X-Tenant-Id": {"acme"}, "X-Tags": {"a, a"}}, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/items", nil)
+			req.Header = tt.header
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestDocumentNegotiatedHeaders tests documenting Accept and Content-Type from the operation's media types
+func TestDocumentNegotiatedHeaders(t *testing.T) {
+	operation := &api.Operation{
+		Parameters: []api.Parameter{{Name: "accept", In: "header"}},
+		RequestBody: &api.RequestBody{Content: map[string]api.Content{
+			"application/json": {}, "application/xml": {},
+		}},
+		Responses: map[string]api.Response{"200": {Content: map[string]api.Content{"application/json": {}}}},
+	}
+	DocumentNegotiatedHeaders(operation)
+
+	if len(operation.Parameters) != 2 {
+		t.Fatalf("Expected the declared Accept header to be kept and Content-Type added, got %+v", operation.Parameters)
+	}
+	contentType := operation.Parameters[1]
+	enum, _ := contentType.Schema["enum"].([]interface{})
+	if contentType.Name != "Content-Type" || contentType.Required || len(enum) != 2 || enum[0] != "application/json" {
+		t.Errorf("Unexpected Content-Type parameter %+v", contentType)
+	}
+
+	operation = &api.Operation{Responses: map[string]api.Response{"200": {Content: map[string]api.Content{"application/json": {}}}}}
+	DocumentNegotiatedHeaders(operation)
+	if len(operation.Parameters) != 1 || operation.Parameters[0].Name != "Accept" {
+		t.Errorf("Expected only Accept without a request body, got %+v", operation.Parameters)
+	}
+}
@@ -0,0 +1,104 @@
+package router
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// EnableResponseFiltering removes fields tagged `swaggerignore:"true"` from JSON responses, so data kept
+// for peers (e.g. replication bookkeeping) doesn't leak past the documented contract
+// Only operations whose response model has ignored fields are buffered
+func (r *Router) EnableResponseFiltering() {
+	r.filterResponses = true
+}
+
+// filterWriter buffers a response so ignored fields can be removed
+type filterWriter struct {
+	*bufferWriter
+}
+
+func newFilterWriter(w http.ResponseWriter) *filterWriter {
+	return &filterWriter{bufferWriter: newBufferWriter(w)}
+}
+
+// flush sends the buffered response without the ignored fields of the model documented for its status;
+// non-JSON bodies are sent unchanged
+func (w *filterWriter) flush(filters responseFilters) {
+	if !w.wrote {
+		return
+	}
+	body := w.body.Bytes()
+	tree, ok := filters[w.status]
+	if !ok && w.status >= 200 && w.status < 300 {
+		tree = filters[0]
+	}
+	mediaType, _, _ := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if tree != nil && mediaType == "application/json" {
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err == nil {
+			if filtered, err := json.Marshal(removeFields(value, tree)); err == nil {
+				body = filtered
+			}
+		}
+	}
+	w.send(w.status, body)
+}
+
+// removeFields deletes the ignored properties of a decoded JSON value, following the model's structure
+func removeFields(value interface{}, tree *api.IgnoreTree) interface{} {
+	if tree == nil {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if tree.Elements != nil {
+			for key, item := range v {
+				v[key] = removeFields(item, tree.Elements)
+			}
+		}
+		for _, field := range tree.Fields {
+			delete(v, field)
+		}
+		for name, child := range tree.Properties {
+			if item, ok := v[name]; ok {
+				v[name] = removeFields(item, child)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = removeFields(item, tree.Elements)
+		}
+	}
+	return value
+}
+
+// responseFilters locates the swaggerignore fields of a definition's response models, keyed by documented status
+// with the success model under 0
+type responseFilters map[int]*api.IgnoreTree
+
+// responseFiltersOf returns the response filters of a definition
+func responseFiltersOf(def *api.APIDefinition) responseFilters {
+	filters := make(responseFilters)
+	if tree := api.IgnoreTreeOf(def.Response); tree != nil {
+		filters[0] = tree
+	}
+	for status, spec := range def.Responses {
+		if spec.Model != nil {
+			filters[status] = api.IgnoreTreeOf(spec.Model)
+		}
+	}
+	return filters
+}
+
+// active reports whether any response model has swaggerignore fields
+func (f responseFilters) active() bool {
+	for _, tree := range f {
+		if tree != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// applyRequestLimits installs the body size limit and the deadline declared on the definition
+// Returns the request carrying the deadline and a cancel function releasing it, or a 413 error when the
+// declared Content-Length already exceeds the limit
+func applyRequestLimits(w http.ResponseWriter, req *http.Request, def *api.APIDefinition, maxBodySize int64) (*http.Request, context.CancelFunc, *ValidationError) {
+	if maxBodySize > 0 {
+		if req.ContentLength > maxBodySize {
+			return req, func() {}, &ValidationError{Status: http.StatusRequestEntityTooLarge, Message: "request body too large"}
+		}
+		req.Body = http.MaxBytesReader(w, req.Body, maxBodySize)
+	}
+
+	if def.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), def.Timeout)
+		return req.WithContext(ctx), cancel, nil
+	}
+	return req, func() {}, nil
+}
+
+// checkTimeout answers with 408 when the handler gave up on an expired deadline without responding
+func checkTimeout(w http.ResponseWriter, req *http.Request) {
+	if errors.Is(req.Context().Err(), context.DeadlineExceeded) && !written(w) {
+		writeError(w, &ValidationError{Status: http.StatusRequestTimeout, Message: "request timeout"})
+	}
+}
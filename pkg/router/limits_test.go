@@ -0,0 +1,77 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestTimeout tests answering 408 when the handler gives up on an expired deadline without responding
+func TestTimeout(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	tests := []struct {
+		name       string
+		path       string
+		handler    http.HandlerFunc
+		wantStatus int
+	}{
+		{
+			name: "handler gives up",
+			path: "/slow",
+			handler: func(w http.ResponseWriter, req *http.Request) {
+				<-req.Context().Done()
+			},
+			wantStatus: http.StatusRequestTimeout,
+		},
+		{
+			name: "handler answers in time",
+			path: "/fast",
+			handler: func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		def := api.NewAPIDefinition("GET", tt.path, "Timed").WithTimeout(10 * time.Millisecond).WithHandler(tt.handler)
+		if err := r.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("GET", "/api"+tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+// TestBodyTooLarge tests rejecting bodies declared larger than the limit with 413
+func TestBodyTooLarge(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	r.SetPayloadLimits(api.PayloadLimits{MaxBodySize: 8})
+	def := api.NewAPIDefinition("POST", "/users", "Create user").
+		WithRequest(createUser{}).
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {})
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/users", strings.NewReader(`{"name":"Ada","email":"ada@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413, got %d", w.Code)
+	}
+}
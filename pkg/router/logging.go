@@ -0,0 +1,109 @@
+package router
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// RequestLogEntry describes a handled request in terms of its documented operation
+type RequestLogEntry struct {
+	OperationID      string            // Operation ID, or "METHOD /route" when none is set
+	Method           string            // HTTP method
+	Route            string            // Route template (e.g., /api/users/{id})
+	Status           int               // Response status code
+	Duration         time.Duration     // Handling time
+	ValidationFailed bool              // Whether parameter or body validation rejected the request
+	Params           map[string]string // Declared parameter values; sensitive ones are masked
+	RequestID        string            // Correlation ID, when request IDs are enabled
+}
+
+// KeyValues returns the entry as alternating keys and values,
+// ready for slog.Logger.InfoContext(ctx, msg, entry.KeyValues()...) or zap's SugaredLogger.Infow
+func (e RequestLogEntry) KeyValues() []interface{} {
+	return []interface{}{
+		"operation_id", e.OperationID,
+		"method", e.Method,
+		"route", e.Route,
+		"status", e.Status,
+		"duration", e.Duration,
+		"validation_failed", e.ValidationFailed,
+		"params", e.Params,
+		"request_id", e.RequestID,
+	}
+}
+
+// RequestLogger receives one entry per handled request
+type RequestLogger interface {
+	LogRequest(ctx context.Context, entry RequestLogEntry)
+}
+
+// RequestLoggerFunc adapts a function to RequestLogger
+type RequestLoggerFunc func(ctx context.Context, entry RequestLogEntry)
+
+// LogRequest implements RequestLogger
+func (f RequestLoggerFunc) LogRequest(ctx context.Context, entry RequestLogEntry) {
+	f(ctx, entry)
+}
+
+// NewStdRequestLogger writes entries to a standard library logger
+func NewStdRequestLogger(logger *log.Logger) RequestLogger {
+	return RequestLoggerFunc(func(ctx context.Context, entry RequestLogEntry) {
+		logger.Println(entry.KeyValues()...)
+	})
+}
+
+// SetRequestLogger logs every request handled by a registered operation
+func (r *Router) SetRequestLogger(logger RequestLogger) {
+	r.requestLogger = logger
+}
+
+// startRequestLog returns a function emitting the log entry once the request has been handled
+// failed reports whether parameter or body validation rejected the request
+func (r *Router) startRequestLog(w ResponseState, req *http.Request, def *api.APIDefinition, route string, failed *bool) func() {
+	start := time.Now()
+	pathParam := r.adapter.ParamExtractor()
+	return func() {
+		r.requestLogger.LogRequest(req.Context(), RequestLogEntry{
+			OperationID:      operationName(def, def.Method, route),
+			Method:           def.Method,
+			Route:            route,
+			Status:           w.Status(),
+			Duration:         time.Since(start),
+			ValidationFailed: *failed,
+			Params:           paramValues(req, pathParam, def.Params),
+			RequestID:        RequestID(req),
+		})
+	}
+}
+
+// paramValues collects the values of declared parameters, masking sensitive ones
+func paramValues(req *http.Request, pathParam ParamExtractor, params []api.Parameter) map[string]string {
+	values := make(map[string]string)
+	for _, param := range params {
+		var value string
+		switch param.In {
+		case "path":
+			value = pathParam(req, param.Name)
+		case "query":
+			value = req.URL.Query().Get(param.Name)
+		case "header":
+			value = req.Header.Get(param.Name)
+		case "cookie":
+			if cookie, err := req.Cookie(param.Name); err == nil {
+				value = cookie.Value
+			}
+		}
+		if value == "" {
+			continue
+		}
+		if param.Sensitive {
+			value = "***"
+		}
+		values[param.Name] = value
+	}
+	return values
+}
@@ -0,0 +1,48 @@
+package router
+
+import (
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// MetricLabels identifies the operation a measurement belongs to
+type MetricLabels struct {
+	OperationID string // Operation ID, or "METHOD /route" when none is set
+	Tag         string // First tag of the operation
+	Method      string
+	Route       string
+	Status      int
+}
+
+// Metrics receives per-operation measurements
+type Metrics interface {
+	ObserveRequest(labels MetricLabels, duration time.Duration)
+	IncValidationFailure(labels MetricLabels)
+}
+
+// SetMetrics records request count, latency and validation failures for every registered operation
+func (r *Router) SetMetrics(metrics Metrics) {
+	r.metrics = metrics
+}
+
+// startMetrics starts timing the request and returns a function reporting the measurements
+// failed reports whether parameter or body validation rejected the request
+func (r *Router) startMetrics(w ResponseState, def *api.APIDefinition, route string, failed *bool) func() {
+	start := time.Now()
+	return func() {
+		labels := MetricLabels{
+			OperationID: operationName(def, def.Method, route),
+			Method:      def.Method,
+			Route:       route,
+			Status:      w.Status(),
+		}
+		if len(def.Tags) > 0 {
+			labels.Tag = def.Tags[0]
+		}
+		r.metrics.ObserveRequest(labels, time.Since(start))
+		if *failed {
+			r.metrics.IncValidationFailure(labels)
+		}
+	}
+}
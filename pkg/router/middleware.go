@@ -0,0 +1,18 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Chain wraps a handler in net/http middlewares, the first listed running first
+func Chain(handler http.Handler, middleware []interface{}) (http.Handler, error) {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		wrap, ok := middleware[i].(func(http.Handler) http.Handler)
+		if !ok {
+			return nil, fmt.Errorf("unsupported middleware type %T", middleware[i])
+		}
+		handler = wrap(handler)
+	}
+	return handler, nil
+}
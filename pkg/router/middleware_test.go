@@ -0,0 +1,36 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestChain tests wrapping a handler in middlewares
+func TestChain(t *testing.T) {
+	tag := func(name string) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(name + ">"))
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("handler"))
+	})
+
+	chained, err := Chain(handler, []interface{}{tag("auth"), tag("quota")})
+	if err != nil {
+		t.Fatalf("Chain failed: %v", err)
+	}
+	w := httptest.NewRecorder()
+	chained.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if got := w.Body.String(); got != "auth>quota>handler" {
+		t.Errorf("Expected middlewares to run in order, got %q", got)
+	}
+
+	if _, err := Chain(handler, []interface{}{func() {}}); err == nil {
+		t.Error("Expected an unsupported middleware to be rejected")
+	}
+}
@@ -0,0 +1,107 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// pathParamPattern matches the {param} segments of an OpenAPI path
+var pathParamPattern = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// muxParamsKey keys the path parameters matched by Mux in request contexts
+type muxParamsKey struct{}
+
+// muxRoute is a route registered on a Mux
+type muxRoute struct {
+	method  string
+	pattern *regexp.Regexp
+	names   []string
+	handler http.Handler
+}
+
+// Mux is a minimal net/http adapter for the core router, matching routes in registration order
+type Mux struct {
+	routes []muxRoute
+	policy PathPolicy
+}
+
+// NewMux creates an empty Mux
+func NewMux() *Mux {
+	return &Mux{}
+}
+
+// SetPathPolicy matches the routes registered afterwards regardless of case when the policy is case-insensitive,
+// and normalizes the trailing slash of request paths before matching
+func (m *Mux) SetPathPolicy(policy PathPolicy) {
+	m.policy = policy
+}
+
+// RegisterRoute binds a handler to a method and an OpenAPI path
+func (m *Mux) RegisterRoute(method, path string, handler http.Handler) error {
+	var names []string
+	var expr strings.Builder
+	if m.policy.CaseInsensitive {
+		expr.WriteString("(?i)")
+	}
+	expr.WriteString("^")
+	last := 0
+	for _, loc := range pathParamPattern.FindAllStringSubmatchIndex(path, -1) {
+		expr.WriteString(regexp.QuoteMeta(path[last:loc[0]]))
+		expr.WriteString("([^/]+)")
+		names = append(names, path[loc[2]:loc[3]])
+		last = loc[1]
+	}
+	expr.WriteString(regexp.QuoteMeta(path[last:]))
+	expr.WriteString("$")
+
+	for _, route := range m.routes {
+		if route.method == method && route.pattern.String() == expr.String() {
+			return fmt.Errorf("%w: %s %s", ErrRouteExists, method, path)
+		}
+	}
+	m.routes = append(m.routes, muxRoute{
+		method:  method,
+		pattern: regexp.MustCompile(expr.String()),
+		names:   names,
+		handler: handler,
+	})
+	return nil
+}
+
+// ParamExtractor reads the path parameters matched by ServeHTTP
+func (m *Mux) ParamExtractor() ParamExtractor {
+	return func(r *http.Request, name string) string {
+		params, _ := r.Context().Value(muxParamsKey{}).(map[string]string)
+		return params[name]
+	}
+}
+
+// ServeHTTP dispatches a request to the first matching route, answering 405 when only the method differs
+func (m *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	pathMatched := false
+	path := m.policy.TrailingSlash.Apply(r.URL.Path)
+	for _, route := range m.routes {
+		match := route.pattern.FindStringSubmatch(path)
+		if match == nil {
+			continue
+		}
+		if route.method != r.Method {
+			pathMatched = true
+			continue
+		}
+		params := make(map[string]string, len(route.names))
+		for i, name := range route.names {
+			params[name] = match[i+1]
+		}
+		route.handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), muxParamsKey{}, params)))
+		return
+	}
+	if pathMatched {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	http.NotFound(w, r)
+}
@@ -0,0 +1,46 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestMux tests route matching, path parameters and method mismatches
+func TestMux(t *testing.T) {
+	mux := NewMux()
+	extract := mux.ParamExtractor()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(extract(r, "id") + "/" + extract(r, "postId")))
+	})
+	if err := mux.RegisterRoute("GET", "/users/{id}/posts/{postId}", handler); err != nil {
+		t.Fatalf("RegisterRoute failed: %v", err)
+	}
+	if err := mux.RegisterRoute("GET", "/users/{userId}/posts/{id}", handler); err == nil {
+		t.Error("Expected duplicate route to be rejected")
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "match", method: "GET", target: "/users/1/posts/2", wantStatus: http.StatusOK, wantBody: "1/2"},
+		{name: "wrong method", method: "DELETE", target: "/users/1/posts/2", wantStatus: http.StatusMethodNotAllowed},
+		{name: "no route", method: "GET", target: "/users/1", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(tt.method, tt.target, nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("Expected body %q, got %q", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
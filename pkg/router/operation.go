@@ -0,0 +1,309 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// BuildOperation documents a definition as an OpenAPI operation: parameters, request body, responses and
+// the specification extensions derived from the definition
+func BuildOperation(def *api.APIDefinition) (*api.Operation, error) {
+	return buildOperation(def, func(err error) error { return err })
+}
+
+// BuildOperationLenient documents a definition like BuildOperation, but schemas that fail to generate are
+// replaced with GenerationErrorSchema placeholders and their errors returned instead of failing
+func BuildOperationLenient(def *api.APIDefinition) (*api.Operation, []error) {
+	var errs []error
+	operation, _ := buildOperation(def, func(err error) error {
+		errs = append(errs, err)
+		return nil
+	})
+	return operation, errs
+}
+
+// GenerationErrorSchema is the placeholder of a schema that failed to generate; it accepts any value
+func GenerationErrorSchema(err error) map[string]interface{} {
+	return map[string]interface{}{
+		"description":        "Schema could not be generated",
+		"x-generation-error": err.Error(),
+	}
+}
+
+// buildOperation documents a definition, reporting each failure to fail; a non-nil result aborts the build
+func buildOperation(def *api.APIDefinition, fail func(error) error) (*api.Operation, error) {
+	description, err := def.ResolveDescription()
+	if err != nil {
+		if err := fail(err); err != nil {
+			return nil, err
+		}
+	}
+
+	operation := &api.Operation{
+		Summary:      def.Summary,
+		Description:  description,
+		Tags:         def.Tags,
+		Responses:    make(map[string]api.Response),
+		Deprecated:   def.Deprecated,
+		OperationID:  def.OperationID,
+		Security:     def.Security,
+		ExternalDocs: def.ExternalDocs,
+	}
+
+	// Operation servers override the document's, e.g. for uploads served by another host
+	for _, server := range def.Servers {
+		if err := api.ValidateServer(server); err != nil {
+			if err := fail(err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		operation.Servers = append(operation.Servers, server)
+	}
+
+	// Carry specification extensions
+	operation.Extensions = DeprecationExtensions(def)
+	if def.CORS != nil {
+		operation.Extensions["x-cors"] = def.CORS.Extension()
+	}
+	if def.RateLimit != nil {
+		operation.Extensions["x-ratelimit"] = def.RateLimit.Extension()
+	}
+	if def.SLO != nil {
+		operation.Extensions["x-slo"] = def.SLO.Extension()
+	}
+	if len(def.Plans) > 0 {
+		operation.Extensions["x-plans"] = def.Plans
+	}
+	if len(def.Conditions) > 0 {
+		operation.Extensions["x-conditions"] = def.Conditions
+	}
+	if len(def.RequestRules) > 0 {
+		rules := make([]string, len(def.RequestRules))
+		for i, rule := range def.RequestRules {
+			rules[i] = rule.Description
+		}
+		operation.Extensions["x-request-rules"] = rules
+	}
+	if len(def.Changelog) > 0 {
+		operation.Extensions["x-changelog"] = def.Changelog
+	}
+	for key, value := range def.Extensions {
+		operation.Extensions[key] = value
+	}
+
+	// Generate parameter definitions
+	if len(def.Params) > 0 {
+		operation.Parameters = make([]api.Parameter, len(def.Params))
+		for i, param := range def.Params {
+			operation.Parameters[i] = DocumentedParameter(param)
+		}
+	}
+
+	// Generate request body schema
+	if def.Request != nil {
+		schema, err := api.RequestSchema(def)
+		if err != nil {
+			err = fmt.Errorf("failed to generate request schema: %w", err)
+			if err := fail(err); err != nil {
+				return nil, err
+			}
+			schema = GenerationErrorSchema(err)
+		}
+		if schema != nil {
+			operation.RequestBody = &api.RequestBody{
+				Content: map[string]api.Content{
+					"application/json": {
+						Schema: schema,
+					},
+				},
+			}
+			for _, mediaType := range def.RequestTypes {
+				operation.RequestBody.Content[mediaType] = api.Content{Schema: schema}
+			}
+		}
+	}
+
+	// Patch formats replace the JSON request model
+	if len(def.PatchFormats) > 0 {
+		content := make(map[string]api.Content, len(def.PatchFormats))
+		for _, format := range def.PatchFormats {
+			switch format {
+			case api.MediaTypeMergePatch:
+				if operation.RequestBody != nil {
+					model := operation.RequestBody.Content["application/json"].Schema
+					content[format] = api.Content{Schema: api.MergePatchSchema(model)}
+				}
+			case api.MediaTypeJSONPatch:
+				content[format] = api.Content{Schema: api.JSONPatchSchema()}
+			}
+		}
+		operation.RequestBody = &api.RequestBody{Content: content}
+	}
+
+	// Streamed bodies are documented but never decoded
+	if def.StreamsRequest() {
+		var model map[string]interface{}
+		if operation.RequestBody != nil {
+			model = operation.RequestBody.Content["application/json"].Schema
+		}
+		content := make(map[string]api.Content, len(def.RequestStream))
+		for _, mediaType := range def.RequestStream {
+			content[mediaType] = api.Content{Schema: api.StreamedBodySchema(mediaType, model)}
+		}
+		operation.RequestBody = &api.RequestBody{Content: content}
+	}
+
+	// Generate response schema
+	if def.Response == nil && def.ResponseType != "" {
+		operation.Responses["200"] = api.Response{
+			Description: "Success",
+			Content: map[string]api.Content{
+				def.ResponseType: {
+					Schema: api.BinarySchema(),
+				},
+			},
+		}
+	}
+	if def.Response != nil {
+		schema, err := api.SafeSchemaFromStruct(def.Response)
+		if err != nil {
+			err = fmt.Errorf("failed to generate response schema: %w", err)
+			if err := fail(err); err != nil {
+				return nil, err
+			}
+			schema = GenerationErrorSchema(err)
+		} else if schema != nil && def.Hypermedia != nil {
+			linked, err := def.Hypermedia.Schema(schema)
+			if err != nil {
+				if err := fail(err); err != nil {
+					return nil, err
+				}
+				linked = GenerationErrorSchema(err)
+			}
+			schema = linked
+		}
+		if schema != nil {
+			operation.Responses["200"] = api.Response{
+				Description: "Success",
+				Content: map[string]api.Content{
+					"application/json": {
+						Schema: schema,
+					},
+				},
+			}
+		}
+	}
+
+	// Attach documented success response headers
+	if len(def.Headers) > 0 {
+		success, ok := operation.Responses["200"]
+		if !ok {
+			success = api.Response{Description: "Success"}
+		}
+		success.Headers = def.Headers
+		operation.Responses["200"] = success
+	}
+
+	// Add additional documented responses
+	for status, spec := range def.Responses {
+		resp := api.Response{
+			Description: spec.Description,
+			Headers:     spec.Headers,
+			Links:       spec.Links,
+		}
+		if spec.Model != nil {
+			schema, err := api.SafeSchemaFromStruct(spec.Model)
+			if err != nil {
+				err = fmt.Errorf("failed to generate %d response schema: %w", status, err)
+				if err := fail(err); err != nil {
+					return nil, err
+				}
+				schema = GenerationErrorSchema(err)
+			}
+			resp.Content = map[string]api.Content{
+				"application/json": {
+					Schema: schema,
+				},
+			}
+		}
+		operation.Responses[strconv.Itoa(status)] = resp
+	}
+
+	return operation, nil
+}
+
+// SharedParameters returns the referenced parameters of a definition, keyed by their components.parameters name
+func SharedParameters(def *api.APIDefinition) map[string]api.Parameter {
+	var shared map[string]api.Parameter
+	for _, param := range def.Params {
+		if param.Ref == "" {
+			continue
+		}
+		if shared == nil {
+			shared = make(map[string]api.Parameter)
+		}
+		documented := DocumentedParameter(param)
+		documented.Ref = ""
+		shared[api.ParameterRefName(param.Ref)] = documented
+	}
+	return shared
+}
+
+// DocumentedParameter fills in what the specification requires of a parameter:
+// a schema (string when none was given) carrying the keywords of its validation rules, and required for path parameters
+func DocumentedParameter(param api.Parameter) api.Parameter {
+	if param.Schema == nil && len(param.Content) == 0 {
+		param.Schema = map[string]interface{}{"type": "string"}
+	}
+	if keywords := param.RuleKeywords(); len(keywords) > 0 && param.Schema != nil {
+		schema := make(map[string]interface{}, len(param.Schema)+len(keywords))
+		for key, value := range param.Schema {
+			schema[key] = value
+		}
+		for key, value := range keywords {
+			schema[key] = value
+		}
+		param.Schema = schema
+	}
+	if param.In == "path" {
+		param.Required = true
+	}
+	return param
+}
+
+// DeprecationExtensions returns the x-sunset/x-replacement extensions for a deprecated definition
+func DeprecationExtensions(def *api.APIDefinition) map[string]interface{} {
+	extensions := make(map[string]interface{})
+	if !def.Deprecated {
+		return extensions
+	}
+	if !def.Sunset.IsZero() {
+		extensions["x-sunset"] = def.Sunset.UTC().Format("2006-01-02")
+	}
+	if def.Replacement != "" {
+		extensions["x-replacement"] = def.Replacement
+	}
+	return extensions
+}
+
+// NormalizeMethod upper-cases a method, rejecting those that can't be documented
+func NormalizeMethod(method string) (string, error) {
+	if !api.IsSupportedMethod(method) {
+		return "", fmt.Errorf("unsupported HTTP method: %s", method)
+	}
+	return strings.ToUpper(method), nil
+}
+
+// HasRequestBody reports whether requests of the method carry a body validated against the request model
+func HasRequestBody(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// OperationIDStrategy derives the operationId of an operation that does not set one explicitly
+type OperationIDStrategy func(method, path string, def api.APIDefinition) string
+
+// SetOperationIDStrategy replaces the default tag_path operationId generation
+// Generated IDs colliding with another operation get a deterministic numeric suffix (_2, _3, ...)
+func (r *Router) SetOperationIDStrategy(strategy OperationIDStrategy) {
+	r.idStrategy = strategy
+}
+
+// AssignOperationIDs names every operation of doc lacking an operationId with strategy, falling back to
+// GenerateOperationID, and keeps IDs unique across the document
+// Explicit IDs are reserved first and operations are visited in path and method order so suffixes are stable
+func AssignOperationIDs(doc *api.OpenAPIDoc, defs []api.APIDefinition, strategy OperationIDStrategy) {
+	definitions := make(map[string]api.APIDefinition, len(defs))
+	for _, def := range defs {
+		definitions[operationKey(def.Method, def.Path)] = def
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	used := make(map[string]bool)
+	for _, path := range paths {
+		for _, op := range doc.Paths[path].Operations() {
+			if op.OperationID != "" {
+				used[op.OperationID] = true
+			}
+		}
+	}
+
+	for _, path := range paths {
+		pathItem := doc.Paths[path]
+		for _, method := range api.SupportedMethods {
+			op := pathItem.Operation(method)
+			if op == nil || op.OperationID != "" {
+				continue
+			}
+
+			var id string
+			if strategy != nil {
+				def, ok := definitions[operationKey(method, path)]
+				if !ok {
+					def = api.APIDefinition{Method: method, Path: path, Tags: op.Tags}
+				}
+				id = strategy(method, path, def)
+			}
+			if id == "" {
+				id = GenerateOperationID(path, op)
+			}
+
+			unique := id
+			for n := 2; used[unique]; n++ {
+				unique = fmt.Sprintf("%s_%d", id, n)
+			}
+			used[unique] = true
+			op.OperationID = unique
+		}
+	}
+}
+
+// GenerateOperationID generates an operation ID from the first tag and the path without its parameters
+func GenerateOperationID(path string, op *api.Operation) string {
+	// Remove path parameters
+	path = regexp.MustCompile(`\{[^}]+\}`).ReplaceAllString(path, "")
+	// Remove special characters
+	path = regexp.MustCompile(`[^a-zA-Z0-9]`).ReplaceAllString(path, "_")
+	// Remove consecutive underscores
+	path = regexp.MustCompile(`_+`).ReplaceAllString(path, "_")
+	// Remove leading and trailing underscores
+	path = strings.Trim(path, "_")
+
+	// Get the first tag if available
+	prefix := "operation"
+	if len(op.Tags) > 0 {
+		prefix = strings.ToLower(op.Tags[0])
+	}
+	return fmt.Sprintf("%s_%s", prefix, path)
+}
+
+// operationKey identifies an operation by method and documented path
+func operationKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
+// RecordOperationIDs remembers the operationIds of a generated document so request contexts carry them too
+func (r *Router) RecordOperationIDs(doc *api.OpenAPIDoc) {
+	ids := make(map[string]string)
+	for path, item := range doc.Paths {
+		for _, method := range api.SupportedMethods {
+			if op := item.Operation(method); op != nil {
+				ids[operationKey(method, path)] = op.OperationID
+			}
+		}
+	}
+	r.docMu.Lock()
+	r.operationIDs = ids
+	r.docMu.Unlock()
+}
+
+// HasOperationID reports whether the last generated document names an operation with the ID
+func (r *Router) HasOperationID(id string) bool {
+	if id == "" {
+		return false
+	}
+	r.docMu.RLock()
+	defer r.docMu.RUnlock()
+	for _, known := range r.operationIDs {
+		if known == id {
+			return true
+		}
+	}
+	return false
+}
+
+// withOperation stores the matched definition in the request context for api.OperationFromContext
+// Definitions without an explicit operationId carry the one of the last generated document
+func (r *Router) withOperation(req *http.Request, def *api.APIDefinition) *http.Request {
+	if def.OperationID == "" {
+		r.docMu.RLock()
+		id := r.operationIDs[operationKey(def.Method, def.Path)]
+		r.docMu.RUnlock()
+		if id != "" {
+			named := *def
+			named.OperationID = id
+			def = &named
+		}
+	}
+	return req.WithContext(api.ContextWithOperation(req.Context(), def))
+}
@@ -0,0 +1,239 @@
+package router
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// userResponse is a response model
+type userResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// TestBuildOperation tests documenting a definition as an operation
+func TestBuildOperation(t *testing.T) {
+	sunset := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	def := api.NewAPIDefinition("POST", "/users/{id}", "Update user").
+		WithOperationID("updateUser").
+		WithPathParam("id", "User ID", false).
+		WithRequest(createUser{}).
+		WithResponse(userResponse{}).
+		WithStatusResponse(404, "Not found", nil).
+		WithDeprecation(sunset, "/v2/users/{id}").
+		WithCORS(api.CORSPolicy{AllowOrigins: []string{"https://example.com"}}).
+		WithExtension("x-owner", "accounts")
+
+	op, err := BuildOperation(def)
+	if err != nil {
+		t.Fatalf("BuildOperation failed: %v", err)
+	}
+	if op.OperationID != "updateUser" || !op.Deprecated {
+		t.Errorf("Unexpected operation: %+v", op)
+	}
+	if len(op.Parameters) != 1 || !op.Parameters[0].Required || op.Parameters[0].Schema["type"] != "string" {
+		t.Errorf("Expected a required string path parameter, got %+v", op.Parameters)
+	}
+	if op.RequestBody == nil || op.RequestBody.Content["application/json"].Schema == nil {
+		t.Error("Expected a JSON request body schema")
+	}
+	if _, ok := op.Responses["200"]; !ok {
+		t.Error("Expected a 200 response")
+	}
+	if _, ok := op.Responses["404"]; !ok {
+		t.Error("Expected a 404 response")
+	}
+	for _, key := range []string{"x-sunset", "x-replacement", "x-cors", "x-owner"} {
+		if _, ok := op.Extensions[key]; !ok {
+			t.Errorf("Expected extension %s, got %v", key, op.Extensions)
+		}
+	}
+}
+
+// TestSharedParameters tests collecting referenced parameters
+func TestSharedParameters(t *testing.T) {
+	def := api.NewAPIDefinition("GET", "/items", "List items").
+		WithParams([]api.Parameter{
+			{Name: "page", In: "query", Ref: "#/components/parameters/Page"},
+			{Name: "q", In: "query"},
+		})
+	shared := SharedParameters(def)
+	if len(shared) != 1 {
+		t.Fatalf("Expected one shared parameter, got %v", shared)
+	}
+	if page, ok := shared["Page"]; !ok || page.Ref != "" || page.Name != "page" {
+		t.Errorf("Expected the Page parameter without its ref, got %+v", shared)
+	}
+}
+
+// TestNormalizeMethod tests method normalization
+func TestNormalizeMethod(t *testing.T) {
+	tests := []struct {
+		method  string
+		want    string
+		wantErr bool
+	}{
+		{method: "get", want: "GET"},
+		{method: "PATCH", want: "PATCH"},
+		{method: "TRACE", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			got, err := NormalizeMethod(tt.method)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("NormalizeMethod(%q) = %q, %v", tt.method, got, err)
+			}
+		})
+	}
+}
+
+// TestDocumentedParameterRules tests that rule keywords are documented without touching the declared schema
+func TestDocumentedParameterRules(t *testing.T) {
+	declared := map[string]interface{}{"type": "number"}
+	param := api.Parameter{
+		Name:   "amount",
+		In:     "query",
+		Schema: declared,
+		Validations: []api.ValidationRule{
+			api.NewValidationRule(api.RuleExclusiveMin, 0, "amount must be positive"),
+			api.NewValidationRule(api.RuleMultipleOf, 0.01, "amount has too many decimals"),
+		},
+	}
+	documented := DocumentedParameter(param)
+	if documented.Schema["exclusiveMinimum"] != true || documented.Schema["minimum"] != 0 || documented.Schema["multipleOf"] != 0.01 {
+		t.Errorf("Unexpected schema %v", documented.Schema)
+	}
+	if len(declared) != 1 {
+		t.Errorf("Expected the declared schema to be left unchanged, got %v", declared)
+	}
+}
+
+// TestBuildOperationHypermedia tests documenting success responses with their hypermedia links
+func TestBuildOperationHypermedia(t *testing.T) {
+	def := api.NewAPIDefinition("GET", "/users/{id}", "Get user").
+		WithHALResponse(userResponse{}, []string{"self"}, nil)
+	operation, err := BuildOperation(def)
+	if err != nil {
+		t.Fatalf("BuildOperation failed: %v", err)
+	}
+	props := operation.Responses["200"].Content["application/json"].Schema["properties"].(map[string]interface{})
+	if _, ok := props["_links"]; !ok || props["name"] == nil {
+		t.Errorf("Expected the user schema with _links, got %v", props)
+	}
+}
+
+// brokenSchema declares a schema that cannot be generated
+type brokenSchema struct{}
+
+// OpenAPISchema fails the schema generation
+func (brokenSchema) OpenAPISchema() map[string]interface{} {
+	panic("unsupported model")
+}
+
+// brokenResponse is a response model whose schema fails to generate
+type brokenResponse struct {
+	Value brokenSchema `json:"value"`
+}
+
+// TestBuildOperationLenient tests documenting placeholders for schemas that fail to generate
+func TestBuildOperationLenient(t *testing.T) {
+	def := api.NewAPIDefinition("POST", "/users", "Create user").
+		WithRequest(createUser{}).
+		WithResponse(brokenResponse{}).
+		WithStatusResponse(409, "Conflict", brokenResponse{})
+
+	if _, err := BuildOperation(def); err == nil {
+		t.Fatal("BuildOperation succeeded with a broken response model")
+	}
+
+	operation, errs := BuildOperationLenient(def)
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", errs)
+	}
+	if operation.RequestBody == nil || operation.RequestBody.Content["application/json"].Schema["type"] != "object" {
+		t.Error("Expected the request schema to be generated")
+	}
+	for _, status := range []string{"200", "409"} {
+		schema := operation.Responses[status].Content["application/json"].Schema
+		if _, ok := schema["x-generation-error"]; !ok {
+			t.Errorf("Expected a placeholder %s response schema, got %v", status, schema)
+		}
+	}
+}
+
+// TestBuildOperationServers tests emitting operation-specific servers and rejecting invalid ones
+func TestBuildOperationServers(t *testing.T) {
+	def := api.NewAPIDefinition("PUT", "/files/{name}", "Upload file").
+		WithServer("/uploads", "Upload gateway").
+		WithServerVariables("https://{region}.uploads.example.com", "Regional uploads", map[string]api.ServerVariable{
+			"region": {Default: "eu", Enum: []string{"eu", "us"}},
+		})
+	operation, err := BuildOperation(def)
+	if err != nil {
+		t.Fatalf("BuildOperation() error = %v", err)
+	}
+	if len(operation.Servers) != 2 || operation.Servers[0].URL != "/uploads" || operation.Servers[1].Variables["region"].Default != "eu" {
+		t.Errorf("Unexpected servers %+v", operation.Servers)
+	}
+
+	plain, err := BuildOperation(api.NewAPIDefinition("GET", "/files", "List files"))
+	if err != nil {
+		t.Fatalf("BuildOperation() error = %v", err)
+	}
+	if plain.Servers != nil {
+		t.Errorf("Expected no servers, got %+v", plain.Servers)
+	}
+
+	invalid := api.NewAPIDefinition("GET", "/files", "List files").WithServer("https://{region}.example.com", "Regional")
+	if _, err := BuildOperation(invalid); err == nil {
+		t.Error("Expected an undeclared server variable to fail the build")
+	}
+	if operation, errs := BuildOperationLenient(invalid); len(errs) != 1 || operation.Servers != nil {
+		t.Errorf("BuildOperationLenient() servers = %+v, errors = %v", operation.Servers, errs)
+	}
+}
+
+// TestBuildOperationMetadata tests carrying the definition metadata through to the operation
+func TestBuildOperationMetadata(t *testing.T) {
+	docs := &api.ExternalDocumentation{Description: "User guide", URL: "https://docs.example.com/users"}
+	security := []map[string][]string{{"oauth2": {"users:write"}}}
+	tests := []struct {
+		name  string
+		def   *api.APIDefinition
+		check func(op *api.Operation) bool
+	}{
+		{"operation ID", api.NewAPIDefinition("GET", "/users", "List users").WithOperationID("listUsers"), func(op *api.Operation) bool {
+			return op.OperationID == "listUsers"
+		}},
+		{"deprecated", api.NewAPIDefinition("GET", "/users", "List users").WithDeprecated(true), func(op *api.Operation) bool {
+			return op.Deprecated
+		}},
+		{"security", api.NewAPIDefinition("GET", "/users", "List users").WithSecurity("oauth2", []string{"users:write"}), func(op *api.Operation) bool {
+			return reflect.DeepEqual(op.Security, security)
+		}},
+		{"external docs", api.NewAPIDefinition("GET", "/users", "List users").WithExternalDocs(docs.Description, docs.URL), func(op *api.Operation) bool {
+			return reflect.DeepEqual(op.ExternalDocs, docs)
+		}},
+		{"tags and summary", api.NewAPIDefinition("GET", "/users", "List users").WithTags("users"), func(op *api.Operation) bool {
+			return op.Summary == "List users" && reflect.DeepEqual(op.Tags, []string{"users"})
+		}},
+		{"unset metadata", api.NewAPIDefinition("GET", "/users", "List users"), func(op *api.Operation) bool {
+			return op.OperationID == "" && !op.Deprecated && len(op.Security) == 0 && op.ExternalDocs == nil && len(op.Servers) == 0
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := BuildOperation(tt.def)
+			if err != nil {
+				t.Fatalf("BuildOperation() error = %v", err)
+			}
+			if !tt.check(op) {
+				t.Errorf("Unexpected operation %+v", op)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// ValidatePatch reads the body of a definition accepting patch formats, answering 415 for other media types
+// With a validator the document is checked and returned decoded (see api.PatchValidator.Validate);
+// without one it is returned as a json.RawMessage. The body is restored for the handler
+func ValidatePatch(def *api.APIDefinition, validator *api.PatchValidator, r *http.Request) (interface{}, *ValidationError) {
+	mediaType := api.PatchMediaType(def, r.Header.Get("Content-Type"))
+	if mediaType == "" {
+		return nil, &ValidationError{
+			Status:  http.StatusUnsupportedMediaType,
+			Message: fmt.Sprintf("unsupported content type: %s; expected %s", r.Header.Get("Content-Type"), strings.Join(def.PatchFormats, " or ")),
+		}
+	}
+	data, verr := readBody(r)
+	if verr != nil {
+		return nil, verr
+	}
+	if validator == nil {
+		return json.RawMessage(data), nil
+	}
+	doc, err := validator.Validate(mediaType, data)
+	if err != nil {
+		return nil, badRequest("invalid patch document: %v", err)
+	}
+	return doc, nil
+}
+
+// PatchValidation checks the patch bodies of one definition, compiling its validator on first use
+// The zero value is ready to use
+type PatchValidation struct {
+	once      sync.Once
+	validator *api.PatchValidator
+	err       error
+}
+
+// Check reads the patch body of a request with ValidatePatch, validating the document when validate is set
+func (p *PatchValidation) Check(def *api.APIDefinition, r *http.Request, validate bool) (interface{}, *ValidationError) {
+	if !validate {
+		return ValidatePatch(def, nil, r)
+	}
+	p.once.Do(func() { p.validator, p.err = api.NewPatchValidator(def) })
+	if p.err != nil {
+		return nil, &ValidationError{Status: http.StatusInternalServerError, Message: p.err.Error()}
+	}
+	return ValidatePatch(def, p.validator, r)
+}
@@ -0,0 +1,86 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestPatchRequests tests accepting, validating and documenting patch documents
+func TestPatchRequests(t *testing.T) {
+	tests := []struct {
+		name        string
+		validate    bool
+		contentType string
+		body        string
+		wantStatus  int
+		wantBody    string
+	}{
+		{name: "merge patch", validate: true, contentType: api.MediaTypeMergePatch, body: `{"name":"alice"}`, wantStatus: http.StatusOK, wantBody: "merge patch"},
+		{name: "json patch", validate: true, contentType: api.MediaTypeJSONPatch, body: `[{"op":"remove","path":"/name"}]`, wantStatus: http.StatusOK, wantBody: "json patch"},
+		{name: "invalid merge patch", validate: true, contentType: api.MediaTypeMergePatch, body: `{"name":1}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid json patch", validate: true, contentType: api.MediaTypeJSONPatch, body: `[{"op":"remove","path":"/role"}]`, wantStatus: http.StatusBadRequest},
+		{name: "unvalidated", contentType: api.MediaTypeJSONPatch, body: `[{"op":"remove","path":"/role"}]`, wantStatus: http.StatusOK, wantBody: "raw"},
+		{name: "plain json", validate: true, contentType: "application/json", body: `{"name":"alice"}`, wantStatus: http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := NewMux()
+			r := New(mux, "/api", "Test API", "1.0.0", "Test")
+			if tt.validate {
+				r.EnablePatchValidation()
+			}
+			def := api.NewAPIDefinition("PATCH", "/users/{id}", "Patch user").
+				WithJSONMergePatch(createUser{}).
+				WithJSONPatch().
+				WithHandler(func(w http.ResponseWriter, req *http.Request) {
+					switch RequestBody(req.Context()).(type) {
+					case map[string]interface{}:
+						fmt.Fprint(w, "merge patch")
+					case []api.PatchOperation:
+						fmt.Fprint(w, "json patch")
+					case json.RawMessage:
+						fmt.Fprint(w, "raw")
+					}
+				})
+			if err := r.Register(def); err != nil {
+				t.Fatalf("Register failed: %v", err)
+			}
+
+			req := httptest.NewRequest("PATCH", "/api/users/1", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("Expected %s body, got %s", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestPatchDocumentation tests documenting patch media types instead of the JSON request model
+func TestPatchDocumentation(t *testing.T) {
+	def := api.NewAPIDefinition("PATCH", "/users/{id}", "Patch user").WithJSONMergePatch(createUser{}).WithJSONPatch()
+	operation, err := BuildOperation(def)
+	if err != nil {
+		t.Fatalf("BuildOperation failed: %v", err)
+	}
+	content := operation.RequestBody.Content
+	if _, ok := content["application/json"]; ok || len(content) != 2 {
+		t.Fatalf("Expected only the patch media types, got %v", content)
+	}
+	if _, ok := content[api.MediaTypeMergePatch].Schema["required"]; ok {
+		t.Error("Expected an all-optional merge patch schema")
+	}
+	if content[api.MediaTypeJSONPatch].Schema["type"] != "array" {
+		t.Errorf("Expected a JSON Patch array schema, got %v", content[api.MediaTypeJSONPatch].Schema)
+	}
+}
@@ -0,0 +1,46 @@
+package router
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// BindPath converts the path parameters of a request into a new instance of the definition's path model and
+// checks its `binding` tags; the pointer is returned
+func BindPath(def *api.APIDefinition, r *http.Request, pathParam ParamExtractor) (interface{}, *ValidationError) {
+	t := reflect.TypeOf(def.PathModel)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	target := reflect.New(t)
+	if t.Kind() != reflect.Struct {
+		return target.Interface(), nil
+	}
+
+	if verr := bindPathFields(target.Elem(), r, pathParam); verr != nil {
+		return nil, verr
+	}
+	if err := bodyValidator.Struct(target.Interface()); err != nil {
+		return nil, badRequest("invalid path parameters: %v", err)
+	}
+	return target.Interface(), nil
+}
+
+// bindPathFields converts the path parameters of a request into the `path` tagged fields of a struct
+func bindPathFields(v reflect.Value, r *http.Request, pathParam ParamExtractor) *ValidationError {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("path"), ",")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		if err := bindValue(v.Field(i), pathParam(r, name)); err != nil {
+			return badRequest("invalid path parameter %s: %v", name, err)
+		}
+	}
+	return nil
+}
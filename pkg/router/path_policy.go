@@ -0,0 +1,63 @@
+package router
+
+import "strings"
+
+// TrailingSlash selects how the trailing slash of registered paths is normalized
+type TrailingSlash int
+
+const (
+	// KeepTrailingSlash registers and documents paths as declared
+	KeepTrailingSlash TrailingSlash = iota
+	// StripTrailingSlash turns "/users/" into "/users"
+	StripTrailingSlash
+	// AddTrailingSlash turns "/users" into "/users/"
+	AddTrailingSlash
+)
+
+// Apply normalizes the trailing slash of a path, leaving the root path alone
+func (t TrailingSlash) Apply(path string) string {
+	if path == "" || path == "/" {
+		return path
+	}
+	switch t {
+	case StripTrailingSlash:
+		if trimmed := strings.TrimRight(path, "/"); trimmed != "" {
+			return trimmed
+		}
+		return "/"
+	case AddTrailingSlash:
+		if !strings.HasSuffix(path, "/") {
+			return path + "/"
+		}
+	}
+	return path
+}
+
+// PathPolicy normalizes definition paths before they are registered and documented, so that routes and the
+// Paths keys of the document always agree
+type PathPolicy struct {
+	TrailingSlash   TrailingSlash
+	CaseInsensitive bool // Lowercase static path segments and match requests regardless of their case
+}
+
+// Normalize returns a path in the policy's canonical form; parameter names keep their case
+func (p PathPolicy) Normalize(path string) string {
+	if p.CaseInsensitive {
+		var b strings.Builder
+		last := 0
+		for _, loc := range pathParamPattern.FindAllStringIndex(path, -1) {
+			b.WriteString(strings.ToLower(path[last:loc[0]]))
+			b.WriteString(path[loc[0]:loc[1]])
+			last = loc[1]
+		}
+		b.WriteString(strings.ToLower(path[last:]))
+		path = b.String()
+	}
+	return p.TrailingSlash.Apply(path)
+}
+
+// PathPolicyAdapter is implemented by adapters that match requests according to the router's path policy
+type PathPolicyAdapter interface {
+	// SetPathPolicy makes routes registered afterwards match requests differing in case or trailing slash
+	SetPathPolicy(policy PathPolicy)
+}
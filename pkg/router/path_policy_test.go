@@ -0,0 +1,81 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestPathPolicyNormalize tests trailing-slash and casing normalization of paths
+func TestPathPolicyNormalize(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy PathPolicy
+		path   string
+		want   string
+	}{
+		{"keep", PathPolicy{}, "/Users/", "/Users/"},
+		{"strip", PathPolicy{TrailingSlash: StripTrailingSlash}, "/users//", "/users"},
+		{"strip root", PathPolicy{TrailingSlash: StripTrailingSlash}, "/", "/"},
+		{"add", PathPolicy{TrailingSlash: AddTrailingSlash}, "/users", "/users/"},
+		{"lowercase keeps parameter names", PathPolicy{CaseInsensitive: true}, "/Users/{userID}/Posts", "/users/{userID}/posts"},
+		{"both", PathPolicy{TrailingSlash: StripTrailingSlash, CaseInsensitive: true}, "/Users/{ID}/", "/users/{ID}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Normalize(tt.path); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPathPolicy tests that registered routes and documented paths follow the policy
+func TestPathPolicy(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	r.SetPathPolicy(PathPolicy{TrailingSlash: StripTrailingSlash, CaseInsensitive: true})
+	var id string
+	handler := func(w http.ResponseWriter, req *http.Request) {
+		id = mux.ParamExtractor()(req, "ID")
+		w.WriteHeader(http.StatusOK)
+	}
+	if err := r.Register(api.NewAPIDefinition("GET", "/Users/{ID}/", "Get user").WithPathParam("ID", "User ID", true).WithHandler(handler)); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+	}{
+		{name: "canonical", target: "/api/users/Ab1", wantStatus: http.StatusOK},
+		{name: "trailing slash", target: "/api/users/Ab1/", wantStatus: http.StatusOK},
+		{name: "mixed case", target: "/api/USERS/Ab1", wantStatus: http.StatusOK},
+		{name: "other path", target: "/api/orders/Ab1", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id = ""
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("GET", tt.target, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus == http.StatusOK && id != "Ab1" {
+				t.Errorf("Expected path parameter Ab1, got %q", id)
+			}
+		})
+	}
+
+	doc, err := r.BuildOpenAPI()
+	if err != nil {
+		t.Fatalf("BuildOpenAPI failed: %v", err)
+	}
+	if _, ok := doc.Paths["/users/{ID}"]; !ok || len(doc.Paths) != 1 {
+		t.Errorf("Expected the normalized path, got %v", doc.Paths)
+	}
+}
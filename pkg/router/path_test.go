@@ -0,0 +1,112 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// sku is a path value decoded with encoding.TextUnmarshaler, like uuid.UUID
+type sku string
+
+// UnmarshalText accepts SKU-prefixed codes
+func (s *sku) UnmarshalText(text []byte) error {
+	if !strings.HasPrefix(string(text), "SKU-") {
+		return fmt.Errorf("missing SKU- prefix")
+	}
+	*s = sku(text)
+	return nil
+}
+
+type itemPath struct {
+	OrderID int64 `path:"id" binding:"min=1"`
+	SKU     sku   `path:"sku"`
+}
+
+// TestBindPath tests converting path parameters into the path model
+func TestBindPath(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	def := api.NewAPIDefinition("GET", "/orders/{id}/items/{sku}", "Get item").
+		WithPathModel(itemPath{}).
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {
+			p := PathModel(req.Context()).(*itemPath)
+			fmt.Fprintf(w, "%d %s", p.OrderID, p.SKU)
+		})
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "bound", target: "/api/orders/42/items/SKU-7", wantStatus: http.StatusOK, wantBody: "42 SKU-7"},
+		{name: "invalid integer", target: "/api/orders/x/items/SKU-7", wantStatus: http.StatusBadRequest},
+		{name: "invalid value", target: "/api/orders/0/items/SKU-7", wantStatus: http.StatusBadRequest},
+		{name: "invalid text", target: "/api/orders/42/items/7", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("GET", tt.target, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("Expected %q, got %q", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+type renameItem struct {
+	OrderID int64  `path:"id" json:"-" binding:"min=1"`
+	Name    string `json:"name" binding:"required"`
+}
+
+// TestRequestPathFields tests filling path tagged fields of the request model before validation
+func TestRequestPathFields(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	def := api.NewAPIDefinition("PUT", "/orders/{id}", "Rename order").
+		WithRequest(renameItem{}).
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {
+			body := RequestBody(req.Context()).(*renameItem)
+			fmt.Fprintf(w, "%d %s", body.OrderID, body.Name)
+		})
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "bound", target: "/api/orders/42", wantStatus: http.StatusOK, wantBody: "42 gift"},
+		{name: "invalid integer", target: "/api/orders/x", wantStatus: http.StatusBadRequest},
+		{name: "invalid value", target: "/api/orders/0", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", tt.target, strings.NewReader(`{"name":"gift"}`))
+			req.Header.Set("Content-Type", "application/json")
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("Expected %q, got %q", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// EffectiveLimits returns the payload limits applying to a definition: its own, or the router-wide defaults
+// A body size set with WithMaxBodySize wins over both; streamed bodies are only bounded in size
+func EffectiveLimits(def *api.APIDefinition, defaults api.PayloadLimits) api.PayloadLimits {
+	limits := defaults
+	if def.PayloadLimits != nil {
+		limits = *def.PayloadLimits
+	}
+	if def.MaxBodySize > 0 {
+		limits.MaxBodySize = def.MaxBodySize
+	}
+	if def.StreamsRequest() {
+		return api.PayloadLimits{MaxBodySize: limits.MaxBodySize}
+	}
+	return limits
+}
+
+// CheckPayloadLimits rejects a request body exceeding the shape limits with 400 before it is decoded
+// The body is restored for later decoding
+func CheckPayloadLimits(limits api.PayloadLimits, r *http.Request) *ValidationError {
+	data, verr := readBody(r)
+	if verr != nil {
+		return verr
+	}
+	if err := api.CheckPayload(data, limits); err != nil {
+		return badRequest("invalid request body: %v", err)
+	}
+	return nil
+}
+
+// DocumentPayloadLimits adds the 413 and 400 responses of router-wide payload limits to an operation
+// with a request body, keeping responses it already documents
+func DocumentPayloadLimits(operation *api.Operation, limits api.PayloadLimits) {
+	if operation.RequestBody == nil || limits.IsZero() {
+		return
+	}
+	if operation.Responses == nil {
+		operation.Responses = make(map[string]api.Response)
+	}
+	if _, ok := operation.Responses["413"]; !ok && limits.MaxBodySize > 0 {
+		operation.Responses["413"] = api.Response{
+			Description: fmt.Sprintf("Payload Too Large - The request body exceeds %d bytes", limits.MaxBodySize),
+		}
+	}
+	if _, ok := operation.Responses["400"]; !ok && (limits.MaxDepth > 0 || limits.MaxArrayLength > 0 || limits.MaxStringLength > 0) {
+		operation.Responses["400"] = api.Response{Description: limits.Describe()}
+	}
+}
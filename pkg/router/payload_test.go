@@ -0,0 +1,63 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestPayloadLimits tests router-wide limits, per-definition overrides and their documentation
+func TestPayloadLimits(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	r.SetPayloadLimits(api.PayloadLimits{MaxBodySize: 64, MaxStringLength: 8})
+	handler := func(w http.ResponseWriter, req *http.Request) { w.WriteHeader(http.StatusCreated) }
+	users := api.NewAPIDefinition("POST", "/users", "Create user").WithRequest(createUser{}).WithHandler(handler)
+	imports := api.NewAPIDefinition("POST", "/imports", "Import users").
+		WithRequest(createUser{}).
+		WithPayloadLimits(api.PayloadLimits{MaxStringLength: 64}).
+		WithHandler(handler)
+	for _, def := range []*api.APIDefinition{users, imports} {
+		if err := r.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		body       string
+		wantStatus int
+	}{
+		{name: "within limits", target: "/api/users", body: `{"name":"Ada","email":"a@b.co"}`, wantStatus: http.StatusCreated},
+		{name: "long string", target: "/api/users", body: `{"name":"Ada Lovelace","email":"a@b.co"}`, wantStatus: http.StatusBadRequest},
+		{name: "too large", target: "/api/users", body: `{"name":"Ada","email":"a@b.co","bio":"` + strings.Repeat("x", 64) + `"}`, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "definition override", target: "/api/imports", body: `{"name":"Ada Lovelace","email":"a@b.co"}`, wantStatus: http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	doc, err := r.BuildOpenAPI()
+	if err != nil {
+		t.Fatalf("BuildOpenAPI failed: %v", err)
+	}
+	responses := doc.Paths["/users"].Post.Responses
+	if _, ok := responses["413"]; !ok {
+		t.Error("Expected 413 response from router-wide limits")
+	}
+	if !strings.Contains(responses["400"].Description, "strings of 8 bytes") {
+		t.Errorf("Unexpected 400 response %q", responses["400"].Description)
+	}
+}
@@ -0,0 +1,13 @@
+package router
+
+import (
+	"net/http"
+)
+
+// PlanResolver returns the plan of the tenant making a request
+type PlanResolver func(r *http.Request) string
+
+// SetPlanResolver enables rejecting requests to operations outside the caller's plan with 403
+func (r *Router) SetPlanResolver(resolver PlanResolver) {
+	r.planResolver = resolver
+}
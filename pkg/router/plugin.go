@@ -0,0 +1,74 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// Plugin extends the router without dedicated options; it implements any of RegisterHook,
+// GenerateHook and RequestHook to take part in the corresponding lifecycle stage
+type Plugin interface {
+	Name() string
+}
+
+// RegisterHook is called for every definition before its route is registered
+// The definition may be adjusted; an error aborts the registration
+type RegisterHook interface {
+	OnRegister(def *api.APIDefinition) error
+}
+
+// GenerateHook is called with every generated document before it is cached and served
+// The document may be adjusted; an error aborts the generation
+type GenerateHook interface {
+	OnGenerate(doc *api.OpenAPIDoc) error
+}
+
+// RequestHook is called for every request to a registered operation before validation
+// Returning false stops the request, the hook having answered it
+type RequestHook interface {
+	OnRequest(def *api.APIDefinition, w http.ResponseWriter, r *http.Request) bool
+}
+
+// Use adds plugins to the router; hooks run in the order plugins were added
+// Plugins must be added before the operations they should see are registered
+func (r *Router) Use(plugins ...Plugin) {
+	r.plugins = append(r.plugins, plugins...)
+}
+
+// runRegisterHooks runs the OnRegister hooks of the plugins
+func (r *Router) runRegisterHooks(def *api.APIDefinition) error {
+	for _, plugin := range r.plugins {
+		if hook, ok := plugin.(RegisterHook); ok {
+			if err := hook.OnRegister(def); err != nil {
+				return fmt.Errorf("plugin %s rejected %s %s: %w", plugin.Name(), def.Method, def.Path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RunGenerateHooks runs the OnGenerate hooks of the plugins on a generated document
+func (r *Router) RunGenerateHooks(doc *api.OpenAPIDoc) error {
+	for _, plugin := range r.plugins {
+		if hook, ok := plugin.(GenerateHook); ok {
+			if err := hook.OnGenerate(doc); err != nil {
+				return fmt.Errorf("plugin %s failed to process document: %w", plugin.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// runRequestHooks runs the OnRequest hooks of the plugins, reporting whether one stopped the request
+func (r *Router) runRequestHooks(def *api.APIDefinition, w http.ResponseWriter, req *http.Request) bool {
+	for _, plugin := range r.plugins {
+		if hook, ok := plugin.(RequestHook); ok {
+			if !hook.OnRequest(def, w, req) {
+				return true
+			}
+		}
+	}
+	return false
+}
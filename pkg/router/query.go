@@ -0,0 +1,151 @@
+package router
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// BindQuery binds decoded query parameters (see QueryParams) into a new instance of the definition's query
+// model and checks its `binding` tags, including dive rules on each element; the pointer is returned
+func BindQuery(def *api.APIDefinition, decoded map[string]interface{}) (interface{}, *ValidationError) {
+	t := reflect.TypeOf(def.Query)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	target := reflect.New(t)
+	if t.Kind() != reflect.Struct {
+		return target.Interface(), nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("query"), ",")
+		value, ok := decoded[name]
+		if name == "" || name == "-" || !field.IsExported() || !ok {
+			continue
+		}
+		if err := bindValue(target.Elem().Field(i), value); err != nil {
+			return nil, badRequest("invalid query parameter %s: %v", name, err)
+		}
+	}
+	if err := bodyValidator.Struct(target.Interface()); err != nil {
+		return nil, badRequest("invalid query parameters: %v", err)
+	}
+	return target.Interface(), nil
+}
+
+// bindValue sets a field from a decoded query value: a string, []string or map[string]string
+func bindValue(v reflect.Value, value interface{}) error {
+	if v.Kind() == reflect.Ptr {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	switch decoded := value.(type) {
+	case string:
+		if v.Kind() == reflect.Slice {
+			return bindValue(v, []string{decoded})
+		}
+		return setString(v, decoded)
+	case []string:
+		if v.Kind() != reflect.Slice {
+			if len(decoded) == 0 {
+				return nil
+			}
+			return setString(v, decoded[0])
+		}
+		items := reflect.MakeSlice(v.Type(), len(decoded), len(decoded))
+		for i, item := range decoded {
+			if err := bindValue(items.Index(i), item); err != nil {
+				return fmt.Errorf("item %d: %w", i, err)
+			}
+		}
+		v.Set(items)
+	case map[string]string:
+		switch v.Kind() {
+		case reflect.Map:
+			m := reflect.MakeMapWithSize(v.Type(), len(decoded))
+			for key, item := range decoded {
+				elem := reflect.New(v.Type().Elem()).Elem()
+				if err := bindValue(elem, item); err != nil {
+					return fmt.Errorf("%s: %w", key, err)
+				}
+				mapKey := reflect.New(v.Type().Key()).Elem()
+				if err := setString(mapKey, key); err != nil {
+					return fmt.Errorf("key %s: %w", key, err)
+				}
+				m.SetMapIndex(mapKey, elem)
+			}
+			v.Set(m)
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				field := v.Type().Field(i)
+				item, ok := decoded[propertyName(field)]
+				if !ok || !field.IsExported() {
+					continue
+				}
+				if err := bindValue(v.Field(i), item); err != nil {
+					return fmt.Errorf("%s: %w", propertyName(field), err)
+				}
+			}
+		default:
+			return fmt.Errorf("cannot bind an object into %s", v.Type())
+		}
+	}
+	return nil
+}
+
+// propertyName returns the deepObject property a struct field binds: its query, then json name
+func propertyName(field reflect.StructField) string {
+	for _, tag := range []string{"query", "json"} {
+		if name, _, _ := strings.Cut(field.Tag.Get(tag), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// setString converts a query string value to a scalar field, or a field implementing encoding.TextUnmarshaler
+func setString(v reflect.Value, s string) error {
+	if unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(s)); err != nil {
+			return fmt.Errorf("%q is not a valid %s: %v", s, v.Type(), err)
+		}
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", s)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", s)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not an unsigned integer", s)
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a number", s)
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("cannot bind a string into %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+type listQuery struct {
+	IDs    []int             `query:"id" binding:"max=3,dive,gte=1"`
+	Filter listFilter        `query:"filter"`
+	Labels map[string]string `query:"label"`
+	Page   *int              `query:"page"`
+}
+
+type listFilter struct {
+	Role   string `json:"role" binding:"omitempty,oneof=admin user"`
+	Active bool   `json:"active"`
+}
+
+// TestBindQuery tests binding repeated and deepObject query parameters into the query model
+func TestBindQuery(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	def := api.NewAPIDefinition("GET", "/users", "List users").
+		WithQueryModel(listQuery{}).
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {
+			q := QueryModel(req.Context()).(*listQuery)
+			page := 0
+			if q.Page != nil {
+				page = *q.Page
+			}
+			fmt.Fprintf(w, "%v %s %t %v %d", q.IDs, q.Filter.Role, q.Filter.Active, q.Labels, page)
+		})
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "bound", target: "/api/users?id=1&id=2&filter[role]=admin&filter[active]=true&label[team]=core&page=2", wantStatus: http.StatusOK, wantBody: "[1 2] admin true map[team:core] 2"},
+		{name: "empty", target: "/api/users", wantStatus: http.StatusOK, wantBody: "[]  false map[] 0"},
+		{name: "invalid item type", target: "/api/users?id=1&id=x", wantStatus: http.StatusBadRequest},
+		{name: "invalid item value", target: "/api/users?id=0", wantStatus: http.StatusBadRequest},
+		{name: "too many items", target: "/api/users?id=1&id=2&id=3&id=4", wantStatus: http.StatusBadRequest},
+		{name: "invalid object property", target: "/api/users?filter[role]=root", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("GET", tt.target, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("Expected %q, got %q", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+type scoreQuery struct {
+	Scores map[int]float64 `query:"score"`
+}
+
+// TestBindQueryMapKeys tests parsing deepObject keys into non-string map keys
+func TestBindQueryMapKeys(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	def := api.NewAPIDefinition("GET", "/scores", "List scores").
+		WithQueryModel(scoreQuery{}).
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {
+			fmt.Fprintf(w, "%v", QueryModel(req.Context()).(*scoreQuery).Scores)
+		})
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "integer keys", target: "/api/scores?score[2]=0.5&score[10]=1", wantStatus: http.StatusOK, wantBody: "map[2:0.5 10:1]"},
+		{name: "invalid key", target: "/api/scores?score[top]=1", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("GET", tt.target, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("Expected %q, got %q", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
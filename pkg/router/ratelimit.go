@@ -0,0 +1,136 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// RateLimitDecision is the outcome of a Limiter check
+type RateLimitDecision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Duration // Time until the window resets
+}
+
+// Limiter decides whether a request may proceed under an operation's rate limit policy
+// The key identifies the bucket: the policy scope, or the operation's method and path, followed by the client
+type Limiter interface {
+	Allow(ctx context.Context, key string, policy api.RateLimitPolicy) RateLimitDecision
+}
+
+// RateLimitKeyFunc identifies the client a request is counted against
+type RateLimitKeyFunc func(r *http.Request) string
+
+// SetLimiter sets the limiter consulted for operations declaring WithRateLimit
+// Limits apply per client, as identified by the rate limit key function (ClientKey unless set)
+func (r *Router) SetLimiter(limiter Limiter) {
+	r.limiter = limiter
+}
+
+// SetRateLimitKey sets how requests are attributed to clients for rate limiting, e.g. by API key or tenant
+func (r *Router) SetRateLimitKey(key RateLimitKeyFunc) {
+	r.rateLimitKey = key
+}
+
+// ClientKey identifies the client of a request by its authenticated principal, or else by its remote IP address
+// Forwarded headers are not trusted; behind a proxy, set a key function reading the address it reports
+func ClientKey(r *http.Request) string {
+	if key, ok := PrincipalKey(r); ok {
+		return key
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// PrincipalKey identifies the authenticated principal of a request (see api.ContextWithPrincipal), when it is a
+// string or a fmt.Stringer
+func PrincipalKey(r *http.Request) (string, bool) {
+	principal, _ := api.PrincipalFromContext(r.Context())
+	switch p := principal.(type) {
+	case string:
+		return "principal:" + p, true
+	case fmt.Stringer:
+		return "principal:" + p.String(), true
+	}
+	return "", false
+}
+
+// bucketKey returns the limiter key of a request to a definition
+func (r *Router) bucketKey(req *http.Request, def *api.APIDefinition, method string) string {
+	bucket := def.RateLimit.Scope
+	if bucket == "" {
+		bucket = operationKey(method, def.Path)
+	}
+	client := ClientKey
+	if r.rateLimitKey != nil {
+		client = r.rateLimitKey
+	}
+	return bucket + "|" + client(req)
+}
+
+// setRateLimitHeaders writes the X-RateLimit-* headers documented by WithRateLimit
+func setRateLimitHeaders(h http.Header, decision RateLimitDecision) {
+	reset := int(decision.Reset.Round(time.Second) / time.Second)
+	h.Set("X-RateLimit-Limit", strconv.Itoa(decision.Limit))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+	h.Set("X-RateLimit-Reset", strconv.Itoa(reset))
+	if !decision.Allowed {
+		h.Set("Retry-After", strconv.Itoa(reset))
+	}
+}
+
+// MemoryLimiter is an in-process fixed window Limiter, suitable for single instance deployments and tests
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	windows map[string]*rateWindow
+	now     func() time.Time
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// NewMemoryLimiter creates a new in-memory fixed window limiter
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		windows: make(map[string]*rateWindow),
+		now:     time.Now,
+	}
+}
+
+// Allow implements Limiter
+func (l *MemoryLimiter) Allow(ctx context.Context, key string, policy api.RateLimitPolicy) RateLimitDecision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	window, ok := l.windows[key]
+	if !ok || now.Sub(window.start) >= policy.Window {
+		window = &rateWindow{start: now}
+		l.windows[key] = window
+	}
+
+	decision := RateLimitDecision{
+		Limit: policy.Requests,
+		Reset: window.start.Add(policy.Window).Sub(now),
+	}
+	if window.count >= policy.Requests {
+		return decision
+	}
+	window.count++
+	decision.Allowed = true
+	decision.Remaining = policy.Requests - window.count
+	return decision
+}
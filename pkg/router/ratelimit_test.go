@@ -0,0 +1,111 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestRateLimit tests rejecting requests over an operation's rate limit with 429
+func TestRateLimit(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	r.SetLimiter(NewMemoryLimiter())
+	def := api.NewAPIDefinition("GET", "/search", "Search").
+		WithRateLimit(api.RateLimitPolicy{Requests: 1, Window: time.Minute}).
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {})
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		wantStatus    int
+		wantRemaining string
+	}{
+		{name: "within limit", wantStatus: http.StatusOK, wantRemaining: "0"},
+		{name: "over limit", wantStatus: http.StatusTooManyRequests, wantRemaining: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/search", nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("X-RateLimit-Remaining"); got != tt.wantRemaining {
+				t.Errorf("Expected X-RateLimit-Remaining %q, got %q", tt.wantRemaining, got)
+			}
+		})
+	}
+}
+
+// TestRateLimitPerClient tests that each client is limited separately
+func TestRateLimitPerClient(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	r.SetLimiter(NewMemoryLimiter())
+	def := api.NewAPIDefinition("GET", "/search", "Search").
+		WithRateLimit(api.RateLimitPolicy{Requests: 1, Window: time.Minute}).
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {})
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	// Authenticate requests carrying a user header, as an authentication middleware would
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if user := req.Header.Get("X-User"); user != "" {
+			req = req.WithContext(api.ContextWithPrincipal(req.Context(), user))
+		}
+		mux.ServeHTTP(w, req)
+	})
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		user       string
+		wantStatus int
+	}{
+		{name: "first client", remoteAddr: "192.0.2.1:1234", wantStatus: http.StatusOK},
+		{name: "first client again", remoteAddr: "192.0.2.1:5678", wantStatus: http.StatusTooManyRequests},
+		{name: "second client", remoteAddr: "198.51.100.7:1234", wantStatus: http.StatusOK},
+		{name: "authenticated user", remoteAddr: "192.0.2.1:1234", user: "ada", wantStatus: http.StatusOK},
+		{name: "authenticated user from another address", remoteAddr: "198.51.100.7:1234", user: "ada", wantStatus: http.StatusTooManyRequests},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/search", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.user != "" {
+				req.Header.Set("X-User", tt.user)
+			}
+			handler.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+// TestMemoryLimiterWindow tests that the window resets after it elapses
+func TestMemoryLimiterWindow(t *testing.T) {
+	limiter := NewMemoryLimiter()
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	policy := api.RateLimitPolicy{Requests: 1, Window: time.Second}
+
+	if !limiter.Allow(context.Background(), "k", policy).Allowed {
+		t.Fatal("Expected first request to be allowed")
+	}
+	if limiter.Allow(context.Background(), "k", policy).Allowed {
+		t.Fatal("Expected second request to be rejected")
+	}
+	now = now.Add(time.Second)
+	if !limiter.Allow(context.Background(), "k", policy).Allowed {
+		t.Error("Expected request in a new window to be allowed")
+	}
+}
@@ -0,0 +1,198 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// DefaultRedactedFields lists JSON properties masked in recorded examples
+var DefaultRedactedFields = []string{"password", "token", "secret", "authorization", "api_key", "apikey"}
+
+// RecordingOptions configures sampling of live traffic into spec examples
+type RecordingOptions struct {
+	SampleRate      float64  // Fraction of requests recorded, between 0 and 1
+	MaxPerOperation int      // Number of recent samples kept per operation (default 3)
+	RedactFields    []string // JSON properties masked in samples (default DefaultRedactedFields)
+	MaxBodyBytes    int      // Bodies larger than this are not recorded (default 64KB)
+}
+
+// recordedExample is a sampled request/response pair
+type recordedExample struct {
+	Request  interface{}
+	Response interface{}
+	Status   int
+	Recorded time.Time
+}
+
+// exampleRecorder stores sampled examples keyed by operation
+type exampleRecorder struct {
+	opts    RecordingOptions
+	mu      sync.Mutex
+	samples map[string][]recordedExample
+}
+
+// EnableExampleRecording samples live JSON traffic and attaches it to operations as named examples
+// Samples appear in the spec the next time the document is generated
+func (r *Router) EnableExampleRecording(opts RecordingOptions) {
+	if opts.MaxPerOperation <= 0 {
+		opts.MaxPerOperation = 3
+	}
+	if opts.RedactFields == nil {
+		opts.RedactFields = DefaultRedactedFields
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = 64 << 10
+	}
+	r.recorder = &exampleRecorder{
+		opts:    opts,
+		samples: make(map[string][]recordedExample),
+	}
+}
+
+// capture starts recording the request if it is sampled, returning the writer copying the response and a
+// function to call after the handler
+// Properties named in sensitive are masked in addition to the configured RedactFields
+func (rec *exampleRecorder) capture(w *statusWriter, req *http.Request, key string, sensitive []string) (http.ResponseWriter, func()) {
+	if rand.Float64() >= rec.opts.SampleRate {
+		return w, func() {}
+	}
+
+	var requestBody []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(io.LimitReader(req.Body, int64(rec.opts.MaxBodyBytes)+1))
+		// Restore what was read, followed by whatever remains unread
+		req.Body = readCloser{io.MultiReader(bytes.NewReader(data), req.Body), req.Body}
+		if err != nil || len(data) > rec.opts.MaxBodyBytes {
+			return w, func() {}
+		}
+		requestBody = data
+	}
+
+	writer := &teeWriter{statusWriter: w}
+	return writer, func() {
+		status := writer.Status()
+		if status < 200 || status >= 300 || writer.body.Len() > rec.opts.MaxBodyBytes {
+			return
+		}
+		if !strings.HasPrefix(writer.Header().Get("Content-Type"), "application/json") {
+			return
+		}
+		fields := append(append([]string{}, rec.opts.RedactFields...), sensitive...)
+		rec.add(key, recordedExample{
+			Request:  redactJSON(requestBody, fields),
+			Response: redactJSON(writer.body.Bytes(), fields),
+			Status:   status,
+			Recorded: time.Now().UTC(),
+		})
+	}
+}
+
+func (rec *exampleRecorder) add(key string, sample recordedExample) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	samples := append(rec.samples[key], sample)
+	if len(samples) > rec.opts.MaxPerOperation {
+		samples = samples[len(samples)-rec.opts.MaxPerOperation:]
+	}
+	rec.samples[key] = samples
+}
+
+// examples returns the named request and response examples recorded for an operation
+func (rec *exampleRecorder) examples(key string) (map[string]api.Example, map[string]api.Example) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	samples := rec.samples[key]
+	if len(samples) == 0 {
+		return nil, nil
+	}
+	requests := make(map[string]api.Example)
+	responses := make(map[string]api.Example)
+	for i, sample := range samples {
+		name := fmt.Sprintf("recorded-%d", i+1)
+		summary := fmt.Sprintf("Recorded %s", sample.Recorded.Format(time.RFC3339))
+		if sample.Request != nil {
+			requests[name] = api.Example{Summary: summary, Value: sample.Request}
+		}
+		if sample.Response != nil {
+			responses[name] = api.Example{Summary: summary, Value: sample.Response}
+		}
+	}
+	return requests, responses
+}
+
+// sensitiveFields returns the properties marked sensitive in a definition's request and response models
+func sensitiveFields(def *api.APIDefinition) []string {
+	return append(api.SensitiveFields(def.Request), api.SensitiveFields(def.Response)...)
+}
+
+// redactJSON decodes a JSON body and masks the given fields; non-JSON bodies are dropped
+func redactJSON(body []byte, fields []string) interface{} {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil
+	}
+	return redactValue(value, fields)
+}
+
+// redactValue masks object properties whose names match the given fields (case-insensitive)
+func redactValue(value interface{}, fields []string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if containsFold(fields, key) {
+				v[key] = "***"
+				continue
+			}
+			v[key] = redactValue(item, fields)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item, fields)
+		}
+	}
+	return value
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// readCloser combines a reader with the original body's Close
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// attach sets the examples recorded for an operation on its JSON request body and 200 response
+func (rec *exampleRecorder) attach(operation *api.Operation, key string) {
+	requests, responses := rec.examples(key)
+	if operation.RequestBody != nil && len(requests) > 0 {
+		content := operation.RequestBody.Content["application/json"]
+		content.Examples = requests
+		operation.RequestBody.Content["application/json"] = content
+	}
+	if success, ok := operation.Responses["200"]; ok && len(responses) > 0 {
+		if content, ok := success.Content["application/json"]; ok {
+			content.Examples = responses
+			success.Content["application/json"] = content
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package router
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// RequestIDHeader is the header carrying the correlation ID of a request
+const RequestIDHeader = "X-Request-ID"
+
+// EnableRequestID makes every operation accept and echo an X-Request-ID header
+// Requests without one get an ID from generate (NewRequestID when nil); the ID is echoed in the response,
+// stored in the request context and documented as a parameter and response header of every operation
+func (r *Router) EnableRequestID(generate func() string) {
+	if generate == nil {
+		generate = NewRequestID
+	}
+	r.requestID = generate
+}
+
+// RequestID returns the correlation ID of a request, or "" when request IDs are not enabled
+func RequestID(req *http.Request) string {
+	id, _ := api.RequestIDFromContext(req.Context())
+	return id
+}
+
+// NewRequestID returns a random 128-bit hex identifier
+func NewRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// PropagateRequestID reuses the client's request ID or one from generate, echoing it in the response
+// Returns the request carrying the ID in its context
+func PropagateRequestID(w http.ResponseWriter, req *http.Request, generate func() string) *http.Request {
+	id := req.Header.Get(RequestIDHeader)
+	if id == "" {
+		id = generate()
+		req.Header.Set(RequestIDHeader, id)
+	}
+	w.Header().Set(RequestIDHeader, id)
+	return req.WithContext(api.ContextWithRequestID(req.Context(), id))
+}
+
+// DocumentRequestIDs documents the X-Request-ID parameter and response header of every operation
+func DocumentRequestIDs(doc *api.OpenAPIDoc) {
+	param, _ := api.StandardParameter(api.RequestIDParam)
+	header := api.Header{Description: "Correlation ID of the request, echoed or generated", Schema: param.Schema}
+	for _, pathItem := range doc.Paths {
+		for _, op := range pathItem.Operations() {
+			if !hasHeaderParam(op, RequestIDHeader) {
+				ref := param
+				ref.Ref = api.ParameterRef(api.RequestIDParam)
+				op.Parameters = append(op.Parameters, ref)
+				if doc.Components == nil {
+					doc.Components = &api.Components{}
+				}
+				if doc.Components.Parameters == nil {
+					doc.Components.Parameters = make(map[string]api.Parameter)
+				}
+				doc.Components.Parameters[api.RequestIDParam] = param
+			}
+			for status, response := range op.Responses {
+				if response.Headers == nil {
+					response.Headers = make(map[string]api.Header)
+				}
+				if _, ok := response.Headers[RequestIDHeader]; !ok {
+					response.Headers[RequestIDHeader] = header
+				}
+				op.Responses[status] = response
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestRequestID tests propagating, generating and documenting X-Request-ID
+func TestRequestID(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	r.EnableRequestID(func() string { return "generated" })
+	def := api.NewAPIDefinition("GET", "/users", "List users").
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(RequestID(req)))
+		})
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "echoed", header: "client-id", want: "client-id"},
+		{name: "generated", want: "generated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/users", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			mux.ServeHTTP(w, req)
+			if got := w.Header().Get(RequestIDHeader); got != tt.want {
+				t.Errorf("Expected response header %q, got %q", tt.want, got)
+			}
+			if w.Body.String() != tt.want {
+				t.Errorf("Expected handler to see %q, got %q", tt.want, w.Body.String())
+			}
+		})
+	}
+
+	doc, err := r.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	op := doc.Paths["/users"].Get
+	if len(op.Parameters) != 1 || op.Parameters[0].Ref != api.ParameterRef(api.RequestIDParam) {
+		t.Errorf("Expected a X-Request-ID parameter reference, got %+v", op.Parameters)
+	}
+	if _, ok := op.Responses["400"].Headers[RequestIDHeader]; !ok {
+		t.Errorf("Expected the X-Request-ID response header, got %+v", op.Responses["400"])
+	}
+}
+
+// TestRequestIDDefaultGenerator tests that generated request IDs are unique
+func TestRequestIDDefaultGenerator(t *testing.T) {
+	if a, b := NewRequestID(), NewRequestID(); len(a) != 32 || a == b {
+		t.Errorf("Expected distinct 32 character IDs, got %q and %q", a, b)
+	}
+}
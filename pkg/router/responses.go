@@ -0,0 +1,65 @@
+package router
+
+import (
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// DefaultResponse is a canned response added to operations that do not document the status themselves
+type DefaultResponse struct {
+	Status      string // Status code, e.g. "400" or "default"
+	Description string
+	Secured     bool // Only added to operations with security requirements
+}
+
+// DefaultResponses are the canned responses added when no policy has been set
+var DefaultResponses = []DefaultResponse{
+	{Status: "400", Description: "Bad Request - Invalid input parameters"},
+	{Status: "401", Description: "Unauthorized - Authentication required", Secured: true},
+	{Status: "403", Description: "Forbidden - Insufficient permissions", Secured: true},
+	{Status: "500", Description: "Internal Server Error"},
+}
+
+// SetDefaultResponsePolicy replaces the canned responses added to every operation
+// Calling it without responses disables them entirely
+func (r *Router) SetDefaultResponsePolicy(responses ...DefaultResponse) {
+	r.responses = responses
+	r.responsesSet = true
+}
+
+// ApplyDefaultResponses adds the canned responses to every operation of doc whose definition has not opted
+// out, keeping the responses the operation documents itself; operations left without any response document
+// a 200 one, as the specification requires
+func ApplyDefaultResponses(doc *api.OpenAPIDoc, defs []api.APIDefinition, responses []DefaultResponse) {
+	optedOut := make(map[string]bool)
+	for _, def := range defs {
+		if def.NoDefaults {
+			optedOut[operationKey(def.Method, def.Path)] = true
+		}
+	}
+
+	for path, pathItem := range doc.Paths {
+		for _, method := range api.SupportedMethods {
+			op := pathItem.Operation(method)
+			if op == nil {
+				continue
+			}
+			if op.Responses == nil {
+				op.Responses = make(map[string]api.Response)
+			}
+			if !optedOut[operationKey(method, path)] {
+				for _, response := range responses {
+					if response.Secured && len(op.Security) == 0 && len(doc.Security) == 0 {
+						continue
+					}
+					if _, ok := op.Responses[response.Status]; !ok {
+						op.Responses[response.Status] = api.Response{Description: response.Description}
+					}
+				}
+			}
+			// The specification requires every operation to document at least one response
+			if len(op.Responses) == 0 {
+				op.Responses["200"] = api.Response{Description: "Success"}
+			}
+		}
+	}
+}
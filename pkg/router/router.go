@@ -0,0 +1,737 @@
+// Package router is the framework-neutral core shared by the framework integrations: it registers
+// definitions, validates requests against them and generates the OpenAPI document
+//
+// Frameworks plug in through a small Adapter that binds routes and extracts path parameters, so an
+// integration is a thin shim and behaves like the others:
+//
+//	mux := router.NewMux()
+//	r := router.New(mux, "/api", "My API", "1.0.0", "Description")
+//	r.Register(api.NewAPIDefinition("GET", "/users/{id}", "Get user").WithHandler(getUser))
+//	http.ListenAndServe(":8080", mux)
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// ParamExtractor returns the value of a path parameter of a request matched by the adapter
+type ParamExtractor func(r *http.Request, name string) string
+
+// Adapter binds the core router to a web framework
+type Adapter interface {
+	// RegisterRoute binds a handler to a method and a path in OpenAPI form (e.g. /api/users/{id})
+	RegisterRoute(method, path string, handler http.Handler) error
+	// ParamExtractor returns the function reading path parameters of requests routed by the adapter
+	ParamExtractor() ParamExtractor
+}
+
+// OperationAdapter is implemented by adapters running operation middlewares and handlers themselves, e.g. to
+// support framework-native ones; steps wraps what the adapter runs in the router's request steps
+// Registering a method and path again replaces the earlier operation
+type OperationAdapter interface {
+	Adapter
+	RegisterOperation(method, path string, def *api.APIDefinition, steps func(next http.Handler) http.Handler) error
+}
+
+// Router registers API definitions on an adapter and generates their OpenAPI document
+type Router struct {
+	adapter         Adapter
+	basePath        string
+	title           string
+	version         string
+	description     string
+	definitions     []api.APIDefinition
+	securitySchemes map[string]api.SecurityScheme
+	globalSecurity  []map[string][]string
+	strictRequests  bool              // Whether request bodies reject undocumented fields by default
+	disallowUnknown bool              // Whether request bodies reject undocumented fields without closing the schemas
+	applyDefaults   bool              // Whether documented defaults fill absent optional parameters and body fields
+	payloadLimits   api.PayloadLimits // Request body limits of definitions declaring none
+	maxDecompressed int64             // Decompressed size limit of gzip and deflate bodies, 0 when not accepted
+	negotiation     bool              // Whether operations document the Accept and Content-Type headers
+	envelope        interface{}       // Template wrapping JSON success responses, or nil
+	validatePatches bool              // Whether patch documents are checked against the request model
+	filterResponses bool              // Whether swaggerignore fields are removed from JSON responses
+	bodyHandling    BodyHandling      // What handlers find in validated request bodies
+	pathPolicy      PathPolicy        // Normalization of registered and documented paths
+	requestID       func() string     // Generates missing X-Request-ID headers; nil disables propagation
+	cors            *api.CORSPolicy   // Default cross-origin policy
+	preflight       map[string]*PreflightRoute
+	routes          map[string]*coreRoute // Registered routes by method and full path
+	duplicatePolicy DuplicatePolicy       // How repeated method and path registrations are handled
+	warn            WarningLogger         // Receives configuration warnings
+	responses       []DefaultResponse     // Canned responses added to every operation
+	responsesSet    bool                  // Whether responses replaces DefaultResponses
+	idStrategy      OperationIDStrategy   // Names operations without an explicit operationId
+	operationIDs    map[string]string     // operationIds of the generated document by method and path
+
+	limiter           Limiter                     // Limiter for operations declaring a rate limit
+	rateLimitKey      RateLimitKeyFunc            // Client identity of rate limit buckets; ClientKey when nil
+	authorizer        Authorizer                  // Checks the permissions of every operation
+	sessionValidators map[string]SessionValidator // Runtime checks for cookie security schemes
+	planResolver      PlanResolver                // Resolves the caller's plan for operations restricted to plans
+	tracer            Tracer                      // Tracer wrapping handlers in spans
+	metrics           Metrics                     // Per-operation metrics sink
+	sloMonitor        *SLOMonitor                 // Measures operations declaring an SLO
+	requestLogger     RequestLogger               // Per-request structured logger
+	recorder          *exampleRecorder            // Samples traffic into spec examples
+	plugins           []Plugin                    // Extensions hooked into registration, generation and requests
+
+	docMu      sync.RWMutex
+	swaggerDoc []byte // Cached swagger document
+}
+
+// New creates a router registering routes on the adapter under basePath
+func New(adapter Adapter, basePath, title, version, description string) *Router {
+	return &Router{
+		adapter:         adapter,
+		basePath:        basePath,
+		title:           title,
+		version:         version,
+		description:     description,
+		definitions:     make([]api.APIDefinition, 0),
+		securitySchemes: make(map[string]api.SecurityScheme),
+	}
+}
+
+// SetInfo sets basic API information
+func (r *Router) SetInfo(title, version, description string) {
+	r.title = title
+	r.version = version
+	r.description = description
+}
+
+// Info returns the API information documented by the router
+func (r *Router) Info() api.OpenAPIInfo {
+	return api.OpenAPIInfo{Title: r.title, Version: r.version, Description: r.description}
+}
+
+// SetBasePath sets the path prefix of the routes registered afterwards
+func (r *Router) SetBasePath(basePath string) {
+	r.basePath = basePath
+}
+
+// BasePath returns the path prefix of registered routes
+func (r *Router) BasePath() string {
+	return r.basePath
+}
+
+// AddSecurityScheme declares a security scheme under components.securitySchemes
+func (r *Router) AddSecurityScheme(name string, scheme api.SecurityScheme) {
+	r.securitySchemes[name] = scheme
+}
+
+// SecuritySchemes returns the declared security schemes by name
+func (r *Router) SecuritySchemes() map[string]api.SecurityScheme {
+	return r.securitySchemes
+}
+
+// SetGlobalSecurity sets global security requirements
+func (r *Router) SetGlobalSecurity(requirements []map[string][]string) {
+	r.globalSecurity = requirements
+}
+
+// GlobalSecurity returns the global security requirements
+func (r *Router) GlobalSecurity() []map[string][]string {
+	return r.globalSecurity
+}
+
+// SetStrictRequests makes request bodies reject undocumented fields by default: request schemas are
+// documented with additionalProperties: false unless their model opts out through api.StrictModel
+// Models declaring strictness themselves are enforced whatever the default
+func (r *Router) SetStrictRequests(strict bool) {
+	r.strictRequests = strict
+}
+
+// SetDisallowUnknownFields makes request validation reject bodies with undocumented properties, answering
+// 400 with the offending fields; models opting out through api.StrictModel still accept them
+// Unlike strict schemas, the documented contract is left unchanged
+func (r *Router) SetDisallowUnknownFields(disallow bool) {
+	r.disallowUnknown = disallow
+}
+
+// EnableDefaults fills absent optional query and header parameters and body fields with their documented
+// defaults before validation, so handlers see what the documentation promises
+func (r *Router) EnableDefaults() {
+	r.applyDefaults = true
+}
+
+// SetPayloadLimits bounds the size and shape of the request bodies of definitions declaring no limits
+// Bodies are checked before they are decoded; the 413 and 400 responses are documented
+func (r *Router) SetPayloadLimits(limits api.PayloadLimits) {
+	r.payloadLimits = limits
+}
+
+// EnableRequestDecompression accepts gzip and deflate encoded request bodies, decompressing them before
+// validation and rejecting bodies inflating beyond maxSize bytes; operations document x-request-encodings
+func (r *Router) EnableRequestDecompression(maxSize int64) {
+	r.maxDecompressed = maxSize
+}
+
+// SetBodyHandling selects whether handlers can re-read validated request bodies (RestoreBody, the default)
+// or only find their decoded value with RequestBody (DecodedBodyOnly)
+func (r *Router) SetBodyHandling(mode BodyHandling) {
+	r.bodyHandling = mode
+}
+
+// SetPathPolicy normalizes the trailing slash and casing of the paths registered afterwards, both as routes
+// and as Paths keys; adapters implementing PathPolicyAdapter also match requests accordingly
+func (r *Router) SetPathPolicy(policy PathPolicy) {
+	r.pathPolicy = policy
+	if adapter, ok := r.adapter.(PathPolicyAdapter); ok {
+		adapter.SetPathPolicy(policy)
+	}
+}
+
+// EnableNegotiatedHeaders documents the Accept and Content-Type headers of every operation as optional
+// parameters listing the media types it produces and consumes
+func (r *Router) EnableNegotiatedHeaders() {
+	r.negotiation = true
+}
+
+// SetResponseEnvelope wraps JSON 2xx responses in an envelope such as {code, message, data}
+// The template is a struct whose `envelope:"data"` field (or field serialized as "data") receives the payload;
+// response schemas are documented inside it and Respond wraps handler values with a copy of the template
+func (r *Router) SetResponseEnvelope(template interface{}) {
+	r.envelope = template
+}
+
+// EnablePatchValidation checks JSON Patch and JSON Merge Patch documents sent to definitions accepting them:
+// merge patches against the optional variant of the request schema, JSON Patch operations and their paths
+func (r *Router) EnablePatchValidation() {
+	r.validatePatches = true
+}
+
+// Register validates a definition and binds its handler, wrapped in the request steps, on the adapter
+// Requests run through the request ID, observers, CORS, body limits, rate limiting, plugin hooks, session and
+// plan checks, parameter and body validation and the authorizer before the operation middlewares and the
+// handler; handlers read decoded query parameters with QueryParams (or QueryModel) and the validated body
+// with RequestBody
+func (r *Router) Register(def *api.APIDefinition) error {
+	if def == nil {
+		return fmt.Errorf("api definition cannot be nil")
+	}
+	// Adapters running handlers themselves get the request steps; plain ones get the handler wrapped in them
+	operations, native := r.adapter.(OperationAdapter)
+	var handler http.Handler
+	if native {
+		if def.Handler == nil && def.NativeHandler == nil {
+			return fmt.Errorf("handler cannot be nil for path: %s", def.Path)
+		}
+	} else if def.Handler != nil {
+		handler = def.Handler
+	} else if h, ok := def.NativeHandler.(http.Handler); ok {
+		handler = h
+	} else {
+		return fmt.Errorf("handler cannot be nil for path: %s", def.Path)
+	}
+	if def.Path == "" {
+		return fmt.Errorf("path cannot be empty")
+	}
+	def.Path = r.pathPolicy.Normalize(def.Path)
+	method, err := NormalizeMethod(def.Method)
+	if err != nil {
+		return err
+	}
+	def.Method = method
+	if def.CORS != nil {
+		if err := def.CORS.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// Let plugins inspect or adjust the definition
+	if err := r.runRegisterHooks(def); err != nil {
+		return err
+	}
+
+	fullPath := r.basePath + def.Path
+	if method == http.MethodOptions && r.preflight[fullPath] != nil {
+		return fmt.Errorf("OPTIONS %s is already served as a CORS preflight route", fullPath)
+	}
+	steps := r.requestSteps(def, fullPath)
+
+	if !native {
+		// Run operation-specific middlewares after validation, in order
+		if handler, err = Chain(handler, def.Middleware); err != nil {
+			return err
+		}
+		handler = steps(handler)
+	}
+
+	key := routeKey(method, fullPath)
+	if existing, ok := r.routes[key]; ok {
+		switch r.duplicatePolicy {
+		case DuplicateWarn:
+			r.Warnf("go-swagger: ignoring duplicate registration of %s %s (%q)", method, fullPath, def.Summary)
+			return nil
+		case DuplicateLastWins:
+			if native {
+				if err := operations.RegisterOperation(method, fullPath, def, steps); err != nil {
+					return err
+				}
+			} else {
+				existing.handler = handler
+			}
+			r.definitions[existing.index] = *def
+			return nil
+		default:
+			return fmt.Errorf("duplicate registration of %s %s", method, fullPath)
+		}
+	}
+
+	route := &coreRoute{handler: handler, index: len(r.definitions)}
+	if native {
+		err = operations.RegisterOperation(method, fullPath, def, steps)
+	} else {
+		err = r.adapter.RegisterRoute(method, fullPath, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			route.handler.ServeHTTP(w, req)
+		}))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to register %s %s: %w", method, def.Path, err)
+	}
+	if r.routes == nil {
+		r.routes = make(map[string]*coreRoute)
+	}
+	r.routes[key] = route
+	if policy := r.corsPolicy(def); policy != nil && method != http.MethodOptions {
+		if err := r.registerPreflight(fullPath, method, policy); err != nil {
+			return err
+		}
+	}
+	r.definitions = append(r.definitions, *def)
+	return nil
+}
+
+// requestSteps returns the middleware running the request steps of a definition served on route
+func (r *Router) requestSteps(def *api.APIDefinition, route string) func(next http.Handler) http.Handler {
+	method := def.Method
+	pathParam := r.adapter.ParamExtractor()
+	// Streamed bodies are bounded but left unread for the handler
+	streaming := def.StreamsRequest() && HasRequestBody(method)
+	hasBody := (def.Request != nil || len(def.PatchFormats) > 0) && HasRequestBody(method) && !streaming
+	var sensitiveOnce sync.Once
+	var sensitive []string
+	var ignoredOnce sync.Once
+	var ignored responseFilters
+	var strictOnce sync.Once
+	var strict map[string]interface{}
+	var defaultsOnce sync.Once
+	var defaults map[string]interface{}
+	var patches PatchValidation
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			// Expose the matched definition to middlewares and handlers
+			req = r.withOperation(req, def)
+			if r.envelope != nil {
+				req = req.WithContext(contextWithEnvelope(req.Context(), r.envelope))
+			}
+
+			// Record the status sent to the client for the observers
+			status := &statusWriter{ResponseWriter: w}
+			w = status
+
+			// Propagate the correlation ID before anything answers the request
+			if r.requestID != nil {
+				req = PropagateRequestID(w, req, r.requestID)
+			}
+
+			// Trace, measure and log the request under its operation
+			failed := false
+			if r.tracer != nil {
+				var end func()
+				req, end = r.startSpan(status, req, def, route)
+				defer end()
+			}
+			if r.metrics != nil {
+				defer r.startMetrics(status, def, route, &failed)()
+			}
+			if r.sloMonitor != nil && def.SLO != nil {
+				defer r.sloMonitor.startSLO(def, method, route)()
+			}
+			if r.requestLogger != nil {
+				defer r.startRequestLog(status, req, def, route, &failed)()
+			}
+			reject := func(verr *ValidationError) {
+				failed = true
+				writeError(w, verr)
+			}
+
+			// Signal deprecation and apply the cross-origin policy
+			SetDeprecationHeaders(w.Header(), def)
+			ApplyCORS(w, req, r.corsPolicy(def))
+
+			// Bound the body before anything reads it and install the handling deadline
+			limits := EffectiveLimits(def, r.payloadLimits)
+			req, cancel, verr := applyRequestLimits(w, req, def, limits.MaxBodySize)
+			defer cancel()
+			if verr != nil {
+				writeError(w, verr)
+				return
+			}
+
+			// Decompress encoded bodies so every later step sees the payload
+			if hasBody && r.maxDecompressed > 0 {
+				if verr := DecompressBody(req, r.maxDecompressed); verr != nil {
+					reject(verr)
+					return
+				}
+			}
+
+			// Enforce the rate limit policy
+			if def.RateLimit != nil && r.limiter != nil {
+				decision := r.limiter.Allow(req.Context(), r.bucketKey(req, def, method), *def.RateLimit)
+				setRateLimitHeaders(w.Header(), decision)
+				if !decision.Allowed {
+					writeError(w, &ValidationError{Status: http.StatusTooManyRequests, Message: "rate limit exceeded"})
+					return
+				}
+			}
+
+			// Sample traffic into spec examples
+			if r.recorder != nil {
+				sensitiveOnce.Do(func() { sensitive = sensitiveFields(def) })
+				var done func()
+				w, done = r.recorder.capture(status, req, operationKey(method, def.Path), sensitive)
+				defer done()
+			}
+
+			// Run plugin request hooks, which may answer the request
+			if r.runRequestHooks(def, w, req) {
+				return
+			}
+
+			// Check session cookies required by the operation's security
+			if req = r.checkSession(req, def); req == nil {
+				writeError(w, &ValidationError{Status: http.StatusUnauthorized, Message: "invalid or missing session"})
+				return
+			}
+
+			// Reject operations outside the caller's plan
+			if r.planResolver != nil && len(def.Plans) > 0 && !def.InPlan(r.planResolver(req)) {
+				writeError(w, &ValidationError{Status: http.StatusForbidden, Message: "operation not available on current plan"})
+				return
+			}
+
+			// Validate path, query, header and cookie parameters, decoding query parameters by style
+			if r.applyDefaults {
+				ApplyParamDefaults(def, req)
+			}
+			query, verr := ValidateParams(def, req, pathParam)
+			if verr != nil {
+				reject(verr)
+				return
+			}
+
+			// Reject hostile body shapes before anything decodes the body
+			if hasBody && !limits.IsZero() {
+				if verr := CheckPayloadLimits(limits, req); verr != nil {
+					reject(verr)
+					return
+				}
+			}
+
+			// Check cross-field conditions and request rules over parameters, body properties and the principal
+			if verr := ValidateConditions(def, req, pathParam); verr != nil {
+				reject(verr)
+				return
+			}
+			if verr := ValidateRequestRules(def, req, pathParam); verr != nil {
+				reject(verr)
+				return
+			}
+			ctx := context.WithValue(req.Context(), queryParamsKey, query)
+			if def.Query != nil {
+				model, verr := BindQuery(def, query)
+				if verr != nil {
+					reject(verr)
+					return
+				}
+				ctx = context.WithValue(ctx, queryModelKey, model)
+			}
+			if def.PathModel != nil {
+				model, verr := BindPath(def, req, pathParam)
+				if verr != nil {
+					reject(verr)
+					return
+				}
+				ctx = context.WithValue(ctx, pathModelKey, model)
+			}
+
+			// Validate patch documents, or the request body
+			if hasBody && len(def.PatchFormats) > 0 {
+				body, verr := patches.Check(def, req, r.validatePatches)
+				if verr != nil {
+					reject(verr)
+					return
+				}
+				ctx = context.WithValue(ctx, requestBodyKey, body)
+				HandOffBody(req, r.bodyHandling)
+			} else if hasBody {
+				if r.applyDefaults {
+					defaultsOnce.Do(func() { defaults = DefaultsSchema(def) })
+					if defaults != nil {
+						if verr := ApplyBodyDefaults(defaults, req); verr != nil {
+							reject(verr)
+							return
+						}
+					}
+				}
+				strictOnce.Do(func() { strict = StrictSchema(def, r.strictRequests || r.disallowUnknown) })
+				if strict != nil {
+					if verr := CheckUnknownFields(strict, req); verr != nil {
+						reject(verr)
+						return
+					}
+				}
+				body, verr := ValidateBody(def, req, pathParam)
+				if verr != nil {
+					reject(verr)
+					return
+				}
+				ctx = context.WithValue(ctx, requestBodyKey, body)
+				HandOffBody(req, r.bodyHandling)
+			}
+			req = req.WithContext(ctx)
+
+			// Check permissions with the authorizer
+			if r.authorizer != nil && !r.authorizer.Authorize(req.Context(), def.Metadata) {
+				writeError(w, &ValidationError{Status: http.StatusForbidden, Message: "Access denied"})
+				return
+			}
+
+			// Buffer the response to compute an ETag for conditional GET operations
+			if def.Conditional && method == http.MethodGet {
+				writer := newETagWriter(w)
+				w = writer
+				defer writer.flush(req)
+			}
+
+			// Buffer the response to remove fields excluded from the documented contract
+			if r.filterResponses {
+				ignoredOnce.Do(func() { ignored = responseFiltersOf(def) })
+				if ignored.active() {
+					writer := newFilterWriter(w)
+					w = writer
+					defer writer.flush(ignored)
+				}
+			}
+
+			// Answer with 408 if the handler returns on an expired deadline without responding
+			if def.Timeout > 0 {
+				defer checkTimeout(w, req)
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// Document adds a definition to the generated document without binding a route, e.g. for routes registered
+// directly on the framework
+func (r *Router) Document(def api.APIDefinition) {
+	r.definitions = append(r.definitions, def)
+}
+
+// registerPreflight binds a preflight route for the path, or records the method on an existing one
+// An OPTIONS route registered explicitly, through the router or on the framework, owns the route
+func (r *Router) registerPreflight(fullPath, method string, policy *api.CORSPolicy) error {
+	if route, ok := r.preflight[fullPath]; ok {
+		route.AddMethod(method, policy)
+		return nil
+	}
+	if r.routes[routeKey(http.MethodOptions, fullPath)] != nil {
+		return nil
+	}
+	route := NewPreflightRoute(policy, method)
+	if err := r.adapter.RegisterRoute(http.MethodOptions, fullPath, route); err != nil {
+		if errors.Is(err, ErrRouteExists) {
+			return nil
+		}
+		return fmt.Errorf("failed to register the CORS preflight of %s: %w", fullPath, err)
+	}
+	if r.preflight == nil {
+		r.preflight = make(map[string]*PreflightRoute)
+	}
+	r.preflight[fullPath] = route
+	return nil
+}
+
+// writeError responds with a validation error as a JSON error body
+func writeError(w http.ResponseWriter, err *ValidationError) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(err.Status)
+	body := map[string]interface{}{"error": err.Message}
+	if len(err.Fields) > 0 {
+		body["fields"] = err.Fields
+	}
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// RegisterRegistry registers every definition collected in a registry, in the order they were added
+// Pass api.DefaultRegistry for definitions added with api.Add
+func (r *Router) RegisterRegistry(registry *api.Registry) error {
+	for _, def := range registry.Definitions() {
+		if err := r.Register(def); err != nil {
+			return fmt.Errorf("failed to register %s %s: %w", def.Method, def.Path, err)
+		}
+	}
+	return nil
+}
+
+// GetDefinitions returns all registered API definitions
+func (r *Router) GetDefinitions() []api.APIDefinition {
+	return r.definitions
+}
+
+// BuildOpenAPI builds the OpenAPI document of the registered definitions
+func (r *Router) BuildOpenAPI() (*api.OpenAPIDoc, error) {
+	doc := &api.OpenAPIDoc{
+		OpenAPI: "3.0.0",
+		Info:    r.Info(),
+		Servers: []api.OpenAPIServer{
+			{
+				URL:         r.basePath,
+				Description: "API Server",
+			},
+		},
+		Paths: make(map[string]api.PathItem),
+		Components: &api.Components{
+			SecuritySchemes: r.securitySchemes,
+		},
+		Security: r.globalSecurity,
+	}
+
+	for i := range r.definitions {
+		def := &r.definitions[i]
+		operation, err := BuildOperation(def)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.DocumentOperation(doc, def, operation); err != nil {
+			return nil, err
+		}
+		pathItem := doc.Paths[def.Path]
+		pathItem.SetOperation(def.Method, operation)
+		doc.Paths[def.Path] = pathItem
+	}
+
+	r.CompleteDocument(doc)
+	return doc, nil
+}
+
+// DocumentOperation documents what the router's configuration adds to the operation built for a definition:
+// the default CORS policy, payload limits, request encodings, negotiated headers, the response envelope,
+// shared parameters and recorded examples
+func (r *Router) DocumentOperation(doc *api.OpenAPIDoc, def *api.APIDefinition, operation *api.Operation) error {
+	// Document the router-wide CORS policy unless the definition overrides it
+	if _, ok := def.Extensions["x-cors"]; !ok && def.CORS == nil && r.cors != nil {
+		if operation.Extensions == nil {
+			operation.Extensions = make(map[string]interface{})
+		}
+		operation.Extensions["x-cors"] = r.cors.Extension()
+	}
+
+	// Document the 413 and 400 responses of router-wide payload limits
+	DocumentPayloadLimits(operation, EffectiveLimits(def, r.payloadLimits))
+
+	// Document the accepted request encodings
+	if r.maxDecompressed > 0 && !def.StreamsRequest() {
+		DocumentRequestEncodings(operation, r.maxDecompressed)
+	}
+
+	// Document the negotiated Accept and Content-Type headers
+	if r.negotiation {
+		DocumentNegotiatedHeaders(operation)
+	}
+
+	// Document success responses inside the response envelope
+	if r.envelope != nil {
+		if err := EnvelopeResponse(operation, r.envelope); err != nil {
+			return err
+		}
+	}
+
+	// Share referenced parameters through components
+	for name, param := range SharedParameters(def) {
+		if doc.Components.Parameters == nil {
+			doc.Components.Parameters = make(map[string]api.Parameter)
+		}
+		doc.Components.Parameters[name] = param
+	}
+
+	// Attach recorded traffic samples
+	if r.recorder != nil {
+		r.recorder.attach(operation, operationKey(def.Method, def.Path))
+	}
+	return nil
+}
+
+// CompleteDocument adds what spans every operation of a built document: default responses, closed request
+// bodies when strict requests are the default, the X-Request-ID parameter and header, and missing operationIds
+func (r *Router) CompleteDocument(doc *api.OpenAPIDoc) {
+	responses := DefaultResponses
+	if r.responsesSet {
+		responses = r.responses
+	}
+	ApplyDefaultResponses(doc, r.definitions, responses)
+	if r.strictRequests {
+		ApplyStrictRequests(doc)
+	}
+	if r.requestID != nil {
+		DocumentRequestIDs(doc)
+	}
+	AssignOperationIDs(doc, r.definitions, r.idStrategy)
+}
+
+// GenerateSwagger builds, caches and returns the OpenAPI document served by SwaggerHandler
+func (r *Router) GenerateSwagger() (*api.OpenAPIDoc, error) {
+	if r.title == "" {
+		return nil, fmt.Errorf("API title is required")
+	}
+	if r.version == "" {
+		return nil, fmt.Errorf("API version is required")
+	}
+	doc, err := r.BuildOpenAPI()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OpenAPI document: %w", err)
+	}
+	if err := r.RunGenerateHooks(doc); err != nil {
+		return nil, err
+	}
+	r.RecordOperationIDs(doc)
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal OpenAPI document: %w", err)
+	}
+
+	r.docMu.Lock()
+	r.swaggerDoc = data
+	r.docMu.Unlock()
+	return doc, nil
+}
+
+// SwaggerHandler serves the document cached by GenerateSwagger
+func (r *Router) SwaggerHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.docMu.RLock()
+		data := r.swaggerDoc
+		r.docMu.RUnlock()
+
+		if data == nil {
+			http.Error(w, "Swagger documentation not available", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write(data)
+	})
+}
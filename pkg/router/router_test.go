@@ -0,0 +1,216 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestRegister tests registration and request validation through the Mux adapter
+func TestRegister(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+
+	getUser := api.NewAPIDefinition("get", "/users/{id}", "Get user").
+		WithPathParam("id", "User ID", true, api.NewValidationRule("pattern", "^[0-9]+$", "id must be numeric")).
+		WithQueryParam("fields", "Fields", false).
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {
+			op, _ := api.OperationFromContext(req.Context())
+			fmt.Fprintf(w, "%s: user %v", op.Summary, QueryParams(req.Context())["fields"])
+		})
+	createUser := api.NewAPIDefinition("POST", "/users", "Create user").
+		WithRequest(createUser{}).
+		WithNativeHandler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			user := RequestBody(req.Context()).(*createUser)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, user.Name)
+		}))
+	for _, def := range []*api.APIDefinition{getUser, createUser} {
+		if err := r.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "valid path", method: "GET", target: "/api/users/1?fields=name", wantStatus: http.StatusOK, wantBody: "Get user: user name"},
+		{name: "invalid path", method: "GET", target: "/api/users/abc", wantStatus: http.StatusBadRequest, wantBody: "invalid path parameter id"},
+		{name: "valid body", method: "POST", target: "/api/users", body: `{"name":"Ada","email":"ada@example.com"}`, wantStatus: http.StatusCreated, wantBody: "Ada"},
+		{name: "invalid body", method: "POST", target: "/api/users", body: `{"name":"Ada"}`, wantStatus: http.StatusBadRequest, wantBody: "invalid request body"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("Expected body to contain %q, got %q", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestRegisterErrors tests rejected definitions
+func TestRegisterErrors(t *testing.T) {
+	handler := func(w http.ResponseWriter, req *http.Request) {}
+	tests := []struct {
+		name string
+		def  *api.APIDefinition
+	}{
+		{name: "nil definition"},
+		{name: "no handler", def: api.NewAPIDefinition("GET", "/users", "List users")},
+		{name: "empty path", def: api.NewAPIDefinition("GET", "", "Root").WithHandler(handler)},
+		{name: "unsupported method", def: api.NewAPIDefinition("TRACE", "/users", "Trace").WithHandler(handler)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(NewMux(), "/api", "Test API", "1.0.0", "Test")
+			if err := r.Register(tt.def); err == nil {
+				t.Error("Expected Register to fail")
+			}
+		})
+	}
+}
+
+// TestGenerateSwagger tests generating and serving the document
+func TestGenerateSwagger(t *testing.T) {
+	r := New(NewMux(), "/api", "Test API", "1.0.0", "Test")
+	r.AddSecurityScheme("bearer", api.SecurityScheme{Type: "http", Scheme: "bearer"})
+	r.SetGlobalSecurity([]map[string][]string{{"bearer": {}}})
+
+	w := httptest.NewRecorder()
+	r.SwaggerHandler().ServeHTTP(w, httptest.NewRequest("GET", "/swagger.json", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500 before generation, got %d", w.Code)
+	}
+
+	def := api.NewAPIDefinition("GET", "/users/{id}", "Get user").
+		WithResponse(userResponse{}).
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {})
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	doc, err := r.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if op := doc.Paths["/users/{id}"].Get; op == nil || op.Summary != "Get user" {
+		t.Fatalf("Expected the GET operation, got %+v", doc.Paths)
+	}
+	if len(doc.Security) != 1 || doc.Components.SecuritySchemes["bearer"].Scheme != "bearer" {
+		t.Errorf("Expected security to be documented, got %+v", doc.Components)
+	}
+
+	w = httptest.NewRecorder()
+	r.SwaggerHandler().ServeHTTP(w, httptest.NewRequest("GET", "/swagger.json", nil))
+	var served api.OpenAPIDoc
+	if err := json.Unmarshal(w.Body.Bytes(), &served); err != nil {
+		t.Fatalf("Failed to decode served document: %v", err)
+	}
+	if served.Info.Title != "Test API" || len(served.Paths) != 1 {
+		t.Errorf("Unexpected served document: %+v", served.Info)
+	}
+}
+
+// TestGenerateSwaggerComplete tests that the generated document has responses and operationIds and validates
+func TestGenerateSwaggerComplete(t *testing.T) {
+	r := New(NewMux(), "/api", "Test API", "1.0.0", "Test")
+	handler := func(w http.ResponseWriter, req *http.Request) {}
+	for _, def := range []*api.APIDefinition{
+		api.NewAPIDefinition("GET", "/users", "List users").WithTags("users").WithHandler(handler),
+		api.NewAPIDefinition("POST", "/users", "Create user").WithTags("users").WithHandler(handler),
+		api.NewAPIDefinition("GET", "/health", "Health").WithOperationID("health").WithHandler(handler),
+	} {
+		if err := r.Register(def); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	doc, err := r.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	if err := api.ValidateDoc(doc); err != nil {
+		t.Errorf("Expected a valid document, got %v", err)
+	}
+	users := doc.Paths["/users"]
+	if users.Get.OperationID != "users_users" || users.Post.OperationID != "users_users_2" {
+		t.Errorf("Expected users_users and users_users_2, got %q and %q", users.Get.OperationID, users.Post.OperationID)
+	}
+	if id := doc.Paths["/health"].Get.OperationID; id != "health" {
+		t.Errorf("Expected the explicit operationId health, got %q", id)
+	}
+	if _, ok := users.Get.Responses["400"]; !ok {
+		t.Errorf("Expected the default 400 response, got %+v", users.Get.Responses)
+	}
+	if _, ok := users.Get.Responses["401"]; ok {
+		t.Error("Expected no 401 response on an unsecured operation")
+	}
+}
+
+// TestGenerateSwaggerPolicies tests custom default responses and operationId strategies
+func TestGenerateSwaggerPolicies(t *testing.T) {
+	r := New(NewMux(), "/api", "Test API", "1.0.0", "Test")
+	r.SetDefaultResponsePolicy()
+	r.SetOperationIDStrategy(func(method, path string, def api.APIDefinition) string {
+		return strings.ToLower(method) + "Users"
+	})
+	def := api.NewAPIDefinition("GET", "/users", "List users").
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {})
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	doc, err := r.GenerateSwagger()
+	if err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+	op := doc.Paths["/users"].Get
+	if op.OperationID != "getUsers" {
+		t.Errorf("Expected operationId getUsers, got %q", op.OperationID)
+	}
+	if len(op.Responses) != 1 || op.Responses["200"].Description == "" {
+		t.Errorf("Expected only the 200 response, got %+v", op.Responses)
+	}
+}
+
+// TestDeprecationHeaders tests the headers emitted for deprecated operations
+func TestDeprecationHeaders(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	sunset := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	def := api.NewAPIDefinition("GET", "/v1/users", "List users").
+		WithDeprecation(sunset, "/api/v2/users").
+		WithHandler(func(w http.ResponseWriter, req *http.Request) {})
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/users", nil))
+	if got := w.Header().Get("Deprecation"); got != "true" {
+		t.Errorf("Expected Deprecation true, got %q", got)
+	}
+	if got := w.Header().Get("Sunset"); got != sunset.Format(http.TimeFormat) {
+		t.Errorf("Expected Sunset %q, got %q", sunset.Format(http.TimeFormat), got)
+	}
+	if got := w.Header().Get("Link"); got != `</api/v2/users>; rel="successor-version"` {
+		t.Errorf("Expected the successor Link header, got %q", got)
+	}
+}
@@ -0,0 +1,77 @@
+package router
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// SessionValidator checks the value of a session cookie, returning false to reject the request
+type SessionValidator func(r *http.Request, session string) bool
+
+// sessionKey keys the session cookie value that satisfied the operation's security in request contexts
+type sessionKey struct{}
+
+// SetSessionValidator enforces a cookie security scheme at runtime
+// Operations requiring the scheme reject requests whose cookie is missing or fails the validator with 401
+func (r *Router) SetSessionValidator(scheme string, validator SessionValidator) {
+	if r.sessionValidators == nil {
+		r.sessionValidators = make(map[string]SessionValidator)
+	}
+	r.sessionValidators[scheme] = validator
+}
+
+// SessionCookie returns the session cookie value accepted for the request of ctx
+func SessionCookie(ctx context.Context) (string, bool) {
+	session, ok := ctx.Value(sessionKey{}).(string)
+	return session, ok
+}
+
+// checkSession enforces the validated cookie schemes of the operation's security requirements
+// Requirements are alternatives: the request passes if any of them has all its cookie schemes satisfied,
+// schemes without a session validator being left to the application
+// Returns the request carrying the accepted session, or nil if the request was rejected
+func (r *Router) checkSession(req *http.Request, def *api.APIDefinition) *http.Request {
+	if len(r.sessionValidators) == 0 {
+		return req
+	}
+	requirements := def.Security
+	if len(requirements) == 0 {
+		requirements = r.globalSecurity
+	}
+	if len(requirements) == 0 {
+		return req
+	}
+
+	for _, requirement := range requirements {
+		if session, ok := r.satisfySession(req, requirement); ok {
+			if session != "" {
+				req = req.WithContext(context.WithValue(req.Context(), sessionKey{}, session))
+			}
+			return req
+		}
+	}
+	return nil
+}
+
+// satisfySession checks the cookie schemes of one security requirement, returning the accepted session value
+func (r *Router) satisfySession(req *http.Request, requirement map[string][]string) (string, bool) {
+	var session string
+	for scheme := range requirement {
+		validator, ok := r.sessionValidators[scheme]
+		if !ok {
+			continue
+		}
+		definition, ok := r.securitySchemes[scheme]
+		if !ok || definition.In != "cookie" {
+			continue
+		}
+		cookie, err := req.Cookie(definition.Name)
+		if err != nil || cookie.Value == "" || !validator(req, cookie.Value) {
+			return "", false
+		}
+		session = cookie.Value
+	}
+	return session, true
+}
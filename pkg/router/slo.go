@@ -0,0 +1,149 @@
+package router
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// SLOResult compares the measured performance of an operation with its objectives
+type SLOResult struct {
+	OperationID   string
+	Objective     api.SLOPolicy
+	Requests      int           // Requests measured
+	P99           time.Duration // Observed 99th percentile latency over the recent samples
+	RPS           float64       // Observed throughput between the first and last measured request
+	LatencyMet    bool          // Whether the observed p99 is within the latency objective
+	ThroughputMet bool          // Whether the observed throughput reached the throughput objective
+}
+
+// Conforming reports whether every objective was met
+func (r SLOResult) Conforming() bool {
+	return r.LatencyMet && r.ThroughputMet
+}
+
+// SLOMonitor measures operations declaring an SLO against their objectives
+type SLOMonitor struct {
+	sampleSize int
+	mu         sync.Mutex
+	operations map[string]*sloWindow
+}
+
+// sloWindow holds the recent latencies and request timing of one operation
+type sloWindow struct {
+	objective api.SLOPolicy
+	latencies []time.Duration // Ring buffer of the most recent latencies
+	next      int
+	requests  int
+	first     time.Time
+	last      time.Time
+}
+
+// EnableSLOMonitor measures the latency and throughput of operations declaring an SLO, keeping the given
+// number of recent latencies per operation (default 1000) for percentile computation
+func (r *Router) EnableSLOMonitor(sampleSize int) *SLOMonitor {
+	if sampleSize <= 0 {
+		sampleSize = 1000
+	}
+	r.sloMonitor = &SLOMonitor{
+		sampleSize: sampleSize,
+		operations: make(map[string]*sloWindow),
+	}
+	return r.sloMonitor
+}
+
+// startSLO starts timing the request and returns a function recording it
+func (m *SLOMonitor) startSLO(def *api.APIDefinition, method, route string) func() {
+	start := time.Now()
+	return func() {
+		m.observe(operationName(def, method, route), *def.SLO, start, time.Since(start))
+	}
+}
+
+func (m *SLOMonitor) observe(name string, objective api.SLOPolicy, start time.Time, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, ok := m.operations[name]
+	if !ok {
+		w = &sloWindow{objective: objective, first: start}
+		m.operations[name] = w
+	}
+	if len(w.latencies) < m.sampleSize {
+		w.latencies = append(w.latencies, latency)
+	} else {
+		w.latencies[w.next] = latency
+		w.next = (w.next + 1) % m.sampleSize
+	}
+	w.requests++
+	if end := start.Add(latency); end.After(w.last) {
+		w.last = end
+	}
+}
+
+// Report returns the measurements of every operation that received requests, sorted by operation
+func (m *SLOMonitor) Report() []SLOResult {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	results := make([]SLOResult, 0, len(m.operations))
+	for name, w := range m.operations {
+		result := SLOResult{
+			OperationID: name,
+			Objective:   w.objective,
+			Requests:    w.requests,
+			P99:         percentile(w.latencies, 0.99),
+		}
+		if elapsed := w.last.Sub(w.first).Seconds(); elapsed > 0 {
+			result.RPS = float64(w.requests) / elapsed
+		}
+		result.LatencyMet = w.objective.LatencyP99 <= 0 || result.P99 <= w.objective.LatencyP99
+		result.ThroughputMet = w.objective.RPS <= 0 || result.RPS >= w.objective.RPS
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].OperationID < results[j].OperationID })
+	return results
+}
+
+// Verify returns an error describing every operation missing its objectives, for CI smoke tests
+func (m *SLOMonitor) Verify() error {
+	var violations []string
+	for _, result := range m.Report() {
+		if !result.LatencyMet {
+			violations = append(violations, fmt.Sprintf("%s: p99 %s exceeds %s", result.OperationID, result.P99, result.Objective.LatencyP99))
+		}
+		if !result.ThroughputMet {
+			violations = append(violations, fmt.Sprintf("%s: %.1f rps below %.1f", result.OperationID, result.RPS, result.Objective.RPS))
+		}
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("SLO not met: %s", strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// Reset discards all measurements, e.g. after a warm-up phase
+func (m *SLOMonitor) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.operations = make(map[string]*sloWindow)
+}
+
+// percentile returns the nearest-rank percentile of the latencies
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
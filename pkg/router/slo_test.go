@@ -0,0 +1,20 @@
+package router
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPercentile tests nearest-rank percentiles
+func TestPercentile(t *testing.T) {
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		latencies[i] = time.Duration(100-i) * time.Millisecond
+	}
+	if got := percentile(latencies, 0.99); got != 99*time.Millisecond {
+		t.Errorf("Expected p99 of 99ms, got %s", got)
+	}
+	if got := percentile(nil, 0.99); got != 0 {
+		t.Errorf("Expected 0 for no samples, got %s", got)
+	}
+}
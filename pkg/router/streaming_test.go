@@ -0,0 +1,70 @@
+package router
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// TestStreamingRequest tests that streamed bodies reach the handler unread and are only bounded in size
+func TestStreamingRequest(t *testing.T) {
+	mux := NewMux()
+	r := New(mux, "/api", "Test API", "1.0.0", "Test")
+	r.SetPayloadLimits(api.PayloadLimits{MaxBodySize: 64, MaxStringLength: 4})
+	r.EnableRequestDecompression(1024)
+	handler := func(w http.ResponseWriter, req *http.Request) {
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write(data)
+	}
+	def := api.NewAPIDefinition("POST", "/uploads", "Upload").WithRequest(createUser{}).WithStreamingRequest().WithHandler(handler)
+	if err := r.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "unvalidated body", body: `{"name":"Ada Lovelace"}`, wantStatus: http.StatusCreated},
+		{name: "too large", body: strings.Repeat("x", 65), wantStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/uploads", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", api.MediaTypeOctetStream)
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusCreated && w.Body.String() != tt.body {
+				t.Errorf("Handler read %q, want %q", w.Body.String(), tt.body)
+			}
+		})
+	}
+
+	doc, err := r.BuildOpenAPI()
+	if err != nil {
+		t.Fatalf("BuildOpenAPI failed: %v", err)
+	}
+	operation := doc.Paths["/uploads"].Post
+	if schema := operation.RequestBody.Content[api.MediaTypeOctetStream].Schema; schema["format"] != "binary" {
+		t.Errorf("Unexpected streamed body schema %v", schema)
+	}
+	if response := operation.Responses["400"]; response.Description != DefaultResponses[0].Description {
+		t.Errorf("Expected no shape limit response for a streamed body, got %q", response.Description)
+	}
+	if _, ok := operation.Extensions["x-request-encodings"]; ok {
+		t.Error("Expected streamed bodies not to advertise decompression")
+	}
+}
@@ -0,0 +1,60 @@
+package router
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// Tracer starts spans around registered handlers
+// It mirrors the shape of OpenTelemetry's trace.Tracer so an adapter is a few lines:
+//
+//	func (t otelTracer) Start(ctx context.Context, name string, attrs map[string]interface{}) (context.Context, router.Span) {
+//		ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(toKeyValues(attrs)...))
+//		return ctx, otelSpan{span}
+//	}
+type Tracer interface {
+	Start(ctx context.Context, spanName string, attributes map[string]interface{}) (context.Context, Span)
+}
+
+// Span is a started span
+type Span interface {
+	SetStatus(httpStatus int) // Records the response status code
+	End()
+}
+
+// SetTracer wraps every registered handler in a span named by the operation ID
+func (r *Router) SetTracer(tracer Tracer) {
+	r.tracer = tracer
+}
+
+// operationName returns the span name for a definition: its operation ID, or "METHOD /route"
+func operationName(def *api.APIDefinition, method, route string) string {
+	if def.OperationID != "" {
+		return def.OperationID
+	}
+	return method + " " + route
+}
+
+// startSpan starts a span for the request, returning the request carrying it and a function ending it
+func (r *Router) startSpan(w ResponseState, req *http.Request, def *api.APIDefinition, route string) (*http.Request, func()) {
+	attributes := map[string]interface{}{
+		"http.method":    def.Method,
+		"http.route":     route,
+		"api.tags":       def.Tags,
+		"api.deprecated": def.Deprecated,
+	}
+	if def.OperationID != "" {
+		attributes["api.operation_id"] = def.OperationID
+	}
+	if id := RequestID(req); id != "" {
+		attributes["http.request_id"] = id
+	}
+
+	ctx, span := r.tracer.Start(req.Context(), operationName(def, def.Method, route), attributes)
+	return req.WithContext(ctx), func() {
+		span.SetStatus(w.Status())
+		span.End()
+	}
+}
@@ -0,0 +1,330 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// ValidationError is a request rejected by validation, with the status to respond with
+type ValidationError struct {
+	Status  int
+	Message string
+	Fields  []string // Offending body properties, for unknown field rejections
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// badRequest creates a 400 validation error
+func badRequest(format string, args ...interface{}) *ValidationError {
+	return &ValidationError{Status: http.StatusBadRequest, Message: fmt.Sprintf(format, args...)}
+}
+
+// ValidateParams validates the path, query, header and cookie parameters of a request against a definition
+// Query parameters are decoded according to their style and returned keyed by name
+func ValidateParams(def *api.APIDefinition, r *http.Request, pathParam ParamExtractor) (map[string]interface{}, *ValidationError) {
+	// Validate path parameters
+	for _, param := range def.Params {
+		if param.In == "path" {
+			value := pathParam(r, param.Name)
+			if param.Required && value == "" {
+				return nil, badRequest("missing required path parameter: %s", param.Name)
+			}
+			if value != "" {
+				if err := param.Validate(value); err != nil {
+					return nil, badRequest("invalid path parameter %s: %v", param.Name, err)
+				}
+			}
+		}
+	}
+
+	// Validate query parameters, decoding them according to their style
+	query := r.URL.Query()
+	decoded := make(map[string]interface{})
+	for _, param := range def.Params {
+		if param.In == "query" {
+			value, ok := param.DecodeQuery(query)
+			if param.Required && (!ok || value == "") {
+				return nil, badRequest("missing required query parameter: %s", param.Name)
+			}
+			if ok && value != "" {
+				if err := param.ValidateDecoded(value); err != nil {
+					return nil, badRequest("invalid query parameter %s: %v", param.Name, err)
+				}
+				decoded[param.Name] = value
+			}
+		}
+	}
+
+	// Validate header parameters, matching names case-insensitively and checking every value of array headers
+	for _, param := range def.Params {
+		if param.In == "header" {
+			value, ok := param.DecodeHeader(r.Header)
+			if param.Required && (!ok || value == "") {
+				return nil, badRequest("missing required header: %s", param.Name)
+			}
+			if ok && value != "" {
+				if err := param.ValidateDecoded(value); err != nil {
+					return nil, badRequest("invalid header %s: %v", param.Name, err)
+				}
+			}
+		}
+	}
+
+	// Validate cookie parameters
+	for _, param := range def.Params {
+		if param.In == "cookie" {
+			cookie, err := r.Cookie(param.Name)
+			if err != nil {
+				if param.Required {
+					return nil, badRequest("missing required cookie: %s", param.Name)
+				}
+				continue
+			}
+			if cookie.Value != "" {
+				if err := param.Validate(cookie.Value); err != nil {
+					return nil, badRequest("invalid cookie %s: %v", param.Name, err)
+				}
+			}
+		}
+	}
+
+	return decoded, nil
+}
+
+// bodyValidator checks decoded request bodies against their `binding` tags, like gin's binding does
+var bodyValidator = func() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+	return v
+}()
+
+// ValidateBody checks the Content-Type of a request and decodes its JSON body, or a body the definition accepts
+// with a registered codec, into a new instance of the request model, checking the model's `binding` tags;
+// `path` tagged fields are filled in from the path parameters first (pathParam may be nil to skip them);
+// the decoded value is returned and the raw body is restored for the handler
+func ValidateBody(def *api.APIDefinition, r *http.Request, pathParam ParamExtractor) (interface{}, *ValidationError) {
+	var codec api.Codec
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		if mediaType != "application/json" {
+			var ok bool
+			if codec, ok = def.RequestCodec(contentType); !ok {
+				return nil, &ValidationError{
+					Status:  http.StatusUnsupportedMediaType,
+					Message: fmt.Sprintf("unsupported content type: %s", mediaType),
+				}
+			}
+		}
+	}
+
+	t := reflect.TypeOf(def.Request)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	data, verr := readBody(r)
+	if verr != nil {
+		return nil, verr
+	}
+	if codec != nil {
+		return decodeBody(codec, def.Batch, t, data, r, pathParam)
+	}
+	if def.Batch != nil {
+		return validateBatch(def.Batch, t, data)
+	}
+	target := reflect.New(t)
+	if verr := BindBodyPath(target.Interface(), r, pathParam); verr != nil {
+		return nil, verr
+	}
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(target.Interface()); err != nil {
+		return nil, badRequest("invalid request body: %v", err)
+	}
+	if t.Kind() == reflect.Struct {
+		if err := bodyValidator.Struct(target.Interface()); err != nil {
+			return nil, badRequest("invalid request body: %v", err)
+		}
+	}
+	return target.Interface(), nil
+}
+
+// BindBodyPath fills the `path` tagged fields of a new request model (a struct pointer) from the request's
+// path parameters, before the body is decoded into it; fields should also be tagged `json:"-"`
+func BindBodyPath(target interface{}, r *http.Request, pathParam ParamExtractor) *ValidationError {
+	v := reflect.ValueOf(target).Elem()
+	if pathParam == nil || v.Kind() != reflect.Struct {
+		return nil
+	}
+	return bindPathFields(v, r, pathParam)
+}
+
+// validateBatch decodes a batch body into a new slice of the request model, checking its size and each item
+func validateBatch(batch *api.BatchPolicy, t reflect.Type, data []byte) (interface{}, *ValidationError) {
+	target := reflect.New(reflect.SliceOf(t))
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(target.Interface()); err != nil {
+		return nil, badRequest("invalid request body: %v", err)
+	}
+	items := target.Elem()
+	if err := batch.Check(items.Len()); err != nil {
+		return nil, badRequest("invalid request body: %v", err)
+	}
+	if t.Kind() == reflect.Struct {
+		for i := 0; i < items.Len(); i++ {
+			if err := bodyValidator.Struct(items.Index(i).Interface()); err != nil {
+				return nil, badRequest("invalid request body: item %d: %v", i, err)
+			}
+		}
+	}
+	return target.Interface(), nil
+}
+
+// decodeBody decodes a body with a codec into a new instance of the request model, or a slice of it for batch
+// requests, checking the batch size and the `binding` tags of each item
+func decodeBody(codec api.Codec, batch *api.BatchPolicy, t reflect.Type, data []byte, r *http.Request, pathParam ParamExtractor) (interface{}, *ValidationError) {
+	if batch != nil {
+		t = reflect.SliceOf(t)
+	}
+	target := reflect.New(t)
+	if verr := BindBodyPath(target.Interface(), r, pathParam); verr != nil {
+		return nil, verr
+	}
+	if err := codec.Decode(data, target.Interface()); err != nil {
+		return nil, badRequest("invalid request body: %v", err)
+	}
+	if err := checkDecoded(batch, target.Elem()); err != nil {
+		return nil, badRequest("invalid request body: %v", err)
+	}
+	return target.Interface(), nil
+}
+
+// checkDecoded checks the batch size and `binding` tags of a decoded request model or batch
+func checkDecoded(batch *api.BatchPolicy, v reflect.Value) error {
+	if batch == nil {
+		if v.Kind() == reflect.Struct {
+			return bodyValidator.Struct(v.Addr().Interface())
+		}
+		return nil
+	}
+	if err := batch.Check(v.Len()); err != nil {
+		return err
+	}
+	for i := 0; i < v.Len(); i++ {
+		if v.Index(i).Kind() == reflect.Struct {
+			if err := bodyValidator.Struct(v.Index(i).Addr().Interface()); err != nil {
+				return fmt.Errorf("item %d: %v", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// StrictSchema returns the request schema of a definition when it rejects unknown fields, or nil
+// With disallowUnknown, every object the schema doesn't explicitly open is closed
+func StrictSchema(def *api.APIDefinition, disallowUnknown bool) map[string]interface{} {
+	schema, err := api.RequestSchema(def)
+	if err != nil || schema == nil {
+		return nil
+	}
+	if disallowUnknown {
+		api.ApplyStrict(schema)
+	}
+	if !api.IsStrict(schema) {
+		return nil
+	}
+	return schema
+}
+
+// ApplyStrictRequests documents the request bodies of every operation of doc as closed, except for models
+// opting out through api.StrictModel
+func ApplyStrictRequests(doc *api.OpenAPIDoc) {
+	for _, pathItem := range doc.Paths {
+		for _, method := range api.SupportedMethods {
+			op := pathItem.Operation(method)
+			if op == nil || op.RequestBody == nil {
+				continue
+			}
+			for _, content := range op.RequestBody.Content {
+				api.ApplyStrict(content.Schema)
+			}
+		}
+	}
+}
+
+// CheckUnknownFields rejects a JSON request body with properties that closed (additionalProperties: false)
+// objects of the schema don't document, listing them; the body is restored for later decoding
+// Malformed bodies are left to ValidateBody
+func CheckUnknownFields(schema map[string]interface{}, r *http.Request) *ValidationError {
+	data, verr := readBody(r)
+	if verr != nil {
+		return verr
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil
+	}
+	if unknown := api.UnknownProperties(schema, value); len(unknown) > 0 {
+		verr := badRequest("invalid request body: unknown fields: %s", strings.Join(unknown, ", "))
+		verr.Fields = unknown
+		return verr
+	}
+	return nil
+}
+
+// readBody reads a request body and restores it for later decoding
+func readBody(r *http.Request) ([]byte, *ValidationError) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, &ValidationError{Status: http.StatusRequestEntityTooLarge, Message: "request body too large"}
+		}
+		return nil, badRequest("invalid request body: %v", err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// contextKey keys the values the core router stores in request contexts
+type contextKey int
+
+const (
+	queryParamsKey contextKey = iota
+	requestBodyKey
+	queryModelKey
+	pathModelKey
+)
+
+// QueryParams returns the query parameters of a request decoded according to their documented style:
+// a string for scalars, []string for arrays and map[string]string for deepObject parameters
+func QueryParams(ctx context.Context) map[string]interface{} {
+	params, _ := ctx.Value(queryParamsKey).(map[string]interface{})
+	return params
+}
+
+// RequestBody returns the validated request body, a pointer to a new instance of the request model
+func RequestBody(ctx context.Context) interface{} {
+	return ctx.Value(requestBodyKey)
+}
+
+// QueryModel returns the query parameters bound into a new instance of the definition's query model (a pointer)
+func QueryModel(ctx context.Context) interface{} {
+	return ctx.Value(queryModelKey)
+}
+
+// PathModel returns the path parameters bound into a new instance of the definition's path model (a pointer)
+func PathModel(ctx context.Context) interface{} {
+	return ctx.Value(pathModelKey)
+}
@@ -0,0 +1,251 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// createUser is a request model with binding rules
+type createUser struct {
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email" binding:"required,email"`
+}
+
+// TestValidateParams tests path, query, header and cookie validation
+func TestValidateParams(t *testing.T) {
+	def := api.NewAPIDefinition("GET", "/users/{id}", "Get user").
+		WithPathParam("id", "User ID", true, api.NewValidationRule("pattern", "^[0-9]+$", "id must be numeric")).
+		WithQueryParam("fields", "Fields", false).
+		WithHeaderParam("X-Tenant", "Tenant", true).
+		WithCookieParam("session", "Session", false, api.NewValidationRule("pattern", "^s-", "invalid session"))
+	pathParam := func(id string) ParamExtractor {
+		return func(_ *http.Request, name string) string {
+			if name == "id" {
+				return id
+			}
+			return ""
+		}
+	}
+
+	tests := []struct {
+		name        string
+		id          string
+		target      string
+		tenant      string
+		cookie      string
+		wantStatus  int
+		wantMessage string
+		wantQuery   map[string]interface{}
+	}{
+		{name: "valid", id: "1", target: "/users/1?fields=name", tenant: "acme", cookie: "s-1", wantQuery: map[string]interface{}{"fields": "name"}},
+		{name: "missing path", target: "/users/", tenant: "acme", wantStatus: http.StatusBadRequest, wantMessage: "missing required path parameter: id"},
+		{name: "invalid path", id: "abc", target: "/users/abc", tenant: "acme", wantStatus: http.StatusBadRequest, wantMessage: "invalid path parameter id: id must be numeric"},
+		{name: "missing header", id: "1", target: "/users/1", wantStatus: http.StatusBadRequest, wantMessage: "missing required header: X-Tenant"},
+		{name: "invalid cookie", id: "1", target: "/users/1", tenant: "acme", cookie: "x", wantStatus: http.StatusBadRequest, wantMessage: "invalid cookie session: invalid session"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.target, nil)
+			if tt.tenant != "" {
+				req.Header.Set("X-Tenant", tt.tenant)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "session", Value: tt.cookie})
+			}
+			query, verr := ValidateParams(def, req, pathParam(tt.id))
+			if tt.wantStatus == 0 {
+				if verr != nil {
+					t.Fatalf("Unexpected validation error: %v", verr)
+				}
+				if !reflect.DeepEqual(query, tt.wantQuery) {
+					t.Errorf("Expected query %v, got %v", tt.wantQuery, query)
+				}
+				return
+			}
+			if verr == nil {
+				t.Fatal("Expected a validation error")
+			}
+			if verr.Status != tt.wantStatus || verr.Message != tt.wantMessage {
+				t.Errorf("Expected %d %q, got %d %q", tt.wantStatus, tt.wantMessage, verr.Status, verr.Message)
+			}
+		})
+	}
+}
+
+// TestValidateBody tests content type, decoding and binding rule checks of request bodies
+func TestValidateBody(t *testing.T) {
+	def := api.NewAPIDefinition("POST", "/users", "Create user").WithRequest(createUser{})
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{name: "valid", contentType: "application/json", body: `{"name":"Ada","email":"ada@example.com"}`},
+		{name: "charset", contentType: "application/json; charset=utf-8", body: `{"name":"Ada","email":"ada@example.com"}`},
+		{name: "unsupported type", contentType: "text/plain", body: "Ada", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "malformed", contentType: "application/json", body: `{"name":`, wantStatus: http.StatusBadRequest},
+		{name: "binding rule", contentType: "application/json", body: `{"name":"Ada","email":"ada"}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/users", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			body, verr := ValidateBody(def, req, nil)
+			if tt.wantStatus == 0 {
+				if verr != nil {
+					t.Fatalf("Unexpected validation error: %v", verr)
+				}
+				if user, ok := body.(*createUser); !ok || user.Name != "Ada" {
+					t.Errorf("Expected decoded *createUser, got %#v", body)
+				}
+				return
+			}
+			if verr == nil || verr.Status != tt.wantStatus {
+				t.Errorf("Expected status %d, got %v", tt.wantStatus, verr)
+			}
+		})
+	}
+}
+
+// kvCodec decodes "key=value" lines into a JSON-tagged model
+type kvCodec struct{}
+
+func (kvCodec) ContentType() string {
+	return "application/x-kv"
+}
+
+func (kvCodec) Decode(data []byte, v interface{}) error {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("malformed line %q", line)
+		}
+		fields[key] = value
+	}
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded, v)
+}
+
+// TestValidateBodyCodec tests decoding request bodies accepted with a registered codec
+func TestValidateBodyCodec(t *testing.T) {
+	api.RegisterCodec(kvCodec{})
+	def := api.NewAPIDefinition("POST", "/users", "Create user").
+		WithRequest(createUser{}).
+		WithRequestTypes("application/x-kv")
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{name: "decoded", contentType: "application/x-kv", body: "name=Ada\nemail=ada@example.com"},
+		{name: "json still accepted", contentType: "application/json", body: `{"name":"Ada","email":"ada@example.com"}`},
+		{name: "malformed", contentType: "application/x-kv", body: "Ada", wantStatus: http.StatusBadRequest},
+		{name: "binding rule", contentType: "application/x-kv", body: "name=Ada\nemail=ada", wantStatus: http.StatusBadRequest},
+		{name: "not accepted", contentType: "application/msgpack", body: "Ada", wantStatus: http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/users", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			body, verr := ValidateBody(def, req, nil)
+			if tt.wantStatus == 0 {
+				if verr != nil {
+					t.Fatalf("Unexpected validation error: %v", verr)
+				}
+				if user, ok := body.(*createUser); !ok || user.Name != "Ada" {
+					t.Errorf("Expected decoded *createUser, got %#v", body)
+				}
+				return
+			}
+			if verr == nil || verr.Status != tt.wantStatus {
+				t.Errorf("Expected status %d, got %v", tt.wantStatus, verr)
+			}
+		})
+	}
+
+	operation, err := BuildOperation(def)
+	if err != nil {
+		t.Fatalf("BuildOperation failed: %v", err)
+	}
+	if _, ok := operation.RequestBody.Content["application/x-kv"]; !ok {
+		t.Errorf("Expected an application/x-kv request body, got %v", operation.RequestBody.Content)
+	}
+}
+
+// TestContextAccessors tests reading values from contexts without them
+func TestContextAccessors(t *testing.T) {
+	if params := QueryParams(context.Background()); params != nil {
+		t.Errorf("Expected no query params, got %v", params)
+	}
+	if body := RequestBody(context.Background()); body != nil {
+		t.Errorf("Expected no body, got %v", body)
+	}
+}
+
+// TestDisallowUnknownFields tests rejecting undocumented body properties with the offending fields listed
+func TestDisallowUnknownFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		disallow   bool
+		body       string
+		wantStatus int
+		wantFields []interface{}
+	}{
+		{name: "known fields", disallow: true, body: `{"name":"Ada","email":"ada@example.com"}`, wantStatus: http.StatusCreated},
+		{name: "unknown fields", disallow: true, body: `{"name":"Ada","email":"ada@example.com","role":"admin","id":1}`, wantStatus: http.StatusBadRequest, wantFields: []interface{}{"id", "role"}},
+		{name: "mode disabled", body: `{"name":"Ada","email":"ada@example.com","role":"admin"}`, wantStatus: http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := NewMux()
+			r := New(mux, "/api", "Test API", "1.0.0", "Test")
+			r.SetDisallowUnknownFields(tt.disallow)
+			def := api.NewAPIDefinition("POST", "/users", "Create user").
+				WithRequest(createUser{}).
+				WithHandler(func(w http.ResponseWriter, req *http.Request) {
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprint(w, RequestBody(req.Context()).(*createUser).Name)
+				})
+			if err := r.Register(def); err != nil {
+				t.Fatalf("Register failed: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/users", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantFields == nil {
+				if w.Body.String() != "Ada" {
+					t.Errorf("Expected the handler to decode the body, got %s", w.Body.String())
+				}
+				return
+			}
+			var body map[string]interface{}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if !reflect.DeepEqual(body["fields"], tt.wantFields) {
+				t.Errorf("Expected fields %v, got %v", tt.wantFields, body["fields"])
+			}
+		})
+	}
+}
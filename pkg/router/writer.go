@@ -0,0 +1,142 @@
+package router
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// ResponseState is implemented by the response writers the router hands to middlewares and handlers
+// Framework adapters use it to report the status of responses written through the core
+type ResponseState interface {
+	Status() int   // Status code written, or 200 when none has been written yet
+	Size() int     // Bytes of body written
+	Written() bool // Whether a status or body has been written
+}
+
+// statusWriter records the status and size of the response sent to the client
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+		w.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (w *statusWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(data)
+	w.size += n
+	return n, err
+}
+
+func (w *statusWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *statusWriter) Size() int {
+	return w.size
+}
+
+func (w *statusWriter) Written() bool {
+	return w.status != 0
+}
+
+// Flush sends buffered data to the client, so streamed responses keep working
+func (w *statusWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		flusher.Flush()
+	}
+}
+
+// Hijack lets handlers take over the connection, e.g. for WebSocket upgrades
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
+// Unwrap returns the underlying writer for http.ResponseController
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// teeWriter copies the response body while writing it to the client
+type teeWriter struct {
+	*statusWriter
+	body bytes.Buffer
+}
+
+func (w *teeWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.statusWriter.Write(data)
+}
+
+// bufferWriter holds back a handler response so it can be rewritten before anything is sent
+type bufferWriter struct {
+	http.ResponseWriter
+	body   bytes.Buffer
+	status int
+	wrote  bool
+}
+
+func newBufferWriter(w http.ResponseWriter) *bufferWriter {
+	return &bufferWriter{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (w *bufferWriter) WriteHeader(code int) {
+	if !w.wrote {
+		w.status = code
+	}
+	w.wrote = true
+}
+
+func (w *bufferWriter) Write(data []byte) (int, error) {
+	w.wrote = true
+	return w.body.Write(data)
+}
+
+func (w *bufferWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferWriter) Written() bool {
+	return w.wrote
+}
+
+// send writes the status and the body to the underlying writer; 204 and 304 responses have no body
+func (w *bufferWriter) send(status int, body []byte) {
+	w.ResponseWriter.WriteHeader(status)
+	if status != http.StatusNoContent && status != http.StatusNotModified {
+		_, _ = w.ResponseWriter.Write(body)
+	}
+}
+
+// written reports whether anything was written to w, which is either a router writer or a plain one
+func written(w http.ResponseWriter) bool {
+	if state, ok := w.(ResponseState); ok {
+		return state.Written()
+	}
+	return false
+}
@@ -0,0 +1,288 @@
+// Package schemaregistry shares component schemas through a central schema registry speaking the Confluent
+// Schema Registry REST API (also served by Apicurio and Karapace), with schemas stored as JSON Schema
+package schemaregistry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+	"github.com/smartcat999/go-swagger/pkg/publish"
+)
+
+// contentType is the media type of registry requests
+const contentType = "application/vnd.schemaregistry.v1+json"
+
+// SchemaVersion identifies a registered schema
+type SchemaVersion struct {
+	Subject string `json:"subject"`
+	ID      int    `json:"id"`      // Registry-wide schema ID
+	Version int    `json:"version"` // Version of the schema under its subject
+}
+
+// Reference declares a registered schema that a schema's $ref names, resolved by the registry at its version
+type Reference struct {
+	Name    string `json:"name"` // Value of the $ref naming the schema
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// Client talks to a schema registry
+type Client struct {
+	baseURL string
+	header  http.Header
+	client  *http.Client
+}
+
+// NewClient creates a client for the registry at baseURL (e.g., https://registry.example.com)
+func NewClient(baseURL string) *Client {
+	return &Client{baseURL: strings.TrimSuffix(baseURL, "/"), header: make(http.Header), client: http.DefaultClient}
+}
+
+// WithHeader sets a header sent with every request, e.g. Authorization
+// Chain call: schemaregistry.NewClient(url).WithHeader("Authorization", "Basic "+credentials)
+func (c *Client) WithHeader(key, value string) *Client {
+	c.header.Set(key, value)
+	return c
+}
+
+// WithClient sets the HTTP client, http.DefaultClient by default
+// Chain call: schemaregistry.NewClient(url).WithClient(&http.Client{Timeout: 10 * time.Second})
+func (c *Client) WithClient(client *http.Client) *Client {
+	c.client = client
+	return c
+}
+
+// RefURL returns the location of a subject's schema at version (a number or "latest")
+func (c *Client) RefURL(subject, version string) string {
+	return c.baseURL + "/subjects/" + url.PathEscape(subject) + "/versions/" + url.PathEscape(version) + "/schema"
+}
+
+// Ref returns a schema referencing a shared schema of the registry, for RegisterTypeSchema or SchemaProvider
+// Generated documents resolve it through an api.RefResolver using Loader
+func (c *Client) Ref(subject, version string) map[string]interface{} {
+	return api.ExternalRef(c.RefURL(subject, version))
+}
+
+// Loader returns an api.RefLoader reading registry locations with the client's credentials and other
+// locations with api.LoadRef
+func (c *Client) Loader() api.RefLoader {
+	return func(location string) ([]byte, error) {
+		if !strings.HasPrefix(location, c.baseURL+"/") {
+			return api.LoadRef(location)
+		}
+		return c.do(context.Background(), http.MethodGet, location, nil)
+	}
+}
+
+// Fetch returns the schema of a subject at version (a number or "latest")
+func (c *Client) Fetch(ctx context.Context, subject, version string) ([]byte, error) {
+	data, err := c.do(ctx, http.MethodGet, c.RefURL(subject, version), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s version %s: %w", subject, version, err)
+	}
+	return data, nil
+}
+
+// Register stores a schema under a subject, returning its version; registering an unchanged schema returns the
+// existing version. References declare the registered schemas its $refs name
+func (c *Client) Register(ctx context.Context, subject string, schema interface{}, references ...Reference) (SchemaVersion, error) {
+	text, err := json.Marshal(schema)
+	if err != nil {
+		return SchemaVersion{}, fmt.Errorf("failed to marshal %s schema: %w", subject, err)
+	}
+	request := map[string]interface{}{"schemaType": "JSON", "schema": string(text)}
+	if len(references) > 0 {
+		request["references"] = references
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return SchemaVersion{}, err
+	}
+
+	subjectURL := c.baseURL + "/subjects/" + url.PathEscape(subject)
+	if _, err := c.do(ctx, http.MethodPost, subjectURL+"/versions", body); err != nil {
+		return SchemaVersion{}, fmt.Errorf("failed to register %s: %w", subject, err)
+	}
+	// Registration only answers the schema ID; looking the schema up answers its version too
+	data, err := c.do(ctx, http.MethodPost, subjectURL, body)
+	if err != nil {
+		return SchemaVersion{}, fmt.Errorf("failed to look up %s: %w", subject, err)
+	}
+	var version SchemaVersion
+	if err := json.Unmarshal(data, &version); err != nil {
+		return SchemaVersion{}, fmt.Errorf("invalid registry response for %s: %w", subject, err)
+	}
+	return version, nil
+}
+
+// PushComponents registers every component schema of a document under prefix+name as a JSON Schema,
+// referenced components before the components referring to them and otherwise in name order
+// References between components name the referenced subject and are declared with the version just
+// registered; components referring to each other in a cycle can't be registered and fail the push
+func (c *Client) PushComponents(ctx context.Context, doc *api.OpenAPIDoc, prefix string) ([]SchemaVersion, error) {
+	if doc.Components == nil {
+		return nil, nil
+	}
+	names := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	push := &componentPush{
+		client:     c,
+		components: doc.Components.Schemas,
+		prefix:     prefix,
+		registered: make(map[string]SchemaVersion, len(names)),
+		pushing:    make(map[string]bool),
+	}
+	for _, name := range names {
+		if err := push.register(ctx, name); err != nil {
+			return push.versions, err
+		}
+	}
+	return push.versions, nil
+}
+
+// componentPush tracks the components registered by PushComponents
+type componentPush struct {
+	client     *Client
+	components map[string]interface{}
+	prefix     string
+	registered map[string]SchemaVersion // Versions by component name
+	pushing    map[string]bool          // Components whose references are being registered
+	versions   []SchemaVersion          // Versions in registration order
+}
+
+// register registers a component once its referenced components are registered
+func (p *componentPush) register(ctx context.Context, name string) error {
+	if _, ok := p.registered[name]; ok {
+		return nil
+	}
+	if p.pushing[name] {
+		return fmt.Errorf("failed to register %s: circular reference between components", p.prefix+name)
+	}
+	p.pushing[name] = true
+	defer delete(p.pushing, name)
+
+	schema := p.components[name]
+	if openAPISchema, ok := schema.(map[string]interface{}); ok {
+		schema = api.ToJSONSchema(openAPISchema)
+	}
+	referenced := make(map[string]bool)
+	schema = p.rewriteRefs(schema, name, referenced)
+
+	references := make([]Reference, 0, len(referenced))
+	for _, target := range sortedNames(referenced) {
+		if err := p.register(ctx, target); err != nil {
+			return err
+		}
+		version := p.registered[target]
+		references = append(references, Reference{Name: version.Subject, Subject: version.Subject, Version: version.Version})
+	}
+	version, err := p.client.Register(ctx, p.prefix+name, schema, references...)
+	if err != nil {
+		return err
+	}
+	p.registered[name] = version
+	p.versions = append(p.versions, version)
+	return nil
+}
+
+// rewriteRefs returns a copy of a component's schema with #/components/schemas references naming the
+// registry subject, collecting the referenced components; references to the component itself point at its root
+func (p *componentPush) rewriteRefs(value interface{}, self string, referenced map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if ref, ok := item.(string); ok && key == "$ref" {
+				if name, ok := strings.CutPrefix(ref, "#/components/schemas/"); ok {
+					if name == self {
+						out[key] = "#"
+						continue
+					}
+					if _, ok := p.components[name]; ok {
+						referenced[name] = true
+						out[key] = p.prefix + name
+						continue
+					}
+				}
+			}
+			out[key] = p.rewriteRefs(item, self, referenced)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = p.rewriteRefs(item, self, referenced)
+		}
+		return out
+	}
+	return value
+}
+
+// sortedNames returns the keys of a set in order
+func sortedNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Publisher returns a publish.Publisher pushing the component schemas of every generated document
+func (c *Client) Publisher(prefix string) publish.Publisher {
+	return publish.PublisherFunc(func(ctx context.Context, spec publish.Spec) error {
+		var doc api.OpenAPIDoc
+		if err := json.Unmarshal(spec.Document, &doc); err != nil {
+			return fmt.Errorf("failed to read document: %w", err)
+		}
+		_, err := c.PushComponents(ctx, &doc, publish.Expand(prefix, spec))
+		return err
+	})
+}
+
+// do sends a registry request and returns the response body, failing on non-2xx responses
+func (c *Client) do(ctx context.Context, method, location string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, location, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", contentType+", application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var registryErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(data, &registryErr) == nil && registryErr.Message != "" {
+			return nil, fmt.Errorf("%s: %s", resp.Status, registryErr.Message)
+		}
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return data, nil
+}
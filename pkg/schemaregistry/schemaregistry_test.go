@@ -0,0 +1,272 @@
+package schemaregistry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+	"github.com/smartcat999/go-swagger/pkg/publish"
+)
+
+// fakeRegistry implements the parts of the schema registry API the client uses
+type fakeRegistry struct {
+	mu         sync.Mutex
+	subjects   map[string][]string    // Schemas by subject, oldest first
+	ids        map[string]int         // Schema IDs by schema
+	references map[string][]Reference // References of the latest version by subject
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.subjects == nil {
+		f.subjects, f.ids, f.references = make(map[string][]string), make(map[string]int), make(map[string][]Reference)
+	}
+	if r.Header.Get("Authorization") != "Bearer secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error_code":401,"message":"Unauthorized"}`))
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/subjects/"), "/")
+	subject := parts[0]
+	var body struct {
+		Schema     string      `json:"schema"`
+		References []Reference `json:"references"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	switch {
+	case r.Method == http.MethodPost && len(parts) == 2:
+		versions := f.subjects[subject]
+		if len(versions) == 0 || versions[len(versions)-1] != body.Schema {
+			f.subjects[subject] = append(versions, body.Schema)
+			f.references[subject] = body.References
+		}
+		if _, ok := f.ids[body.Schema]; !ok {
+			f.ids[body.Schema] = len(f.ids) + 1
+		}
+		_ = json.NewEncoder(w).Encode(map[string]int{"id": f.ids[body.Schema]})
+	case r.Method == http.MethodPost && len(parts) == 1:
+		for i, schema := range f.subjects[subject] {
+			if schema == body.Schema {
+				_ = json.NewEncoder(w).Encode(SchemaVersion{Subject: subject, ID: f.ids[schema], Version: i + 1})
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case r.Method == http.MethodGet && len(parts) == 4:
+		versions := f.subjects[subject]
+		index := len(versions) - 1
+		if parts[2] != "latest" {
+			index, _ = strconv.Atoi(parts[2])
+			index--
+		}
+		if index < 0 || index >= len(versions) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code":40402,"message":"Version not found."}`))
+			return
+		}
+		_, _ = w.Write([]byte(versions[index]))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// TestRegister tests registering schema versions
+func TestRegister(t *testing.T) {
+	server := httptest.NewServer(&fakeRegistry{})
+	defer server.Close()
+	client := NewClient(server.URL).WithHeader("Authorization", "Bearer secret")
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		schema  map[string]interface{}
+		version int
+	}{
+		{"first version", map[string]interface{}{"type": "string"}, 1},
+		{"unchanged schema", map[string]interface{}{"type": "string"}, 1},
+		{"changed schema", map[string]interface{}{"type": "string", "format": "email"}, 2},
+	}
+
+	for _, tt := range tests {
+		got, err := client.Register(ctx, "common.Email", tt.schema)
+		if err != nil {
+			t.Fatalf("%s: Register failed: %v", tt.name, err)
+		}
+		if got.Subject != "common.Email" || got.Version != tt.version {
+			t.Errorf("%s: Expected version %d, got %+v", tt.name, tt.version, got)
+		}
+	}
+
+	data, err := client.Fetch(ctx, "common.Email", "1")
+	if err != nil || string(data) != `{"type":"string"}` {
+		t.Errorf("Expected the first version, got %s (%v)", data, err)
+	}
+	if _, err := client.Fetch(ctx, "common.Email", "3"); err == nil || !strings.Contains(err.Error(), "Version not found") {
+		t.Errorf("Expected the registry message, got %v", err)
+	}
+	if _, err := NewClient(server.URL).Fetch(ctx, "common.Email", "1"); err == nil {
+		t.Error("Expected fetching without credentials to fail")
+	}
+}
+
+// TestPushComponents tests pushing component schemas as JSON Schemas declaring their references
+func TestPushComponents(t *testing.T) {
+	registry := &fakeRegistry{}
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	client := NewClient(server.URL).WithHeader("Authorization", "Bearer secret")
+	doc := &api.OpenAPIDoc{
+		OpenAPI: "3.0.0",
+		Info:    api.OpenAPIInfo{Title: "Orders", Version: "1.0.0"},
+		Paths:   map[string]api.PathItem{},
+		Components: &api.Components{Schemas: map[string]interface{}{
+			"Order": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"address": map[string]interface{}{"$ref": "#/components/schemas/Address"},
+					"note":    map[string]interface{}{"type": "string", "nullable": true},
+				},
+			},
+			"Address": map[string]interface{}{"type": "object"},
+		}},
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to encode the document: %v", err)
+	}
+
+	if err := client.Publisher("{title}.").Publish(context.Background(), publish.Spec{Title: "Orders", Document: data}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if len(registry.subjects["orders.Address"]) != 1 || len(registry.subjects["orders.Order"]) != 1 {
+		t.Fatalf("Expected one version of orders.Address and orders.Order, got %v", registry.subjects)
+	}
+	var order map[string]interface{}
+	if err := json.Unmarshal([]byte(registry.subjects["orders.Order"][0]), &order); err != nil {
+		t.Fatalf("Failed to decode orders.Order: %v", err)
+	}
+	props := order["properties"].(map[string]interface{})
+	if ref := props["address"].(map[string]interface{})["$ref"]; ref != "orders.Address" {
+		t.Errorf("Expected $ref orders.Address, got %v", ref)
+	}
+	if order["$schema"] != api.JSONSchemaDialect || !reflect.DeepEqual(props["note"], map[string]interface{}{"type": []interface{}{"string", "null"}}) {
+		t.Errorf("Expected Order as a JSON Schema, got %v", order)
+	}
+	want := []Reference{{Name: "orders.Address", Subject: "orders.Address", Version: 1}}
+	if got := registry.references["orders.Order"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected references %+v, got %+v", want, got)
+	}
+	// The document itself keeps its local references
+	address := doc.Components.Schemas["Order"].(map[string]interface{})["properties"].(map[string]interface{})["address"]
+	if address.(map[string]interface{})["$ref"] != "#/components/schemas/Address" {
+		t.Error("Expected PushComponents to leave the document unchanged")
+	}
+}
+
+// TestPushComponentsOrder tests registering referenced components first and rejecting reference cycles
+func TestPushComponentsOrder(t *testing.T) {
+	ref := func(name string) map[string]interface{} {
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	}
+	tests := []struct {
+		name    string
+		schemas map[string]interface{}
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "dependencies first",
+			schemas: map[string]interface{}{
+				"A": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"b": ref("B")}},
+				"B": map[string]interface{}{"type": "array", "items": ref("C")},
+				"C": map[string]interface{}{"type": "string"},
+			},
+			want: []string{"C", "B", "A"},
+		},
+		{
+			name: "self reference",
+			schemas: map[string]interface{}{
+				"Node": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"children": map[string]interface{}{"type": "array", "items": ref("Node")}}},
+			},
+			want: []string{"Node"},
+		},
+		{
+			name: "cycle",
+			schemas: map[string]interface{}{
+				"A": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"b": ref("B")}},
+				"B": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"a": ref("A")}},
+			},
+			wantErr: "circular reference",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(&fakeRegistry{})
+			defer server.Close()
+			client := NewClient(server.URL).WithHeader("Authorization", "Bearer secret")
+			doc := &api.OpenAPIDoc{Components: &api.Components{Schemas: tt.schemas}}
+			versions, err := client.PushComponents(context.Background(), doc, "")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PushComponents failed: %v", err)
+			}
+			var got []string
+			for _, version := range versions {
+				got = append(got, version.Subject)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected push order %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestLoader tests resolving shared schemas of the registry during generation
+func TestLoader(t *testing.T) {
+	server := httptest.NewServer(&fakeRegistry{})
+	defer server.Close()
+	client := NewClient(server.URL).WithHeader("Authorization", "Bearer secret")
+	ctx := context.Background()
+	if _, err := client.Register(ctx, "common.Money", map[string]interface{}{"type": "object", "properties": map[string]interface{}{
+		"currency": map[string]interface{}{"$ref": client.RefURL("common.Currency", "1")},
+	}}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, err := client.Register(ctx, "common.Currency", map[string]interface{}{"type": "string", "minLength": 3}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	doc := &api.OpenAPIDoc{
+		OpenAPI: "3.0.0",
+		Info:    api.OpenAPIInfo{Title: "Orders", Version: "1.0.0"},
+		Paths:   map[string]api.PathItem{},
+		Components: &api.Components{Schemas: map[string]interface{}{
+			"Price": client.Ref("common.Money", "latest"),
+		}},
+	}
+	resolver := api.NewRefResolver(api.RefBundle, "")
+	resolver.Loader = client.Loader()
+	if err := resolver.Resolve(doc); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	price, _ := json.Marshal(doc.Components.Schemas["Price"])
+	if want := `{"properties":{"currency":{"minLength":3,"type":"string"}},"type":"object"}`; string(price) != want {
+		t.Errorf("Expected Price %s, got %s", want, price)
+	}
+}
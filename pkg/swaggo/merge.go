@@ -0,0 +1,166 @@
+package swaggo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// Merge adds the operations of an imported document to doc
+//
+// Natively documented operations win over imported ones with the same path and method, so an endpoint
+// moves to its native definition as soon as it is registered. Imported paths are rebased from the
+// imported server onto the first server of doc; operations outside it keep the imported servers.
+// Components, security schemes and tags are added unless doc already declares the same name.
+// The imported document is left unchanged
+func Merge(doc, imported *api.OpenAPIDoc) error {
+	if imported == nil {
+		return nil
+	}
+	src, err := clone(imported)
+	if err != nil {
+		return err
+	}
+	if doc.Paths == nil {
+		doc.Paths = make(map[string]api.PathItem)
+	}
+
+	operationIDs := make(map[string]bool)
+	for _, item := range doc.Paths {
+		for _, op := range item.Operations() {
+			if op.OperationID != "" {
+				operationIDs[op.OperationID] = true
+			}
+		}
+	}
+
+	importedBase := ""
+	if len(src.Servers) > 0 {
+		importedBase = serverPath(src.Servers[0].URL)
+	}
+	nativeBase := ""
+	if len(doc.Servers) > 0 {
+		nativeBase = serverPath(doc.Servers[0].URL)
+	}
+
+	for _, path := range sortedPaths(src.Paths) {
+		item := src.Paths[path]
+		full := importedBase + path
+		target, servers := full, []api.OpenAPIServer(nil)
+		switch {
+		case nativeBase == "":
+		case strings.HasPrefix(full, nativeBase+"/"):
+			target = strings.TrimPrefix(full, nativeBase)
+		default:
+			target, servers = path, src.Servers
+		}
+
+		merged := doc.Paths[target]
+		for _, method := range api.SupportedMethods {
+			op := item.Operation(method)
+			if op == nil || merged.Operation(method) != nil {
+				continue
+			}
+			if op.OperationID != "" {
+				if operationIDs[op.OperationID] {
+					return fmt.Errorf("operationId %q of imported %s %s is already used", op.OperationID, method, path)
+				}
+				operationIDs[op.OperationID] = true
+			}
+			if servers != nil && op.Servers == nil {
+				op.Servers = servers
+			}
+			merged.SetOperation(method, op)
+		}
+		if len(merged.Operations()) > 0 {
+			doc.Paths[target] = merged
+		}
+	}
+
+	if src.Components != nil {
+		if doc.Components == nil {
+			doc.Components = &api.Components{}
+		}
+		for name, schema := range src.Components.Schemas {
+			if doc.Components.Schemas == nil {
+				doc.Components.Schemas = make(map[string]interface{})
+			}
+			if _, ok := doc.Components.Schemas[name]; !ok {
+				doc.Components.Schemas[name] = schema
+			}
+		}
+		for name, scheme := range src.Components.SecuritySchemes {
+			if doc.Components.SecuritySchemes == nil {
+				doc.Components.SecuritySchemes = make(map[string]api.SecurityScheme)
+			}
+			if _, ok := doc.Components.SecuritySchemes[name]; !ok {
+				doc.Components.SecuritySchemes[name] = scheme
+			}
+		}
+		for name, param := range src.Components.Parameters {
+			if doc.Components.Parameters == nil {
+				doc.Components.Parameters = make(map[string]api.Parameter)
+			}
+			if _, ok := doc.Components.Parameters[name]; !ok {
+				doc.Components.Parameters[name] = param
+			}
+		}
+	}
+
+	tags := make(map[string]bool, len(doc.Tags))
+	for _, tag := range doc.Tags {
+		tags[tag.Name] = true
+	}
+	for _, tag := range src.Tags {
+		if !tags[tag.Name] {
+			doc.Tags = append(doc.Tags, tag)
+			tags[tag.Name] = true
+		}
+	}
+	return nil
+}
+
+// serverPath returns the path of a server URL without its trailing slash
+func serverPath(server string) string {
+	if u, err := url.Parse(server); err == nil {
+		server = u.Path
+	}
+	return strings.TrimSuffix(server, "/")
+}
+
+// clone deep-copies a document so merged operations are not shared with the imported one
+func clone(doc *api.OpenAPIDoc) (*api.OpenAPIDoc, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy imported document: %w", err)
+	}
+	var copied api.OpenAPIDoc
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, fmt.Errorf("failed to copy imported document: %w", err)
+	}
+	return &copied, nil
+}
+
+// Importer merges an imported document into every generated document
+// It implements the gin router's GenerateHook, so it is added with router.Use
+type Importer struct {
+	doc *api.OpenAPIDoc
+}
+
+// NewImporter creates an importer merging doc into generated documents
+func NewImporter(doc *api.OpenAPIDoc) *Importer {
+	return &Importer{doc: doc}
+}
+
+// Name identifies the importer among the router's plugins
+func (i *Importer) Name() string {
+	return "swaggo"
+}
+
+// OnGenerate merges the imported document into a generated one
+func (i *Importer) OnGenerate(doc *api.OpenAPIDoc) error {
+	return Merge(doc, i.doc)
+}
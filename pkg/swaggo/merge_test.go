@@ -0,0 +1,163 @@
+package swaggo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+	ginSwagger "github.com/smartcat999/go-swagger/pkg/gin"
+)
+
+// nativeDoc returns a document of natively registered operations served under /api/v1
+func nativeDoc() *api.OpenAPIDoc {
+	return &api.OpenAPIDoc{
+		OpenAPI: "3.0.0",
+		Servers: []api.OpenAPIServer{{URL: "/api/v1"}},
+		Paths: map[string]api.PathItem{
+			"/users/{id}": {Get: &api.Operation{Summary: "Get user (native)", OperationID: "getUser"}},
+		},
+		Components: &api.Components{
+			Schemas: map[string]interface{}{"model.User": map[string]interface{}{"type": "object"}},
+		},
+		Tags: []api.Tag{{Name: "users", Description: "Native users"}},
+	}
+}
+
+// TestMerge tests that imported operations fill in what is not documented natively
+func TestMerge(t *testing.T) {
+	imported, err := FromSwagger2([]byte(swaggerJSON))
+	if err != nil {
+		t.Fatalf("FromSwagger2() error = %v", err)
+	}
+	imported.Tags = []api.Tag{{Name: "users"}, {Name: "avatars"}}
+
+	doc := nativeDoc()
+	if err := Merge(doc, imported); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+
+	if got := doc.Paths["/users/{id}"].Get.Summary; got != "Get user (native)" {
+		t.Errorf("Expected the native GET /users/{id} to win, got summary %q", got)
+	}
+	create := doc.Paths["/users"].Post
+	if create == nil || create.Summary != "Create user" {
+		t.Fatalf("Expected POST /users to be imported, got %+v", create)
+	}
+	if create.Servers != nil {
+		t.Errorf("Expected no servers override under the native base path, got %+v", create.Servers)
+	}
+	if doc.Paths["/avatars"].Post == nil {
+		t.Error("Expected POST /avatars to be imported")
+	}
+
+	if doc.Components.Schemas["model.User"].(map[string]interface{})["properties"] != nil {
+		t.Error("Expected native component schemas to be kept")
+	}
+	if _, ok := doc.Components.SecuritySchemes["OAuth2"]; !ok {
+		t.Error("Expected imported security schemes to be added")
+	}
+	if len(doc.Tags) != 2 || doc.Tags[0].Description != "Native users" || doc.Tags[1].Name != "avatars" {
+		t.Errorf("Expected the native users tag followed by avatars, got %+v", doc.Tags)
+	}
+
+	// The imported document is not shared with the merged one
+	create.Summary = "changed"
+	if imported.Paths["/users"].Post.Summary != "Create user" {
+		t.Error("Expected Merge not to share operations with the imported document")
+	}
+}
+
+// TestMergeRebase tests how imported paths are placed relative to the native server
+func TestMergeRebase(t *testing.T) {
+	tests := []struct {
+		name        string
+		nativeURL   string
+		importedURL string
+		wantPath    string
+		wantServers bool
+	}{
+		{"same base", "/api/v1", "/api/v1", "/ping", false},
+		{"nested base", "/api", "https://legacy.example.com/api/v1", "/v1/ping", false},
+		{"no native base", "", "/legacy", "/legacy/ping", false},
+		{"other base", "/api/v2", "/api/v1", "/ping", true},
+		{"prefix is not a segment", "/api", "/apiv1", "/ping", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &api.OpenAPIDoc{Paths: map[string]api.PathItem{}}
+			if tt.nativeURL != "" {
+				doc.Servers = []api.OpenAPIServer{{URL: tt.nativeURL}}
+			}
+			imported := &api.OpenAPIDoc{
+				Servers: []api.OpenAPIServer{{URL: tt.importedURL}},
+				Paths:   map[string]api.PathItem{"/ping": {Get: &api.Operation{Summary: "Ping"}}},
+			}
+			if err := Merge(doc, imported); err != nil {
+				t.Fatalf("Merge failed: %v", err)
+			}
+			op := doc.Paths[tt.wantPath].Get
+			if op == nil {
+				t.Fatalf("Expected GET %s, got paths %v", tt.wantPath, doc.Paths)
+			}
+			if (op.Servers != nil) != tt.wantServers {
+				t.Errorf("Expected operation servers %v, got %+v", tt.wantServers, op.Servers)
+			}
+		})
+	}
+}
+
+// TestMergeOperationIDConflict tests that an imported operationId already used natively is rejected
+func TestMergeOperationIDConflict(t *testing.T) {
+	imported := &api.OpenAPIDoc{
+		Servers: []api.OpenAPIServer{{URL: "/api/v1"}},
+		Paths:   map[string]api.PathItem{"/accounts/{id}": {Get: &api.Operation{OperationID: "getUser"}}},
+	}
+	err := Merge(nativeDoc(), imported)
+	if err == nil || !strings.Contains(err.Error(), `operationId "getUser"`) {
+		t.Errorf("Expected an operationId conflict, got %v", err)
+	}
+}
+
+// TestImporter tests serving one combined document from the gin router
+func TestImporter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	imported, err := FromSwagger2([]byte(swaggerJSON))
+	if err != nil {
+		t.Fatalf("FromSwagger2() error = %v", err)
+	}
+
+	engine := gin.New()
+	router := ginSwagger.NewAPIRouter(engine, "/api/v1", "Test API", "1.0.0", "Test")
+	router.Use(NewImporter(imported))
+	if err := router.Register(api.NewAPIDefinition("GET", "/users/{id}", "Get user (native)").
+		WithPathParam("id", "User ID", true).
+		WithNativeHandler(func(c *gin.Context) {})); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, err := router.GenerateSwagger(); err != nil {
+		t.Fatalf("GenerateSwagger failed: %v", err)
+	}
+
+	engine.GET("/swagger.json", router.SwaggerHandler)
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger.json", nil))
+	var doc api.OpenAPIDoc
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to decode the document: %v", err)
+	}
+	if got := doc.Paths["/users/{id}"].Get; got == nil || got.Summary != "Get user (native)" {
+		t.Errorf("Expected the native GET /users/{id}, got %+v", got)
+	}
+	if doc.Paths["/users"].Post == nil || doc.Paths["/avatars"].Post == nil {
+		t.Errorf("Expected the imported operations in the served document, got %v", doc.Paths)
+	}
+	if _, ok := doc.Components.Schemas["model.User"]; !ok {
+		t.Error("Expected the imported schemas in the served document")
+	}
+}
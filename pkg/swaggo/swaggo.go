@@ -0,0 +1,486 @@
+// Package swaggo imports the Swagger 2.0 documents generated by swaggo/swag (swagger.json or docs.go)
+// as OpenAPI 3 documents and merges them into the document of natively registered definitions,
+// so a service can migrate endpoint by endpoint while serving one combined document
+//
+//	imported, err := swaggo.Load("docs/swagger.json")
+//	router.Use(swaggo.NewImporter(imported))
+package swaggo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// Load reads a swag-generated swagger.json, or the docs.go embedding it, as an OpenAPI 3 document
+func Load(path string) (*api.OpenAPIDoc, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if filepath.Ext(path) == ".go" {
+		return FromDocsGo(data)
+	}
+	return FromSwagger2(data)
+}
+
+// docsInfo holds the values swag fills into the docs.go template from SwaggerInfo
+type docsInfo struct {
+	Version     string
+	Host        string
+	BasePath    string
+	Schemes     []string
+	Title       string
+	Description string
+}
+
+// FromDocsGo converts the document embedded in a swag-generated docs.go source file
+// The template is filled with the SwaggerInfo values declared in the same file
+func FromDocsGo(src []byte) (*api.OpenAPIDoc, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "docs.go", src, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse docs.go: %w", err)
+	}
+
+	var tmpl string
+	info := docsInfo{Schemes: []string{}}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, name := range value.Names {
+				if i >= len(value.Values) {
+					break
+				}
+				switch name.Name {
+				case "docTemplate", "doc":
+					if lit, ok := value.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						tmpl, _ = strconv.Unquote(lit.Value)
+					}
+				case "SwaggerInfo":
+					readSwaggerInfo(value.Values[i], &info)
+				}
+			}
+		}
+	}
+	if tmpl == "" {
+		return nil, fmt.Errorf("docs.go declares no docTemplate")
+	}
+
+	t, err := template.New("swagger").Funcs(template.FuncMap{
+		"marshal": func(v interface{}) string {
+			data, _ := json.Marshal(v)
+			return string(data)
+		},
+		"escape": func(v interface{}) string {
+			data, _ := json.Marshal(fmt.Sprint(v))
+			return string(data[1 : len(data)-1])
+		},
+	}).Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse docTemplate: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, info); err != nil {
+		return nil, fmt.Errorf("failed to fill docTemplate: %w", err)
+	}
+	return FromSwagger2(buf.Bytes())
+}
+
+// readSwaggerInfo reads the literal fields of the &swag.Spec{...} assigned to SwaggerInfo
+func readSwaggerInfo(expr ast.Expr, info *docsInfo) {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		if key.Name == "Schemes" {
+			if schemes, ok := kv.Value.(*ast.CompositeLit); ok {
+				for _, s := range schemes.Elts {
+					if v, ok := stringLit(s); ok {
+						info.Schemes = append(info.Schemes, v)
+					}
+				}
+			}
+			continue
+		}
+		v, ok := stringLit(kv.Value)
+		if !ok {
+			continue
+		}
+		switch key.Name {
+		case "Version":
+			info.Version = v
+		case "Host":
+			info.Host = v
+		case "BasePath":
+			info.BasePath = v
+		case "Title":
+			info.Title = v
+		case "Description":
+			info.Description = v
+		}
+	}
+}
+
+// stringLit returns the value of a string literal expression
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	v, err := strconv.Unquote(lit.Value)
+	return v, err == nil
+}
+
+// FromSwagger2 converts a Swagger 2.0 document to OpenAPI 3: definitions become component schemas,
+// body and form parameters become request bodies and responses get content for the produced media types
+func FromSwagger2(data []byte) (*api.OpenAPIDoc, error) {
+	var spec map[string]interface{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to decode Swagger document: %w", err)
+	}
+	if version, _ := spec["swagger"].(string); version != "2.0" {
+		return nil, fmt.Errorf("unsupported Swagger version %q, expected 2.0", version)
+	}
+
+	info := object(spec["info"])
+	doc := &api.OpenAPIDoc{
+		OpenAPI: "3.0.0",
+		Info: api.OpenAPIInfo{
+			Title:       str(info["title"]),
+			Version:     str(info["version"]),
+			Description: str(info["description"]),
+		},
+		Paths:      make(map[string]api.PathItem),
+		Components: &api.Components{},
+	}
+	if server := serverURL(spec); server != "" {
+		doc.Servers = []api.OpenAPIServer{{URL: server}}
+	}
+
+	if definitions := object(spec["definitions"]); len(definitions) > 0 {
+		doc.Components.Schemas = make(map[string]interface{}, len(definitions))
+		for name, schema := range definitions {
+			doc.Components.Schemas[name] = convertSchema(schema)
+		}
+	}
+	if schemes := object(spec["securityDefinitions"]); len(schemes) > 0 {
+		doc.Components.SecuritySchemes = make(map[string]api.SecurityScheme, len(schemes))
+		for name, scheme := range schemes {
+			doc.Components.SecuritySchemes[name] = securityScheme(object(scheme))
+		}
+	}
+	doc.Security = requirements(spec["security"])
+	for _, tag := range list(spec["tags"]) {
+		t := object(tag)
+		doc.Tags = append(doc.Tags, api.Tag{Name: str(t["name"]), Description: str(t["description"])})
+	}
+
+	consumes := stringList(spec["consumes"], "application/json")
+	produces := stringList(spec["produces"], "application/json")
+	for path, item := range object(spec["paths"]) {
+		shared := list(object(item)["parameters"])
+		pathItem := api.PathItem{}
+		for method, value := range object(item) {
+			if !api.IsSupportedMethod(method) {
+				continue
+			}
+			op, err := convertOperation(object(value), shared, consumes, produces)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			pathItem.SetOperation(method, op)
+		}
+		doc.Paths[path] = pathItem
+	}
+	return doc, nil
+}
+
+// serverURL combines the host, basePath and first scheme of a Swagger document
+func serverURL(spec map[string]interface{}) string {
+	basePath := str(spec["basePath"])
+	host := str(spec["host"])
+	if host == "" {
+		return basePath
+	}
+	scheme := "https"
+	if schemes := stringList(spec["schemes"]); len(schemes) > 0 {
+		scheme = schemes[0]
+	}
+	return scheme + "://" + host + basePath
+}
+
+// convertOperation converts a Swagger operation, with the parameters shared by its path item
+func convertOperation(spec map[string]interface{}, shared []interface{}, consumes, produces []string) (*api.Operation, error) {
+	op := &api.Operation{
+		Summary:     str(spec["summary"]),
+		Description: str(spec["description"]),
+		OperationID: str(spec["operationId"]),
+		Tags:        stringList(spec["tags"]),
+		Deprecated:  spec["deprecated"] == true,
+		Security:    requirements(spec["security"]),
+		Responses:   make(map[string]api.Response),
+	}
+	consumes = stringList(spec["consumes"], consumes...)
+	produces = stringList(spec["produces"], produces...)
+
+	form := map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+	var formRequired []string
+	multipart := false
+	for _, value := range append(append([]interface{}{}, shared...), list(spec["parameters"])...) {
+		param := object(value)
+		if ref := str(param["$ref"]); ref != "" {
+			op.Parameters = append(op.Parameters, api.Parameter{Ref: strings.Replace(ref, "#/parameters/", "#/components/parameters/", 1)})
+			continue
+		}
+		name := str(param["name"])
+		switch in := str(param["in"]); in {
+		case "body":
+			op.RequestBody = &api.RequestBody{Content: content(consumes, object(convertSchema(param["schema"])))}
+		case "formData":
+			schema := parameterSchema(param)
+			if schema["type"] == "file" {
+				schema = map[string]interface{}{"type": "string", "format": "binary"}
+				multipart = true
+			}
+			if desc := str(param["description"]); desc != "" {
+				schema["description"] = desc
+			}
+			form["properties"].(map[string]interface{})[name] = schema
+			if param["required"] == true {
+				formRequired = append(formRequired, name)
+			}
+		case "path", "query", "header":
+			op.Parameters = append(op.Parameters, api.Parameter{
+				Name:        name,
+				In:          in,
+				Description: str(param["description"]),
+				Required:    param["required"] == true || in == "path",
+				Schema:      parameterSchema(param),
+			})
+		default:
+			return nil, fmt.Errorf("unsupported parameter location %q", in)
+		}
+	}
+	if len(form["properties"].(map[string]interface{})) > 0 {
+		if len(formRequired) > 0 {
+			form["required"] = formRequired
+		}
+		mediaType := "application/x-www-form-urlencoded"
+		if multipart || containsString(consumes, "multipart/form-data") {
+			mediaType = "multipart/form-data"
+		}
+		op.RequestBody = &api.RequestBody{Content: map[string]api.Content{mediaType: {Schema: form}}}
+	}
+
+	for status, value := range object(spec["responses"]) {
+		resp := object(value)
+		if ref := str(resp["$ref"]); ref != "" {
+			return nil, fmt.Errorf("response %s: shared responses are not supported", status)
+		}
+		converted := api.Response{Description: str(resp["description"])}
+		if schema := object(convertSchema(resp["schema"])); len(schema) > 0 {
+			converted.Content = content(produces, schema)
+		}
+		for name, header := range object(resp["headers"]) {
+			if converted.Headers == nil {
+				converted.Headers = make(map[string]api.Header)
+			}
+			h := object(header)
+			converted.Headers[name] = api.Header{Description: str(h["description"]), Schema: parameterSchema(h)}
+		}
+		op.Responses[status] = converted
+	}
+	return op, nil
+}
+
+// parameterKeywords are the schema keywords Swagger 2.0 declares directly on non-body parameters and headers
+var parameterKeywords = []string{
+	"type", "format", "items", "default", "enum", "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum",
+	"minLength", "maxLength", "pattern", "minItems", "maxItems", "uniqueItems", "multipleOf",
+}
+
+// parameterSchema collects the schema keywords of a non-body parameter
+func parameterSchema(param map[string]interface{}) map[string]interface{} {
+	schema := make(map[string]interface{})
+	for _, key := range parameterKeywords {
+		if value, ok := param[key]; ok {
+			schema[key] = convertSchema(value)
+		}
+	}
+	if len(schema) == 0 {
+		schema["type"] = "string"
+	}
+	return schema
+}
+
+// convertSchema rewrites a Swagger schema for OpenAPI 3: definition references point to
+// components.schemas, x-nullable becomes nullable and file types become binary strings
+func convertSchema(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			switch {
+			case key == "$ref":
+				out[key] = strings.Replace(str(item), "#/definitions/", "#/components/schemas/", 1)
+			case key == "x-nullable":
+				out["nullable"] = item
+			case key == "type" && item == "file":
+				out["type"], out["format"] = "string", "binary"
+			case key == "properties" || key == "definitions":
+				// Property names are not keywords, only their schemas are converted
+				props := make(map[string]interface{}, len(object(item)))
+				for name, schema := range object(item) {
+					props[name] = convertSchema(schema)
+				}
+				out[key] = props
+			default:
+				out[key] = convertSchema(item)
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = convertSchema(item)
+		}
+		return out
+	}
+	return value
+}
+
+// content describes a schema under each media type
+func content(mediaTypes []string, schema map[string]interface{}) map[string]api.Content {
+	out := make(map[string]api.Content, len(mediaTypes))
+	for _, mediaType := range mediaTypes {
+		out[mediaType] = api.Content{Schema: schema}
+	}
+	return out
+}
+
+// securityScheme converts a Swagger security definition
+func securityScheme(spec map[string]interface{}) api.SecurityScheme {
+	scheme := api.SecurityScheme{Type: str(spec["type"]), Description: str(spec["description"])}
+	switch scheme.Type {
+	case "basic":
+		scheme.Type, scheme.Scheme = "http", "basic"
+	case "apiKey":
+		scheme.Name, scheme.In = str(spec["name"]), str(spec["in"])
+	case "oauth2":
+		scopes := make(map[string]string)
+		for name, desc := range object(spec["scopes"]) {
+			scopes[name] = str(desc)
+		}
+		flow := &api.OAuthFlow{
+			AuthorizationURL: str(spec["authorizationUrl"]),
+			TokenURL:         str(spec["tokenUrl"]),
+			Scopes:           scopes,
+		}
+		scheme.Flows = &api.OAuthFlows{}
+		switch str(spec["flow"]) {
+		case "implicit":
+			scheme.Flows.Implicit = flow
+		case "password":
+			scheme.Flows.Password = flow
+		case "application":
+			scheme.Flows.ClientCredentials = flow
+		case "accessCode":
+			scheme.Flows.AuthorizationCode = flow
+		}
+	}
+	return scheme
+}
+
+// requirements converts a list of security requirements
+func requirements(value interface{}) []map[string][]string {
+	var out []map[string][]string
+	for _, item := range list(value) {
+		requirement := make(map[string][]string)
+		for name, scopes := range object(item) {
+			requirement[name] = stringList(scopes)
+			if requirement[name] == nil {
+				requirement[name] = []string{}
+			}
+		}
+		out = append(out, requirement)
+	}
+	return out
+}
+
+func object(value interface{}) map[string]interface{} {
+	m, _ := value.(map[string]interface{})
+	return m
+}
+
+func list(value interface{}) []interface{} {
+	l, _ := value.([]interface{})
+	return l
+}
+
+func str(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
+
+// stringList returns a list of strings, or the defaults when it is absent or empty
+func stringList(value interface{}, defaults ...string) []string {
+	var out []string
+	for _, item := range list(value) {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	if len(out) == 0 && len(defaults) > 0 {
+		return append([]string(nil), defaults...)
+	}
+	return out
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedPaths returns the paths of a document in order
+func sortedPaths(paths map[string]api.PathItem) []string {
+	keys := make([]string, 0, len(paths))
+	for key := range paths {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,240 @@
+package swaggo
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/smartcat999/go-swagger/pkg/api"
+)
+
+// swaggerJSON is a document in the shape swag generates
+const swaggerJSON = `{
+    "swagger": "2.0",
+    "info": {"title": "Legacy API", "version": "1.0", "description": "Handlers documented with swag"},
+    "host": "localhost:8080",
+    "basePath": "/api/v1",
+    "schemes": ["http"],
+    "paths": {
+        "/users/{id}": {
+            "get": {
+                "produces": ["application/json"],
+                "tags": ["users"],
+                "summary": "Get user",
+                "operationId": "legacyGetUser",
+                "parameters": [
+                    {"type": "integer", "description": "User ID", "name": "id", "in": "path", "required": true},
+                    {"enum": ["asc", "desc"], "type": "string", "default": "asc", "name": "sort", "in": "query"}
+                ],
+                "responses": {
+                    "200": {"description": "OK", "schema": {"$ref": "#/definitions/model.User"}},
+                    "404": {"description": "Not Found", "headers": {"X-Trace": {"type": "string"}}}
+                },
+                "security": [{"OAuth2": ["read"]}]
+            }
+        },
+        "/users": {
+            "post": {
+                "consumes": ["application/json"],
+                "summary": "Create user",
+                "deprecated": true,
+                "parameters": [
+                    {"description": "User", "name": "user", "in": "body", "required": true, "schema": {"$ref": "#/definitions/model.User"}}
+                ],
+                "responses": {"201": {"description": "Created"}}
+            }
+        },
+        "/avatars": {
+            "post": {
+                "parameters": [
+                    {"type": "file", "description": "Image", "name": "file", "in": "formData", "required": true},
+                    {"type": "string", "name": "caption", "in": "formData"}
+                ],
+                "responses": {"204": {"description": "No Content"}}
+            }
+        }
+    },
+    "definitions": {
+        "model.User": {
+            "type": "object",
+            "properties": {
+                "id": {"type": "integer"},
+                "manager": {"$ref": "#/definitions/model.User"},
+                "nickname": {"type": "string", "x-nullable": true}
+            }
+        }
+    },
+    "securityDefinitions": {
+        "OAuth2": {"type": "oauth2", "flow": "application", "tokenUrl": "https://auth.example.com/token", "scopes": {"read": "Read access"}},
+        "ApiKeyAuth": {"type": "apiKey", "name": "X-API-Key", "in": "header"},
+        "BasicAuth": {"type": "basic"}
+    }
+}`
+
+// TestFromSwagger2 tests the conversion of a swag document to OpenAPI 3
+func TestFromSwagger2(t *testing.T) {
+	doc, err := FromSwagger2([]byte(swaggerJSON))
+	if err != nil {
+		t.Fatalf("FromSwagger2() error = %v", err)
+	}
+	if doc.OpenAPI != "3.0.0" || doc.Info.Title != "Legacy API" || doc.Info.Version != "1.0" {
+		t.Errorf("Expected OpenAPI 3.0.0 for Legacy API 1.0, got %s %+v", doc.OpenAPI, doc.Info)
+	}
+	if len(doc.Servers) != 1 || doc.Servers[0].URL != "http://localhost:8080/api/v1" {
+		t.Errorf("Expected the host, scheme and base path combined, got servers %+v", doc.Servers)
+	}
+
+	get := doc.Paths["/users/{id}"].Get
+	if get == nil || get.OperationID != "legacyGetUser" || get.Summary != "Get user" {
+		t.Fatalf("Expected GET /users/{id} as legacyGetUser, got %+v", get)
+	}
+	if len(get.Parameters) != 2 || !get.Parameters[0].Required || get.Parameters[0].Schema["type"] != "integer" {
+		t.Errorf("Expected a required integer path parameter and a query parameter, got %+v", get.Parameters)
+	}
+	if enum, _ := get.Parameters[1].Schema["enum"].([]interface{}); len(enum) != 2 || get.Parameters[1].Schema["default"] != "asc" {
+		t.Errorf("Expected the query parameter enum and default, got %v", get.Parameters[1].Schema)
+	}
+	ok := get.Responses["200"].Content["application/json"].Schema
+	if ok["$ref"] != "#/components/schemas/model.User" {
+		t.Errorf("Expected a component reference for 200, got %v", ok)
+	}
+	if get.Responses["404"].Headers["X-Trace"].Schema["type"] != "string" {
+		t.Errorf("Expected a string X-Trace header on 404, got %+v", get.Responses["404"].Headers)
+	}
+	if !reflect.DeepEqual(get.Security, []map[string][]string{{"OAuth2": {"read"}}}) {
+		t.Errorf("Expected OAuth2 read security, got %v", get.Security)
+	}
+
+	create := doc.Paths["/users"].Post
+	if create == nil || !create.Deprecated || create.RequestBody == nil {
+		t.Fatalf("Expected a deprecated POST /users with a body, got %+v", create)
+	}
+	if create.RequestBody.Content["application/json"].Schema["$ref"] != "#/components/schemas/model.User" {
+		t.Errorf("Expected a model.User request body, got %+v", create.RequestBody)
+	}
+
+	upload := doc.Paths["/avatars"].Post
+	form, ok2 := upload.RequestBody.Content["multipart/form-data"]
+	if !ok2 {
+		t.Fatalf("Expected form parameters to become a multipart body, got %+v", upload.RequestBody)
+	}
+	props := form.Schema["properties"].(map[string]interface{})
+	if file := props["file"].(map[string]interface{}); file["format"] != "binary" || file["type"] != "string" {
+		t.Errorf("Expected the file property to be a binary string, got %v", file)
+	}
+	if !reflect.DeepEqual(form.Schema["required"], []string{"file"}) {
+		t.Errorf("Expected required [file], got %v", form.Schema["required"])
+	}
+
+	user := doc.Components.Schemas["model.User"].(map[string]interface{})
+	userProps := user["properties"].(map[string]interface{})
+	if userProps["manager"].(map[string]interface{})["$ref"] != "#/components/schemas/model.User" {
+		t.Errorf("Expected the nested reference to be rewritten, got %v", userProps["manager"])
+	}
+	if nickname := userProps["nickname"].(map[string]interface{}); nickname["nullable"] != true {
+		t.Errorf("Expected x-nullable to become nullable, got %v", nickname)
+	}
+
+	schemes := doc.Components.SecuritySchemes
+	if flow := schemes["OAuth2"].Flows; flow == nil || flow.ClientCredentials == nil || flow.ClientCredentials.TokenURL != "https://auth.example.com/token" {
+		t.Errorf("Expected an OAuth2 client credentials flow, got %+v", schemes["OAuth2"])
+	}
+	if schemes["ApiKeyAuth"] != (api.SecurityScheme{Type: "apiKey", Name: "X-API-Key", In: "header"}) {
+		t.Errorf("Expected an X-API-Key header scheme, got %+v", schemes["ApiKeyAuth"])
+	}
+	if schemes["BasicAuth"].Type != "http" || schemes["BasicAuth"].Scheme != "basic" {
+		t.Errorf("Expected an HTTP basic scheme, got %+v", schemes["BasicAuth"])
+	}
+}
+
+// TestFromSwagger2Errors tests that documents other than Swagger 2.0 are rejected
+func TestFromSwagger2Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"invalid json", `{`, "failed to decode"},
+		{"openapi 3", `{"openapi": "3.0.0"}`, "unsupported Swagger version"},
+		{"unknown location", `{"swagger": "2.0", "paths": {"/a": {"get": {"parameters": [{"name": "x", "in": "cookie"}]}}}}`, "unsupported parameter location"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromSwagger2([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// docsGo is a docs.go file in the shape swag generates
+const docsGo = "// Package docs Code generated by swaggo/swag. DO NOT EDIT\npackage docs\n\n" +
+	"import \"github.com/swaggo/swag\"\n\n" +
+	"const docTemplate = `{\n" +
+	"    \"schemes\": {{ marshal .Schemes }},\n" +
+	"    \"swagger\": \"2.0\",\n" +
+	"    \"info\": {\"description\": \"{{escape .Description}}\", \"title\": \"{{.Title}}\", \"version\": \"{{.Version}}\"},\n" +
+	"    \"host\": \"{{.Host}}\",\n" +
+	"    \"basePath\": \"{{.BasePath}}\",\n" +
+	"    \"paths\": {\"/ping\": {\"get\": {\"summary\": \"Ping\", \"responses\": {\"200\": {\"description\": \"OK\"}}}}}\n" +
+	"}`\n\n" +
+	"// SwaggerInfo holds exported Swagger Info so clients can modify it\n" +
+	"var SwaggerInfo = &swag.Spec{\n" +
+	"\tVersion:          \"2.1\",\n" +
+	"\tHost:             \"\",\n" +
+	"\tBasePath:         \"/api/v1\",\n" +
+	"\tSchemes:          []string{},\n" +
+	"\tTitle:            \"Legacy API\",\n" +
+	"\tDescription:      \"Says \\\"pong\\\"\",\n" +
+	"\tInfoInstanceName: \"swagger\",\n" +
+	"\tSwaggerTemplate:  docTemplate,\n" +
+	"}\n\n" +
+	"func init() {\n\tswag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)\n}\n"
+
+// TestFromDocsGo tests that the docs.go template is filled from SwaggerInfo
+func TestFromDocsGo(t *testing.T) {
+	doc, err := FromDocsGo([]byte(docsGo))
+	if err != nil {
+		t.Fatalf("FromDocsGo failed: %v", err)
+	}
+	if doc.Info.Title != "Legacy API" || doc.Info.Version != "2.1" || doc.Info.Description != `Says "pong"` {
+		t.Errorf("Expected the info filled from SwaggerInfo, got %+v", doc.Info)
+	}
+	if len(doc.Servers) != 1 || doc.Servers[0].URL != "/api/v1" {
+		t.Errorf("Expected the base path as server, got %+v", doc.Servers)
+	}
+	if doc.Paths["/ping"].Get == nil {
+		t.Error("Expected GET /ping to be imported")
+	}
+
+	if _, err := FromDocsGo([]byte("package docs\n")); err == nil {
+		t.Error("Expected FromDocsGo to fail without a docTemplate")
+	}
+}
+
+// TestLoad tests that Load picks the format from the file extension
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{"swagger.json": swaggerJSON, "docs.go": docsGo}
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		doc, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load %s failed: %v", name, err)
+		}
+		if doc.Info.Title != "Legacy API" {
+			t.Errorf("Expected title Legacy API from %s, got %q", name, doc.Info.Title)
+		}
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected loading a missing file to fail")
+	}
+}